@@ -18,4 +18,5 @@ const (
 const (
 	LoginTypeCredentials = "credentials"
 	LoginTypeGoogle      = "google"
+	LoginTypeMagicLink   = "magic_link"
 )
@@ -0,0 +1,18 @@
+package events
+
+import "time"
+
+// MagicLink is published by sso when user requests sign in link, notification emails link with the token
+type MagicLink struct {
+	// Empty if there is no user with the email yet, user is registered when link is used
+	ID    string `json:"id,omitempty"`
+	Email string `json:"email"`
+	Token string `json:"token"`
+	// Preferred languages in Accept-Language format
+	Locale    string    `json:"locale,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+const MagicLinkTopic = "magic_link"
+
+const NotificationMagicLinkQueue = "notification_magic_link_queue"
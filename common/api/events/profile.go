@@ -0,0 +1,25 @@
+package events
+
+type ProfileCreated struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name,omitempty"`
+	Avatar    string `json:"avatar,omitempty"`
+}
+
+// ProfileUpdated contains only changed fields, empty value means field was cleared
+type ProfileUpdated struct {
+	ID      string            `json:"id"`
+	Version int64             `json:"version"`
+	Changes map[string]string `json:"changes"`
+}
+
+// Key of ProfileUpdated.Changes with BCP 47 language tag chosen by user
+const ProfileFieldLocale = "locale"
+
+const (
+	ProfileCreatedTopic = "profile.created"
+	ProfileUpdatedTopic = "profile.updated"
+)
+
+const NotificationProfileQueue = "notification_profile_queue"
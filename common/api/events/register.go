@@ -5,6 +5,8 @@ type UserRegister struct {
 	Email  string `json:"email"`
 	Name   string `json:"name,omitempty"`
 	Avatar string `json:"avatar,omitempty"`
+	// Preferred languages in Accept-Language format
+	Locale string `json:"locale,omitempty"`
 }
 
 const RegisterTopic = "register"
@@ -0,0 +1,16 @@
+package events
+
+import "time"
+
+// TelegramLinked is published by notification when user links Telegram account
+type TelegramLinked struct {
+	ID         string `json:"id"`
+	TelegramID int64  `json:"telegram_id"`
+	// Empty if user has no Telegram username
+	Username string    `json:"username,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+const TelegramLinkedTopic = "telegram.linked"
+
+const NotificationTelegramQueue = "notification_telegram_queue"
@@ -19,7 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Notification_GenerateTelegramToken_FullMethodName = "/notification.Notification/GenerateTelegramToken"
+	Notification_GenerateTelegramToken_FullMethodName  = "/notification.Notification/GenerateTelegramToken"
+	Notification_ListSubscriptions_FullMethodName      = "/notification.Notification/ListSubscriptions"
+	Notification_UpdateSubscription_FullMethodName     = "/notification.Notification/UpdateSubscription"
+	Notification_UnlinkTelegram_FullMethodName         = "/notification.Notification/UnlinkTelegram"
+	Notification_GetPreferences_FullMethodName         = "/notification.Notification/GetPreferences"
+	Notification_UpdatePreference_FullMethodName       = "/notification.Notification/UpdatePreference"
+	Notification_GetSchedule_FullMethodName            = "/notification.Notification/GetSchedule"
+	Notification_UpdateSchedule_FullMethodName         = "/notification.Notification/UpdateSchedule"
+	Notification_ListDeliveries_FullMethodName         = "/notification.Notification/ListDeliveries"
+	Notification_SetContact_FullMethodName             = "/notification.Notification/SetContact"
+	Notification_DeleteContact_FullMethodName          = "/notification.Notification/DeleteContact"
+	Notification_GetPushPublicKey_FullMethodName       = "/notification.Notification/GetPushPublicKey"
+	Notification_AddPushSubscription_FullMethodName    = "/notification.Notification/AddPushSubscription"
+	Notification_RemovePushSubscription_FullMethodName = "/notification.Notification/RemovePushSubscription"
+	Notification_Broadcast_FullMethodName              = "/notification.Notification/Broadcast"
+	Notification_GetBroadcast_FullMethodName           = "/notification.Notification/GetBroadcast"
 )
 
 // NotificationClient is the client API for Notification service.
@@ -27,6 +42,22 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NotificationClient interface {
 	GenerateTelegramToken(ctx context.Context, in *GenerateTelegramTokenRequest, opts ...grpc.CallOption) (*GenerateTelegramTokenResponse, error)
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	UpdateSubscription(ctx context.Context, in *UpdateSubscriptionRequest, opts ...grpc.CallOption) (*UpdateSubscriptionResponse, error)
+	UnlinkTelegram(ctx context.Context, in *UnlinkTelegramRequest, opts ...grpc.CallOption) (*UnlinkTelegramResponse, error)
+	GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*Preferences, error)
+	UpdatePreference(ctx context.Context, in *UpdatePreferenceRequest, opts ...grpc.CallOption) (*Preferences, error)
+	GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	UpdateSchedule(ctx context.Context, in *Schedule, opts ...grpc.CallOption) (*Schedule, error)
+	ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error)
+	SetContact(ctx context.Context, in *SetContactRequest, opts ...grpc.CallOption) (*SetContactResponse, error)
+	DeleteContact(ctx context.Context, in *DeleteContactRequest, opts ...grpc.CallOption) (*DeleteContactResponse, error)
+	GetPushPublicKey(ctx context.Context, in *GetPushPublicKeyRequest, opts ...grpc.CallOption) (*GetPushPublicKeyResponse, error)
+	AddPushSubscription(ctx context.Context, in *AddPushSubscriptionRequest, opts ...grpc.CallOption) (*AddPushSubscriptionResponse, error)
+	RemovePushSubscription(ctx context.Context, in *RemovePushSubscriptionRequest, opts ...grpc.CallOption) (*RemovePushSubscriptionResponse, error)
+	// Admin only
+	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastReport, error)
+	GetBroadcast(ctx context.Context, in *GetBroadcastRequest, opts ...grpc.CallOption) (*BroadcastReport, error)
 }
 
 type notificationClient struct {
@@ -47,11 +78,177 @@ func (c *notificationClient) GenerateTelegramToken(ctx context.Context, in *Gene
 	return out, nil
 }
 
+func (c *notificationClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, Notification_ListSubscriptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) UpdateSubscription(ctx context.Context, in *UpdateSubscriptionRequest, opts ...grpc.CallOption) (*UpdateSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSubscriptionResponse)
+	err := c.cc.Invoke(ctx, Notification_UpdateSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) UnlinkTelegram(ctx context.Context, in *UnlinkTelegramRequest, opts ...grpc.CallOption) (*UnlinkTelegramResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlinkTelegramResponse)
+	err := c.cc.Invoke(ctx, Notification_UnlinkTelegram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, Notification_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) UpdatePreference(ctx context.Context, in *UpdatePreferenceRequest, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, Notification_UpdatePreference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, Notification_GetSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) UpdateSchedule(ctx context.Context, in *Schedule, opts ...grpc.CallOption) (*Schedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, Notification_UpdateSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeliveriesResponse)
+	err := c.cc.Invoke(ctx, Notification_ListDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) SetContact(ctx context.Context, in *SetContactRequest, opts ...grpc.CallOption) (*SetContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetContactResponse)
+	err := c.cc.Invoke(ctx, Notification_SetContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) DeleteContact(ctx context.Context, in *DeleteContactRequest, opts ...grpc.CallOption) (*DeleteContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteContactResponse)
+	err := c.cc.Invoke(ctx, Notification_DeleteContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) GetPushPublicKey(ctx context.Context, in *GetPushPublicKeyRequest, opts ...grpc.CallOption) (*GetPushPublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPushPublicKeyResponse)
+	err := c.cc.Invoke(ctx, Notification_GetPushPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) AddPushSubscription(ctx context.Context, in *AddPushSubscriptionRequest, opts ...grpc.CallOption) (*AddPushSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddPushSubscriptionResponse)
+	err := c.cc.Invoke(ctx, Notification_AddPushSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) RemovePushSubscription(ctx context.Context, in *RemovePushSubscriptionRequest, opts ...grpc.CallOption) (*RemovePushSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePushSubscriptionResponse)
+	err := c.cc.Invoke(ctx, Notification_RemovePushSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastReport)
+	err := c.cc.Invoke(ctx, Notification_Broadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) GetBroadcast(ctx context.Context, in *GetBroadcastRequest, opts ...grpc.CallOption) (*BroadcastReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastReport)
+	err := c.cc.Invoke(ctx, Notification_GetBroadcast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServer is the server API for Notification service.
 // All implementations must embed UnimplementedNotificationServer
 // for forward compatibility.
 type NotificationServer interface {
 	GenerateTelegramToken(context.Context, *GenerateTelegramTokenRequest) (*GenerateTelegramTokenResponse, error)
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	UpdateSubscription(context.Context, *UpdateSubscriptionRequest) (*UpdateSubscriptionResponse, error)
+	UnlinkTelegram(context.Context, *UnlinkTelegramRequest) (*UnlinkTelegramResponse, error)
+	GetPreferences(context.Context, *GetPreferencesRequest) (*Preferences, error)
+	UpdatePreference(context.Context, *UpdatePreferenceRequest) (*Preferences, error)
+	GetSchedule(context.Context, *GetScheduleRequest) (*Schedule, error)
+	UpdateSchedule(context.Context, *Schedule) (*Schedule, error)
+	ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error)
+	SetContact(context.Context, *SetContactRequest) (*SetContactResponse, error)
+	DeleteContact(context.Context, *DeleteContactRequest) (*DeleteContactResponse, error)
+	GetPushPublicKey(context.Context, *GetPushPublicKeyRequest) (*GetPushPublicKeyResponse, error)
+	AddPushSubscription(context.Context, *AddPushSubscriptionRequest) (*AddPushSubscriptionResponse, error)
+	RemovePushSubscription(context.Context, *RemovePushSubscriptionRequest) (*RemovePushSubscriptionResponse, error)
+	// Admin only
+	Broadcast(context.Context, *BroadcastRequest) (*BroadcastReport, error)
+	GetBroadcast(context.Context, *GetBroadcastRequest) (*BroadcastReport, error)
 	mustEmbedUnimplementedNotificationServer()
 }
 
@@ -65,6 +262,51 @@ type UnimplementedNotificationServer struct{}
 func (UnimplementedNotificationServer) GenerateTelegramToken(context.Context, *GenerateTelegramTokenRequest) (*GenerateTelegramTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateTelegramToken not implemented")
 }
+func (UnimplementedNotificationServer) ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedNotificationServer) UpdateSubscription(context.Context, *UpdateSubscriptionRequest) (*UpdateSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSubscription not implemented")
+}
+func (UnimplementedNotificationServer) UnlinkTelegram(context.Context, *UnlinkTelegramRequest) (*UnlinkTelegramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkTelegram not implemented")
+}
+func (UnimplementedNotificationServer) GetPreferences(context.Context, *GetPreferencesRequest) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedNotificationServer) UpdatePreference(context.Context, *UpdatePreferenceRequest) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePreference not implemented")
+}
+func (UnimplementedNotificationServer) GetSchedule(context.Context, *GetScheduleRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+}
+func (UnimplementedNotificationServer) UpdateSchedule(context.Context, *Schedule) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSchedule not implemented")
+}
+func (UnimplementedNotificationServer) ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+func (UnimplementedNotificationServer) SetContact(context.Context, *SetContactRequest) (*SetContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetContact not implemented")
+}
+func (UnimplementedNotificationServer) DeleteContact(context.Context, *DeleteContactRequest) (*DeleteContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteContact not implemented")
+}
+func (UnimplementedNotificationServer) GetPushPublicKey(context.Context, *GetPushPublicKeyRequest) (*GetPushPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPushPublicKey not implemented")
+}
+func (UnimplementedNotificationServer) AddPushSubscription(context.Context, *AddPushSubscriptionRequest) (*AddPushSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPushSubscription not implemented")
+}
+func (UnimplementedNotificationServer) RemovePushSubscription(context.Context, *RemovePushSubscriptionRequest) (*RemovePushSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePushSubscription not implemented")
+}
+func (UnimplementedNotificationServer) Broadcast(context.Context, *BroadcastRequest) (*BroadcastReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Broadcast not implemented")
+}
+func (UnimplementedNotificationServer) GetBroadcast(context.Context, *GetBroadcastRequest) (*BroadcastReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcast not implemented")
+}
 func (UnimplementedNotificationServer) mustEmbedUnimplementedNotificationServer() {}
 func (UnimplementedNotificationServer) testEmbeddedByValue()                      {}
 
@@ -104,6 +346,276 @@ func _Notification_GenerateTelegramToken_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Notification_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_ListSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_UpdateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).UpdateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_UpdateSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).UpdateSubscription(ctx, req.(*UpdateSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_UnlinkTelegram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkTelegramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).UnlinkTelegram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_UnlinkTelegram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).UnlinkTelegram(ctx, req.(*UnlinkTelegramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).GetPreferences(ctx, req.(*GetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_UpdatePreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).UpdatePreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_UpdatePreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).UpdatePreference(ctx, req.(*UpdatePreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).GetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_GetSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).GetSchedule(ctx, req.(*GetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_UpdateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Schedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).UpdateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_UpdateSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).UpdateSchedule(ctx, req.(*Schedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_ListDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).ListDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_ListDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).ListDeliveries(ctx, req.(*ListDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_SetContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).SetContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_SetContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).SetContact(ctx, req.(*SetContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_DeleteContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).DeleteContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_DeleteContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).DeleteContact(ctx, req.(*DeleteContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_GetPushPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPushPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).GetPushPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_GetPushPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).GetPushPublicKey(ctx, req.(*GetPushPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_AddPushSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPushSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).AddPushSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_AddPushSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).AddPushSubscription(ctx, req.(*AddPushSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_RemovePushSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePushSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).RemovePushSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_RemovePushSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).RemovePushSubscription(ctx, req.(*RemovePushSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_Broadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).Broadcast(ctx, req.(*BroadcastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_GetBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBroadcastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).GetBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_GetBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).GetBroadcast(ctx, req.(*GetBroadcastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Notification_ServiceDesc is the grpc.ServiceDesc for Notification service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +627,66 @@ var Notification_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateTelegramToken",
 			Handler:    _Notification_GenerateTelegramToken_Handler,
 		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _Notification_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "UpdateSubscription",
+			Handler:    _Notification_UpdateSubscription_Handler,
+		},
+		{
+			MethodName: "UnlinkTelegram",
+			Handler:    _Notification_UnlinkTelegram_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _Notification_GetPreferences_Handler,
+		},
+		{
+			MethodName: "UpdatePreference",
+			Handler:    _Notification_UpdatePreference_Handler,
+		},
+		{
+			MethodName: "GetSchedule",
+			Handler:    _Notification_GetSchedule_Handler,
+		},
+		{
+			MethodName: "UpdateSchedule",
+			Handler:    _Notification_UpdateSchedule_Handler,
+		},
+		{
+			MethodName: "ListDeliveries",
+			Handler:    _Notification_ListDeliveries_Handler,
+		},
+		{
+			MethodName: "SetContact",
+			Handler:    _Notification_SetContact_Handler,
+		},
+		{
+			MethodName: "DeleteContact",
+			Handler:    _Notification_DeleteContact_Handler,
+		},
+		{
+			MethodName: "GetPushPublicKey",
+			Handler:    _Notification_GetPushPublicKey_Handler,
+		},
+		{
+			MethodName: "AddPushSubscription",
+			Handler:    _Notification_AddPushSubscription_Handler,
+		},
+		{
+			MethodName: "RemovePushSubscription",
+			Handler:    _Notification_RemovePushSubscription_Handler,
+		},
+		{
+			MethodName: "Broadcast",
+			Handler:    _Notification_Broadcast_Handler,
+		},
+		{
+			MethodName: "GetBroadcast",
+			Handler:    _Notification_GetBroadcast_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification.proto",
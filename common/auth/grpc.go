@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"google.golang.org/grpc"
@@ -12,6 +13,8 @@ import (
 
 const UserIdKey = "user_id"
 
+type claimsKey struct{}
+
 func ExtractUserID(ctx context.Context) string {
 	vals := metadata.ValueFromIncomingContext(ctx, UserIdKey)
 	if len(vals) == 0 {
@@ -20,30 +23,110 @@ func ExtractUserID(ctx context.Context) string {
 	return vals[0]
 }
 
-func JwtInterceptor(secret []byte) grpc.UnaryServerInterceptor {
+// ExtractClaims returns claims of verified token, nil if request is not authenticated
+func ExtractClaims(ctx context.Context) *TokenClaims {
+	claims, _ := ctx.Value(claimsKey{}).(*TokenClaims)
+	return claims
+}
+
+type options struct {
+	personalTokens TokenVerifier
+}
+
+type Option func(*options)
+
+// WithPersonalTokens accepts personal access tokens verified by verifier, they are rejected otherwise
+func WithPersonalTokens(verifier TokenVerifier) Option {
+	return func(o *options) {
+		o.personalTokens = verifier
+	}
+}
+
+func JwtInterceptor(secret []byte, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
+		ctx, err := authenticate(ctx, info.FullMethod, secret, true, o)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		authHeader, exists := md["authorization"]
-		if !exists || len(authHeader) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "authorization token is missing")
+// OptionalJwtInterceptor verifies token if request has it, services with public methods use it
+// together with AuthzInterceptor
+func OptionalJwtInterceptor(secret []byte, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, info.FullMethod, secret, false, o)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		tokenString := strings.TrimPrefix(authHeader[0], "Bearer ")
-		if tokenString == authHeader[0] {
-			return nil, status.Error(codes.Unauthenticated, "invalid token format")
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func authenticate(ctx context.Context, method string, secret []byte, required bool, o options) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		if !required {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
+	}
+
+	authHeader, exists := md["authorization"]
+	if !exists || len(authHeader) == 0 {
+		if !required {
+			return ctx, nil
 		}
+		return nil, status.Error(codes.Unauthenticated, "authorization token is missing")
+	}
+
+	tokenString := strings.TrimPrefix(authHeader[0], "Bearer ")
+	if tokenString == authHeader[0] {
+		return nil, status.Error(codes.Unauthenticated, "invalid token format")
+	}
+
+	claims, err := verify(ctx, tokenString, secret, o)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.AllowsMethod(method) {
+		return nil, status.Error(codes.PermissionDenied, "method is not allowed by token scopes")
+	}
 
+	// Set replaces user ID which client could send in metadata
+	md.Set(UserIdKey, claims.UserID)
+	ctx = metadata.NewIncomingContext(ctx, md)
+	return context.WithValue(ctx, claimsKey{}, claims), nil
+}
+
+func verify(ctx context.Context, tokenString string, secret []byte, o options) (*TokenClaims, error) {
+	if !IsPersonalToken(tokenString) {
 		claims, err := VerifyJWT(tokenString, secret)
 		if err != nil {
 			return nil, status.Error(codes.Unauthenticated, "invalid token")
 		}
-
-		md.Append(UserIdKey, claims.UserID)
-		ctx = metadata.NewIncomingContext(ctx, md)
-		return handler(ctx, req)
+		return claims, nil
+	}
+	if o.personalTokens == nil {
+		return nil, status.Error(codes.Unauthenticated, "personal access tokens are not accepted")
+	}
+	claims, err := o.personalTokens.VerifyPersonalToken(ctx, tokenString)
+	if errors.Is(err, ErrInvalidToken) {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "failed to verify token")
 	}
+	return claims, nil
 }
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -8,14 +10,41 @@ import (
 
 type TokenClaims struct {
 	UserID string `json:"user_id"`
+	// Roles of user and permissions granted by them when token was signed
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// Set only for personal access tokens, access tokens are not limited by scopes
+	Scopes []string `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
 }
 
+func (c *TokenClaims) IsPersonal() bool {
+	return c.Scopes != nil
+}
+
+// AllowsMethod checks scopes of personal access token, full method is e.g. /profile.Profile/GetProfile
+func (c *TokenClaims) AllowsMethod(fullMethod string) bool {
+	if !c.IsPersonal() {
+		return true
+	}
+	pkg, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), ".")
+	return slices.Contains(c.Scopes, pkg)
+}
+
+func (c *TokenClaims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+func (c *TokenClaims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
 func VerifyJWT(tokenString string, secret []byte) (*TokenClaims, error) {
 	claims := &TokenClaims{}
+	// Tokens signed with other methods, e.g. tokens of OpenID Connect clients, are not access tokens
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
 		return secret, nil
-	})
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 	if err != nil {
 		return nil, err
 	}
@@ -25,9 +54,11 @@ func VerifyJWT(tokenString string, secret []byte) (*TokenClaims, error) {
 	return claims, nil
 }
 
-func SignJWT(userID string, secretKey []byte, ttl time.Duration, iss string) (string, error) {
+func SignJWT(userID string, roles, permissions []string, secretKey []byte, ttl time.Duration, iss string) (string, error) {
 	claims := TokenClaims{
-		UserID: userID,
+		UserID:      userID,
+		Roles:       roles,
+		Permissions: permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    iss,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
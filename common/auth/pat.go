@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Personal access tokens are issued by sso for programmatic access, they are sent like access tokens
+const PersonalTokenPrefix = "pat_"
+
+// Scopes of personal access tokens are APIs which token can call, named by their proto package
+const (
+	ScopeSSO          = "sso"
+	ScopeProfile      = "profile"
+	ScopeNotification = "notification"
+)
+
+var Scopes = []string{ScopeSSO, ScopeProfile, ScopeNotification}
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// TokenVerifier verifies personal access tokens, it returns ErrInvalidToken for unknown, expired and revoked tokens
+type TokenVerifier interface {
+	VerifyPersonalToken(ctx context.Context, token string) (*TokenClaims, error)
+}
+
+func IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, PersonalTokenPrefix)
+}
+
+type ssoVerifier struct {
+	client pb.PersonalTokensClient
+}
+
+// NewSSOVerifier verifies personal access tokens in sso, services use it with WithPersonalTokens
+func NewSSOVerifier(client pb.PersonalTokensClient) *ssoVerifier {
+	return &ssoVerifier{client: client}
+}
+
+func (v *ssoVerifier) VerifyPersonalToken(ctx context.Context, token string) (*TokenClaims, error) {
+	// Client of request is forwarded, so sso records where token was used
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range []string{request.IPKey, request.UserAgentKey, request.IDKey} {
+			if values := md.Get(key); len(values) > 0 {
+				ctx = metadata.AppendToOutgoingContext(ctx, key, values[0])
+			}
+		}
+	}
+	resp, err := v.client.IntrospectToken(ctx, &pb.IntrospectTokenRequest{Token: token})
+	if status.Code(err) == codes.Unauthenticated {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect token: %w", err)
+	}
+	// Nil scopes mean token is not limited, so empty scopes must stay non-nil
+	scopes := append([]string{}, resp.Scopes...)
+	return &TokenClaims{
+		UserID:      resp.UserId,
+		Roles:       resp.Roles,
+		Permissions: resp.Permissions,
+		Scopes:      scopes,
+	}, nil
+}
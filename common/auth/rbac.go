@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles and permissions are stored in sso and embedded in access token
+const RoleAdmin = "admin"
+
+const (
+	PermissionAssignRoles = "roles:assign"
+	PermissionBroadcast   = "broadcasts:send"
+	PermissionManageUsers = "users:manage"
+	PermissionReadAudit   = "audit:read"
+	// Registers OpenID Connect clients in sso
+	PermissionManageClients = "clients:manage"
+	// Granted to service tokens only, services manage sessions on behalf of users, e.g. telegram bot
+	PermissionManageSessions = "sessions:manage"
+)
+
+// Policy maps full gRPC method names, e.g. /sso.SSO/AssignRole, to permission required to call them.
+// Methods missing in policy don't require permissions
+type Policy map[string]string
+
+// AuthzInterceptor checks permissions of claims verified by JwtInterceptor or OptionalJwtInterceptor,
+// so it must be chained after them
+func AuthzInterceptor(policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		permission, ok := policy[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		claims := ExtractClaims(ctx)
+		if claims == nil {
+			return nil, status.Error(codes.Unauthenticated, "authorization token is missing")
+		}
+		if !claims.HasPermission(permission) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,112 @@
+package request
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Info describes client of request, gateway forwards it to services in metadata
+type Info struct {
+	IP        string
+	UserAgent string
+	ID        string
+}
+
+const (
+	IPKey = "x-real-ip"
+	// gRPC replaces user-agent with its own, so agent of client is sent in separate key
+	UserAgentKey = "x-client-user-agent"
+	IDKey        = "x-request-id"
+)
+
+type infoKey struct{}
+
+func Inject(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, infoKey{}, info)
+}
+
+func Extract(ctx context.Context) Info {
+	info, _ := ctx.Value(infoKey{}).(Info)
+	return info
+}
+
+// Interceptor injects Info from metadata. Services are called only by gateway and each other,
+// so metadata is trusted. Peer address is used if IP is not forwarded, ID is generated if missing
+func Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(Inject(ctx, fromMetadata(ctx)), req)
+	}
+}
+
+func fromMetadata(ctx context.Context) Info {
+	md, _ := metadata.FromIncomingContext(ctx)
+	info := Info{
+		IP:        first(md.Get(IPKey)),
+		UserAgent: first(md.Get(UserAgentKey)),
+		ID:        first(md.Get(IDKey)),
+	}
+	if info.IP == "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			info.IP = hostOf(p.Addr.String())
+		}
+	}
+	if info.ID == "" {
+		info.ID = newID()
+	}
+	return info
+}
+
+// FromHTTP describes HTTP request, first address of X-Forwarded-For is used if it is set
+func FromHTTP(r *http.Request) Info {
+	info := Info{
+		IP:        hostOf(r.RemoteAddr),
+		UserAgent: r.UserAgent(),
+		ID:        r.Header.Get("X-Request-Id"),
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		info.IP = strings.TrimSpace(ip)
+	}
+	if info.ID == "" {
+		info.ID = newID()
+	}
+	return info
+}
+
+// Outgoing forwards info to called service
+func Outgoing(ctx context.Context, info Info) context.Context {
+	return metadata.AppendToOutgoingContext(ctx,
+		IPKey, info.IP,
+		UserAgentKey, info.UserAgent,
+		IDKey, info.ID,
+	)
+}
+
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -15,6 +15,7 @@ import (
 	notificationPb "github.com/SergeyBogomolovv/profile-manager/common/api/notification"
 	profilePb "github.com/SergeyBogomolovv/profile-manager/common/api/profile"
 	ssoPb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
 
 	_ "github.com/SergeyBogomolovv/profile-manager/gateway/docs"
 	"github.com/SergeyBogomolovv/profile-manager/gateway/internal/config"
@@ -38,6 +39,8 @@ func main() {
 	exitOnErr("failed to connect to sso", err)
 	defer ssoConn.Close()
 	ssoClient := ssoPb.NewSSOClient(ssoConn)
+	adminClient := ssoPb.NewAdminClient(ssoConn)
+	tokensClient := ssoPb.NewPersonalTokensClient(ssoConn)
 
 	profileConn, err := grpc.NewClient(conf.ProfileAddr, opts...)
 	exitOnErr("failed to connect to profile", err)
@@ -50,8 +53,9 @@ func main() {
 	notificationClient := notificationPb.NewNotificationClient(notificationConn)
 
 	profileController := controller.NewProfileController(logger, profileClient)
-	authController := controller.NewAuthController(logger, ssoClient)
+	authController := controller.NewAuthController(logger, ssoClient, tokensClient)
 	notiController := controller.NewNotificationController(logger, notificationClient)
+	adminController := controller.NewAdminController(logger, adminClient, ssoClient, auth.NewSSOVerifier(tokensClient), []byte(conf.JwtSecret))
 
 	r := chi.NewRouter()
 
@@ -59,6 +63,7 @@ func main() {
 	authController.Init(r)
 	profileController.Init(r)
 	notiController.Init(r)
+	adminController.Init(r)
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", conf.HttpPort),
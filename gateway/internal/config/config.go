@@ -11,11 +11,15 @@ type Config struct {
 	SsoAddr          string `mapstructure:"sso_addr"`
 	ProfileAddr      string `mapstructure:"profile_addr"`
 	NotificationAddr string `mapstructure:"notification_addr"`
+	// Verifies access tokens of admin routes before they reach services
+	JwtSecret string `mapstructure:"jwt_secret"`
 }
 
 func MustLoadConfig(path string) *Config {
 	viper.SetConfigFile(path)
 
+	viper.BindEnv("jwt_secret", "JWT_SECRET")
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatalf("could not read config file: %v", err)
 	}
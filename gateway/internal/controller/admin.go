@@ -0,0 +1,463 @@
+package controller
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/httpx"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type adminController struct {
+	logger    *slog.Logger
+	validate  *validator.Validate
+	client    pb.AdminClient
+	sso       pb.SSOClient
+	tokens    auth.TokenVerifier
+	jwtSecret []byte
+}
+
+func NewAdminController(logger *slog.Logger, client pb.AdminClient, sso pb.SSOClient, tokens auth.TokenVerifier, jwtSecret []byte) *adminController {
+	validate := validator.New()
+	return &adminController{logger: logger, validate: validate, client: client, sso: sso, tokens: tokens, jwtSecret: jwtSecret}
+}
+
+// Init initializes admin routes, they are available only to users with admin role.
+func (c *adminController) Init(r *chi.Mux) {
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(requireRole(c.jwtSecret, c.tokens, auth.RoleAdmin))
+		r.Get("/users", c.HandleListUsers)
+		r.Get("/users/{id}", c.HandleGetUser)
+		r.Post("/users/{id}/block", c.HandleBlockUser)
+		r.Delete("/users/{id}/block", c.HandleUnblockUser)
+		r.Post("/users/{id}/logout", c.HandleForceLogout)
+		r.Post("/users/{id}/roles", c.HandleAssignRole)
+		r.Get("/audit", c.HandleListAuditLog)
+		r.Get("/audit/verify", c.HandleVerifyAuditLog)
+	})
+}
+
+// HandleListUsers returns users matching filters.
+// @Summary List users
+// @Description Returns page of users, newest first. Requires admin role.
+// @Tags admin
+// @Produce json
+// @Param email query string false "Part of email, case insensitive"
+// @Param role query string false "Role of users"
+// @Param blocked query bool false "Only blocked or only active users"
+// @Param limit query int false "Max users, 1-100" default(20)
+// @Param offset query int false "Users to skip" default(0)
+// @Success 200 {object} UsersResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Router /admin/users [get]
+// @Security BearerAuth
+func (c *adminController) HandleListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.ListUsersRequest{Email: query.Get("email"), Role: query.Get("role")}
+	if v := query.Get("blocked"); v != "" {
+		blocked, err := strconv.ParseBool(v)
+		if err != nil {
+			httpx.WriteError(w, "Invalid blocked", http.StatusBadRequest)
+			return
+		}
+		req.Blocked = &blocked
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || limit < 1 {
+			httpx.WriteError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		req.Limit = int32(limit)
+	}
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || offset < 0 {
+			httpx.WriteError(w, "Invalid offset", http.StatusBadRequest)
+			return
+		}
+		req.Offset = int32(offset)
+	}
+
+	resp, err := c.client.ListUsers(authCtx(r), req)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to list users", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		default:
+			httpx.WriteError(w, "Failed to list users", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := UsersResponse{Users: make([]UserResponse, len(resp.Users)), Total: resp.Total}
+	for i, user := range resp.Users {
+		res.Users[i] = userResponse(user)
+	}
+	httpx.WriteJSON(w, res, http.StatusOK)
+}
+
+// HandleGetUser returns details of the user.
+// @Summary Get user
+// @Description Returns accounts, roles, active sessions and profile of the user. Requires admin role, the view is written to audit log.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} UserDetailsResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /admin/users/{id} [get]
+// @Security BearerAuth
+func (c *adminController) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.GetUser(authCtx(r), &pb.GetUserRequest{UserId: chi.URLParam(r, "id")})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to get user", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		case codes.NotFound:
+			httpx.WriteError(w, "User not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to get user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := UserDetailsResponse{
+		User:     userResponse(resp.User),
+		Accounts: resp.Accounts,
+		Roles:    resp.Roles,
+		Sessions: make([]SessionResponse, len(resp.Sessions)),
+	}
+	for i, s := range resp.Sessions {
+		res.Sessions[i] = SessionResponse{ID: s.Id, IP: s.Ip, Type: s.Type, CreatedAt: s.CreatedAt, ExpiresAt: s.ExpiresAt}
+	}
+	if p := resp.Profile; p != nil {
+		res.Profile = &ProfileSummaryResponse{Username: p.Username, FirstName: p.FirstName, LastName: p.LastName, Avatar: p.Avatar}
+	}
+	httpx.WriteJSON(w, res, http.StatusOK)
+}
+
+// HandleBlockUser blocks the user.
+// @Summary Block user
+// @Description Blocked user can't log in or refresh tokens, all sessions of the user are terminated. Requires admin role.
+// @Tags admin
+// @Accept json
+// @Param id path string true "User ID"
+// @Param request body BlockUserRequest false "Reason of block"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Failure 409 {object} httpx.ErrorResponse "Admin can't block own account"
+// @Router /admin/users/{id}/block [post]
+// @Security BearerAuth
+func (c *adminController) HandleBlockUser(w http.ResponseWriter, r *http.Request) {
+	var body BlockUserRequest
+	if r.ContentLength != 0 {
+		if err := httpx.DecodeBody(r, &body); err != nil {
+			httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+			return
+		}
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, "Invalid reason", http.StatusBadRequest)
+		return
+	}
+
+	_, err := c.client.BlockUser(authCtx(r), &pb.BlockUserRequest{UserId: chi.URLParam(r, "id"), Reason: body.Reason})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to block user", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		case codes.NotFound:
+			httpx.WriteError(w, "User not found", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			httpx.WriteError(w, st.Message(), http.StatusConflict)
+		default:
+			httpx.WriteError(w, "Failed to block user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleUnblockUser unblocks the user.
+// @Summary Unblock user
+// @Description Allows the user to log in again. Requires admin role.
+// @Tags admin
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /admin/users/{id}/block [delete]
+// @Security BearerAuth
+func (c *adminController) HandleUnblockUser(w http.ResponseWriter, r *http.Request) {
+	_, err := c.client.UnblockUser(authCtx(r), &pb.UnblockUserRequest{UserId: chi.URLParam(r, "id")})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to unblock user", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		case codes.NotFound:
+			httpx.WriteError(w, "User not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to unblock user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleForceLogout terminates all sessions of the user.
+// @Summary Force logout
+// @Description Terminates all sessions of the user, access tokens which are already issued live until expiration. Requires admin role.
+// @Tags admin
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /admin/users/{id}/logout [post]
+// @Security BearerAuth
+func (c *adminController) HandleForceLogout(w http.ResponseWriter, r *http.Request) {
+	_, err := c.client.ForceLogout(authCtx(r), &pb.ForceLogoutRequest{UserId: chi.URLParam(r, "id")})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to logout user", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		case codes.NotFound:
+			httpx.WriteError(w, "User not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to logout user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleAssignRole grants role to the user.
+// @Summary Assign role
+// @Description Grants role to the user, it is applied when the user refreshes access token. Requires admin role.
+// @Tags admin
+// @Accept json
+// @Param id path string true "User ID"
+// @Param request body AssignRoleRequest true "Role"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse "User or role not found"
+// @Router /admin/users/{id}/roles [post]
+// @Security BearerAuth
+func (c *adminController) HandleAssignRole(w http.ResponseWriter, r *http.Request) {
+	var body AssignRoleRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, "Invalid role", http.StatusBadRequest)
+		return
+	}
+
+	_, err := c.sso.AssignRole(authCtx(r), &pb.AssignRoleRequest{UserId: chi.URLParam(r, "id"), Role: body.Role})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to assign role", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		case codes.NotFound:
+			httpx.WriteError(w, st.Message(), http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to assign role", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleListAuditLog returns security audit log.
+// @Summary List audit log
+// @Description Returns audit entries newest first, filtered by user (as actor or target) and action. Requires admin role.
+// @Tags admin
+// @Produce json
+// @Param user_id query string false "User ID"
+// @Param action query string false "Action, e.g. auth.login"
+// @Param before_id query int false "Returns entries older than this ID"
+// @Param limit query int false "Max entries, 1-200" default(50)
+// @Success 200 {object} AuditLogResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Router /admin/audit [get]
+// @Security BearerAuth
+func (c *adminController) HandleListAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.ListAuditLogRequest{UserId: query.Get("user_id"), Action: query.Get("action")}
+	if v := query.Get("before_id"); v != "" {
+		beforeID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || beforeID < 1 {
+			httpx.WriteError(w, "Invalid before_id", http.StatusBadRequest)
+			return
+		}
+		req.BeforeId = beforeID
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || limit < 1 {
+			httpx.WriteError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		req.Limit = int32(limit)
+	}
+
+	resp, err := c.client.ListAuditLog(authCtx(r), req)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to list audit log", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		default:
+			httpx.WriteError(w, "Failed to list audit log", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := AuditLogResponse{Entries: make([]AuditEntryResponse, len(resp.Entries))}
+	for i, e := range resp.Entries {
+		res.Entries[i] = AuditEntryResponse{
+			ID:        e.Id,
+			ActorID:   e.ActorId,
+			Action:    e.Action,
+			TargetID:  e.TargetId,
+			Result:    e.Result,
+			IP:        e.Ip,
+			UserAgent: e.UserAgent,
+			RequestID: e.RequestId,
+			Details:   e.Details,
+			CreatedAt: e.CreatedAt,
+			Hash:      e.Hash,
+		}
+	}
+	httpx.WriteJSON(w, res, http.StatusOK)
+}
+
+// HandleVerifyAuditLog checks integrity of audit log.
+// @Summary Verify audit log
+// @Description Checks hash chain of audit log and returns first entry which was changed or follows removed one. Requires admin role.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} AuditVerificationResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Router /admin/audit/verify [get]
+// @Security BearerAuth
+func (c *adminController) HandleVerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.VerifyAuditLog(authCtx(r), &pb.VerifyAuditLogRequest{})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to verify audit log", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+		default:
+			httpx.WriteError(w, "Failed to verify audit log", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	httpx.WriteJSON(w, AuditVerificationResponse{Valid: resp.Valid, Checked: resp.Checked, BrokenID: resp.BrokenId}, http.StatusOK)
+}
+
+func userResponse(user *pb.User) UserResponse {
+	return UserResponse{
+		ID:           user.Id,
+		Email:        user.Email,
+		RegisteredAt: user.RegisteredAt,
+		BlockedAt:    user.BlockedAt,
+		BlockReason:  user.BlockReason,
+	}
+}
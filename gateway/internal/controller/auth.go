@@ -17,20 +17,26 @@ type authController struct {
 	logger   *slog.Logger
 	validate *validator.Validate
 	client   pb.SSOClient
+	tokens   pb.PersonalTokensClient
 }
 
-func NewAuthController(logger *slog.Logger, client pb.SSOClient) *authController {
+func NewAuthController(logger *slog.Logger, client pb.SSOClient, tokens pb.PersonalTokensClient) *authController {
 	validate := validator.New()
-	return &authController{validate: validate, logger: logger, client: client}
+	return &authController{validate: validate, logger: logger, client: client, tokens: tokens}
 }
 
 // Init initializes authentication routes.
 func (c *authController) Init(r *chi.Mux) {
 	r.Route("/auth", func(r chi.Router) {
 		r.Post("/login", c.HandleLogin)
+		r.Post("/magic-link", c.HandleRequestMagicLink)
+		r.Post("/magic-link/verify", c.HandleConsumeMagicLink)
 		r.Post("/register", c.HandleRegister)
 		r.Post("/refresh", c.HandleRefresh)
 		r.Post("/logout", c.HandleLogout)
+		r.Get("/tokens", c.HandleListPersonalTokens)
+		r.Post("/tokens", c.HandleCreatePersonalToken)
+		r.Delete("/tokens/{id}", c.HandleRevokePersonalToken)
 	})
 }
 
@@ -56,7 +62,7 @@ func (c *authController) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := c.client.Login(r.Context(), &pb.LoginRequest{
+	resp, err := c.client.Login(requestCtx(r), &pb.LoginRequest{
 		Email:    body.Email,
 		Password: body.Password,
 	})
@@ -84,6 +90,89 @@ func (c *authController) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	httpx.WriteSuccess(w, "Login successful", http.StatusOK)
 }
 
+// HandleRequestMagicLink sends sign in link.
+// @Summary Request sign in link
+// @Description Emails single use sign in link, user is registered on sign in if email is new. Response is the same for registered and new emails.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body MagicLinkRequest true "Email to send the link to"
+// @Param Accept-Language header string false "Preferred languages of email"
+// @Success 202 {object} httpx.SuccessResponse "Link is sent"
+// @Failure 400 {object} httpx.ErrorResponse "Bad request"
+// @Failure 429 {object} httpx.ErrorResponse "Too many links requested for the email"
+// @Router /auth/magic-link [post]
+func (c *authController) HandleRequestMagicLink(w http.ResponseWriter, r *http.Request) {
+	var body MagicLinkRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err := c.client.RequestMagicLink(requestCtx(r), &pb.RequestMagicLinkRequest{
+		Email:  body.Email,
+		Locale: r.Header.Get("Accept-Language"),
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, "Invalid parameters", http.StatusBadRequest)
+		case codes.ResourceExhausted:
+			httpx.WriteError(w, "Too many sign in links, try again later", http.StatusTooManyRequests)
+		default:
+			httpx.WriteError(w, "Failed to send sign in link", http.StatusInternalServerError)
+		}
+		return
+	}
+	httpx.WriteSuccess(w, "Sign in link is sent", http.StatusAccepted)
+}
+
+// HandleConsumeMagicLink signs user in by sign in link.
+// @Summary Sign in by link
+// @Description Exchanges token from sign in link for tokens, token can be used once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ConsumeMagicLinkRequest true "Token from the link"
+// @Success 200 {object} httpx.SuccessResponse "Successful login, tokens are set as cookies"
+// @Failure 400 {object} httpx.ErrorResponse "Bad request"
+// @Failure 401 {object} httpx.ErrorResponse "Link is invalid, expired or already used"
+// @Failure 403 {object} httpx.ErrorResponse "User is blocked"
+// @Router /auth/magic-link/verify [post]
+func (c *authController) HandleConsumeMagicLink(w http.ResponseWriter, r *http.Request) {
+	var body ConsumeMagicLinkRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := c.client.ConsumeMagicLink(requestCtx(r), &pb.ConsumeMagicLinkRequest{Token: body.Token})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Invalid or expired link", http.StatusUnauthorized)
+		case codes.PermissionDenied:
+			httpx.WriteError(w, "User is blocked", http.StatusForbidden)
+		default:
+			httpx.WriteError(w, "Failed to login", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.SetCookie(w, newSecureCookie("refresh_token", resp.RefreshToken, time.Hour*24*7))
+	http.SetCookie(w, newSecureCookie("access_token", resp.AccessToken, time.Hour*24))
+
+	httpx.WriteSuccess(w, "Login successful", http.StatusOK)
+}
+
 // HandleRegister registers a new user.
 // @Summary User registration
 // @Description Registers a new user using email and password and returns the created user ID.
@@ -91,6 +180,7 @@ func (c *authController) HandleLogin(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param request body RegisterRequest true "User registration data"
+// @Param Accept-Language header string false "Preferred languages of notifications"
 // @Success 201 {object} RegisterResponse "User registered successfully, returns user ID"
 // @Failure 400 {object} httpx.ErrorResponse "Invalid data or bad request"
 // @Failure 409 {object} httpx.ErrorResponse "User with this email already exists"
@@ -106,9 +196,10 @@ func (c *authController) HandleRegister(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp, err := c.client.Register(r.Context(), &pb.RegisterRequest{
+	resp, err := c.client.Register(requestCtx(r), &pb.RegisterRequest{
 		Email:    body.Email,
 		Password: body.Password,
+		Locale:   r.Header.Get("Accept-Language"),
 	})
 
 	if err != nil {
@@ -147,7 +238,7 @@ func (c *authController) HandleRefresh(w http.ResponseWriter, r *http.Request) {
 		httpx.WriteError(w, "Refresh token not found", http.StatusUnauthorized)
 		return
 	}
-	resp, err := c.client.Refresh(r.Context(), &pb.RefreshRequest{
+	resp, err := c.client.Refresh(requestCtx(r), &pb.RefreshRequest{
 		RefreshToken: cookie.Value,
 	})
 
@@ -186,7 +277,7 @@ func (c *authController) HandleLogout(w http.ResponseWriter, r *http.Request) {
 		httpx.WriteError(w, "Refresh token not found", http.StatusUnauthorized)
 		return
 	}
-	_, err = c.client.Logout(r.Context(), &pb.LogoutRequest{
+	_, err = c.client.Logout(requestCtx(r), &pb.LogoutRequest{
 		RefreshToken: cookie.Value,
 	})
 	if err != nil {
@@ -199,3 +290,122 @@ func (c *authController) HandleLogout(w http.ResponseWriter, r *http.Request) {
 
 	httpx.WriteSuccess(w, "Logout successful", http.StatusOK)
 }
+
+// HandleCreatePersonalToken issues personal access token.
+// @Summary Create personal access token
+// @Description Token is returned only in this response, it is sent as "Authorization: Bearer <token>" and can call APIs of its scopes. Personal access tokens can't manage tokens, at most 20 tokens are allowed.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body CreatePersonalTokenRequest true "Token"
+// @Success 201 {object} CreatePersonalTokenResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Failure 409 {object} httpx.ErrorResponse
+// @Router /auth/tokens [post]
+// @Security BearerAuth
+func (c *authController) HandleCreatePersonalToken(w http.ResponseWriter, r *http.Request) {
+	var body CreatePersonalTokenRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := c.tokens.CreatePersonalToken(authCtx(r), &pb.CreatePersonalTokenRequest{
+		Name:          body.Name,
+		Scopes:        body.Scopes,
+		ExpiresInDays: body.ExpiresInDays,
+	})
+	if err != nil {
+		writePersonalTokenError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, CreatePersonalTokenResponse{
+		PersonalTokenResponse: personalTokenResponse(resp.Token),
+		Token:                 resp.Secret,
+	}, http.StatusCreated)
+}
+
+// HandleListPersonalTokens returns personal access tokens of the user.
+// @Summary List personal access tokens
+// @Description Returns tokens newest first, expired tokens are listed until they are revoked.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} PersonalTokensResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Router /auth/tokens [get]
+// @Security BearerAuth
+func (c *authController) HandleListPersonalTokens(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.tokens.ListPersonalTokens(authCtx(r), &pb.ListPersonalTokensRequest{})
+	if err != nil {
+		writePersonalTokenError(w, err)
+		return
+	}
+	res := PersonalTokensResponse{Tokens: make([]PersonalTokenResponse, len(resp.Tokens))}
+	for i, token := range resp.Tokens {
+		res.Tokens[i] = personalTokenResponse(token)
+	}
+	httpx.WriteJSON(w, res, http.StatusOK)
+}
+
+// HandleRevokePersonalToken revokes personal access token of the user.
+// @Summary Revoke personal access token
+// @Tags auth
+// @Produce json
+// @Param id path string true "Token ID"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 403 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /auth/tokens/{id} [delete]
+// @Security BearerAuth
+func (c *authController) HandleRevokePersonalToken(w http.ResponseWriter, r *http.Request) {
+	_, err := c.tokens.RevokePersonalToken(authCtx(r), &pb.RevokePersonalTokenRequest{TokenId: chi.URLParam(r, "id")})
+	if err != nil {
+		writePersonalTokenError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writePersonalTokenError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		httpx.WriteError(w, "Failed to process token", http.StatusInternalServerError)
+		return
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+	case codes.PermissionDenied:
+		httpx.WriteError(w, st.Message(), http.StatusForbidden)
+	case codes.NotFound:
+		httpx.WriteError(w, st.Message(), http.StatusNotFound)
+	case codes.FailedPrecondition:
+		httpx.WriteError(w, st.Message(), http.StatusConflict)
+	default:
+		httpx.WriteError(w, "Failed to process token", http.StatusInternalServerError)
+	}
+}
+
+func personalTokenResponse(token *pb.PersonalToken) PersonalTokenResponse {
+	return PersonalTokenResponse{
+		ID:         token.Id,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     token.Scopes,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIP: token.LastUsedIp,
+	}
+}
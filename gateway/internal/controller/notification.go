@@ -3,38 +3,56 @@ package controller
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	pb "github.com/SergeyBogomolovv/profile-manager/common/api/notification"
 	"github.com/SergeyBogomolovv/profile-manager/common/httpx"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type notiController struct {
-	logger *slog.Logger
-	client pb.NotificationClient
+	logger   *slog.Logger
+	validate *validator.Validate
+	client   pb.NotificationClient
 }
 
 func NewNotificationController(logger *slog.Logger, client pb.NotificationClient) *notiController {
-	return &notiController{logger: logger, client: client}
+	validate := validator.New()
+	return &notiController{logger: logger, validate: validate, client: client}
 }
 
 // Init initializes notification routes.
 func (c *notiController) Init(r *chi.Mux) {
 	r.Route("/notification", func(r chi.Router) {
 		r.Post("/token", c.HandleToken)
+		r.Get("/subscriptions", c.HandleListSubscriptions)
+		r.Put("/subscriptions/{type}", c.HandleUpdateSubscription)
+		r.Delete("/telegram", c.HandleUnlinkTelegram)
+		r.Get("/preferences", c.HandleGetPreferences)
+		r.Put("/preferences", c.HandleUpdatePreference)
+		r.Get("/schedule", c.HandleGetSchedule)
+		r.Put("/schedule", c.HandleUpdateSchedule)
+		r.Get("/deliveries", c.HandleListDeliveries)
+		r.Put("/contacts/{channel}", c.HandleSetContact)
+		r.Delete("/contacts/{channel}", c.HandleDeleteContact)
+		r.Get("/push/key", c.HandlePushKey)
+		r.Post("/push/subscriptions", c.HandleAddPushSubscription)
+		r.Delete("/push/subscriptions", c.HandleRemovePushSubscription)
 	})
 }
 
 // HandleToken generates a token for Telegram notifications.
 // @Summary Generate Telegram token
-// @Description Generates a token for Telegram notifications
+// @Description Generates a single-use token for Telegram notifications and a deep link to the bot with it, previous token is revoked
 // @Tags notification
 // @Accept json
 // @Produce json
 // @Success 200 {object} TokenResponse
 // @Failure 401 {object} httpx.ErrorResponse
+// @Failure 429 {object} httpx.ErrorResponse "Too many tokens generated recently"
 // @Router /notification/token [post]
 // @Security BearerAuth
 func (c *notiController) HandleToken(w http.ResponseWriter, r *http.Request) {
@@ -49,11 +67,533 @@ func (c *notiController) HandleToken(w http.ResponseWriter, r *http.Request) {
 		switch st.Code() {
 		case codes.Unauthenticated:
 			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.ResourceExhausted:
+			httpx.WriteError(w, "Too many tokens, try again later", http.StatusTooManyRequests)
 		default:
 			httpx.WriteError(w, "Failed to generate token", http.StatusInternalServerError)
 		}
 		return
 	}
 
-	httpx.WriteJSON(w, TokenResponse{Token: resp.Token}, http.StatusOK)
+	httpx.WriteJSON(w, TokenResponse{Token: resp.Token, Link: resp.Link}, http.StatusOK)
+}
+
+// HandleListSubscriptions returns the user's notification subscriptions.
+// @Summary List subscriptions
+// @Description Returns email and Telegram subscriptions of the authenticated user. Telegram subscription exists only after linking the account.
+// @Tags notification
+// @Produce json
+// @Success 200 {object} SubscriptionsResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/subscriptions [get]
+// @Security BearerAuth
+func (c *notiController) HandleListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.ListSubscriptions(authCtx(r), &pb.ListSubscriptionsRequest{})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to list subscriptions", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.NotFound:
+			httpx.WriteError(w, "User not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to list subscriptions", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	subscriptions := make([]SubscriptionResponse, len(resp.Subscriptions))
+	for i, sub := range resp.Subscriptions {
+		subscriptions[i] = SubscriptionResponse{Type: sub.Type, Enabled: sub.Enabled}
+	}
+	httpx.WriteJSON(w, SubscriptionsResponse{Subscriptions: subscriptions}, http.StatusOK)
+}
+
+// HandleUpdateSubscription enables or disables a subscription.
+// @Summary Update subscription
+// @Description Enables or disables notifications of the channel for the authenticated user.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param type path string true "Subscription type" Enums(email, telegram, webhook, sms, webpush)
+// @Param request body UpdateSubscriptionRequest true "Subscription status"
+// @Success 200 {object} SubscriptionResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse "Subscription not found, link Telegram first"
+// @Router /notification/subscriptions/{type} [put]
+// @Security BearerAuth
+func (c *notiController) HandleUpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	var body UpdateSubscriptionRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if body.Enabled == nil {
+		httpx.WriteError(w, "Enabled is required", http.StatusBadRequest)
+		return
+	}
+	subType := chi.URLParam(r, "type")
+
+	_, err := c.client.UpdateSubscription(authCtx(r), &pb.UpdateSubscriptionRequest{Type: subType, Enabled: *body.Enabled})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to update subscription", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, "Invalid subscription type", http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.NotFound:
+			httpx.WriteError(w, "Subscription not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to update subscription", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	httpx.WriteJSON(w, SubscriptionResponse{Type: subType, Enabled: *body.Enabled}, http.StatusOK)
+}
+
+// HandleUnlinkTelegram unlinks the user's Telegram account.
+// @Summary Unlink Telegram
+// @Description Unlinks Telegram account of the authenticated user and removes Telegram subscription.
+// @Tags notification
+// @Produce json
+// @Success 204
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 409 {object} httpx.ErrorResponse "Telegram is not linked"
+// @Router /notification/telegram [delete]
+// @Security BearerAuth
+func (c *notiController) HandleUnlinkTelegram(w http.ResponseWriter, r *http.Request) {
+	_, err := c.client.UnlinkTelegram(authCtx(r), &pb.UnlinkTelegramRequest{})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to unlink telegram", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.NotFound:
+			httpx.WriteError(w, "User not found", http.StatusNotFound)
+		case codes.FailedPrecondition:
+			httpx.WriteError(w, "Telegram is not linked", http.StatusConflict)
+		default:
+			httpx.WriteError(w, "Failed to unlink telegram", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGetPreferences returns the user's notification preferences.
+// @Summary Get notification preferences
+// @Description Returns the full matrix of channels (email, telegram, webhook, sms, webpush) by event categories (login, security, account, marketing).
+// @Tags notification
+// @Produce json
+// @Success 200 {object} PreferencesResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/preferences [get]
+// @Security BearerAuth
+func (c *notiController) HandleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.GetPreferences(authCtx(r), &pb.GetPreferencesRequest{})
+	if err != nil {
+		writePreferencesError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, preferencesResponse(resp), http.StatusOK)
+}
+
+// HandleUpdatePreference enables or disables a category for a channel.
+// @Summary Update notification preference
+// @Description Enables or disables events of the category for the channel. Marketing is disabled by default, other categories are enabled.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param request body UpdatePreferenceRequest true "Preference"
+// @Success 200 {object} PreferencesResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/preferences [put]
+// @Security BearerAuth
+func (c *notiController) HandleUpdatePreference(w http.ResponseWriter, r *http.Request) {
+	var body UpdatePreferenceRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := c.client.UpdatePreference(authCtx(r), &pb.UpdatePreferenceRequest{
+		Channel:  body.Channel,
+		Category: body.Category,
+		Enabled:  *body.Enabled,
+	})
+	if err != nil {
+		writePreferencesError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, preferencesResponse(resp), http.StatusOK)
+}
+
+func writePreferencesError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		httpx.WriteError(w, "Failed to process preferences", http.StatusInternalServerError)
+		return
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+	case codes.NotFound:
+		httpx.WriteError(w, "User not found", http.StatusNotFound)
+	default:
+		httpx.WriteError(w, "Failed to process preferences", http.StatusInternalServerError)
+	}
+}
+
+func preferencesResponse(prefs *pb.Preferences) PreferencesResponse {
+	res := PreferencesResponse{Preferences: make([]PreferenceResponse, len(prefs.Preferences))}
+	for i, pref := range prefs.Preferences {
+		res.Preferences[i] = PreferenceResponse{Channel: pref.Channel, Category: pref.Category, Enabled: pref.Enabled}
+	}
+	return res
+}
+
+// HandleGetSchedule returns the user's delivery schedule.
+// @Summary Get notification schedule
+// @Description Returns quiet hours and daily digest settings.
+// @Tags notification
+// @Produce json
+// @Success 200 {object} ScheduleResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/schedule [get]
+// @Security BearerAuth
+func (c *notiController) HandleGetSchedule(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.GetSchedule(authCtx(r), &pb.GetScheduleRequest{})
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, scheduleResponse(resp), http.StatusOK)
+}
+
+// HandleUpdateSchedule updates quiet hours and daily digest settings.
+// @Summary Update notification schedule
+// @Description Notifications arriving during quiet hours are delivered when they end. With digest enabled notifications are collected and delivered once a day at digest hour.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param request body UpdateScheduleRequest true "Schedule"
+// @Success 200 {object} ScheduleResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/schedule [put]
+// @Security BearerAuth
+func (c *notiController) HandleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	var body UpdateScheduleRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := c.client.UpdateSchedule(authCtx(r), &pb.Schedule{
+		Timezone:   body.Timezone,
+		QuietHours: body.QuietHours,
+		QuietFrom:  body.QuietFrom,
+		QuietTo:    body.QuietTo,
+		Digest:     body.Digest,
+		DigestHour: body.DigestHour,
+	})
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, scheduleResponse(resp), http.StatusOK)
+}
+
+func writeScheduleError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		httpx.WriteError(w, "Failed to process schedule", http.StatusInternalServerError)
+		return
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+	case codes.NotFound:
+		httpx.WriteError(w, "User not found", http.StatusNotFound)
+	default:
+		httpx.WriteError(w, "Failed to process schedule", http.StatusInternalServerError)
+	}
+}
+
+func scheduleResponse(schedule *pb.Schedule) ScheduleResponse {
+	return ScheduleResponse{
+		Timezone:   schedule.Timezone,
+		QuietHours: schedule.QuietHours,
+		QuietFrom:  schedule.QuietFrom,
+		QuietTo:    schedule.QuietTo,
+		Digest:     schedule.Digest,
+		DigestHour: schedule.DigestHour,
+	}
+}
+
+// HandleListDeliveries returns the user's recent notification deliveries.
+// @Summary List notification deliveries
+// @Description Returns recent delivery attempts, one per message and channel, newest first. Failed channels are retried with backoff while status is pending.
+// @Tags notification
+// @Produce json
+// @Param limit query int false "Max deliveries, 1-100" default(20)
+// @Success 200 {object} DeliveriesResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/deliveries [get]
+// @Security BearerAuth
+func (c *notiController) HandleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	var limit int64
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.ParseInt(v, 10, 32); err != nil || limit < 1 {
+			httpx.WriteError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	resp, err := c.client.ListDeliveries(authCtx(r), &pb.ListDeliveriesRequest{Limit: int32(limit)})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to list deliveries", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.NotFound:
+			httpx.WriteError(w, "User not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to list deliveries", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := DeliveriesResponse{Deliveries: make([]DeliveryResponse, len(resp.Deliveries))}
+	for i, d := range resp.Deliveries {
+		res.Deliveries[i] = DeliveryResponse{
+			ID:                d.Id,
+			Channel:           d.Channel,
+			Category:          d.Category,
+			Status:            d.Status,
+			Attempts:          d.Attempts,
+			LastError:         d.LastError,
+			ProviderMessageID: d.ProviderMessageId,
+			CreatedAt:         d.CreatedAt,
+			UpdatedAt:         d.UpdatedAt,
+			Digest:            d.Digest,
+		}
+	}
+	httpx.WriteJSON(w, res, http.StatusOK)
+}
+
+// HandleSetContact sets the user's webhook URL or phone number and subscribes to the channel.
+// @Summary Set channel contact
+// @Description Webhook requires https URL, requests to it are signed with HMAC-SHA256 of "<timestamp>.<body>" in X-Webhook-Signature header. Secret is returned only in this response, setting webhook again generates new secret. SMS requires phone number in E.164 format.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param channel path string true "Channel" Enums(webhook, sms)
+// @Param request body SetContactRequest true "Contact"
+// @Success 200 {object} ContactResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/contacts/{channel} [put]
+// @Security BearerAuth
+func (c *notiController) HandleSetContact(w http.ResponseWriter, r *http.Request) {
+	var body SetContactRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := c.client.SetContact(authCtx(r), &pb.SetContactRequest{Channel: chi.URLParam(r, "channel"), Address: body.Address})
+	if err != nil {
+		writeContactError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, ContactResponse{Channel: resp.Channel, Address: resp.Address, Secret: resp.Secret}, http.StatusOK)
+}
+
+// HandleDeleteContact removes the user's webhook URL or phone number with subscription to the channel.
+// @Summary Delete channel contact
+// @Tags notification
+// @Produce json
+// @Param channel path string true "Channel" Enums(webhook, sms)
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/contacts/{channel} [delete]
+// @Security BearerAuth
+func (c *notiController) HandleDeleteContact(w http.ResponseWriter, r *http.Request) {
+	_, err := c.client.DeleteContact(authCtx(r), &pb.DeleteContactRequest{Channel: chi.URLParam(r, "channel")})
+	if err != nil {
+		writeContactError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeContactError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		httpx.WriteError(w, "Failed to process contact", http.StatusInternalServerError)
+		return
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+	case codes.NotFound:
+		httpx.WriteError(w, st.Message(), http.StatusNotFound)
+	default:
+		httpx.WriteError(w, "Failed to process contact", http.StatusInternalServerError)
+	}
+}
+
+// HandlePushKey returns the VAPID public key for subscribing browser to web push.
+// @Summary Get web push key
+// @Description Returns VAPID public key, web client passes it as applicationServerKey to pushManager.subscribe.
+// @Tags notification
+// @Produce json
+// @Success 200 {object} PushKeyResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 503 {object} httpx.ErrorResponse
+// @Router /notification/push/key [get]
+// @Security BearerAuth
+func (c *notiController) HandlePushKey(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.GetPushPublicKey(authCtx(r), &pb.GetPushPublicKeyRequest{})
+	if err != nil {
+		writePushError(w, err)
+		return
+	}
+	httpx.WriteJSON(w, PushKeyResponse{PublicKey: resp.PublicKey}, http.StatusOK)
+}
+
+// HandleAddPushSubscription registers the user's browser and subscribes to web push.
+// @Summary Add web push subscription
+// @Description Accepts result of PushSubscription.toJSON() as is. Pushes are sent to every registered browser, browsers whose subscriptions expired are removed.
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param request body PushSubscriptionRequest true "Push subscription"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Failure 503 {object} httpx.ErrorResponse
+// @Router /notification/push/subscriptions [post]
+// @Security BearerAuth
+func (c *notiController) HandleAddPushSubscription(w http.ResponseWriter, r *http.Request) {
+	var body PushSubscriptionRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &pb.AddPushSubscriptionRequest{Endpoint: body.Endpoint, P256Dh: body.Keys.P256dh, Auth: body.Keys.Auth}
+	if _, err := c.client.AddPushSubscription(authCtx(r), req); err != nil {
+		writePushError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRemovePushSubscription unregisters the user's browser, subscription to web push is removed with the last browser.
+// @Summary Remove web push subscription
+// @Tags notification
+// @Accept json
+// @Produce json
+// @Param request body RemovePushSubscriptionRequest true "Push subscription"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /notification/push/subscriptions [delete]
+// @Security BearerAuth
+func (c *notiController) HandleRemovePushSubscription(w http.ResponseWriter, r *http.Request) {
+	var body RemovePushSubscriptionRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := c.client.RemovePushSubscription(authCtx(r), &pb.RemovePushSubscriptionRequest{Endpoint: body.Endpoint}); err != nil {
+		writePushError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writePushError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		httpx.WriteError(w, "Failed to process push subscription", http.StatusInternalServerError)
+		return
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+	case codes.NotFound:
+		httpx.WriteError(w, st.Message(), http.StatusNotFound)
+	case codes.FailedPrecondition:
+		httpx.WriteError(w, "Web push is not configured", http.StatusServiceUnavailable)
+	default:
+		httpx.WriteError(w, "Failed to process push subscription", http.StatusInternalServerError)
+	}
 }
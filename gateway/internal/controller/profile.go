@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	pb "github.com/SergeyBogomolovv/profile-manager/common/api/profile"
 	"github.com/SergeyBogomolovv/profile-manager/common/httpx"
@@ -11,6 +14,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type profileController struct {
@@ -28,7 +32,15 @@ func NewProfileController(logger *slog.Logger, client pb.ProfileClient) *profile
 func (c *profileController) Init(r *chi.Mux) {
 	r.Route("/profile", func(r chi.Router) {
 		r.Post("/update", c.HandleUpdate)
+		r.Patch("/", c.HandlePatch)
+		r.Delete("/avatar", c.HandleDeleteAvatar)
 		r.Get("/my", c.HandleGet)
+		r.Post("/privacy", c.HandleUpdatePrivacy)
+		r.Get("/contacts", c.HandleListContacts)
+		r.Put("/contacts/{user_id}", c.HandleAddContact)
+		r.Delete("/contacts/{user_id}", c.HandleDeleteContact)
+		r.Get("/username/{username}", c.HandleGetByUsername)
+		r.Get("/{user_id}", c.HandleGetUser)
 	})
 }
 
@@ -43,10 +55,16 @@ func (c *profileController) Init(r *chi.Mux) {
 // @Param last_name formData string false "Last name"
 // @Param birth_date formData string false "Birth date (YYYY-MM-DD)"
 // @Param gender formData string false "Gender (male or female)"
+// @Param locale formData string false "Language of notifications (BCP 47 tag)"
 // @Param avatar formData file false "Profile avatar"
+// @Param If-Match header string false "ETag of the profile"
 // @Success 200 {object} ProfileResponse "Profile updated successfully"
+// @Header 200 {string} ETag "Profile version"
 // @Failure 400 {object} httpx.ErrorResponse "Validation error or bad request"
 // @Failure 401 {object} httpx.ErrorResponse "Unauthorized"
+// @Failure 409 {object} httpx.ErrorResponse "Username already exists"
+// @Failure 412 {object} httpx.ErrorResponse "Profile was modified since it was read"
+// @Failure 429 {object} httpx.ErrorResponse "Username was changed recently"
 // @Router /profile/update [post]
 // @Security BearerAuth
 func (c *profileController) HandleUpdate(w http.ResponseWriter, r *http.Request) {
@@ -60,6 +78,7 @@ func (c *profileController) HandleUpdate(w http.ResponseWriter, r *http.Request)
 		LastName:  r.FormValue("last_name"),
 		BirthDate: r.FormValue("birth_date"),
 		Gender:    r.FormValue("gender"),
+		Locale:    r.FormValue("locale"),
 	}
 	// process avatar
 	file, _, err := r.FormFile("avatar")
@@ -79,32 +98,213 @@ func (c *profileController) HandleUpdate(w http.ResponseWriter, r *http.Request)
 		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		httpx.WriteError(w, "Precondition failed", http.StatusPreconditionFailed)
+		return
+	}
 	resp, err := c.client.UpdateProfile(authCtx(r), &pb.UpdateProfileRequest{
-		Username:  req.Username,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		BirthDate: req.BirthDate,
-		Gender:    req.Gender,
-		Avatar:    req.Avatar,
+		Username:        req.Username,
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		BirthDate:       req.BirthDate,
+		Gender:          req.Gender,
+		Locale:          req.Locale,
+		Avatar:          req.Avatar,
+		ExpectedVersion: version,
 	})
 
+	if err != nil {
+		writeUpdateError(w, err)
+		return
+	}
+
+	writeProfile(w, resp)
+}
+
+// HandlePatch partially updates the user's profile.
+// @Summary Partially update user profile
+// @Description Updates only the fields present in the body. A null value clears the field, null gender resets it to "not specified".
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param request body PatchProfileRequest true "Fields to update"
+// @Param If-Match header string false "ETag of the profile"
+// @Success 200 {object} ProfileResponse "Profile updated successfully"
+// @Header 200 {string} ETag "Profile version"
+// @Failure 400 {object} httpx.ErrorResponse "Validation error or bad request"
+// @Failure 401 {object} httpx.ErrorResponse "Unauthorized"
+// @Failure 409 {object} httpx.ErrorResponse "Username already exists"
+// @Failure 412 {object} httpx.ErrorResponse "Profile was modified since it was read"
+// @Failure 429 {object} httpx.ErrorResponse "Username was changed recently"
+// @Router /profile [patch]
+// @Security BearerAuth
+func (c *profileController) HandlePatch(w http.ResponseWriter, r *http.Request) {
+	var body PatchProfileRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	req := UpdateProfileRequest{
+		Username:  body.Username.Value,
+		FirstName: body.FirstName.Value,
+		LastName:  body.LastName.Value,
+		BirthDate: body.BirthDate.Value,
+		Gender:    body.Gender.Value,
+		Locale:    body.Locale.Value,
+	}
+	if err := c.validate.Struct(req); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mask := body.Mask()
+	if len(mask) == 0 {
+		httpx.WriteError(w, "Nothing to update", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		httpx.WriteError(w, "Precondition failed", http.StatusPreconditionFailed)
+		return
+	}
+
+	resp, err := c.client.UpdateProfile(authCtx(r), &pb.UpdateProfileRequest{
+		Username:        req.Username,
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		BirthDate:       req.BirthDate,
+		Gender:          req.Gender,
+		Locale:          req.Locale,
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: mask},
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		writeUpdateError(w, err)
+		return
+	}
+
+	writeProfile(w, resp)
+}
+
+// HandleDeleteAvatar removes the user's avatar.
+// @Summary Delete avatar
+// @Description Removes the authenticated user's avatar and deletes the stored image.
+// @Tags profile
+// @Produce json
+// @Param If-Match header string false "ETag of the profile"
+// @Success 200 {object} ProfileResponse "Avatar removed successfully"
+// @Header 200 {string} ETag "Profile version"
+// @Failure 401 {object} httpx.ErrorResponse "Unauthorized"
+// @Failure 412 {object} httpx.ErrorResponse "Profile was modified since it was read"
+// @Router /profile/avatar [delete]
+// @Security BearerAuth
+func (c *profileController) HandleDeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		httpx.WriteError(w, "Precondition failed", http.StatusPreconditionFailed)
+		return
+	}
+	resp, err := c.client.UpdateProfile(authCtx(r), &pb.UpdateProfileRequest{
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"avatar"}},
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		writeUpdateError(w, err)
+		return
+	}
+
+	writeProfile(w, resp)
+}
+
+func writeUpdateError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		httpx.WriteError(w, "Failed to update profile", http.StatusInternalServerError)
+		return
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+	case codes.AlreadyExists:
+		httpx.WriteError(w, "Username already exists", http.StatusConflict)
+	case codes.FailedPrecondition:
+		httpx.WriteError(w, "Username was changed recently", http.StatusTooManyRequests)
+	case codes.Aborted:
+		httpx.WriteError(w, "Profile was modified, reload and retry", http.StatusPreconditionFailed)
+	case codes.Unauthenticated:
+		httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+	case codes.NotFound:
+		httpx.WriteError(w, "Profile not found", http.StatusNotFound)
+	default:
+		httpx.WriteError(w, "Failed to update profile", http.StatusInternalServerError)
+	}
+}
+
+// HandleGet retrieves the authenticated user's profile.
+// @Summary Get user profile
+// @Description Retrieves the profile of the authenticated user
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Success 200 {object} ProfileResponse
+// @Header 200 {string} ETag "Profile version"
+// @Failure 401 {object} httpx.ErrorResponse
+// @Router /profile/my [get]
+// @Security BearerAuth
+func (c *profileController) HandleGet(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.GetProfile(authCtx(r), &pb.GetProfileRequest{})
 	if err != nil {
 		st, ok := status.FromError(err)
 		if !ok {
-			httpx.WriteError(w, "Failed to update profile", http.StatusInternalServerError)
+			httpx.WriteError(w, "Failed to get profile", http.StatusInternalServerError)
 			return
 		}
 		switch st.Code() {
 		case codes.InvalidArgument:
-			httpx.WriteError(w, "Invalid request", http.StatusBadRequest)
-		case codes.AlreadyExists:
-			httpx.WriteError(w, "Username already exists", http.StatusConflict)
+			httpx.WriteError(w, "Invalid user id", http.StatusBadRequest)
+		case codes.NotFound:
+			httpx.WriteError(w, "Profile not found", http.StatusNotFound)
 		case codes.Unauthenticated:
 			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		default:
+			httpx.WriteError(w, "Failed to get profile", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeProfile(w, resp)
+}
+
+// HandleGetUser retrieves another user's profile.
+// @Summary Get another user's profile
+// @Description Retrieves the profile of the given user. Fields hidden by the user's privacy settings are omitted.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} ProfileResponse
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /profile/{user_id} [get]
+// @Security BearerAuth
+func (c *profileController) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.GetUserProfile(authCtx(r), &pb.GetUserProfileRequest{UserId: chi.URLParam(r, "user_id")})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to get profile", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, "Invalid user id", http.StatusBadRequest)
 		case codes.NotFound:
 			httpx.WriteError(w, "Profile not found", http.StatusNotFound)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
 		default:
-			httpx.WriteError(w, "Failed to update profile", http.StatusInternalServerError)
+			httpx.WriteError(w, "Failed to get profile", http.StatusInternalServerError)
 		}
 		return
 	}
@@ -112,18 +312,21 @@ func (c *profileController) HandleUpdate(w http.ResponseWriter, r *http.Request)
 	httpx.WriteJSON(w, profileResponse(resp), http.StatusOK)
 }
 
-// HandleGet retrieves the authenticated user's profile.
-// @Summary Get user profile
-// @Description Retrieves the profile of the authenticated user
+// HandleGetByUsername retrieves a user's profile by username.
+// @Summary Get profile by username
+// @Description Retrieves the profile of the user with the given username. Recently changed usernames still resolve to their previous owner. Fields hidden by the user's privacy settings are omitted.
 // @Tags profile
 // @Accept json
 // @Produce json
+// @Param username path string true "Username"
 // @Success 200 {object} ProfileResponse
+// @Failure 400 {object} httpx.ErrorResponse
 // @Failure 401 {object} httpx.ErrorResponse
-// @Router /profile/my [get]
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /profile/username/{username} [get]
 // @Security BearerAuth
-func (c *profileController) HandleGet(w http.ResponseWriter, r *http.Request) {
-	resp, err := c.client.GetProfile(authCtx(r), &pb.GetProfileRequest{})
+func (c *profileController) HandleGetByUsername(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.GetProfileByUsername(authCtx(r), &pb.GetProfileByUsernameRequest{Username: chi.URLParam(r, "username")})
 	if err != nil {
 		st, ok := status.FromError(err)
 		if !ok {
@@ -132,7 +335,7 @@ func (c *profileController) HandleGet(w http.ResponseWriter, r *http.Request) {
 		}
 		switch st.Code() {
 		case codes.InvalidArgument:
-			httpx.WriteError(w, "Invalid user id", http.StatusBadRequest)
+			httpx.WriteError(w, "Invalid username", http.StatusBadRequest)
 		case codes.NotFound:
 			httpx.WriteError(w, "Profile not found", http.StatusNotFound)
 		case codes.Unauthenticated:
@@ -146,7 +349,150 @@ func (c *profileController) HandleGet(w http.ResponseWriter, r *http.Request) {
 	httpx.WriteJSON(w, profileResponse(resp), http.StatusOK)
 }
 
+// HandleUpdatePrivacy updates the user's privacy settings.
+// @Summary Update privacy settings
+// @Description Sets who can see full name, birth date and gender: public, contacts or private. Omitted fields are left unchanged.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param request body UpdatePrivacyRequest true "Privacy settings"
+// @Success 200 {object} PrivacySettings "Privacy settings updated successfully"
+// @Failure 400 {object} httpx.ErrorResponse "Validation error or bad request"
+// @Failure 401 {object} httpx.ErrorResponse "Unauthorized"
+// @Router /profile/privacy [post]
+// @Security BearerAuth
+func (c *profileController) HandleUpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	var body UpdatePrivacyRequest
+	if err := httpx.DecodeBody(r, &body); err != nil {
+		httpx.WriteError(w, "Failed to decode request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validate.Struct(body); err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := c.client.UpdatePrivacy(authCtx(r), &pb.UpdatePrivacyRequest{
+		FullName:  body.FullName,
+		BirthDate: body.BirthDate,
+		Gender:    body.Gender,
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			httpx.WriteError(w, "Failed to update privacy", http.StatusInternalServerError)
+			return
+		}
+		switch st.Code() {
+		case codes.InvalidArgument:
+			httpx.WriteError(w, "Invalid request", http.StatusBadRequest)
+		case codes.Unauthenticated:
+			httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+		case codes.NotFound:
+			httpx.WriteError(w, "Profile not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "Failed to update privacy", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	httpx.WriteJSON(w, privacyResponse(resp), http.StatusOK)
+}
+
+// HandleListContacts returns the user's contacts.
+// @Summary List contacts
+// @Description Returns IDs of users who can see fields with contacts privacy level, recently added first.
+// @Tags profile
+// @Produce json
+// @Success 200 {object} ProfileContactsResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Router /profile/contacts [get]
+// @Security BearerAuth
+func (c *profileController) HandleListContacts(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.client.ListContacts(authCtx(r), &pb.ListContactsRequest{})
+	if err != nil {
+		writeProfileContactError(w, err)
+		return
+	}
+	ids := resp.UserIds
+	if ids == nil {
+		ids = []string{}
+	}
+	httpx.WriteJSON(w, ProfileContactsResponse{UserIDs: ids}, http.StatusOK)
+}
+
+// HandleAddContact adds user to the user's contacts.
+// @Summary Add contact
+// @Description Allows the user to see fields with contacts privacy level. Adding existing contact succeeds.
+// @Tags profile
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Failure 404 {object} httpx.ErrorResponse
+// @Router /profile/contacts/{user_id} [put]
+// @Security BearerAuth
+func (c *profileController) HandleAddContact(w http.ResponseWriter, r *http.Request) {
+	_, err := c.client.AddContact(authCtx(r), &pb.AddContactRequest{UserId: chi.URLParam(r, "user_id")})
+	if err != nil {
+		writeProfileContactError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleDeleteContact removes user from the user's contacts.
+// @Summary Delete contact
+// @Tags profile
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} httpx.ErrorResponse
+// @Failure 401 {object} httpx.ErrorResponse
+// @Router /profile/contacts/{user_id} [delete]
+// @Security BearerAuth
+func (c *profileController) HandleDeleteContact(w http.ResponseWriter, r *http.Request) {
+	_, err := c.client.DeleteContact(authCtx(r), &pb.DeleteContactRequest{UserId: chi.URLParam(r, "user_id")})
+	if err != nil {
+		writeProfileContactError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeProfileContactError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		httpx.WriteError(w, "Failed to process contact", http.StatusInternalServerError)
+		return
+	}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		httpx.WriteError(w, st.Message(), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+	case codes.NotFound:
+		httpx.WriteError(w, "Profile not found", http.StatusNotFound)
+	default:
+		httpx.WriteError(w, "Failed to process contact", http.StatusInternalServerError)
+	}
+}
+
+func privacyResponse(settings *pb.PrivacySettings) PrivacySettings {
+	return PrivacySettings{
+		FullName:  settings.FullName,
+		BirthDate: settings.BirthDate,
+		Gender:    settings.Gender,
+	}
+}
+
 func profileResponse(profile *pb.ProfileResponse) ProfileResponse {
+	var privacy *PrivacySettings
+	if profile.Privacy != nil {
+		settings := privacyResponse(profile.Privacy)
+		privacy = &settings
+	}
 	return ProfileResponse{
 		UserID:    profile.UserId,
 		Username:  profile.Username,
@@ -155,5 +501,33 @@ func profileResponse(profile *pb.ProfileResponse) ProfileResponse {
 		BirthDate: profile.BirthDate,
 		Gender:    profile.Gender,
 		Avatar:    profile.Avatar,
+		Locale:    profile.Locale,
+		Privacy:   privacy,
+		Version:   profile.Version,
+	}
+}
+
+// Writes own profile with its version as ETag
+func writeProfile(w http.ResponseWriter, profile *pb.ProfileResponse) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(profile.Version, 10)))
+	httpx.WriteJSON(w, profileResponse(profile), http.StatusOK)
+}
+
+var errInvalidETag = errors.New("invalid etag")
+
+// Returns version from If-Match header, zero means no precondition
+func ifMatchVersion(r *http.Request) (int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	// Weak tags are not allowed in If-Match
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || len(header) < 2 {
+		return 0, errInvalidETag
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidETag
 	}
+	return version, nil
 }
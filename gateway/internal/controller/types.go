@@ -1,5 +1,7 @@
 package controller
 
+import "encoding/json"
+
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"email" example:"xLb3u@example.com"`
 	Password string `json:"password" validate:"min=6" example:"password"`
@@ -10,6 +12,15 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"min=6" example:"password"`
 }
 
+type MagicLinkRequest struct {
+	Email string `json:"email" validate:"email" example:"xLb3u@example.com"`
+}
+
+type ConsumeMagicLinkRequest struct {
+	// Token from the link in email
+	Token string `json:"token" validate:"uuid" example:"1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"`
+}
+
 type AccessTokenResponse struct {
 	AccessToken string `json:"access_token" example:"access_token"`
 }
@@ -27,6 +38,29 @@ type ProfileResponse struct {
 	BirthDate string `json:"birth_date,omitempty" example:"birth_date"`
 	Gender    string `json:"gender,omitempty" example:"gender"`
 	Avatar    string `json:"avatar,omitempty" example:"avatar"`
+	// Returned only for the owner of the profile
+	Locale string `json:"locale,omitempty" example:"en"`
+	// Returned only for the owner of the profile
+	Privacy *PrivacySettings `json:"privacy,omitempty"`
+	// Same as ETag header, send it back in If-Match to avoid overwriting concurrent changes
+	Version int64 `json:"version,omitempty" example:"1"`
+}
+
+type PrivacySettings struct {
+	FullName  string `json:"full_name" example:"public"`
+	BirthDate string `json:"birth_date" example:"contacts"`
+	Gender    string `json:"gender" example:"private"`
+}
+
+// Contacts can see profile fields with contacts privacy level
+type ProfileContactsResponse struct {
+	UserIDs []string `json:"user_ids" example:"6b2c6f0e-2f1a-4b3e-9f6d-1c2d3e4f5a6b"`
+}
+
+type UpdatePrivacyRequest struct {
+	FullName  string `json:"full_name" example:"public" validate:"omitempty,oneof=public contacts private"`
+	BirthDate string `json:"birth_date" example:"contacts" validate:"omitempty,oneof=public contacts private"`
+	Gender    string `json:"gender" example:"private" validate:"omitempty,oneof=public contacts private"`
 }
 
 type UpdateProfileRequest struct {
@@ -35,9 +69,253 @@ type UpdateProfileRequest struct {
 	LastName  string `form:"last_name" json:"last_name" example:"Doe" validate:"omitempty"`
 	BirthDate string `form:"birth_date" json:"birth_date" example:"2000-01-01" validate:"omitempty,datetime=2006-01-02"`
 	Gender    string `form:"gender" json:"gender" example:"male" validate:"omitempty,oneof=male female"`
+	Locale    string `form:"locale" json:"locale" example:"en" validate:"omitempty,bcp47_language_tag"`
 	Avatar    []byte `form:"avatar" json:"avatar" swaggerignore:"true" validate:"omitempty"`
 }
 
+// NullableString tells apart a missing JSON field, null and a value
+type NullableString struct {
+	Value string
+	Set   bool
+}
+
+func (s *NullableString) UnmarshalJSON(data []byte) error {
+	s.Set = true
+	if string(data) == "null" {
+		s.Value = ""
+		return nil
+	}
+	return json.Unmarshal(data, &s.Value)
+}
+
+type PatchProfileRequest struct {
+	Username  NullableString `json:"username" swaggertype:"string" example:"username"`
+	FirstName NullableString `json:"first_name" swaggertype:"string" example:"John"`
+	LastName  NullableString `json:"last_name" swaggertype:"string" example:"Doe"`
+	BirthDate NullableString `json:"birth_date" swaggertype:"string" example:"2000-01-01"`
+	Gender    NullableString `json:"gender" swaggertype:"string" example:"male"`
+	Locale    NullableString `json:"locale" swaggertype:"string" example:"en"`
+}
+
+// Mask returns names of fields present in request body
+func (p PatchProfileRequest) Mask() []string {
+	var mask []string
+	fields := []struct {
+		name  string
+		value NullableString
+	}{
+		{"username", p.Username},
+		{"first_name", p.FirstName},
+		{"last_name", p.LastName},
+		{"birth_date", p.BirthDate},
+		{"gender", p.Gender},
+		{"locale", p.Locale},
+	}
+	for _, f := range fields {
+		if f.value.Set {
+			mask = append(mask, f.name)
+		}
+	}
+	return mask
+}
+
 type TokenResponse struct {
-	Token string `json:"token" example:"sf34fdsfsdf-sdf3ef"`
+	Token string `json:"token" example:"Xq3vL0bT9kZp2mWc"`
+	Link  string `json:"link" example:"https://t.me/profile_bot?start=Xq3vL0bT9kZp2mWc"`
+}
+
+type SubscriptionResponse struct {
+	Type    string `json:"type" example:"email"`
+	Enabled bool   `json:"enabled" example:"true"`
+}
+
+type SubscriptionsResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
+
+type UpdateSubscriptionRequest struct {
+	Enabled *bool `json:"enabled" example:"false"`
+}
+
+type PreferenceResponse struct {
+	Channel  string `json:"channel" example:"email"`
+	Category string `json:"category" example:"login"`
+	Enabled  bool   `json:"enabled" example:"true"`
+}
+
+type PreferencesResponse struct {
+	Preferences []PreferenceResponse `json:"preferences"`
+}
+
+type UpdatePreferenceRequest struct {
+	Channel  string `json:"channel" validate:"required,oneof=email telegram webhook sms webpush" example:"email"`
+	Category string `json:"category" validate:"required,oneof=login security account marketing" example:"marketing"`
+	Enabled  *bool  `json:"enabled" validate:"required" example:"false"`
+}
+
+// Hours are in the user's timezone
+type ScheduleResponse struct {
+	Timezone   string `json:"timezone" example:"Europe/Moscow"`
+	QuietHours bool   `json:"quiet_hours" example:"true"`
+	QuietFrom  int32  `json:"quiet_from" example:"23"`
+	QuietTo    int32  `json:"quiet_to" example:"8"`
+	Digest     bool   `json:"digest" example:"false"`
+	DigestHour int32  `json:"digest_hour" example:"9"`
+}
+
+type UpdateScheduleRequest struct {
+	Timezone   string `json:"timezone" validate:"required" example:"Europe/Moscow"`
+	QuietHours bool   `json:"quiet_hours" example:"true"`
+	QuietFrom  int32  `json:"quiet_from" validate:"gte=0,lte=23" example:"23"`
+	QuietTo    int32  `json:"quiet_to" validate:"gte=0,lte=23" example:"8"`
+	Digest     bool   `json:"digest" example:"false"`
+	DigestHour int32  `json:"digest_hour" validate:"gte=0,lte=23" example:"9"`
+}
+
+type DeliveryResponse struct {
+	ID                int64  `json:"id" example:"42"`
+	Channel           string `json:"channel" example:"email"`
+	Category          string `json:"category" example:"login"`
+	Status            string `json:"status" example:"sent"`
+	Attempts          int32  `json:"attempts" example:"1"`
+	LastError         string `json:"last_error,omitempty"`
+	ProviderMessageID string `json:"provider_message_id,omitempty"`
+	CreatedAt         int64  `json:"created_at" example:"1735689600"`
+	UpdatedAt         int64  `json:"updated_at" example:"1735689600"`
+	Digest            bool   `json:"digest" example:"false"`
+}
+
+type DeliveriesResponse struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+}
+
+type SetContactRequest struct {
+	Address string `json:"address" validate:"required" example:"https://example.com/hooks/notifications"`
+}
+
+// Secret is returned only when contact is set, it signs webhook requests
+type ContactResponse struct {
+	Channel string `json:"channel" example:"webhook"`
+	Address string `json:"address" example:"https://example.com/hooks/notifications"`
+	Secret  string `json:"secret,omitempty" example:"4f9c2a..."`
+}
+
+type PushKeyResponse struct {
+	PublicKey string `json:"public_key" example:"BEl62iUYgUivxIkv69yViEuiBIa-Ib9-SkvMeAtA3LFgDzkrxZJjSgSnfckjBJuBkr3qBUYIHBQFLXYp5Nksh8U"`
+}
+
+type PushKeys struct {
+	P256dh string `json:"p256dh" validate:"required" example:"BNcRdreALRFXTkOOUHK1EtK2wtaz5Ry4YfYCA_0QTpQtUbVlUls0VJXg7A8u-Ts1XbjhazAkj7I99e8QcYP7DkM"`
+	Auth   string `json:"auth" validate:"required" example:"tBHItJI5svbpez7KI4CCXg"`
+}
+
+// Same shape as PushSubscription.toJSON() in browser
+type PushSubscriptionRequest struct {
+	Endpoint string   `json:"endpoint" validate:"required,url,max=2048" example:"https://fcm.googleapis.com/fcm/send/c1KrmpTuRm..."`
+	Keys     PushKeys `json:"keys"`
+}
+
+type RemovePushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,url,max=2048" example:"https://fcm.googleapis.com/fcm/send/c1KrmpTuRm..."`
+}
+
+type UserResponse struct {
+	ID           string `json:"id" example:"0b1f7e0a-5c3d-4a4e-9f1a-2b7c8d9e0f12"`
+	Email        string `json:"email" example:"john@example.com"`
+	RegisteredAt int64  `json:"registered_at" example:"1735689600"`
+	// Zero if user is not blocked
+	BlockedAt   int64  `json:"blocked_at,omitempty" example:"1735689600"`
+	BlockReason string `json:"block_reason,omitempty" example:"spam"`
+}
+
+type UsersResponse struct {
+	Users []UserResponse `json:"users"`
+	// Number of users matching filters
+	Total int32 `json:"total" example:"42"`
+}
+
+type SessionResponse struct {
+	ID        string `json:"id" example:"6a4c1b7e-2f3d-4e5a-8b9c-0d1e2f3a4b5c"`
+	IP        string `json:"ip" example:"192.168.0.1"`
+	Type      string `json:"type" example:"credentials"`
+	CreatedAt int64  `json:"created_at" example:"1735689600"`
+	ExpiresAt int64  `json:"expires_at" example:"1736294400"`
+}
+
+type ProfileSummaryResponse struct {
+	Username  string `json:"username" example:"john"`
+	FirstName string `json:"first_name,omitempty" example:"John"`
+	LastName  string `json:"last_name,omitempty" example:"Doe"`
+	Avatar    string `json:"avatar,omitempty" example:"https://example.com/avatar.png"`
+}
+
+// Profile is omitted if user has no profile or profile service is unavailable
+type UserDetailsResponse struct {
+	User     UserResponse            `json:"user"`
+	Accounts []string                `json:"accounts" example:"credentials,google"`
+	Roles    []string                `json:"roles" example:"admin"`
+	Sessions []SessionResponse       `json:"sessions"`
+	Profile  *ProfileSummaryResponse `json:"profile,omitempty"`
+}
+
+type BlockUserRequest struct {
+	Reason string `json:"reason" validate:"max=500" example:"spam"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,max=32" example:"admin"`
+}
+
+// Actor is empty for actions of the system and anonymous clients
+type AuditEntryResponse struct {
+	ID        int64             `json:"id" example:"42"`
+	ActorID   string            `json:"actor_id,omitempty" example:"0b1f7e0a-5c3d-4a4e-9f1a-2b7c8d9e0f12"`
+	Action    string            `json:"action" example:"auth.login"`
+	TargetID  string            `json:"target_id,omitempty" example:"0b1f7e0a-5c3d-4a4e-9f1a-2b7c8d9e0f12"`
+	Result    string            `json:"result" example:"success"`
+	IP        string            `json:"ip" example:"192.168.0.1"`
+	UserAgent string            `json:"user_agent" example:"Mozilla/5.0"`
+	RequestID string            `json:"request_id" example:"9f86d081884c7d65"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt int64             `json:"created_at" example:"1735689600"`
+	Hash      string            `json:"hash,omitempty"`
+}
+
+type AuditLogResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+}
+
+type AuditVerificationResponse struct {
+	Valid   bool  `json:"valid" example:"true"`
+	Checked int32 `json:"checked" example:"1024"`
+	// First entry which doesn't match the chain
+	BrokenID int64 `json:"broken_id,omitempty" example:"0"`
+}
+
+type CreatePersonalTokenRequest struct {
+	Name          string   `json:"name" validate:"required,max=64" example:"CI"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=sso profile notification" example:"profile"`
+	ExpiresInDays int32    `json:"expires_in_days" validate:"min=1,max=365" example:"30"`
+}
+
+// Last use is zero if token was never used
+type PersonalTokenResponse struct {
+	ID         string   `json:"id" example:"6a4c1b7e-2f3d-4e5a-8b9c-0d1e2f3a4b5c"`
+	Name       string   `json:"name" example:"CI"`
+	Prefix     string   `json:"prefix" example:"pat_3q2-7wE1"`
+	Scopes     []string `json:"scopes" example:"profile"`
+	CreatedAt  int64    `json:"created_at" example:"1735689600"`
+	ExpiresAt  int64    `json:"expires_at" example:"1738281600"`
+	LastUsedAt int64    `json:"last_used_at,omitempty" example:"1735776000"`
+	LastUsedIP string   `json:"last_used_ip,omitempty" example:"192.168.0.1"`
+}
+
+// Token is shown only once, it is sent as "Authorization: Bearer <token>"
+type CreatePersonalTokenResponse struct {
+	PersonalTokenResponse
+	Token string `json:"token" example:"pat_3q2-7wE1..."`
+}
+
+type PersonalTokensResponse struct {
+	Tokens []PersonalTokenResponse `json:"tokens"`
 }
@@ -2,9 +2,14 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/httpx"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -33,11 +38,69 @@ func removeCookie(name string) *http.Cookie {
 	}
 }
 
+// requireRole rejects requests without valid access token of the role, personal access tokens are verified by tokens.
+// Services check permissions of their methods anyway, it hides admin routes from other users
+func requireRole(secret []byte, tokens auth.TokenVerifier, role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := accessToken(r)
+			if token == "" {
+				httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			claims, err := verifyToken(r, secret, tokens, token)
+			if errors.Is(err, auth.ErrInvalidToken) {
+				httpx.WriteError(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				httpx.WriteError(w, "Failed to verify token", http.StatusServiceUnavailable)
+				return
+			}
+			if !claims.HasRole(role) {
+				httpx.WriteError(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// verifyToken returns auth.ErrInvalidToken for invalid tokens, other errors mean that sso is unavailable
+func verifyToken(r *http.Request, secret []byte, tokens auth.TokenVerifier, token string) (*auth.TokenClaims, error) {
+	if auth.IsPersonalToken(token) {
+		return tokens.VerifyPersonalToken(requestCtx(r), token)
+	}
+	claims, err := auth.VerifyJWT(token, secret)
+	if err != nil {
+		return nil, auth.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// requestCtx forwards client of request to services, they record it in audit log
+func requestCtx(r *http.Request) context.Context {
+	return request.Outgoing(r.Context(), request.FromHTTP(r))
+}
+
 func authCtx(r *http.Request) context.Context {
+	ctx := requestCtx(r)
+	token := accessToken(r)
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// accessToken returns token from Authorization header, which is used by API clients with personal access tokens,
+// or from cookie set on login
+func accessToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
 	cookie, err := r.Cookie("access_token")
 	if err != nil {
-		return r.Context()
+		return ""
 	}
-	ctx := metadata.NewOutgoingContext(r.Context(), metadata.Pairs("authorization", "Bearer "+cookie.Value))
-	return ctx
+	return cookie.Value
 }
@@ -3,25 +3,35 @@ package main
 import (
 	"context"
 	"flag"
+	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
+	ssoPb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
 	"github.com/SergeyBogomolovv/profile-manager/common/postgres"
 	"github.com/SergeyBogomolovv/profile-manager/common/rabbitmq"
 	"github.com/SergeyBogomolovv/profile-manager/common/redis"
 	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/app"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/broker"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/config"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/controller"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/mailer"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/repo"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/sso"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/telegram"
 	"github.com/SergeyBogomolovv/profile-manager/notification/pkg/bot"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	tele "gopkg.in/telebot.v4"
 )
 
 func main() {
@@ -36,25 +46,43 @@ func main() {
 	amqpConn := rabbitmq.MustNew(conf.RabbitmqURL)
 	defer amqpConn.Close()
 
-	bot := bot.MustNew(conf.TelegramToken)
+	ssoConn, err := grpc.NewClient(conf.SsoAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect to sso: %v", err)
+	}
+	defer ssoConn.Close()
+
+	poller, webhook := newPoller(conf)
+	bot := bot.MustNew(conf.TelegramToken, poller)
 	logger := newLogger()
 
 	mailer := mailer.New(conf.SMTP)
-	sender := telegram.NewSender(bot)
+	defer mailer.Close()
+	queue := telegram.NewQueue(bot, telegram.Limits(conf.Telegram.Limits))
+	sender := telegram.NewSender(queue)
 	userRepo := repo.NewUserRepo(postgres)
 	tokenRepo := repo.NewTokenRepo(redis)
 	subsRepo := repo.NewSubscriptionRepo(postgres)
+	contactRepo := repo.NewContactRepo(postgres)
+	scheduleRepo := repo.NewScheduleRepo(postgres)
+	deliveryRepo := repo.NewDeliveryRepo(postgres)
+	pushRepo := repo.NewPushRepo(postgres)
+	channels := newChannels(conf, mailer, sender, pushRepo)
 	txManager := transaction.NewTxManager(postgres)
-	setupSvc := service.NewSetupService(txManager, userRepo, tokenRepo, subsRepo)
-	notifySvc := service.NewNotifyService(txManager, mailer, sender, userRepo, subsRepo)
+	notifySvc := service.NewNotifyService(txManager, mailer, channels, userRepo, subsRepo, scheduleRepo, deliveryRepo, conf.MagicLinkURL)
+	broker := broker.MustNew(logger, amqpConn, notifySvc)
+	setupSvc := service.NewSetupService(txManager, userRepo, tokenRepo, subsRepo, contactRepo, broker, bot.Me.Username)
+	scheduleSvc := service.NewScheduleService(userRepo, scheduleRepo)
+	broadcastSvc := service.NewBroadcastService(txManager, repo.NewBroadcastRepo(postgres), userRepo, subsRepo, channels, conf.Broadcast.Rate)
+	pushSvc := service.NewPushService(txManager, userRepo, subsRepo, contactRepo, pushRepo, pushPublicKey(conf), conf.WebPush.Hosts)
 
-	loginer := telegram.NewLoginer(logger, bot, setupSvc)
+	conversationRepo := repo.NewConversationRepo(redis)
+	sessionSvc := service.NewSessionService(userRepo, sso.NewClient(ssoPb.NewSSOClient(ssoConn), []byte(conf.JwtSecret)))
+	loginer := telegram.NewLoginer(logger, bot, queue, setupSvc, sessionSvc, conversationRepo)
 	loginer.Init()
 
-	broker := broker.MustNew(logger, amqpConn, notifySvc)
-
-	controller := controller.New(setupSvc)
-	app := app.New(logger, conf, controller, bot, broker)
+	grpcController := controller.New(setupSvc, scheduleSvc, notifySvc, pushSvc, broadcastSvc)
+	app := app.New(logger, conf, grpcController, controller.Policy, bot, webhook, queue, broker, notifySvc, broadcastSvc, loginer, auth.NewSSOVerifier(ssoPb.NewPersonalTokensClient(ssoConn)))
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -67,6 +95,51 @@ func init() {
 	godotenv.Load()
 }
 
+func newChannels(conf *config.Config, mailer mailer.Mailer, sender telegram.Sender, pushes channel.PushSubscriptionRepo) *channel.Registry {
+	channels := channel.NewRegistry()
+	channels.Register(domain.SubscriptionTypeEmail, channel.NewEmail(mailer))
+	channels.Register(domain.SubscriptionTypeTelegram, channel.NewTelegram(sender))
+	channels.Register(domain.SubscriptionTypeWebhook, channel.NewWebhook(channel.NewPublicClient(conf.Webhook.Timeout)))
+	if conf.SMS.URL != "" {
+		provider := channel.NewHTTPSMSProvider(&http.Client{Timeout: conf.SMS.Timeout}, conf.SMS.URL, conf.SMS.Token)
+		channels.Register(domain.SubscriptionTypeSMS, channel.NewSMS(provider))
+	}
+	if conf.WebPush.PrivateKey != "" {
+		vapid := channel.VAPID{PublicKey: conf.WebPush.PublicKey, PrivateKey: conf.WebPush.PrivateKey, Subject: conf.WebPush.Subject}
+		webPush, err := channel.NewWebPush(channel.NewPublicClient(conf.WebPush.Timeout), pushes, vapid, conf.WebPush.TTL)
+		if err != nil {
+			log.Fatalf("failed to init web push: %v", err)
+		}
+		channels.Register(domain.SubscriptionTypeWebPush, webPush)
+	}
+	return channels
+}
+
+// Webhook handler is nil in polling mode
+func newPoller(conf *config.Config) (tele.Poller, http.Handler) {
+	switch conf.Telegram.Mode {
+	case config.TelegramModePolling:
+		return bot.NewPoller(conf.Telegram.PollTimeout), nil
+	case config.TelegramModeWebhook:
+		if conf.Telegram.Webhook.URL == "" || conf.Telegram.Webhook.Secret == "" {
+			log.Fatal("telegram webhook url and secret are required in webhook mode")
+		}
+		webhook := bot.NewWebhook(conf.Telegram.Webhook.URL, conf.Telegram.Webhook.Secret)
+		return webhook, webhook
+	default:
+		log.Fatalf("unknown telegram mode: %s", conf.Telegram.Mode)
+		return nil, nil
+	}
+}
+
+// Web client can subscribe browsers only if channel is enabled
+func pushPublicKey(conf *config.Config) string {
+	if conf.WebPush.PrivateKey == "" {
+		return ""
+	}
+	return conf.WebPush.PublicKey
+}
+
 func newLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
 }
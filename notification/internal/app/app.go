@@ -2,10 +2,14 @@ package app
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"fmt"
 	"log"
 	"log/slog"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/auth"
 	"github.com/SergeyBogomolovv/profile-manager/common/logger"
@@ -23,29 +27,82 @@ type Broker interface {
 	Consume(ctx context.Context)
 }
 
+type SchedulerService interface {
+	SendDigests(ctx context.Context) error
+	RetryDeliveries(ctx context.Context) error
+}
+
+type TelegramQueue interface {
+	Run(ctx context.Context)
+}
+
+type Broadcaster interface {
+	ProcessBroadcasts(ctx context.Context) error
+}
+
+type Conversations interface {
+	ExpireConversations(ctx context.Context) error
+}
+
 type app struct {
 	logger *slog.Logger
 	conf   *config.Config
 	srv    *grpc.Server
 	bot    *tele.Bot
-	broker Broker
+	// Serves Telegram webhook, nil in polling mode
+	webhookSrv *http.Server
+	// Serves expvar metrics, nil if disabled
+	metricsSrv *http.Server
+	queue      TelegramQueue
+	broker     Broker
+	scheduler  SchedulerService
+	broadcasts Broadcaster
+	convs      Conversations
 }
 
-func New(log *slog.Logger, conf *config.Config, controller Controller, bot *tele.Bot, broker Broker) *app {
+// New serves webhook on its own port if it is not nil, bot must be created with it as poller.
+// Methods of policy require permissions
+func New(log *slog.Logger, conf *config.Config, controller Controller, policy auth.Policy, bot *tele.Bot, webhook http.Handler, queue TelegramQueue, broker Broker, scheduler SchedulerService, broadcasts Broadcaster, convs Conversations, tokens auth.TokenVerifier) *app {
 	srv := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			logger.LoggerInterceptor(log),
-			auth.JwtInterceptor([]byte(conf.JwtSecret)),
+			auth.JwtInterceptor([]byte(conf.JwtSecret), auth.WithPersonalTokens(tokens)),
+			auth.AuthzInterceptor(policy),
 		),
 	)
 	controller.Init(srv)
-	return &app{logger: log, conf: conf, srv: srv, bot: bot, broker: broker}
+	a := &app{logger: log, conf: conf, srv: srv, bot: bot, queue: queue, broker: broker, scheduler: scheduler, broadcasts: broadcasts, convs: convs}
+	if webhook != nil {
+		a.webhookSrv = &http.Server{
+			Addr:    fmt.Sprintf(":%d", conf.Telegram.Webhook.Port),
+			Handler: webhook,
+		}
+	}
+	if conf.MetricsPort != 0 {
+		mux := http.NewServeMux()
+		mux.Handle("GET /debug/vars", expvar.Handler())
+		a.metricsSrv = &http.Server{
+			Addr:    fmt.Sprintf(":%d", conf.MetricsPort),
+			Handler: mux,
+		}
+	}
+	return a
 }
 
 func (a *app) Start(ctx context.Context) {
 	go a.startServer()
 	go a.startBot()
+	if a.webhookSrv != nil {
+		go a.startWebhookServer()
+	}
+	if a.metricsSrv != nil {
+		go a.startMetricsServer()
+	}
+	go a.startQueue(ctx)
 	go a.startConsumer(ctx)
+	go a.startScheduler(ctx)
+	go a.startBroadcasts(ctx)
+	go a.startConversationTimeouts(ctx)
 }
 
 func (a *app) startBot() {
@@ -53,11 +110,87 @@ func (a *app) startBot() {
 	a.bot.Start()
 }
 
+func (a *app) startWebhookServer() {
+	a.logger.Info("starting webhook server", "addr", a.webhookSrv.Addr)
+	if err := a.webhookSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("failed to run webhook server: %v", err)
+	}
+}
+
+func (a *app) startMetricsServer() {
+	a.logger.Info("starting metrics server", "addr", a.metricsSrv.Addr)
+	if err := a.metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("failed to run metrics server: %v", err)
+	}
+}
+
+// Notifications are sent to Telegram through rate limited queue
+func (a *app) startQueue(ctx context.Context) {
+	a.logger.Info("starting telegram queue")
+	a.queue.Run(ctx)
+}
+
 func (a *app) startConsumer(ctx context.Context) {
 	a.logger.Info("starting consumer")
 	a.broker.Consume(ctx)
 }
 
+// Delivers notifications deferred by quiet hours and digest mode, retries failed deliveries
+func (a *app) startScheduler(ctx context.Context) {
+	a.logger.Info("starting scheduler", "interval", a.conf.DigestInterval)
+	ticker := time.NewTicker(a.conf.DigestInterval)
+	defer ticker.Stop()
+	ctx = logger.Inject(ctx, a.logger)
+	for {
+		select {
+		case <-ctx.Done():
+			a.logger.Info("scheduler stopped")
+			return
+		case <-ticker.C:
+			if err := a.scheduler.SendDigests(ctx); err != nil {
+				a.logger.Error("failed to send digests", "error", err)
+			}
+			if err := a.scheduler.RetryDeliveries(ctx); err != nil {
+				a.logger.Error("failed to retry deliveries", "error", err)
+			}
+		}
+	}
+}
+
+// Sends scheduled broadcasts and continues broadcasts of stopped instances
+func (a *app) startBroadcasts(ctx context.Context) {
+	ticker := time.NewTicker(a.conf.Broadcast.Interval)
+	defer ticker.Stop()
+	ctx = logger.Inject(ctx, a.logger)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.broadcasts.ProcessBroadcasts(ctx); err != nil {
+				a.logger.Error("failed to process broadcasts", "error", err)
+			}
+		}
+	}
+}
+
+// Cancels bot dialogs in which user didn't answer in time
+func (a *app) startConversationTimeouts(ctx context.Context) {
+	ticker := time.NewTicker(a.conf.ConversationInterval)
+	defer ticker.Stop()
+	ctx = logger.Inject(ctx, a.logger)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.convs.ExpireConversations(ctx); err != nil {
+				a.logger.Error("failed to expire conversations", "error", err)
+			}
+		}
+	}
+}
+
 func (a *app) startServer() {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", a.conf.GrpcPort))
 	if err != nil {
@@ -70,8 +203,20 @@ func (a *app) startServer() {
 func (a *app) Stop() {
 	a.srv.GracefulStop()
 	a.logger.Info("grpc server stopped")
+	// Pending webhook requests wait for the bot, so server is stopped first
+	if a.webhookSrv != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.webhookSrv.Shutdown(ctx); err != nil {
+			a.logger.Error("failed to shutdown webhook server", "error", err)
+		}
+		a.logger.Info("webhook server stopped")
+	}
 	a.bot.Stop()
 	a.logger.Info("bot stopped")
+	if a.metricsSrv != nil {
+		a.metricsSrv.Close()
+	}
 	a.broker.Close()
 	a.logger.Info("consumer stopped")
 }
@@ -3,6 +3,7 @@ package broker
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"log/slog"
 
@@ -14,6 +15,9 @@ import (
 type NotifyService interface {
 	SendLoginNotification(ctx context.Context, data events.UserLogin) error
 	HandleRegister(ctx context.Context, data events.UserRegister) error
+	HandleProfileUpdated(ctx context.Context, data events.ProfileUpdated) error
+	HandleTelegramLinked(ctx context.Context, data events.TelegramLinked) error
+	HandleMagicLink(ctx context.Context, data events.MagicLink) error
 }
 
 type broker struct {
@@ -42,6 +46,21 @@ func (b *broker) Close() error {
 func (b *broker) Consume(ctx context.Context) {
 	go b.consumeLogin(ctx)
 	go b.consumeRegister(ctx)
+	go b.consumeProfileUpdated(ctx)
+	go b.consumeTelegramLinked(ctx)
+	go b.consumeMagicLink(ctx)
+}
+
+func (b *broker) PublishTelegramLinked(data events.TelegramLinked) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telegram linked: %w", err)
+	}
+	msg := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        body,
+	}
+	return b.ch.Publish(events.UserExchange, events.TelegramLinkedTopic, false, false, msg)
 }
 
 func (b *broker) consumeLogin(ctx context.Context) {
@@ -121,3 +140,120 @@ func (b *broker) handleRegister(ctx context.Context, msg amqp.Delivery) {
 	}
 	msg.Ack(false)
 }
+
+func (b *broker) consumeProfileUpdated(ctx context.Context) {
+	q, err := b.ch.QueueDeclare(events.NotificationProfileQueue, true, false, false, false, nil)
+	if err != nil {
+		log.Fatalf("failed to declare profile queue: %v", err)
+	}
+
+	if err := b.ch.QueueBind(q.Name, events.ProfileUpdatedTopic, events.UserExchange, false, nil); err != nil {
+		log.Fatalf("failed to bind profile queue: %v", err)
+	}
+
+	msgs, err := b.ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		log.Fatalf("failed to consume profile queue: %v", err)
+	}
+
+	for msg := range msgs {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			go b.handleProfileUpdated(logger.Inject(ctx, b.logger), msg)
+		}
+	}
+}
+
+func (b *broker) handleProfileUpdated(ctx context.Context, msg amqp.Delivery) {
+	var data events.ProfileUpdated
+	if err := json.Unmarshal(msg.Body, &data); err != nil {
+		msg.Nack(false, true)
+		return
+	}
+	if err := b.svc.HandleProfileUpdated(ctx, data); err != nil {
+		logger.Extract(ctx).Error("failed to handle profile update", "error", err)
+		msg.Nack(false, true)
+		return
+	}
+	msg.Ack(false)
+}
+
+func (b *broker) consumeTelegramLinked(ctx context.Context) {
+	q, err := b.ch.QueueDeclare(events.NotificationTelegramQueue, true, false, false, false, nil)
+	if err != nil {
+		log.Fatalf("failed to declare telegram queue: %v", err)
+	}
+
+	if err := b.ch.QueueBind(q.Name, events.TelegramLinkedTopic, events.UserExchange, false, nil); err != nil {
+		log.Fatalf("failed to bind telegram queue: %v", err)
+	}
+
+	msgs, err := b.ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		log.Fatalf("failed to consume telegram queue: %v", err)
+	}
+
+	for msg := range msgs {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			go b.handleTelegramLinked(logger.Inject(ctx, b.logger), msg)
+		}
+	}
+}
+
+func (b *broker) handleTelegramLinked(ctx context.Context, msg amqp.Delivery) {
+	var data events.TelegramLinked
+	if err := json.Unmarshal(msg.Body, &data); err != nil {
+		msg.Nack(false, true)
+		return
+	}
+	if err := b.svc.HandleTelegramLinked(ctx, data); err != nil {
+		logger.Extract(ctx).Error("failed to handle telegram linked", "error", err)
+		msg.Nack(false, true)
+		return
+	}
+	msg.Ack(false)
+}
+
+func (b *broker) consumeMagicLink(ctx context.Context) {
+	q, err := b.ch.QueueDeclare(events.NotificationMagicLinkQueue, true, false, false, false, nil)
+	if err != nil {
+		log.Fatalf("failed to declare magic link queue: %v", err)
+	}
+
+	if err := b.ch.QueueBind(q.Name, events.MagicLinkTopic, events.UserExchange, false, nil); err != nil {
+		log.Fatalf("failed to bind magic link queue: %v", err)
+	}
+
+	msgs, err := b.ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		log.Fatalf("failed to consume magic link queue: %v", err)
+	}
+
+	for msg := range msgs {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			go b.handleMagicLink(logger.Inject(ctx, b.logger), msg)
+		}
+	}
+}
+
+func (b *broker) handleMagicLink(ctx context.Context, msg amqp.Delivery) {
+	var data events.MagicLink
+	if err := json.Unmarshal(msg.Body, &data); err != nil {
+		msg.Nack(false, true)
+		return
+	}
+	if err := b.svc.HandleMagicLink(ctx, data); err != nil {
+		logger.Extract(ctx).Error("failed to handle magic link", "error", err)
+		msg.Nack(false, true)
+		return
+	}
+	msg.Ack(false)
+}
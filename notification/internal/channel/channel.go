@@ -0,0 +1,53 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+)
+
+// Channel delivers notifications to contacts of one type
+type Channel interface {
+	// SendLogin returns ID of message assigned by provider
+	SendLogin(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification) (string, error)
+	SendDigest(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest) error
+}
+
+// Announcer is implemented by channels which can deliver broadcast announcements
+type Announcer interface {
+	SendAnnouncement(ctx context.Context, contact domain.Contact, locale string, data domain.Announcement) error
+}
+
+// Registry resolves channel by subscription type, new channels are added without changes in services
+type Registry struct {
+	channels map[domain.SubscriptionType]Channel
+}
+
+func NewRegistry() *Registry {
+	return &Registry{channels: make(map[domain.SubscriptionType]Channel)}
+}
+
+func (r *Registry) Register(subType domain.SubscriptionType, channel Channel) {
+	r.channels[subType] = channel
+}
+
+func (r *Registry) Get(subType domain.SubscriptionType) (Channel, error) {
+	channel, ok := r.channels[subType]
+	if !ok {
+		return nil, domain.ErrUnknownChannel
+	}
+	return channel, nil
+}
+
+// Announcer returns domain.ErrAnnouncementsUnsupported if channel of the type can't deliver announcements
+func (r *Registry) Announcer(subType domain.SubscriptionType) (Announcer, error) {
+	channel, err := r.Get(subType)
+	if err != nil {
+		return nil, err
+	}
+	announcer, ok := channel.(Announcer)
+	if !ok {
+		return nil, domain.ErrAnnouncementsUnsupported
+	}
+	return announcer, nil
+}
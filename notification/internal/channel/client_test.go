@@ -0,0 +1,91 @@
+package channel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	testCases := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "8.8.8.8", want: true},
+		{addr: "2606:4700:4700::1111", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "::1", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "10.1.2.3", want: false},
+		{addr: "172.16.0.1", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "fd00:ec2::254", want: false},
+		{addr: "64:ff9b::a00:1", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			assert.Equal(t, tc.want, channel.IsPublicAddr(netip.MustParseAddr(tc.addr)))
+		})
+	}
+}
+
+func TestNewPublicClient(t *testing.T) {
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "password"}
+
+	var called bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/hook", http.StatusTemporaryRedirect)
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	testCases := []struct {
+		name    string
+		address string
+	}{
+		{name: "loopback", address: srv.URL + "/hook"},
+		{name: "resolved to loopback", address: strings.Replace(srv.URL, "127.0.0.1", "localhost", 1) + "/hook"},
+		{name: "private network", address: "https://10.0.0.1/hook"},
+		{name: "metadata service", address: "https://169.254.169.254/latest/meta-data"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			called = false
+			webhook := channel.NewWebhook(channel.NewPublicClient(time.Second))
+			contact := domain.Contact{Channel: domain.SubscriptionTypeWebhook, Address: tc.address, Secret: "secret"}
+			_, err := webhook.SendLogin(context.Background(), contact, "en", login)
+			assert.ErrorIs(t, err, channel.ErrForbiddenAddress)
+			assert.ErrorIs(t, err, domain.ErrRecipientUnavailable)
+			assert.False(t, called)
+		})
+	}
+
+	t.Run("redirect", func(t *testing.T) {
+		called = false
+		client := channel.NewPublicClient(time.Second)
+		// Test server is on loopback, its transport skips address check to reach redirect
+		client.Transport = srv.Client().Transport
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebhook, Address: srv.URL + "/redirect", Secret: "secret"}
+		_, err := channel.NewWebhook(client).SendLogin(context.Background(), contact, "en", login)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 307")
+		assert.False(t, called)
+	})
+}
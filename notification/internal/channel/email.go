@@ -0,0 +1,28 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/mailer"
+)
+
+type email struct {
+	mailer mailer.Mailer
+}
+
+func NewEmail(mailer mailer.Mailer) Channel {
+	return &email{mailer: mailer}
+}
+
+func (c *email) SendLogin(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification) (string, error) {
+	return c.mailer.SendLoginEmail(ctx, contact.Address, locale, data)
+}
+
+func (c *email) SendDigest(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest) error {
+	return c.mailer.SendDigestEmail(ctx, contact.Address, locale, digest)
+}
+
+func (c *email) SendAnnouncement(ctx context.Context, contact domain.Contact, locale string, data domain.Announcement) error {
+	return c.mailer.SendAnnouncementEmail(ctx, contact.Address, locale, data)
+}
@@ -0,0 +1,86 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Announcer is an autogenerated mock type for the Announcer type
+type Announcer struct {
+	mock.Mock
+}
+
+type Announcer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Announcer) EXPECT() *Announcer_Expecter {
+	return &Announcer_Expecter{mock: &_m.Mock}
+}
+
+// SendAnnouncement provides a mock function with given fields: ctx, contact, locale, data
+func (_m *Announcer) SendAnnouncement(ctx context.Context, contact domain.Contact, locale string, data domain.Announcement) error {
+	ret := _m.Called(ctx, contact, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAnnouncement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Contact, string, domain.Announcement) error); ok {
+		r0 = rf(ctx, contact, locale, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Announcer_SendAnnouncement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAnnouncement'
+type Announcer_SendAnnouncement_Call struct {
+	*mock.Call
+}
+
+// SendAnnouncement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contact domain.Contact
+//   - locale string
+//   - data domain.Announcement
+func (_e *Announcer_Expecter) SendAnnouncement(ctx interface{}, contact interface{}, locale interface{}, data interface{}) *Announcer_SendAnnouncement_Call {
+	return &Announcer_SendAnnouncement_Call{Call: _e.mock.On("SendAnnouncement", ctx, contact, locale, data)}
+}
+
+func (_c *Announcer_SendAnnouncement_Call) Run(run func(ctx context.Context, contact domain.Contact, locale string, data domain.Announcement)) *Announcer_SendAnnouncement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Contact), args[2].(string), args[3].(domain.Announcement))
+	})
+	return _c
+}
+
+func (_c *Announcer_SendAnnouncement_Call) Return(_a0 error) *Announcer_SendAnnouncement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Announcer_SendAnnouncement_Call) RunAndReturn(run func(context.Context, domain.Contact, string, domain.Announcement) error) *Announcer_SendAnnouncement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAnnouncer creates a new instance of Announcer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAnnouncer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Announcer {
+	mock := &Announcer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
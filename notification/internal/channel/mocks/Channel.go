@@ -0,0 +1,145 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Channel is an autogenerated mock type for the Channel type
+type Channel struct {
+	mock.Mock
+}
+
+type Channel_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Channel) EXPECT() *Channel_Expecter {
+	return &Channel_Expecter{mock: &_m.Mock}
+}
+
+// SendDigest provides a mock function with given fields: ctx, contact, locale, digest
+func (_m *Channel) SendDigest(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest) error {
+	ret := _m.Called(ctx, contact, locale, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDigest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Contact, string, domain.Digest) error); ok {
+		r0 = rf(ctx, contact, locale, digest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Channel_SendDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDigest'
+type Channel_SendDigest_Call struct {
+	*mock.Call
+}
+
+// SendDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contact domain.Contact
+//   - locale string
+//   - digest domain.Digest
+func (_e *Channel_Expecter) SendDigest(ctx interface{}, contact interface{}, locale interface{}, digest interface{}) *Channel_SendDigest_Call {
+	return &Channel_SendDigest_Call{Call: _e.mock.On("SendDigest", ctx, contact, locale, digest)}
+}
+
+func (_c *Channel_SendDigest_Call) Run(run func(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest)) *Channel_SendDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Contact), args[2].(string), args[3].(domain.Digest))
+	})
+	return _c
+}
+
+func (_c *Channel_SendDigest_Call) Return(_a0 error) *Channel_SendDigest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Channel_SendDigest_Call) RunAndReturn(run func(context.Context, domain.Contact, string, domain.Digest) error) *Channel_SendDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendLogin provides a mock function with given fields: ctx, contact, locale, data
+func (_m *Channel) SendLogin(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification) (string, error) {
+	ret := _m.Called(ctx, contact, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendLogin")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Contact, string, domain.LoginNotification) (string, error)); ok {
+		return rf(ctx, contact, locale, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Contact, string, domain.LoginNotification) string); ok {
+		r0 = rf(ctx, contact, locale, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Contact, string, domain.LoginNotification) error); ok {
+		r1 = rf(ctx, contact, locale, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Channel_SendLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendLogin'
+type Channel_SendLogin_Call struct {
+	*mock.Call
+}
+
+// SendLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contact domain.Contact
+//   - locale string
+//   - data domain.LoginNotification
+func (_e *Channel_Expecter) SendLogin(ctx interface{}, contact interface{}, locale interface{}, data interface{}) *Channel_SendLogin_Call {
+	return &Channel_SendLogin_Call{Call: _e.mock.On("SendLogin", ctx, contact, locale, data)}
+}
+
+func (_c *Channel_SendLogin_Call) Run(run func(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification)) *Channel_SendLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Contact), args[2].(string), args[3].(domain.LoginNotification))
+	})
+	return _c
+}
+
+func (_c *Channel_SendLogin_Call) Return(_a0 string, _a1 error) *Channel_SendLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Channel_SendLogin_Call) RunAndReturn(run func(context.Context, domain.Contact, string, domain.LoginNotification) (string, error)) *Channel_SendLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewChannel creates a new instance of Channel. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChannel(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Channel {
+	mock := &Channel{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
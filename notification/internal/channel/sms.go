@@ -0,0 +1,83 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
+)
+
+// SMSProvider sends text message to phone number in E.164 format, returns ID of message
+type SMSProvider interface {
+	Send(ctx context.Context, phone, text string) (string, error)
+}
+
+type sms struct {
+	provider SMSProvider
+}
+
+func NewSMS(provider SMSProvider) Channel {
+	return &sms{provider: provider}
+}
+
+func (c *sms) SendLogin(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification) (string, error) {
+	return c.provider.Send(ctx, contact.Address, i18n.T(locale, i18n.SMSLogin, data.IP, data.Time))
+}
+
+// Digest is sent as one short message with number of events, SMS has no room for details
+func (c *sms) SendDigest(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest) error {
+	_, err := c.provider.Send(ctx, contact.Address, i18n.T(locale, i18n.SMSDigest, len(digest.Logins)))
+	return err
+}
+
+type httpSMSProvider struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+// NewHTTPSMSProvider sends messages through HTTP gateway, which accepts JSON with phone and text
+// and responds with ID of message. Used with self-hosted gateways and as stand-in for tests
+func NewHTTPSMSProvider(client *http.Client, url, token string) SMSProvider {
+	return &httpSMSProvider{client: client, url: url, token: token}
+}
+
+type smsRequest struct {
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+type smsResponse struct {
+	ID string `json:"id"`
+}
+
+func (p *httpSMSProvider) Send(ctx context.Context, phone, text string) (string, error) {
+	body, err := json.Marshal(smsRequest{To: phone, Text: text})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call sms gateway: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("sms gateway responded with status %d", resp.StatusCode)
+	}
+	var res smsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("invalid sms gateway response: %w", err)
+	}
+	return res.ID, nil
+}
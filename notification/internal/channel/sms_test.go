@@ -0,0 +1,70 @@
+package channel_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type smsMessage struct {
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+// Stand-in for SMS gateway, records received messages
+func newSMSGateway(t *testing.T, token string) (*httptest.Server, *[]smsMessage) {
+	var messages []smsMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var msg smsMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		messages = append(messages, msg)
+		json.NewEncoder(w).Encode(map[string]string{"id": "sms-1"})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &messages
+}
+
+func TestSMS_SendLogin(t *testing.T) {
+	srv, messages := newSMSGateway(t, "token")
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "password"}
+	contact := domain.Contact{Channel: domain.SubscriptionTypeSMS, Address: "+79990000000"}
+
+	t.Run("success", func(t *testing.T) {
+		sms := channel.NewSMS(channel.NewHTTPSMSProvider(srv.Client(), srv.URL, "token"))
+		id, err := sms.SendLogin(context.Background(), contact, "en", login)
+		require.NoError(t, err)
+		assert.Equal(t, "sms-1", id)
+		require.Len(t, *messages, 1)
+		assert.Equal(t, smsMessage{To: contact.Address, Text: i18n.T("en", i18n.SMSLogin, login.IP, login.Time)}, (*messages)[0])
+	})
+
+	t.Run("gateway error", func(t *testing.T) {
+		sms := channel.NewSMS(channel.NewHTTPSMSProvider(srv.Client(), srv.URL, "wrong"))
+		_, err := sms.SendLogin(context.Background(), contact, "en", login)
+		assert.Error(t, err)
+	})
+}
+
+func TestSMS_SendDigest(t *testing.T) {
+	srv, messages := newSMSGateway(t, "token")
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "password"}
+	contact := domain.Contact{Channel: domain.SubscriptionTypeSMS, Address: "+79990000000"}
+
+	sms := channel.NewSMS(channel.NewHTTPSMSProvider(srv.Client(), srv.URL, "token"))
+	err := sms.SendDigest(context.Background(), contact, "ru", domain.Digest{Logins: []domain.LoginNotification{login, login}})
+	require.NoError(t, err)
+	require.Len(t, *messages, 1)
+	assert.Equal(t, i18n.T("ru", i18n.SMSDigest, 2), (*messages)[0].Text)
+}
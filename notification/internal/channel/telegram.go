@@ -0,0 +1,42 @@
+package channel
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/telegram"
+)
+
+type tg struct {
+	sender telegram.Sender
+}
+
+// NewTelegram uses chat ID stored as contact address
+func NewTelegram(sender telegram.Sender) Channel {
+	return &tg{sender: sender}
+}
+
+func (c *tg) SendLogin(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification) (string, error) {
+	chatID, err := strconv.ParseInt(contact.Address, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	return c.sender.SendLoginNotification(ctx, chatID, locale, data)
+}
+
+func (c *tg) SendDigest(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest) error {
+	chatID, err := strconv.ParseInt(contact.Address, 10, 64)
+	if err != nil {
+		return err
+	}
+	return c.sender.SendDigest(ctx, chatID, locale, digest)
+}
+
+func (c *tg) SendAnnouncement(ctx context.Context, contact domain.Contact, locale string, data domain.Announcement) error {
+	chatID, err := strconv.ParseInt(contact.Address, 10, 64)
+	if err != nil {
+		return err
+	}
+	return c.sender.SendAnnouncement(ctx, chatID, data)
+}
@@ -0,0 +1,127 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+var ErrInsecureWebhook = errors.New("webhook url must use https")
+
+type LoginPayload struct {
+	IP   string `json:"ip"`
+	Time string `json:"time"`
+	Type string `json:"type"`
+}
+
+type DigestPayload struct {
+	Logins []LoginPayload `json:"logins"`
+}
+
+// WebhookPayload is a body of request to user's webhook, Event is one of: login, digest
+type WebhookPayload struct {
+	ID     string         `json:"id"`
+	Event  string         `json:"event"`
+	Locale string         `json:"locale"`
+	Login  *LoginPayload  `json:"login,omitempty"`
+	Digest *DigestPayload `json:"digest,omitempty"`
+}
+
+type webhook struct {
+	client *http.Client
+}
+
+// NewWebhook posts JSON payloads signed with secret of contact, client must be NewPublicClient
+func NewWebhook(client *http.Client) Channel {
+	return &webhook{client: client}
+}
+
+func (c *webhook) SendLogin(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification) (string, error) {
+	login := loginPayload(data)
+	payload := WebhookPayload{ID: uuid.NewString(), Event: "login", Locale: locale, Login: &login}
+	if err := c.post(ctx, contact, payload); err != nil {
+		return "", err
+	}
+	return payload.ID, nil
+}
+
+func (c *webhook) SendDigest(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest) error {
+	logins := make([]LoginPayload, len(digest.Logins))
+	for i, login := range digest.Logins {
+		logins[i] = loginPayload(login)
+	}
+	payload := WebhookPayload{ID: uuid.NewString(), Event: "digest", Locale: locale, Digest: &DigestPayload{Logins: logins}}
+	return c.post(ctx, contact, payload)
+}
+
+func (c *webhook) post(ctx context.Context, contact domain.Contact, payload WebhookPayload) error {
+	target, err := url.Parse(contact.Address)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if target.Scheme != "https" {
+		return ErrInsecureWebhook
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, payload.ID)
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, Sign(contact.Secret, timestamp, body))
+
+	resp, err := c.client.Do(req)
+	// Webhook in internal network will never be called, subscription is disabled then
+	if errors.Is(err, ErrForbiddenAddress) {
+		return fmt.Errorf("%w: %w", domain.ErrRecipientUnavailable, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drained body lets client reuse connection
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns signature of webhook request, receiver computes it the same way to verify the request.
+// Timestamp is signed too, so receiver can reject replayed requests
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func loginPayload(data domain.LoginNotification) LoginPayload {
+	return LoginPayload{IP: data.IP, Time: data.Time, Type: data.Type}
+}
@@ -0,0 +1,75 @@
+package channel_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_SendLogin(t *testing.T) {
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "password"}
+
+	var got channel.WebhookPayload
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(channel.HeaderWebhookTimestamp), 10, 64)
+		require.NoError(t, err)
+		if r.Header.Get(channel.HeaderWebhookSignature) != channel.Sign("secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Equal(t, got.ID, r.Header.Get(channel.HeaderWebhookID))
+	}))
+	defer srv.Close()
+
+	webhook := channel.NewWebhook(srv.Client())
+
+	t.Run("success", func(t *testing.T) {
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebhook, Address: srv.URL, Secret: "secret"}
+		id, err := webhook.SendLogin(context.Background(), contact, "en", login)
+		require.NoError(t, err)
+		assert.Equal(t, id, got.ID)
+		assert.Equal(t, "login", got.Event)
+		assert.Equal(t, "en", got.Locale)
+		assert.Equal(t, &channel.LoginPayload{IP: login.IP, Time: login.Time, Type: login.Type}, got.Login)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebhook, Address: srv.URL, Secret: "other"}
+		_, err := webhook.SendLogin(context.Background(), contact, "en", login)
+		assert.Error(t, err)
+	})
+
+	t.Run("insecure url", func(t *testing.T) {
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebhook, Address: "http://example.com/hook", Secret: "secret"}
+		_, err := webhook.SendLogin(context.Background(), contact, "en", login)
+		assert.ErrorIs(t, err, channel.ErrInsecureWebhook)
+	})
+}
+
+func TestWebhook_SendDigest(t *testing.T) {
+	var got channel.WebhookPayload
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "password"}
+	contact := domain.Contact{Channel: domain.SubscriptionTypeWebhook, Address: srv.URL, Secret: "secret"}
+	err := channel.NewWebhook(srv.Client()).SendDigest(context.Background(), contact, "ru", domain.Digest{Logins: []domain.LoginNotification{login, login}})
+	require.NoError(t, err)
+	assert.Equal(t, "digest", got.Event)
+	require.NotNil(t, got.Digest)
+	assert.Len(t, got.Digest.Logins, 2)
+}
@@ -0,0 +1,282 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Max size of encrypted record, push services must accept at least 4096 bytes
+const pushRecordSize = 4096
+
+var errPushExpired = errors.New("push subscription expired")
+
+type PushSubscriptionRepo interface {
+	PushSubscriptionsByUser(ctx context.Context, userID string) ([]domain.PushSubscription, error)
+	DeletePushSubscription(ctx context.Context, userID, endpoint string) error
+}
+
+// VAPID keys identify application server to push services, keys are base64url encoded raw P-256 keys
+type VAPID struct {
+	// Browsers use it as applicationServerKey when subscribing
+	PublicKey  string
+	PrivateKey string
+	// Contact of server operator, mailto: or https: URL
+	Subject string
+}
+
+// PushMessage is a payload decrypted by service worker of web client
+type PushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// Category of event, browser replaces shown notification with the same tag
+	Tag string `json:"tag"`
+}
+
+type webPush struct {
+	client     *http.Client
+	pushes     PushSubscriptionRepo
+	publicKey  string
+	privateKey *ecdsa.PrivateKey
+	subject    string
+	ttl        time.Duration
+}
+
+// NewWebPush sends encrypted pushes to all browsers of user, ttl limits how long push service keeps undelivered message.
+// Endpoints are supplied by browsers, so client must be NewPublicClient
+func NewWebPush(client *http.Client, pushes PushSubscriptionRepo, vapid VAPID, ttl time.Duration) (Channel, error) {
+	privateKey, publicKey, err := parseVAPIDKey(vapid.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if publicKey != strings.TrimRight(vapid.PublicKey, "=") {
+		return nil, errors.New("vapid public key does not match private key")
+	}
+	return &webPush{
+		client:     client,
+		pushes:     pushes,
+		publicKey:  publicKey,
+		privateKey: privateKey,
+		subject:    vapid.Subject,
+		ttl:        ttl,
+	}, nil
+}
+
+func (c *webPush) SendLogin(ctx context.Context, contact domain.Contact, locale string, data domain.LoginNotification) (string, error) {
+	return c.push(ctx, contact.Address, PushMessage{
+		Title: i18n.T(locale, i18n.PushLoginTitle),
+		Body:  i18n.T(locale, i18n.PushLoginBody, data.IP, data.Time),
+		Tag:   string(domain.EventCategoryLogin),
+	})
+}
+
+func (c *webPush) SendDigest(ctx context.Context, contact domain.Contact, locale string, digest domain.Digest) error {
+	_, err := c.push(ctx, contact.Address, PushMessage{
+		Title: i18n.T(locale, i18n.PushDigestTitle),
+		Body:  i18n.T(locale, i18n.PushDigestBody, len(digest.Logins)),
+		Tag:   "digest",
+	})
+	return err
+}
+
+// Message is sent to every browser of user, it is delivered if at least one browser accepted it.
+// Browsers whose subscriptions expired are removed
+func (c *webPush) push(ctx context.Context, userID string, msg PushMessage) (string, error) {
+	subs, err := c.pushes.PushSubscriptionsByUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	var sent bool
+	var messageID string
+	var errs []error
+	for _, sub := range subs {
+		id, err := c.send(ctx, sub, payload)
+		if errors.Is(err, errPushExpired) {
+			err = c.pushes.DeletePushSubscription(ctx, userID, sub.Endpoint)
+			if err != nil && !errors.Is(err, domain.ErrPushSubscriptionNotFound) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !sent {
+			sent, messageID = true, id
+		}
+	}
+	if sent {
+		return messageID, nil
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return "", domain.ErrNoActivePushSubscriptions
+}
+
+// Returns location of message assigned by push service
+func (c *webPush) send(ctx context.Context, sub domain.PushSubscription, payload []byte) (string, error) {
+	p256dh, auth, err := sub.Keys()
+	if err != nil {
+		return "", err
+	}
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+	body, err := encryptPush(p256dh, auth, payload)
+	if err != nil {
+		return "", err
+	}
+	token, err := c.vapidToken(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(c.ttl.Seconds())))
+	req.Header.Set("Urgency", "high")
+	req.Header.Set("Authorization", fmt.Sprintf("vapid t=%s, k=%s", token, c.publicKey))
+
+	resp, err := c.client.Do(req)
+	// Endpoint in internal network can't be push service, subscription is removed as expired one
+	if errors.Is(err, ErrForbiddenAddress) {
+		return "", errPushExpired
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to call push service: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return "", errPushExpired
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return "", fmt.Errorf("push service responded with status %d", resp.StatusCode)
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+// vapidToken signs JWT for origin of push service as RFC 8292 defines
+func (c *webPush) vapidToken(endpoint *url.URL) (string, error) {
+	claims := jwt.MapClaims{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": c.subject,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(c.privateKey)
+}
+
+// encryptPush encrypts payload with aes128gcm content encoding (RFC 8188) in one record,
+// keys are derived from browser keys and ephemeral server key as RFC 8291 defines
+func encryptPush(uaPublic, authSecret, plaintext []byte) ([]byte, error) {
+	curve := ecdh.P256()
+	uaKey, err := curve.NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, domain.ErrInvalidPushKeys
+	}
+	asKey, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	ecdhSecret, err := asKey.ECDH(uaKey)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asKey.PublicKey().Bytes()
+
+	keyInfo := "WebPush: info\x00" + string(uaPublic) + string(asPublic)
+	ikm, err := hkdf.Key(sha256.New, ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delimiter 2 marks the last record, no padding is added
+	record := append(slices.Clone(plaintext), 2)
+	if len(record)+gcm.Overhead() > pushRecordSize {
+		return nil, errors.New("push payload is too large")
+	}
+	header := make([]byte, 0, 21+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// Returns signing key and base64url encoded public key derived from it
+func parseVAPIDKey(privateKey string) (*ecdsa.PrivateKey, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(privateKey, "="))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	public := key.PublicKey().Bytes()
+	signer := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}
+	return signer, base64.RawURLEncoding.EncodeToString(public), nil
+}
@@ -0,0 +1,185 @@
+package channel_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var b64 = base64.RawURLEncoding
+
+// Browser side of push subscription
+type browser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newBrowser(t *testing.T) browser {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return browser{key: key, auth: auth}
+}
+
+func (b browser) subscription(endpoint string) domain.PushSubscription {
+	return domain.PushSubscription{Endpoint: endpoint, P256dh: b64.EncodeToString(b.key.PublicKey().Bytes()), Auth: b64.EncodeToString(b.auth)}
+}
+
+// decrypt reverses aes128gcm encoding the way browser does
+func (b browser) decrypt(t *testing.T, body []byte) []byte {
+	salt, idLen := body[:16], int(body[20])
+	assert.Equal(t, uint32(4096), binary.BigEndian.Uint32(body[16:20]))
+	asPublic, ciphertext := body[21:21+idLen], body[21+idLen:]
+
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	require.NoError(t, err)
+	secret, err := b.key.ECDH(asKey)
+	require.NoError(t, err)
+	ikm, err := hkdf.Key(sha256.New, secret, b.auth, "WebPush: info\x00"+string(b.key.PublicKey().Bytes())+string(asPublic), 32)
+	require.NoError(t, err)
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	require.NoError(t, err)
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(2), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func newVAPID(t *testing.T) channel.VAPID {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return channel.VAPID{
+		PublicKey:  b64.EncodeToString(key.PublicKey().Bytes()),
+		PrivateKey: b64.EncodeToString(key.Bytes()),
+		Subject:    "mailto:admin@example.com",
+	}
+}
+
+// verifyVAPID checks Authorization header as push service does
+func verifyVAPID(t *testing.T, r *http.Request, vapid channel.VAPID) {
+	header, ok := strings.CutPrefix(r.Header.Get("Authorization"), "vapid ")
+	require.True(t, ok)
+	params := make(map[string]string)
+	for _, part := range strings.Split(header, ", ") {
+		k, v, _ := strings.Cut(part, "=")
+		params[k] = v
+	}
+	assert.Equal(t, vapid.PublicKey, params["k"])
+
+	raw, err := b64.DecodeString(params["k"])
+	require.NoError(t, err)
+	publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(raw[1:33]), Y: new(big.Int).SetBytes(raw[33:])}
+	token, err := jwt.Parse(params["t"], func(*jwt.Token) (any, error) { return publicKey, nil }, jwt.WithValidMethods([]string{"ES256"}))
+	require.NoError(t, err)
+	claims := token.Claims.(jwt.MapClaims)
+	assert.Equal(t, "https://"+r.Host, claims["aud"])
+	assert.Equal(t, vapid.Subject, claims["sub"])
+}
+
+func TestWebPush_SendLogin(t *testing.T) {
+	vapid := newVAPID(t)
+	active, expired := newBrowser(t), newBrowser(t)
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "password"}
+
+	var got channel.PushMessage
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyVAPID(t, r, vapid)
+		assert.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "3600", r.Header.Get("TTL"))
+		if r.URL.Path == "/expired" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(active.decrypt(t, body), &got))
+		w.Header().Set("Location", "/messages/1")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	t.Run("expired browser is pruned", func(t *testing.T) {
+		pushes := mocks.NewPushSubscriptionRepo(t)
+		pushes.EXPECT().PushSubscriptionsByUser(context.Background(), "user123").Return([]domain.PushSubscription{
+			expired.subscription(srv.URL + "/expired"),
+			active.subscription(srv.URL + "/active"),
+		}, nil)
+		pushes.EXPECT().DeletePushSubscription(context.Background(), "user123", srv.URL+"/expired").Return(nil)
+
+		webPush, err := channel.NewWebPush(srv.Client(), pushes, vapid, time.Hour)
+		require.NoError(t, err)
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebPush, Address: "user123"}
+		id, err := webPush.SendLogin(context.Background(), contact, "en", login)
+		require.NoError(t, err)
+		assert.Equal(t, "/messages/1", id)
+		assert.Equal(t, "login", got.Tag)
+		assert.Contains(t, got.Body, login.IP)
+	})
+
+	t.Run("all browsers expired", func(t *testing.T) {
+		pushes := mocks.NewPushSubscriptionRepo(t)
+		pushes.EXPECT().PushSubscriptionsByUser(context.Background(), "user123").Return([]domain.PushSubscription{
+			expired.subscription(srv.URL + "/expired"),
+		}, nil)
+		pushes.EXPECT().DeletePushSubscription(context.Background(), "user123", srv.URL+"/expired").Return(nil)
+
+		webPush, err := channel.NewWebPush(srv.Client(), pushes, vapid, time.Hour)
+		require.NoError(t, err)
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebPush, Address: "user123"}
+		_, err = webPush.SendLogin(context.Background(), contact, "en", login)
+		assert.ErrorIs(t, err, domain.ErrNoActivePushSubscriptions)
+	})
+
+	t.Run("internal endpoint is pruned", func(t *testing.T) {
+		pushes := mocks.NewPushSubscriptionRepo(t)
+		pushes.EXPECT().PushSubscriptionsByUser(context.Background(), "user123").Return([]domain.PushSubscription{
+			active.subscription(srv.URL + "/active"),
+			active.subscription("https://169.254.169.254/latest/meta-data"),
+		}, nil)
+		pushes.EXPECT().DeletePushSubscription(context.Background(), "user123", srv.URL+"/active").Return(nil)
+		pushes.EXPECT().DeletePushSubscription(context.Background(), "user123", "https://169.254.169.254/latest/meta-data").Return(nil)
+
+		webPush, err := channel.NewWebPush(channel.NewPublicClient(time.Second), pushes, vapid, time.Hour)
+		require.NoError(t, err)
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebPush, Address: "user123"}
+		_, err = webPush.SendLogin(context.Background(), contact, "en", login)
+		assert.ErrorIs(t, err, domain.ErrNoActivePushSubscriptions)
+	})
+}
+
+func TestNewWebPush_KeysMismatch(t *testing.T) {
+	vapid := newVAPID(t)
+	vapid.PublicKey = newVAPID(t).PublicKey
+	_, err := channel.NewWebPush(http.DefaultClient, mocks.NewPushSubscriptionRepo(t), vapid, time.Hour)
+	assert.Error(t, err)
+}
@@ -2,18 +2,68 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	GrpcPort      int    `mapstructure:"grpc_port"`
-	PostgresURL   string `mapstructure:"postgres_url"`
-	RedisURL      string `mapstructure:"redis_url"`
-	RabbitmqURL   string `mapstructure:"rabbitmq_url"`
-	TelegramToken string `mapstructure:"telegram_token"`
-	SMTP          SMTP   `mapstructure:"smtp"`
-	JwtSecret     string `mapstructure:"jwt_secret"`
+	GrpcPort    int    `mapstructure:"grpc_port"`
+	PostgresURL string `mapstructure:"postgres_url"`
+	RedisURL    string `mapstructure:"redis_url"`
+	RabbitmqURL string `mapstructure:"rabbitmq_url"`
+	// Bot lists and terminates sessions of users in sso
+	SsoAddr       string    `mapstructure:"sso_addr"`
+	TelegramToken string    `mapstructure:"telegram_token"`
+	Telegram      Telegram  `mapstructure:"telegram"`
+	SMTP          SMTP      `mapstructure:"smtp"`
+	Webhook       Webhook   `mapstructure:"webhook"`
+	SMS           SMS       `mapstructure:"sms"`
+	WebPush       WebPush   `mapstructure:"webpush"`
+	Broadcast     Broadcast `mapstructure:"broadcast"`
+	JwtSecret     string    `mapstructure:"jwt_secret"`
+	// Page which signs user in by token of magic link, token is added as query parameter
+	MagicLinkURL string `mapstructure:"magic_link_url"`
+	// How often pending notifications are checked
+	DigestInterval time.Duration `mapstructure:"digest_interval"`
+	// How often bot dialogs are checked for timeout
+	ConversationInterval time.Duration `mapstructure:"conversation_interval"`
+	// Port of expvar metrics endpoint /debug/vars, disabled if zero
+	MetricsPort int `mapstructure:"metrics_port"`
+}
+
+const (
+	TelegramModePolling = "polling"
+	TelegramModeWebhook = "webhook"
+)
+
+// Bot receives updates by long polling or webhook, only webhook mode allows several replicas
+type Telegram struct {
+	Mode string `mapstructure:"mode"`
+	// Timeout of getUpdates request in polling mode
+	PollTimeout time.Duration   `mapstructure:"poll_timeout"`
+	Webhook     TelegramWebhook `mapstructure:"webhook"`
+	Limits      TelegramLimits  `mapstructure:"limits"`
+}
+
+type TelegramWebhook struct {
+	// Public HTTPS URL registered in Telegram, requests to it are proxied to the port
+	URL  string `mapstructure:"url"`
+	Port int    `mapstructure:"port"`
+	// Telegram sends it in every request, only A-Z, a-z, 0-9, _ and - are allowed
+	Secret string `mapstructure:"secret"`
+}
+
+// Limits of notifications sent by bot
+type TelegramLimits struct {
+	// Messages per second to all chats
+	Global int `mapstructure:"global"`
+	// Minimal interval between messages to one chat
+	ChatInterval time.Duration `mapstructure:"chat_interval"`
+	// Number of messages sent concurrently
+	Workers int `mapstructure:"workers"`
+	// Attempts of a message which Telegram asked to retry later
+	Attempts int `mapstructure:"attempts"`
 }
 
 type SMTP struct {
@@ -21,16 +71,72 @@ type SMTP struct {
 	Port int    `mapstructure:"port"`
 	User string `mapstructure:"user"`
 	Pass string `mapstructure:"password"`
+	// Max number of open connections
+	PoolSize int `mapstructure:"pool_size"`
+	// Idle connections are reopened, servers usually drop them after a few minutes
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+}
+
+type Webhook struct {
+	// Timeout of request to user's webhook
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// SMS gateway accepts JSON with phone and text, channel is disabled if URL is empty
+type SMS struct {
+	URL     string        `mapstructure:"url"`
+	Token   string        `mapstructure:"token"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// VAPID keys are base64url encoded raw P-256 keys, channel is disabled if private key is empty
+type WebPush struct {
+	PublicKey  string `mapstructure:"public_key"`
+	PrivateKey string `mapstructure:"private_key"`
+	// Contact of server operator sent to push services, mailto: or https: URL
+	Subject string        `mapstructure:"subject"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// How long push service keeps message for offline browser
+	TTL time.Duration `mapstructure:"ttl"`
+	// Push services which browsers may subscribe with, subdomains are allowed. Empty list allows any public host
+	Hosts []string `mapstructure:"hosts"`
+}
+
+type Broadcast struct {
+	// Messages per second of all channels
+	Rate int `mapstructure:"rate"`
+	// How often scheduled broadcasts are checked
+	Interval time.Duration `mapstructure:"interval"`
 }
 
 func MustLoadConfig(path string) *Config {
 	viper.SetConfigFile(path)
 
+	viper.SetDefault("digest_interval", time.Minute)
+	viper.SetDefault("conversation_interval", 30*time.Second)
+	viper.SetDefault("telegram.mode", TelegramModePolling)
+	viper.SetDefault("telegram.poll_timeout", 10*time.Second)
+	viper.SetDefault("telegram.limits.global", 30)
+	viper.SetDefault("telegram.limits.chat_interval", time.Second)
+	viper.SetDefault("telegram.limits.workers", 8)
+	viper.SetDefault("telegram.limits.attempts", 3)
+	viper.SetDefault("smtp.pool_size", 4)
+	viper.SetDefault("smtp.idle_timeout", 30*time.Second)
+	viper.SetDefault("webhook.timeout", 10*time.Second)
+	viper.SetDefault("sms.timeout", 10*time.Second)
+	viper.SetDefault("webpush.timeout", 10*time.Second)
+	viper.SetDefault("webpush.ttl", 24*time.Hour)
+	viper.SetDefault("broadcast.rate", 10)
+	viper.SetDefault("broadcast.interval", time.Minute)
+
 	viper.BindEnv("postgres_url", "POSTGRES_URL")
 	viper.BindEnv("redis_url", "REDIS_URL")
 	viper.BindEnv("rabbitmq_url", "RABBITMQ_URL")
 	viper.BindEnv("telegram_token", "TELEGRAM_TOKEN")
+	viper.BindEnv("telegram.webhook.secret", "TELEGRAM_WEBHOOK_SECRET")
 	viper.BindEnv("smtp.password", "SMTP_PASSWORD")
+	viper.BindEnv("sms.token", "SMS_TOKEN")
+	viper.BindEnv("webpush.private_key", "VAPID_PRIVATE_KEY")
 	viper.BindEnv("jwt_secret", "JWT_SECRET")
 
 	if err := viper.ReadInConfig(); err != nil {
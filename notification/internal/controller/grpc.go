@@ -3,6 +3,8 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	pb "github.com/SergeyBogomolovv/profile-manager/common/api/notification"
 	"github.com/SergeyBogomolovv/profile-manager/common/auth"
@@ -15,18 +17,55 @@ import (
 )
 
 type SetupService interface {
-	GenerateToken(ctx context.Context, userID string) (string, error)
+	GenerateToken(ctx context.Context, userID string) (domain.TelegramLink, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]domain.Subscription, error)
+	UpdateSubscription(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool) error
+	UnlinkUserTelegram(ctx context.Context, userID string) error
+	Preferences(ctx context.Context, userID string) (domain.Preferences, error)
+	UpdatePreference(ctx context.Context, userID string, channel domain.SubscriptionType, category domain.EventCategory, enabled bool) (domain.Preferences, error)
+	SetContact(ctx context.Context, userID string, contact domain.Contact) (domain.Contact, error)
+	DeleteContact(ctx context.Context, userID string, channel domain.SubscriptionType) error
+}
+
+type ScheduleService interface {
+	Schedule(ctx context.Context, userID string) (domain.Schedule, error)
+	UpdateSchedule(ctx context.Context, userID string, schedule domain.Schedule) error
+}
+
+type DeliveryService interface {
+	ListDeliveries(ctx context.Context, userID string, limit int) ([]domain.Delivery, error)
+}
+
+type PushService interface {
+	PublicKey() (string, error)
+	AddPushSubscription(ctx context.Context, userID string, sub domain.PushSubscription) error
+	RemovePushSubscription(ctx context.Context, userID, endpoint string) error
+}
+
+type BroadcastService interface {
+	Broadcast(ctx context.Context, adminID string, broadcast domain.Broadcast) (domain.Broadcast, error)
+	GetBroadcast(ctx context.Context, id int64) (domain.Broadcast, []domain.BroadcastFailure, error)
 }
 
 type controller struct {
 	pb.UnimplementedNotificationServer
-	svc      SetupService
-	validate *validator.Validate
+	svc        SetupService
+	schedules  ScheduleService
+	deliveries DeliveryService
+	push       PushService
+	broadcasts BroadcastService
+	validate   *validator.Validate
+}
+
+// Policy lists permissions required by methods, it is enforced by auth.AuthzInterceptor
+var Policy = auth.Policy{
+	pb.Notification_Broadcast_FullMethodName:    auth.PermissionBroadcast,
+	pb.Notification_GetBroadcast_FullMethodName: auth.PermissionBroadcast,
 }
 
-func New(svc SetupService) *controller {
+func New(svc SetupService, schedules ScheduleService, deliveries DeliveryService, push PushService, broadcasts BroadcastService) *controller {
 	validate := validator.New()
-	return &controller{svc: svc, validate: validate}
+	return &controller{svc: svc, schedules: schedules, deliveries: deliveries, push: push, broadcasts: broadcasts, validate: validate}
 }
 
 func (c *controller) Init(srv *grpc.Server) {
@@ -38,13 +77,428 @@ func (c *controller) GenerateTelegramToken(ctx context.Context, req *pb.Generate
 	if err := c.validate.Var(userID, "required,uuid"); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid user id")
 	}
-	token, err := c.svc.GenerateToken(ctx, userID)
+	link, err := c.svc.GenerateToken(ctx, userID)
 	if errors.Is(err, domain.ErrUserNotFound) {
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
+	if errors.Is(err, domain.ErrTooManyTokens) {
+		return nil, status.Error(codes.ResourceExhausted, "too many tokens, try again later")
+	}
 	if err != nil {
 		logger.Extract(ctx).Error("failed to generate token", "error", err)
 		return nil, status.Error(codes.Internal, "failed to generate token")
 	}
-	return &pb.GenerateTelegramTokenResponse{Token: token}, nil
+	return &pb.GenerateTelegramTokenResponse{Token: link.Token, Link: link.Link}, nil
+}
+
+func (c *controller) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	subscriptions, err := c.svc.ListSubscriptions(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to list subscriptions", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list subscriptions")
+	}
+	resp := &pb.ListSubscriptionsResponse{Subscriptions: make([]*pb.Subscription, len(subscriptions))}
+	for i, sub := range subscriptions {
+		resp.Subscriptions[i] = &pb.Subscription{Type: string(sub.Type), Enabled: sub.Enabled}
+	}
+	return resp, nil
+}
+
+func (c *controller) UpdateSubscription(ctx context.Context, req *pb.UpdateSubscriptionRequest) (*pb.UpdateSubscriptionResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Type, "required,oneof=email telegram webhook sms webpush"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscription type")
+	}
+	err := c.svc.UpdateSubscription(ctx, userID, domain.SubscriptionType(req.Type), req.Enabled)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if errors.Is(err, domain.ErrSubscriptionNotFound) {
+		return nil, status.Error(codes.NotFound, "subscription not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to update subscription", "error", err)
+		return nil, status.Error(codes.Internal, "failed to update subscription")
+	}
+	return &pb.UpdateSubscriptionResponse{}, nil
+}
+
+func (c *controller) UnlinkTelegram(ctx context.Context, req *pb.UnlinkTelegramRequest) (*pb.UnlinkTelegramResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	err := c.svc.UnlinkUserTelegram(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if errors.Is(err, domain.ErrActionDontNeeded) {
+		return nil, status.Error(codes.FailedPrecondition, "telegram is not linked")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to unlink telegram", "error", err)
+		return nil, status.Error(codes.Internal, "failed to unlink telegram")
+	}
+	return &pb.UnlinkTelegramResponse{}, nil
+}
+
+func (c *controller) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.Preferences, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	prefs, err := c.svc.Preferences(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to get preferences", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get preferences")
+	}
+	return preferencesToGRPC(prefs), nil
+}
+
+func (c *controller) UpdatePreference(ctx context.Context, req *pb.UpdatePreferenceRequest) (*pb.Preferences, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Channel, "required,oneof=email telegram webhook sms webpush"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid channel")
+	}
+	if err := c.validate.Var(req.Category, "required,oneof=login security account marketing"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category")
+	}
+	prefs, err := c.svc.UpdatePreference(ctx, userID, domain.SubscriptionType(req.Channel), domain.EventCategory(req.Category), req.Enabled)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to update preference", "error", err)
+		return nil, status.Error(codes.Internal, "failed to update preference")
+	}
+	return preferencesToGRPC(prefs), nil
+}
+
+func (c *controller) GetSchedule(ctx context.Context, req *pb.GetScheduleRequest) (*pb.Schedule, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	schedule, err := c.schedules.Schedule(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to get schedule", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get schedule")
+	}
+	return scheduleToGRPC(schedule), nil
+}
+
+func (c *controller) UpdateSchedule(ctx context.Context, req *pb.Schedule) (*pb.Schedule, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	for _, hour := range []int32{req.QuietFrom, req.QuietTo, req.DigestHour} {
+		if err := c.validate.Var(hour, "gte=0,lte=23"); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid hour")
+		}
+	}
+	schedule := domain.Schedule{
+		Timezone:   req.Timezone,
+		QuietHours: req.QuietHours,
+		QuietFrom:  int(req.QuietFrom),
+		QuietTo:    int(req.QuietTo),
+		Digest:     req.Digest,
+		DigestHour: int(req.DigestHour),
+	}
+	err := c.schedules.UpdateSchedule(ctx, userID, schedule)
+	if errors.Is(err, domain.ErrInvalidTimezone) {
+		return nil, status.Error(codes.InvalidArgument, "invalid timezone")
+	}
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to update schedule", "error", err)
+		return nil, status.Error(codes.Internal, "failed to update schedule")
+	}
+	return scheduleToGRPC(schedule), nil
+}
+
+const (
+	defaultDeliveriesLimit = 20
+	maxDeliveriesLimit     = 100
+)
+
+func (c *controller) ListDeliveries(ctx context.Context, req *pb.ListDeliveriesRequest) (*pb.ListDeliveriesResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Limit, fmt.Sprintf("gte=0,lte=%d", maxDeliveriesLimit)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultDeliveriesLimit
+	}
+	deliveries, err := c.deliveries.ListDeliveries(ctx, userID, limit)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to list deliveries", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list deliveries")
+	}
+	resp := &pb.ListDeliveriesResponse{Deliveries: make([]*pb.Delivery, len(deliveries))}
+	for i, d := range deliveries {
+		resp.Deliveries[i] = &pb.Delivery{
+			Id:                d.ID,
+			Channel:           string(d.Channel),
+			Category:          string(d.Category),
+			Status:            string(d.Status),
+			Attempts:          int32(d.Attempts),
+			LastError:         d.LastError,
+			ProviderMessageId: d.ProviderMessageID,
+			CreatedAt:         d.CreatedAt.Unix(),
+			UpdatedAt:         d.UpdatedAt.Unix(),
+			Digest:            d.IsDigest(),
+		}
+	}
+	return resp, nil
+}
+
+// Contacts of email and telegram are managed by registration and bot
+func (c *controller) SetContact(ctx context.Context, req *pb.SetContactRequest) (*pb.SetContactResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Channel, "required,oneof=webhook sms"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid channel")
+	}
+	addressTag := "required,e164"
+	if req.Channel == string(domain.SubscriptionTypeWebhook) {
+		addressTag = "required,max=2048,url,startswith=https://"
+	}
+	if err := c.validate.Var(req.Address, addressTag); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	contact, err := c.svc.SetContact(ctx, userID, domain.Contact{Channel: domain.SubscriptionType(req.Channel), Address: req.Address})
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to set contact", "error", err)
+		return nil, status.Error(codes.Internal, "failed to set contact")
+	}
+	return &pb.SetContactResponse{Channel: string(contact.Channel), Address: contact.Address, Secret: contact.Secret}, nil
+}
+
+func (c *controller) DeleteContact(ctx context.Context, req *pb.DeleteContactRequest) (*pb.DeleteContactResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Channel, "required,oneof=webhook sms"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid channel")
+	}
+	err := c.svc.DeleteContact(ctx, userID, domain.SubscriptionType(req.Channel))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if errors.Is(err, domain.ErrContactNotFound) {
+		return nil, status.Error(codes.NotFound, "contact not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to delete contact", "error", err)
+		return nil, status.Error(codes.Internal, "failed to delete contact")
+	}
+	return &pb.DeleteContactResponse{}, nil
+}
+
+func (c *controller) GetPushPublicKey(ctx context.Context, req *pb.GetPushPublicKeyRequest) (*pb.GetPushPublicKeyResponse, error) {
+	publicKey, err := c.push.PublicKey()
+	if errors.Is(err, domain.ErrUnknownChannel) {
+		return nil, status.Error(codes.FailedPrecondition, "web push is not configured")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to get push public key", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get push public key")
+	}
+	return &pb.GetPushPublicKeyResponse{PublicKey: publicKey}, nil
+}
+
+func (c *controller) AddPushSubscription(ctx context.Context, req *pb.AddPushSubscriptionRequest) (*pb.AddPushSubscriptionResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Endpoint, "required,max=2048,url,startswith=https://"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid endpoint")
+	}
+	sub := domain.PushSubscription{Endpoint: req.Endpoint, P256dh: req.P256Dh, Auth: req.Auth}
+	err := c.push.AddPushSubscription(ctx, userID, sub)
+	if errors.Is(err, domain.ErrInvalidPushKeys) {
+		return nil, status.Error(codes.InvalidArgument, "invalid keys")
+	}
+	if errors.Is(err, domain.ErrUnknownPushService) {
+		return nil, status.Error(codes.InvalidArgument, "unknown push service")
+	}
+	if errors.Is(err, domain.ErrUnknownChannel) {
+		return nil, status.Error(codes.FailedPrecondition, "web push is not configured")
+	}
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to add push subscription", "error", err)
+		return nil, status.Error(codes.Internal, "failed to add push subscription")
+	}
+	return &pb.AddPushSubscriptionResponse{}, nil
+}
+
+func (c *controller) RemovePushSubscription(ctx context.Context, req *pb.RemovePushSubscriptionRequest) (*pb.RemovePushSubscriptionResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Endpoint, "required"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid endpoint")
+	}
+	err := c.push.RemovePushSubscription(ctx, userID, req.Endpoint)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if errors.Is(err, domain.ErrPushSubscriptionNotFound) {
+		return nil, status.Error(codes.NotFound, "push subscription not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to remove push subscription", "error", err)
+		return nil, status.Error(codes.Internal, "failed to remove push subscription")
+	}
+	return &pb.RemovePushSubscriptionResponse{}, nil
+}
+
+// Broadcast requires broadcasts:send permission, see Policy
+func (c *controller) Broadcast(ctx context.Context, req *pb.BroadcastRequest) (*pb.BroadcastReport, error) {
+	adminID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(adminID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Segment, "required,oneof=all telegram email"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid segment")
+	}
+	if err := c.validate.Var(req.Category, "required,oneof=security account marketing"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category")
+	}
+	if err := c.validate.Var(req.Subject, "required,max=200"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subject")
+	}
+	if err := c.validate.Var(req.Text, "required,max=4000"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid text")
+	}
+	if err := c.validate.Var(req.SendAt, "gte=0"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid send at")
+	}
+	broadcast := domain.Broadcast{
+		Segment:  domain.BroadcastSegment(req.Segment),
+		Category: domain.EventCategory(req.Category),
+		Subject:  req.Subject,
+		Text:     req.Text,
+	}
+	if req.SendAt > 0 {
+		broadcast.SendAt = time.Unix(req.SendAt, 0)
+	}
+	broadcast, err := c.broadcasts.Broadcast(ctx, adminID, broadcast)
+	if errors.Is(err, domain.ErrInvalidBroadcast) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to create broadcast", "error", err)
+		return nil, status.Error(codes.Internal, "failed to create broadcast")
+	}
+	return broadcastToGRPC(broadcast, nil), nil
+}
+
+func (c *controller) GetBroadcast(ctx context.Context, req *pb.GetBroadcastRequest) (*pb.BroadcastReport, error) {
+	if err := c.validate.Var(req.Id, "gt=0"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid id")
+	}
+	broadcast, failures, err := c.broadcasts.GetBroadcast(ctx, req.Id)
+	if errors.Is(err, domain.ErrBroadcastNotFound) {
+		return nil, status.Error(codes.NotFound, "broadcast not found")
+	}
+	if err != nil {
+		logger.Extract(ctx).Error("failed to get broadcast", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get broadcast")
+	}
+	return broadcastToGRPC(broadcast, failures), nil
+}
+
+func broadcastToGRPC(broadcast domain.Broadcast, failures []domain.BroadcastFailure) *pb.BroadcastReport {
+	res := &pb.BroadcastReport{
+		Id:        broadcast.ID,
+		Segment:   string(broadcast.Segment),
+		Category:  string(broadcast.Category),
+		Subject:   broadcast.Subject,
+		Text:      broadcast.Text,
+		Status:    string(broadcast.Status),
+		SendAt:    broadcast.SendAt.Unix(),
+		Total:     int32(broadcast.Total),
+		Processed: int32(broadcast.Processed),
+		Sent:      int32(broadcast.Sent),
+		Failed:    int32(broadcast.Failed),
+		Skipped:   int32(broadcast.Skipped),
+		CreatedAt: broadcast.CreatedAt.Unix(),
+		UpdatedAt: broadcast.UpdatedAt.Unix(),
+		Failures:  make([]*pb.BroadcastFailure, len(failures)),
+	}
+	for i, failure := range failures {
+		res.Failures[i] = &pb.BroadcastFailure{
+			UserId:    failure.UserID,
+			Channel:   string(failure.Channel),
+			Error:     failure.Error,
+			CreatedAt: failure.CreatedAt.Unix(),
+		}
+	}
+	return res
+}
+
+func scheduleToGRPC(schedule domain.Schedule) *pb.Schedule {
+	return &pb.Schedule{
+		Timezone:   schedule.Timezone,
+		QuietHours: schedule.QuietHours,
+		QuietFrom:  int32(schedule.QuietFrom),
+		QuietTo:    int32(schedule.QuietTo),
+		Digest:     schedule.Digest,
+		DigestHour: int32(schedule.DigestHour),
+	}
+}
+
+func preferencesToGRPC(prefs domain.Preferences) *pb.Preferences {
+	res := &pb.Preferences{}
+	for _, channel := range domain.Channels {
+		for _, category := range domain.EventCategories {
+			res.Preferences = append(res.Preferences, &pb.Preference{
+				Channel:  string(channel),
+				Category: string(category),
+				Enabled:  prefs.Enabled(channel, category),
+			})
+		}
+	}
+	return res
 }
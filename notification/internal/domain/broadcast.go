@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// BroadcastSegment selects recipients of broadcast and channels it is sent through
+type BroadcastSegment string
+
+const (
+	BroadcastSegmentAll      BroadcastSegment = "all"
+	BroadcastSegmentTelegram BroadcastSegment = "telegram"
+	BroadcastSegmentEmail    BroadcastSegment = "email"
+)
+
+// Channels returns channels of the segment, nil if segment is unknown
+func (s BroadcastSegment) Channels() []SubscriptionType {
+	switch s {
+	case BroadcastSegmentAll:
+		return []SubscriptionType{SubscriptionTypeEmail, SubscriptionTypeTelegram}
+	case BroadcastSegmentTelegram:
+		return []SubscriptionType{SubscriptionTypeTelegram}
+	case BroadcastSegmentEmail:
+		return []SubscriptionType{SubscriptionTypeEmail}
+	default:
+		return nil
+	}
+}
+
+type BroadcastStatus string
+
+const (
+	BroadcastStatusScheduled BroadcastStatus = "scheduled"
+	BroadcastStatusRunning   BroadcastStatus = "running"
+	BroadcastStatusCompleted BroadcastStatus = "completed"
+)
+
+// Broadcast is an announcement sent to every user of segment who didn't opt out of its category.
+// Subject and text are text/template templates executed with BroadcastData
+type Broadcast struct {
+	ID        int64
+	Segment   BroadcastSegment
+	Category  EventCategory
+	Subject   string
+	Text      string
+	Status    BroadcastStatus
+	CreatedBy string
+	SendAt    time.Time
+	// Users are processed in order of ID, broadcast continues after cursor if it was interrupted
+	Cursor string
+	// Running broadcast is taken over by other instance after lease expires
+	LeaseUntil time.Time
+	// Changed on every claim, instance whose claim was taken over can't save progress
+	Claim string
+	// Number of users in segment when broadcast started and number of processed users
+	Total     int
+	Processed int
+	// Number of messages by result, skipped if user opted out or has no subscription
+	Sent      int
+	Failed    int
+	Skipped   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type BroadcastFailure struct {
+	UserID    string
+	Channel   SubscriptionType
+	Error     string
+	CreatedAt time.Time
+}
+
+// BroadcastData is available in templates of broadcast
+type BroadcastData struct {
+	Email  string
+	Locale string
+}
+
+// Announcement is rendered broadcast message
+type Announcement struct {
+	Subject string
+	Text    string
+}
+
+var (
+	ErrBroadcastNotFound        = errors.New("broadcast not found")
+	ErrInvalidBroadcast         = errors.New("invalid broadcast")
+	ErrAnnouncementsUnsupported = errors.New("channel doesn't support announcements")
+	ErrBroadcastLeaseLost       = errors.New("broadcast was claimed by another instance")
+)
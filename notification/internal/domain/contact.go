@@ -0,0 +1,18 @@
+package domain
+
+import "errors"
+
+// Contact is an address of user in one channel, subscription to the channel requires it
+type Contact struct {
+	Channel SubscriptionType
+	// Email, telegram chat ID, webhook URL or phone number in E.164.
+	// Web push contact holds user ID, browsers of user are stored as push subscriptions
+	Address string
+	// Key for HMAC signature of webhook payloads, empty for other channels
+	Secret string
+}
+
+var (
+	ErrContactNotFound = errors.New("contact not found")
+	ErrUnknownChannel  = errors.New("unknown channel")
+)
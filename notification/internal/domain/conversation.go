@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Step of bot dialog in which bot waits for user's answer
+type ConversationStep string
+
+// Conversation is an unfinished bot dialog, it is cancelled when user doesn't answer until ExpiresAt
+type Conversation struct {
+	TelegramID int64
+	Step       ConversationStep
+	// Language of user's Telegram client, cancel message is sent in it
+	Locale    string
+	ExpiresAt time.Time
+}
+
+var ErrConversationNotFound = errors.New("conversation not found")
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending DeliveryStatus = "pending"
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	DeliveryStatusFailed  DeliveryStatus = "failed"
+)
+
+const (
+	DeliveryMaxAttempts = 5
+	deliveryBaseBackoff = 30 * time.Second
+	deliveryMaxBackoff  = time.Hour
+)
+
+// ErrRecipientUnavailable is returned by channel when recipient will never receive messages,
+// e.g. user blocked the bot, subscription to the channel is disabled then
+var ErrRecipientUnavailable = errors.New("recipient unavailable")
+
+// Delivery is an attempt to deliver one message through one channel
+type Delivery struct {
+	ID       int64
+	Key      string
+	UserID   string
+	Channel  SubscriptionType
+	Category EventCategory
+	Login    LoginNotification
+	// Digest is set instead of Login for digest of pending notifications
+	Digest            Digest
+	Status            DeliveryStatus
+	Attempts          int
+	LastError         string
+	ProviderMessageID string
+	NextAttemptAt     time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (d *Delivery) IsDigest() bool {
+	return !d.Digest.IsEmpty()
+}
+
+func (d *Delivery) Succeed(providerMessageID string) {
+	d.Attempts++
+	d.Status = DeliveryStatusSent
+	d.ProviderMessageID = providerMessageID
+	d.LastError = ""
+}
+
+// Fail schedules next attempt with exponential backoff, delivery fails permanently after DeliveryMaxAttempts
+func (d *Delivery) Fail(err error, now time.Time) {
+	d.Attempts++
+	d.LastError = err.Error()
+	if d.Attempts >= DeliveryMaxAttempts {
+		d.Status = DeliveryStatusFailed
+		return
+	}
+	d.NextAttemptAt = now.Add(DeliveryBackoff(d.Attempts))
+}
+
+// Abort fails delivery without further attempts
+func (d *Delivery) Abort(reason string) {
+	d.Status = DeliveryStatusFailed
+	d.LastError = reason
+}
+
+// DeliveryBackoff returns delay before next attempt after attempt number n
+func DeliveryBackoff(n int) time.Duration {
+	backoff := deliveryBaseBackoff
+	for i := 1; i < n && backoff < deliveryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, deliveryMaxBackoff)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// PendingNotification is stored during quiet hours or in digest mode
+type PendingNotification struct {
+	ID        int64
+	UserID    string
+	Category  EventCategory
+	Login     LoginNotification
+	DeliverAt time.Time
+}
+
+// Digest combines pending notifications into one message
+type Digest struct {
+	Logins []LoginNotification
+}
+
+func (d Digest) IsEmpty() bool {
+	return len(d.Logins) == 0
+}
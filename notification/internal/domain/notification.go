@@ -5,3 +5,15 @@ type LoginNotification struct {
 	Time string
 	Type string
 }
+
+type MagicLinkNotification struct {
+	Link string
+	// Minutes until link expires
+	ExpiresIn int
+}
+
+type TelegramLinkNotification struct {
+	// Telegram username with @ or chat ID if user has no username
+	Account string
+	Time    string
+}
@@ -0,0 +1,33 @@
+package domain
+
+type EventCategory string
+
+const (
+	EventCategoryLogin     EventCategory = "login"
+	EventCategorySecurity  EventCategory = "security"
+	EventCategoryAccount   EventCategory = "account"
+	EventCategoryMarketing EventCategory = "marketing"
+)
+
+var (
+	EventCategories = []EventCategory{EventCategoryLogin, EventCategorySecurity, EventCategoryAccount, EventCategoryMarketing}
+	Channels        = []SubscriptionType{SubscriptionTypeEmail, SubscriptionTypeTelegram, SubscriptionTypeWebhook, SubscriptionTypeSMS, SubscriptionTypeWebPush}
+)
+
+// Preferences is a matrix of channel by category, missing entries use defaults
+type Preferences map[SubscriptionType]map[EventCategory]bool
+
+// Enabled reports whether events of category can be sent to channel, marketing is opt-in
+func (p Preferences) Enabled(channel SubscriptionType, category EventCategory) bool {
+	if enabled, ok := p[channel][category]; ok {
+		return enabled
+	}
+	return category != EventCategoryMarketing
+}
+
+func (p Preferences) Set(channel SubscriptionType, category EventCategory, enabled bool) {
+	if p[channel] == nil {
+		p[channel] = make(map[EventCategory]bool)
+	}
+	p[channel][category] = enabled
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// PushSubscription is a browser registered for Web Push, user may have several of them
+type PushSubscription struct {
+	Endpoint string
+	// Public key of browser, base64url encoded uncompressed P-256 point
+	P256dh string
+	// Auth secret of browser, base64url encoded 16 bytes
+	Auth string
+}
+
+var (
+	ErrInvalidPushKeys           = errors.New("invalid push subscription keys")
+	ErrPushSubscriptionNotFound  = errors.New("push subscription not found")
+	ErrNoActivePushSubscriptions = errors.New("no active push subscriptions")
+	ErrUnknownPushService        = errors.New("endpoint does not belong to known push service")
+)
+
+// Keys decodes keys of subscription, browsers may send them with or without padding
+func (s PushSubscription) Keys() (p256dh, auth []byte, err error) {
+	p256dh, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(s.P256dh, "="))
+	if err != nil || len(p256dh) != 65 || p256dh[0] != 4 {
+		return nil, nil, ErrInvalidPushKeys
+	}
+	auth, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(s.Auth, "="))
+	if err != nil || len(auth) != 16 {
+		return nil, nil, ErrInvalidPushKeys
+	}
+	return p256dh, auth, nil
+}
+
+// FromPushService reports whether endpoint is https URL on one of hosts or their subdomains, empty hosts allow any host
+func (s PushSubscription) FromPushService(hosts []string) bool {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil || endpoint.Scheme != "https" {
+		return false
+	}
+	if len(hosts) == 0 {
+		return true
+	}
+	host := strings.ToLower(endpoint.Hostname())
+	for _, allowed := range hosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Schedule defines when notifications are delivered, hours are in user's timezone
+type Schedule struct {
+	Timezone   string
+	QuietHours bool
+	QuietFrom  int
+	QuietTo    int
+	Digest     bool
+	DigestHour int
+}
+
+var DefaultSchedule = Schedule{Timezone: "UTC", QuietFrom: 23, QuietTo: 8, DigestHour: 9}
+
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+func (s Schedule) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsQuiet reports whether t is inside quiet hours, window may pass midnight
+func (s Schedule) IsQuiet(t time.Time) bool {
+	if !s.QuietHours || s.QuietFrom == s.QuietTo {
+		return false
+	}
+	hour := t.In(s.Location()).Hour()
+	if s.QuietFrom < s.QuietTo {
+		return hour >= s.QuietFrom && hour < s.QuietTo
+	}
+	return hour >= s.QuietFrom || hour < s.QuietTo
+}
+
+// Deferred reports whether notification created at t must be stored and delivered later
+func (s Schedule) Deferred(t time.Time) bool {
+	return s.Digest || s.IsQuiet(t)
+}
+
+// NextDelivery returns time when deferred notification created at t must be delivered
+func (s Schedule) NextDelivery(t time.Time) time.Time {
+	if s.Digest {
+		return nextHour(t.In(s.Location()), s.DigestHour)
+	}
+	return nextHour(t.In(s.Location()), s.QuietTo)
+}
+
+// Returns nearest moment after t when local clock shows hour:00
+func nextHour(t time.Time, hour int) time.Time {
+	next := time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, t.Location())
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
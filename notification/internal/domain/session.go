@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Session is an active login of user in sso
+type Session struct {
+	ID string
+	IP string
+	// How user logged in, credentials, magic_link or OAuth provider
+	Type      string
+	CreatedAt time.Time
+}
+
+var ErrSessionNotFound = errors.New("session not found")
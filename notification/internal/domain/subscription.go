@@ -7,14 +7,20 @@ type SubscriptionType string
 const (
 	SubscriptionTypeEmail    SubscriptionType = "email"
 	SubscriptionTypeTelegram SubscriptionType = "telegram"
+	SubscriptionTypeWebhook  SubscriptionType = "webhook"
+	SubscriptionTypeSMS      SubscriptionType = "sms"
+	SubscriptionTypeWebPush  SubscriptionType = "webpush"
 )
 
 type Subscription struct {
 	User    User
 	Type    SubscriptionType
 	Enabled bool
+	// Address of user in the channel
+	Contact Contact
 }
 
 var (
-	ErrActionDontNeeded = errors.New("action dont needed")
+	ErrActionDontNeeded     = errors.New("action dont needed")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
 )
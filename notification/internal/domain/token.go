@@ -7,4 +7,24 @@ import (
 
 const TokenTTL = time.Minute * 15
 
-var ErrInvalidToken = errors.New("invalid token")
+// How many link tokens user can generate during TokenTTL
+const TokenLimit = 5
+
+var (
+	ErrInvalidToken  = errors.New("invalid token")
+	ErrTooManyTokens = errors.New("too many tokens")
+)
+
+// TelegramLink is a token to link Telegram account with deep link to the bot containing it
+type TelegramLink struct {
+	Token string
+	Link  string
+}
+
+// TelegramAccount is a Telegram user who sent the token to the bot
+type TelegramAccount struct {
+	ID int64
+	// Empty if user has no username
+	Username     string
+	LanguageCode string
+}
@@ -6,6 +6,8 @@ type User struct {
 	ID         string
 	Email      string
 	TelegramID int64
+	// Empty if unknown, notifications are sent in default locale then
+	Locale string
 }
 
 var (
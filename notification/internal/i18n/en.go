@@ -0,0 +1,86 @@
+package i18n
+
+var en = map[Key]string{
+	BotStart:             "Hi! Use /link to link your account.",
+	BotUnknownCommand:    "Unknown command.",
+	BotNoActiveAction:    "No active actions found.",
+	BotCancelled:         "Action cancelled.",
+	BotTimedOut:          "Action cancelled: no answer received in time.",
+	BotEnterToken:        "Enter the token. Use /cancel to cancel",
+	BotInvalidToken:      "Invalid token.",
+	BotInvalidLink:       "The link is invalid or expired, get a new one in your profile.",
+	BotAccountLimit:      "You can link only one account.",
+	BotAlreadyLinked:     "Account is already linked.",
+	BotLinked:            "Account linked successfully!",
+	BotNotLinked:         "Account is not linked.",
+	BotUnlinked:          "Account unlinked successfully!",
+	BotLinkRequired:      "Link your account with the /link command",
+	BotUnexpectedError:   "An unexpected error occurred.",
+	BotChooseChannel:     "Choose notification type.",
+	BotAlreadyEnabled:    "Notifications are already enabled.",
+	BotEnabled:           "Notifications enabled.",
+	BotAlreadyDisabled:   "Notifications are already disabled.",
+	BotDisabled:          "Notifications disabled.",
+	BotPreferencesTitle:  "Telegram notifications:",
+	BotChooseCategory:    "Tap a category to toggle it.",
+	BotCategoryEnabled:   "“%s” notifications enabled.",
+	BotCategoryDisabled:  "“%s” notifications disabled.",
+	BotStatusAccount:     "Account: %s",
+	BotStatusChannels:    "Notification channels (tap to toggle):",
+	BotStatusNoChannels:  "No notification channels connected.",
+	BotSessionsTitle:     "Active sessions:",
+	BotSession:           "%d. %s UTC, IP %s, %s",
+	BotNoSessions:        "No active sessions.",
+	BotTerminateSession:  "Terminate %d",
+	BotSessionTerminated: "Session terminated.",
+	BotSessionNotFound:   "Session is already terminated.",
+
+	ChannelEmail:    "Email",
+	ChannelTelegram: "Telegram",
+	ChannelWebhook:  "Webhook",
+	ChannelSMS:      "SMS",
+	ChannelWebPush:  "Browser",
+
+	CategoryLogin:     "Sign-ins",
+	CategorySecurity:  "Security",
+	CategoryAccount:   "Account changes",
+	CategoryMarketing: "News and offers",
+
+	TelegramLogin:        "⚠️New sign-in to your account⚠️\n\nIP: %s\nTime: %s\nType: %s",
+	TelegramDigestTitle:  "📋Notification digest📋",
+	TelegramDigestLogins: "Sign-ins: %d",
+
+	SMSLogin:  "New sign-in: IP %s, %s. If it was not you, change your password.",
+	SMSDigest: "Digest: %d sign-ins. See details in your account.",
+
+	PushLoginTitle:  "New sign-in",
+	PushLoginBody:   "IP %s, %s",
+	PushDigestTitle: "Notification digest",
+	PushDigestBody:  "Sign-ins: %d",
+
+	EmailLoginSubject:          "New sign-in to your account",
+	EmailLoginTitle:            "Sign-in to your account",
+	EmailLoginText:             "We noticed a sign-in to your account.",
+	EmailLoginWarning:          "If it wasn't you, change your password right away.",
+	EmailRegisterSubject:       "Welcome to profile-manager",
+	EmailRegisterTitle:         "Glad to see you in our app",
+	EmailRegisterText:          "Enjoy using it.",
+	EmailDigestSubject:         "Notification digest",
+	EmailDigestLogins:          "Sign-ins: %d",
+	EmailDigestWarning:         "If any of these sign-ins wasn't you, change your password right away.",
+	EmailTelegramLinkedSubject: "Telegram linked to your account",
+	EmailTelegramLinkedTitle:   "Telegram account linked",
+	EmailTelegramLinkedText:    "A Telegram account was linked to your account, notifications will be sent to it.",
+	EmailTelegramLinkedWarning: "If it wasn't you, change your password and unlink Telegram right away.",
+	EmailTelegramAccount:       "Telegram account",
+	EmailMagicLinkSubject:      "Your sign-in link",
+	EmailMagicLinkTitle:        "Sign in to profile-manager",
+	EmailMagicLinkText:         "Follow the link to sign in, it can be used only once.",
+	EmailMagicLinkButton:       "Sign in",
+	EmailMagicLinkExpires:      "The link expires in %d minutes.",
+	EmailMagicLinkWarning:      "If you didn't request it, just ignore this email.",
+	EmailTime:                  "Date and time",
+	EmailIP:                    "IP address",
+	EmailLoginType:             "Sign-in type",
+	EmailFooter:                "This email was sent automatically, please do not reply.",
+}
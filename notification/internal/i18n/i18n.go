@@ -0,0 +1,74 @@
+// Package i18n contains translations of user-facing texts
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+type Key string
+
+const DefaultLocale = "ru"
+
+// Locales are supported locales, first one is default
+var Locales = []string{"ru", "en"}
+
+var catalog = map[string]map[Key]string{
+	"ru": ru,
+	"en": en,
+}
+
+var matcher = language.NewMatcher([]language.Tag{language.Russian, language.English})
+
+// Lookup returns translation of key without fallbacks
+func Lookup(locale string, key Key) (string, bool) {
+	msg, ok := catalog[locale][key]
+	return msg, ok
+}
+
+// T translates key formatting it with args, unknown locales fall back to DefaultLocale
+func T(locale string, key Key, args ...any) string {
+	msg, ok := Lookup(Normalize(locale), key)
+	if !ok {
+		msg, ok = Lookup(DefaultLocale, key)
+	}
+	if !ok {
+		return string(key)
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Normalize returns locale if it is supported, otherwise DefaultLocale
+func Normalize(locale string) string {
+	if _, ok := catalog[locale]; ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Match picks supported locale from Accept-Language header or language tag,
+// returns empty string if nothing matches
+func Match(preferences string) string {
+	tags, _, err := language.ParseAcceptLanguage(preferences)
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return ""
+	}
+	return Locales[index]
+}
+
+// Keys returns all keys translated to locale
+func Keys(locale string) []Key {
+	keys := make([]Key, 0, len(catalog[locale]))
+	for key := range catalog[locale] {
+		keys = append(keys, key)
+	}
+	return keys
+}
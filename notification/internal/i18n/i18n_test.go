@@ -0,0 +1,99 @@
+package i18n_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every key of every locale
+func allKeys() map[i18n.Key]struct{} {
+	keys := make(map[i18n.Key]struct{})
+	for _, locale := range i18n.Locales {
+		for _, key := range i18n.Keys(locale) {
+			keys[key] = struct{}{}
+		}
+	}
+	return keys
+}
+
+var verbRe = regexp.MustCompile(`%[a-z]`)
+
+func TestCatalog_EveryKeyTranslated(t *testing.T) {
+	for key := range allKeys() {
+		reference, ok := i18n.Lookup(i18n.DefaultLocale, key)
+		assert.True(t, ok, "key %s is missing in default locale", key)
+		for _, locale := range i18n.Locales {
+			msg, ok := i18n.Lookup(locale, key)
+			if !assert.True(t, ok, "key %s is missing in %s", key, locale) {
+				continue
+			}
+			assert.NotEmpty(t, msg, "key %s is empty in %s", key, locale)
+			assert.Equal(t, verbRe.FindAllString(reference, -1), verbRe.FindAllString(msg, -1),
+				"key %s has different format verbs in %s", key, locale)
+		}
+	}
+}
+
+// Keys declared in keys.go must be present in catalogs, otherwise they are rendered as is
+func TestCatalog_DeclaredKeysTranslated(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "keys.go", nil, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	keys := allKeys()
+	var declared int
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for _, value := range spec.Values {
+			lit, ok := value.(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			key, _ := strconv.Unquote(lit.Value)
+			_, ok = keys[i18n.Key(key)]
+			assert.True(t, ok, "key %s is not translated", key)
+			declared++
+		}
+		return true
+	})
+	assert.Equal(t, len(keys), declared, "catalog contains undeclared keys")
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Unknown command.", i18n.T("en", i18n.BotUnknownCommand))
+	assert.Equal(t, "Команда не распознана.", i18n.T("de", i18n.BotUnknownCommand))
+	assert.Equal(t, "Команда не распознана.", i18n.T("", i18n.BotUnknownCommand))
+	assert.Equal(t, "Sign-ins: 3", i18n.T("en", i18n.TelegramDigestLogins, 3))
+	assert.Equal(t, "missing.key", i18n.T("en", i18n.Key("missing.key")))
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		preferences string
+		want        string
+	}{
+		{preferences: "en", want: "en"},
+		{preferences: "en-US", want: "en"},
+		{preferences: "ru-RU,ru;q=0.9,en-US;q=0.8", want: "ru"},
+		{preferences: "de-DE,en;q=0.5", want: "en"},
+		{preferences: "de", want: ""},
+		{preferences: "", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q", tc.preferences), func(t *testing.T) {
+			assert.Equal(t, tc.want, i18n.Match(tc.preferences))
+		})
+	}
+}
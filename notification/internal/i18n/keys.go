@@ -0,0 +1,86 @@
+package i18n
+
+const (
+	BotStart             Key = "bot.start"
+	BotUnknownCommand    Key = "bot.unknown_command"
+	BotNoActiveAction    Key = "bot.no_active_action"
+	BotCancelled         Key = "bot.cancelled"
+	BotTimedOut          Key = "bot.timed_out"
+	BotEnterToken        Key = "bot.enter_token"
+	BotInvalidToken      Key = "bot.invalid_token"
+	BotInvalidLink       Key = "bot.invalid_link"
+	BotAccountLimit      Key = "bot.account_limit"
+	BotAlreadyLinked     Key = "bot.already_linked"
+	BotLinked            Key = "bot.linked"
+	BotNotLinked         Key = "bot.not_linked"
+	BotUnlinked          Key = "bot.unlinked"
+	BotLinkRequired      Key = "bot.link_required"
+	BotUnexpectedError   Key = "bot.unexpected_error"
+	BotChooseChannel     Key = "bot.choose_channel"
+	BotAlreadyEnabled    Key = "bot.already_enabled"
+	BotEnabled           Key = "bot.enabled"
+	BotAlreadyDisabled   Key = "bot.already_disabled"
+	BotDisabled          Key = "bot.disabled"
+	BotPreferencesTitle  Key = "bot.preferences_title"
+	BotChooseCategory    Key = "bot.choose_category"
+	BotCategoryEnabled   Key = "bot.category_enabled"
+	BotCategoryDisabled  Key = "bot.category_disabled"
+	BotStatusAccount     Key = "bot.status_account"
+	BotStatusChannels    Key = "bot.status_channels"
+	BotStatusNoChannels  Key = "bot.status_no_channels"
+	BotSessionsTitle     Key = "bot.sessions_title"
+	BotSession           Key = "bot.session"
+	BotNoSessions        Key = "bot.no_sessions"
+	BotTerminateSession  Key = "bot.terminate_session"
+	BotSessionTerminated Key = "bot.session_terminated"
+	BotSessionNotFound   Key = "bot.session_not_found"
+
+	ChannelEmail    Key = "channel.email"
+	ChannelTelegram Key = "channel.telegram"
+	ChannelWebhook  Key = "channel.webhook"
+	ChannelSMS      Key = "channel.sms"
+	ChannelWebPush  Key = "channel.webpush"
+
+	CategoryLogin     Key = "category.login"
+	CategorySecurity  Key = "category.security"
+	CategoryAccount   Key = "category.account"
+	CategoryMarketing Key = "category.marketing"
+
+	TelegramLogin        Key = "telegram.login"
+	TelegramDigestTitle  Key = "telegram.digest_title"
+	TelegramDigestLogins Key = "telegram.digest_logins"
+
+	SMSLogin  Key = "sms.login"
+	SMSDigest Key = "sms.digest"
+
+	PushLoginTitle  Key = "push.login.title"
+	PushLoginBody   Key = "push.login.body"
+	PushDigestTitle Key = "push.digest.title"
+	PushDigestBody  Key = "push.digest.body"
+
+	EmailLoginSubject          Key = "email.login.subject"
+	EmailLoginTitle            Key = "email.login.title"
+	EmailLoginText             Key = "email.login.text"
+	EmailLoginWarning          Key = "email.login.warning"
+	EmailRegisterSubject       Key = "email.register.subject"
+	EmailRegisterTitle         Key = "email.register.title"
+	EmailRegisterText          Key = "email.register.text"
+	EmailDigestSubject         Key = "email.digest.subject"
+	EmailDigestLogins          Key = "email.digest.logins"
+	EmailDigestWarning         Key = "email.digest.warning"
+	EmailTelegramLinkedSubject Key = "email.telegram_linked.subject"
+	EmailTelegramLinkedTitle   Key = "email.telegram_linked.title"
+	EmailTelegramLinkedText    Key = "email.telegram_linked.text"
+	EmailTelegramLinkedWarning Key = "email.telegram_linked.warning"
+	EmailTelegramAccount       Key = "email.telegram_account"
+	EmailMagicLinkSubject      Key = "email.magic_link.subject"
+	EmailMagicLinkTitle        Key = "email.magic_link.title"
+	EmailMagicLinkText         Key = "email.magic_link.text"
+	EmailMagicLinkButton       Key = "email.magic_link.button"
+	EmailMagicLinkExpires      Key = "email.magic_link.expires"
+	EmailMagicLinkWarning      Key = "email.magic_link.warning"
+	EmailTime                  Key = "email.time"
+	EmailIP                    Key = "email.ip"
+	EmailLoginType             Key = "email.login_type"
+	EmailFooter                Key = "email.footer"
+)
@@ -0,0 +1,86 @@
+package i18n
+
+var ru = map[Key]string{
+	BotStart:             "Привет! Используйте /link для привязки аккаунта.",
+	BotUnknownCommand:    "Команда не распознана.",
+	BotNoActiveAction:    "Активные действия не найдены.",
+	BotCancelled:         "Действие отменено.",
+	BotTimedOut:          "Действие отменено: ответ не получен вовремя.",
+	BotEnterToken:        "Введите токен. Для отмены используйте /cancel",
+	BotInvalidToken:      "Неверный токен.",
+	BotInvalidLink:       "Ссылка недействительна или устарела, получите новую в профиле.",
+	BotAccountLimit:      "Вы можете привязать только 1 аккаунт.",
+	BotAlreadyLinked:     "Аккаунт уже привязан.",
+	BotLinked:            "Аккаунт успешно привязан!",
+	BotNotLinked:         "Аккаунт не привязан.",
+	BotUnlinked:          "Аккаунт успешно отвязан!",
+	BotLinkRequired:      "Привяжите аккаунт с помощью команды /link",
+	BotUnexpectedError:   "Произошла непредвиденная ошибка.",
+	BotChooseChannel:     "Выберите тип уведомлений.",
+	BotAlreadyEnabled:    "Уведомления уже подключены.",
+	BotEnabled:           "Уведомления успешно подключены.",
+	BotAlreadyDisabled:   "Уведомления уже отключены.",
+	BotDisabled:          "Уведомления успешно отключены.",
+	BotPreferencesTitle:  "Уведомления в Телеграм:",
+	BotChooseCategory:    "Нажмите на категорию, чтобы переключить её.",
+	BotCategoryEnabled:   "Уведомления «%s» включены.",
+	BotCategoryDisabled:  "Уведомления «%s» отключены.",
+	BotStatusAccount:     "Аккаунт: %s",
+	BotStatusChannels:    "Каналы уведомлений (нажмите, чтобы переключить):",
+	BotStatusNoChannels:  "Каналы уведомлений не подключены.",
+	BotSessionsTitle:     "Активные сессии:",
+	BotSession:           "%d. %s UTC, IP %s, %s",
+	BotNoSessions:        "Активных сессий нет.",
+	BotTerminateSession:  "Завершить %d",
+	BotSessionTerminated: "Сессия завершена.",
+	BotSessionNotFound:   "Сессия уже завершена.",
+
+	ChannelEmail:    "Почта",
+	ChannelTelegram: "Телеграм",
+	ChannelWebhook:  "Вебхук",
+	ChannelSMS:      "СМС",
+	ChannelWebPush:  "Браузер",
+
+	CategoryLogin:     "Входы в аккаунт",
+	CategorySecurity:  "Безопасность",
+	CategoryAccount:   "Изменения аккаунта",
+	CategoryMarketing: "Новости и акции",
+
+	TelegramLogin:        "⚠️Произведен вход в аккаунт⚠️\n\nIP: %s\nВремя: %s\nТип: %s",
+	TelegramDigestTitle:  "📋Сводка уведомлений📋",
+	TelegramDigestLogins: "Входы в аккаунт: %d",
+
+	SMSLogin:  "Вход в аккаунт: IP %s, %s. Если это не вы, смените пароль.",
+	SMSDigest: "Сводка: входов в аккаунт %d. Подробности в личном кабинете.",
+
+	PushLoginTitle:  "Вход в аккаунт",
+	PushLoginBody:   "IP %s, %s",
+	PushDigestTitle: "Сводка уведомлений",
+	PushDigestBody:  "Входов в аккаунт: %d",
+
+	EmailLoginSubject:          "Произведен вход в аккаунт",
+	EmailLoginTitle:            "Вход в аккаунт",
+	EmailLoginText:             "Мы зафиксировали вход в ваш аккаунт.",
+	EmailLoginWarning:          "Если это были не вы, рекомендуем срочно сменить пароль.",
+	EmailRegisterSubject:       "Добро пожаловать в profile-manager",
+	EmailRegisterTitle:         "Рады видеть вас в нашем приложении",
+	EmailRegisterText:          "Приятного пользования.",
+	EmailDigestSubject:         "Сводка уведомлений",
+	EmailDigestLogins:          "Входы в аккаунт: %d",
+	EmailDigestWarning:         "Если какой-то из входов совершили не вы, рекомендуем срочно сменить пароль.",
+	EmailTelegramLinkedSubject: "К вашему аккаунту привязан Telegram",
+	EmailTelegramLinkedTitle:   "Telegram привязан",
+	EmailTelegramLinkedText:    "К вашему аккаунту привязан Telegram, уведомления будут приходить в него.",
+	EmailTelegramLinkedWarning: "Если это были не вы, срочно смените пароль и отвяжите Telegram.",
+	EmailTelegramAccount:       "Аккаунт Telegram",
+	EmailMagicLinkSubject:      "Ссылка для входа",
+	EmailMagicLinkTitle:        "Вход в profile-manager",
+	EmailMagicLinkText:         "Перейдите по ссылке, чтобы войти. Ее можно использовать только один раз.",
+	EmailMagicLinkButton:       "Войти",
+	EmailMagicLinkExpires:      "Ссылка действительна %d мин.",
+	EmailMagicLinkWarning:      "Если вы не запрашивали вход, просто проигнорируйте это письмо.",
+	EmailTime:                  "Дата и время",
+	EmailIP:                    "IP-адрес",
+	EmailLoginType:             "Тип входа",
+	EmailFooter:                "Это письмо отправлено автоматически, не отвечайте на него.",
+}
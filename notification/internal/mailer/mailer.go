@@ -1,82 +1,107 @@
 package mailer
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"html/template"
 
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/config"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
+	"github.com/google/uuid"
 	"gopkg.in/gomail.v2"
 )
 
 type Mailer interface {
-	SendLoginEmail(to string, data domain.LoginNotification) error
-	SendRegisterEmail(to string) error
+	// SendLoginEmail returns Message-ID of sent email
+	SendLoginEmail(ctx context.Context, to, locale string, data domain.LoginNotification) (string, error)
+	SendRegisterEmail(ctx context.Context, to, locale string) error
+	SendDigestEmail(ctx context.Context, to, locale string, digest domain.Digest) error
+	SendTelegramLinkedEmail(ctx context.Context, to, locale string, data domain.TelegramLinkNotification) error
+	SendMagicLinkEmail(ctx context.Context, to, locale string, data domain.MagicLinkNotification) error
+	SendAnnouncementEmail(ctx context.Context, to, locale string, data domain.Announcement) error
 }
 
 type mailer struct {
-	user string
-	pass string
-	port int
+	from string
 	host string
+	pool *pool
 }
 
-func New(cfg config.SMTP) Mailer {
+func New(cfg config.SMTP) *mailer {
+	dialer := gomail.NewDialer(cfg.Host, cfg.Port, cfg.User, cfg.Pass)
 	return &mailer{
-		user: cfg.User,
-		pass: cfg.Pass,
-		port: cfg.Port,
+		from: cfg.User,
 		host: cfg.Host,
+		pool: newPool(dialer, cfg.PoolSize, cfg.IdleTimeout),
 	}
 }
 
-func (m *mailer) SendLoginEmail(to string, data domain.LoginNotification) error {
-	mail := gomail.NewMessage()
-	mail.SetHeader("From", m.user)
-	mail.SetHeader("To", to)
-	mail.SetHeader("Subject", "Произведен вход в аккаунт")
+func (m *mailer) Close() error {
+	return m.pool.Close()
+}
 
-	tmpl, err := template.ParseFiles("templates/login_notification.html")
+func (m *mailer) SendLoginEmail(ctx context.Context, to, locale string, data domain.LoginNotification) (string, error) {
+	messageID, err := m.send(ctx, to, locale, i18n.T(locale, i18n.EmailLoginSubject), "login_notification", data)
 	if err != nil {
-		return fmt.Errorf("failed to parse email template: %w", err)
+		return "", fmt.Errorf("failed to send login email: %w", err)
 	}
+	return messageID, nil
+}
 
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, data); err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+func (m *mailer) SendRegisterEmail(ctx context.Context, to, locale string) error {
+	if _, err := m.send(ctx, to, locale, i18n.T(locale, i18n.EmailRegisterSubject), "register_notification", nil); err != nil {
+		return fmt.Errorf("failed to send register email: %w", err)
 	}
+	return nil
+}
 
-	mail.SetBody("text/html", body.String())
+func (m *mailer) SendDigestEmail(ctx context.Context, to, locale string, digest domain.Digest) error {
+	if _, err := m.send(ctx, to, locale, i18n.T(locale, i18n.EmailDigestSubject), "digest_notification", digest); err != nil {
+		return fmt.Errorf("failed to send digest email: %w", err)
+	}
+	return nil
+}
 
-	d := gomail.NewDialer(m.host, m.port, m.user, m.pass)
-	if err := d.DialAndSend(mail); err != nil {
-		return fmt.Errorf("failed to send login email: %w", err)
+func (m *mailer) SendTelegramLinkedEmail(ctx context.Context, to, locale string, data domain.TelegramLinkNotification) error {
+	if _, err := m.send(ctx, to, locale, i18n.T(locale, i18n.EmailTelegramLinkedSubject), "telegram_linked", data); err != nil {
+		return fmt.Errorf("failed to send telegram linked email: %w", err)
 	}
 	return nil
 }
 
-func (m mailer) SendRegisterEmail(to string) error {
-	mail := gomail.NewMessage()
-	mail.SetHeader("From", m.user)
-	mail.SetHeader("To", to)
-	mail.SetHeader("Subject", "Добро пожаловать в profile-manager")
+func (m *mailer) SendMagicLinkEmail(ctx context.Context, to, locale string, data domain.MagicLinkNotification) error {
+	if _, err := m.send(ctx, to, locale, i18n.T(locale, i18n.EmailMagicLinkSubject), "magic_link", data); err != nil {
+		return fmt.Errorf("failed to send magic link email: %w", err)
+	}
+	return nil
+}
 
-	tmpl, err := template.ParseFiles("templates/register_notification.html")
-	if err != nil {
-		return fmt.Errorf("failed to parse email template: %w", err)
+// Subject of announcement is written by admin, so it is not translated
+func (m *mailer) SendAnnouncementEmail(ctx context.Context, to, locale string, data domain.Announcement) error {
+	if _, err := m.send(ctx, to, locale, data.Subject, "announcement", data); err != nil {
+		return fmt.Errorf("failed to send announcement email: %w", err)
 	}
+	return nil
+}
 
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, nil); err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+// Renders template pair with the name into plain text and html alternatives and sends them
+func (m *mailer) send(ctx context.Context, to, locale, subject, name string, data any) (string, error) {
+	text, html, err := render(locale, name, data)
+	if err != nil {
+		return "", err
 	}
 
-	mail.SetBody("text/html", body.String())
+	messageID := fmt.Sprintf("<%s@%s>", uuid.NewString(), m.host)
+	mail := gomail.NewMessage()
+	mail.SetHeader("From", m.from)
+	mail.SetHeader("To", to)
+	mail.SetHeader("Subject", subject)
+	mail.SetHeader("Message-ID", messageID)
+	mail.SetBody("text/plain", text)
+	mail.AddAlternative("text/html", html)
 
-	d := gomail.NewDialer(m.host, m.port, m.user, m.pass)
-	if err := d.DialAndSend(mail); err != nil {
-		return fmt.Errorf("failed to send register email: %w", err)
+	if err := m.pool.Send(ctx, mail); err != nil {
+		return "", err
 	}
-	return nil
+	return messageID, nil
 }
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,17 +23,66 @@ func (_m *Mailer) EXPECT() *Mailer_Expecter {
 	return &Mailer_Expecter{mock: &_m.Mock}
 }
 
-// SendLoginEmail provides a mock function with given fields: to, data
-func (_m *Mailer) SendLoginEmail(to string, data domain.LoginNotification) error {
-	ret := _m.Called(to, data)
+// SendAnnouncementEmail provides a mock function with given fields: ctx, to, locale, data
+func (_m *Mailer) SendAnnouncementEmail(ctx context.Context, to string, locale string, data domain.Announcement) error {
+	ret := _m.Called(ctx, to, locale, data)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendLoginEmail")
+		panic("no return value specified for SendAnnouncementEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.Announcement) error); ok {
+		r0 = rf(ctx, to, locale, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Mailer_SendAnnouncementEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAnnouncementEmail'
+type Mailer_SendAnnouncementEmail_Call struct {
+	*mock.Call
+}
+
+// SendAnnouncementEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - to string
+//   - locale string
+//   - data domain.Announcement
+func (_e *Mailer_Expecter) SendAnnouncementEmail(ctx interface{}, to interface{}, locale interface{}, data interface{}) *Mailer_SendAnnouncementEmail_Call {
+	return &Mailer_SendAnnouncementEmail_Call{Call: _e.mock.On("SendAnnouncementEmail", ctx, to, locale, data)}
+}
+
+func (_c *Mailer_SendAnnouncementEmail_Call) Run(run func(ctx context.Context, to string, locale string, data domain.Announcement)) *Mailer_SendAnnouncementEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.Announcement))
+	})
+	return _c
+}
+
+func (_c *Mailer_SendAnnouncementEmail_Call) Return(_a0 error) *Mailer_SendAnnouncementEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Mailer_SendAnnouncementEmail_Call) RunAndReturn(run func(context.Context, string, string, domain.Announcement) error) *Mailer_SendAnnouncementEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendDigestEmail provides a mock function with given fields: ctx, to, locale, digest
+func (_m *Mailer) SendDigestEmail(ctx context.Context, to string, locale string, digest domain.Digest) error {
+	ret := _m.Called(ctx, to, locale, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDigestEmail")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, domain.LoginNotification) error); ok {
-		r0 = rf(to, data)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.Digest) error); ok {
+		r0 = rf(ctx, to, locale, digest)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -39,46 +90,156 @@ func (_m *Mailer) SendLoginEmail(to string, data domain.LoginNotification) error
 	return r0
 }
 
+// Mailer_SendDigestEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDigestEmail'
+type Mailer_SendDigestEmail_Call struct {
+	*mock.Call
+}
+
+// SendDigestEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - to string
+//   - locale string
+//   - digest domain.Digest
+func (_e *Mailer_Expecter) SendDigestEmail(ctx interface{}, to interface{}, locale interface{}, digest interface{}) *Mailer_SendDigestEmail_Call {
+	return &Mailer_SendDigestEmail_Call{Call: _e.mock.On("SendDigestEmail", ctx, to, locale, digest)}
+}
+
+func (_c *Mailer_SendDigestEmail_Call) Run(run func(ctx context.Context, to string, locale string, digest domain.Digest)) *Mailer_SendDigestEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.Digest))
+	})
+	return _c
+}
+
+func (_c *Mailer_SendDigestEmail_Call) Return(_a0 error) *Mailer_SendDigestEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Mailer_SendDigestEmail_Call) RunAndReturn(run func(context.Context, string, string, domain.Digest) error) *Mailer_SendDigestEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendLoginEmail provides a mock function with given fields: ctx, to, locale, data
+func (_m *Mailer) SendLoginEmail(ctx context.Context, to string, locale string, data domain.LoginNotification) (string, error) {
+	ret := _m.Called(ctx, to, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendLoginEmail")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.LoginNotification) (string, error)); ok {
+		return rf(ctx, to, locale, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.LoginNotification) string); ok {
+		r0 = rf(ctx, to, locale, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, domain.LoginNotification) error); ok {
+		r1 = rf(ctx, to, locale, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Mailer_SendLoginEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendLoginEmail'
 type Mailer_SendLoginEmail_Call struct {
 	*mock.Call
 }
 
 // SendLoginEmail is a helper method to define mock.On call
+//   - ctx context.Context
 //   - to string
+//   - locale string
 //   - data domain.LoginNotification
-func (_e *Mailer_Expecter) SendLoginEmail(to interface{}, data interface{}) *Mailer_SendLoginEmail_Call {
-	return &Mailer_SendLoginEmail_Call{Call: _e.mock.On("SendLoginEmail", to, data)}
+func (_e *Mailer_Expecter) SendLoginEmail(ctx interface{}, to interface{}, locale interface{}, data interface{}) *Mailer_SendLoginEmail_Call {
+	return &Mailer_SendLoginEmail_Call{Call: _e.mock.On("SendLoginEmail", ctx, to, locale, data)}
+}
+
+func (_c *Mailer_SendLoginEmail_Call) Run(run func(ctx context.Context, to string, locale string, data domain.LoginNotification)) *Mailer_SendLoginEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.LoginNotification))
+	})
+	return _c
+}
+
+func (_c *Mailer_SendLoginEmail_Call) Return(_a0 string, _a1 error) *Mailer_SendLoginEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mailer_SendLoginEmail_Call) RunAndReturn(run func(context.Context, string, string, domain.LoginNotification) (string, error)) *Mailer_SendLoginEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMagicLinkEmail provides a mock function with given fields: ctx, to, locale, data
+func (_m *Mailer) SendMagicLinkEmail(ctx context.Context, to string, locale string, data domain.MagicLinkNotification) error {
+	ret := _m.Called(ctx, to, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMagicLinkEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.MagicLinkNotification) error); ok {
+		r0 = rf(ctx, to, locale, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Mailer_SendMagicLinkEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMagicLinkEmail'
+type Mailer_SendMagicLinkEmail_Call struct {
+	*mock.Call
+}
+
+// SendMagicLinkEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - to string
+//   - locale string
+//   - data domain.MagicLinkNotification
+func (_e *Mailer_Expecter) SendMagicLinkEmail(ctx interface{}, to interface{}, locale interface{}, data interface{}) *Mailer_SendMagicLinkEmail_Call {
+	return &Mailer_SendMagicLinkEmail_Call{Call: _e.mock.On("SendMagicLinkEmail", ctx, to, locale, data)}
 }
 
-func (_c *Mailer_SendLoginEmail_Call) Run(run func(to string, data domain.LoginNotification)) *Mailer_SendLoginEmail_Call {
+func (_c *Mailer_SendMagicLinkEmail_Call) Run(run func(ctx context.Context, to string, locale string, data domain.MagicLinkNotification)) *Mailer_SendMagicLinkEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(domain.LoginNotification))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.MagicLinkNotification))
 	})
 	return _c
 }
 
-func (_c *Mailer_SendLoginEmail_Call) Return(_a0 error) *Mailer_SendLoginEmail_Call {
+func (_c *Mailer_SendMagicLinkEmail_Call) Return(_a0 error) *Mailer_SendMagicLinkEmail_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Mailer_SendLoginEmail_Call) RunAndReturn(run func(string, domain.LoginNotification) error) *Mailer_SendLoginEmail_Call {
+func (_c *Mailer_SendMagicLinkEmail_Call) RunAndReturn(run func(context.Context, string, string, domain.MagicLinkNotification) error) *Mailer_SendMagicLinkEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SendRegisterEmail provides a mock function with given fields: to
-func (_m *Mailer) SendRegisterEmail(to string) error {
-	ret := _m.Called(to)
+// SendRegisterEmail provides a mock function with given fields: ctx, to, locale
+func (_m *Mailer) SendRegisterEmail(ctx context.Context, to string, locale string) error {
+	ret := _m.Called(ctx, to, locale)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendRegisterEmail")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(to)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, to, locale)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,14 +253,16 @@ type Mailer_SendRegisterEmail_Call struct {
 }
 
 // SendRegisterEmail is a helper method to define mock.On call
+//   - ctx context.Context
 //   - to string
-func (_e *Mailer_Expecter) SendRegisterEmail(to interface{}) *Mailer_SendRegisterEmail_Call {
-	return &Mailer_SendRegisterEmail_Call{Call: _e.mock.On("SendRegisterEmail", to)}
+//   - locale string
+func (_e *Mailer_Expecter) SendRegisterEmail(ctx interface{}, to interface{}, locale interface{}) *Mailer_SendRegisterEmail_Call {
+	return &Mailer_SendRegisterEmail_Call{Call: _e.mock.On("SendRegisterEmail", ctx, to, locale)}
 }
 
-func (_c *Mailer_SendRegisterEmail_Call) Run(run func(to string)) *Mailer_SendRegisterEmail_Call {
+func (_c *Mailer_SendRegisterEmail_Call) Run(run func(ctx context.Context, to string, locale string)) *Mailer_SendRegisterEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -109,7 +272,56 @@ func (_c *Mailer_SendRegisterEmail_Call) Return(_a0 error) *Mailer_SendRegisterE
 	return _c
 }
 
-func (_c *Mailer_SendRegisterEmail_Call) RunAndReturn(run func(string) error) *Mailer_SendRegisterEmail_Call {
+func (_c *Mailer_SendRegisterEmail_Call) RunAndReturn(run func(context.Context, string, string) error) *Mailer_SendRegisterEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendTelegramLinkedEmail provides a mock function with given fields: ctx, to, locale, data
+func (_m *Mailer) SendTelegramLinkedEmail(ctx context.Context, to string, locale string, data domain.TelegramLinkNotification) error {
+	ret := _m.Called(ctx, to, locale, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTelegramLinkedEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain.TelegramLinkNotification) error); ok {
+		r0 = rf(ctx, to, locale, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Mailer_SendTelegramLinkedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTelegramLinkedEmail'
+type Mailer_SendTelegramLinkedEmail_Call struct {
+	*mock.Call
+}
+
+// SendTelegramLinkedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - to string
+//   - locale string
+//   - data domain.TelegramLinkNotification
+func (_e *Mailer_Expecter) SendTelegramLinkedEmail(ctx interface{}, to interface{}, locale interface{}, data interface{}) *Mailer_SendTelegramLinkedEmail_Call {
+	return &Mailer_SendTelegramLinkedEmail_Call{Call: _e.mock.On("SendTelegramLinkedEmail", ctx, to, locale, data)}
+}
+
+func (_c *Mailer_SendTelegramLinkedEmail_Call) Run(run func(ctx context.Context, to string, locale string, data domain.TelegramLinkNotification)) *Mailer_SendTelegramLinkedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(domain.TelegramLinkNotification))
+	})
+	return _c
+}
+
+func (_c *Mailer_SendTelegramLinkedEmail_Call) Return(_a0 error) *Mailer_SendTelegramLinkedEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Mailer_SendTelegramLinkedEmail_Call) RunAndReturn(run func(context.Context, string, string, domain.TelegramLinkNotification) error) *Mailer_SendTelegramLinkedEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
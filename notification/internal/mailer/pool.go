@@ -0,0 +1,115 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+type conn struct {
+	gomail.SendCloser
+	usedAt time.Time
+}
+
+// pool keeps up to size SMTP connections, connections idle longer than idleTimeout are reopened
+type pool struct {
+	dialer      *gomail.Dialer
+	idleTimeout time.Duration
+	slots       chan struct{}
+	idle        chan *conn
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func newPool(dialer *gomail.Dialer, size int, idleTimeout time.Duration) *pool {
+	return &pool{
+		dialer:      dialer,
+		idleTimeout: idleTimeout,
+		slots:       make(chan struct{}, size),
+		idle:        make(chan *conn, size),
+	}
+}
+
+// Send waits for free connection until ctx is done
+func (p *pool) Send(ctx context.Context, msg *gomail.Message) error {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-p.slots }()
+
+	c, err := p.get()
+	if err != nil {
+		return err
+	}
+	if err := gomail.Send(c, msg); err != nil {
+		c.Close()
+		// Server may drop connection at any time, retry once on a fresh one
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if c, err = p.dial(); err != nil {
+			return err
+		}
+		if err := gomail.Send(c, msg); err != nil {
+			c.Close()
+			return err
+		}
+	}
+	p.put(c)
+	return nil
+}
+
+// Close closes idle connections, connections in use are closed when returned
+func (p *pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for {
+		select {
+		case c := <-p.idle:
+			c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (p *pool) get() (*conn, error) {
+	select {
+	case c := <-p.idle:
+		if time.Since(c.usedAt) < p.idleTimeout {
+			return c, nil
+		}
+		c.Close()
+	default:
+	}
+	return p.dial()
+}
+
+func (p *pool) put(c *conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		c.Close()
+		return
+	}
+	c.usedAt = time.Now()
+	select {
+	case p.idle <- c:
+	default:
+		c.Close()
+	}
+}
+
+func (p *pool) dial() (*conn, error) {
+	sc, err := p.dialer.Dial()
+	if err != nil {
+		return nil, err
+	}
+	return &conn{SendCloser: sc}, nil
+}
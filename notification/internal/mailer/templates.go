@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// Templates of every locale, parsed once with t function bound to the locale
+var (
+	htmlTemplates = make(map[string]*htmltemplate.Template)
+	textTemplates = make(map[string]*texttemplate.Template)
+)
+
+func init() {
+	for _, locale := range i18n.Locales {
+		funcs := templateFuncs(locale)
+		htmlTemplates[locale] = htmltemplate.Must(htmltemplate.New("").Funcs(funcs).ParseFS(templatesFS, "templates/*.html"))
+		textTemplates[locale] = texttemplate.Must(texttemplate.New("").Funcs(funcs).ParseFS(templatesFS, "templates/*.txt"))
+	}
+}
+
+// Missing translation fails rendering instead of showing the key
+func templateFuncs(locale string) map[string]any {
+	return map[string]any{
+		"locale": func() string { return locale },
+		"t": func(key string, args ...any) (string, error) {
+			if _, ok := i18n.Lookup(locale, i18n.Key(key)); !ok {
+				return "", fmt.Errorf("no %s translation for %s", locale, key)
+			}
+			return i18n.T(locale, i18n.Key(key), args...), nil
+		},
+	}
+}
+
+// render returns plain text and html versions of template with the name
+func render(locale, name string, data any) (string, string, error) {
+	locale = i18n.Normalize(locale)
+	var text, html bytes.Buffer
+	if err := textTemplates[locale].ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return "", "", fmt.Errorf("failed to execute text template: %w", err)
+	}
+	if err := htmlTemplates[locale].ExecuteTemplate(&html, name+".html", data); err != nil {
+		return "", "", fmt.Errorf("failed to execute html template: %w", err)
+	}
+	return text.String(), html.String(), nil
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"testing"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender_AllLocales(t *testing.T) {
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "credentials"}
+	templates := map[string]any{
+		"login_notification":    login,
+		"register_notification": nil,
+		"digest_notification":   domain.Digest{Logins: []domain.LoginNotification{login}},
+		"telegram_linked":       domain.TelegramLinkNotification{Account: "@user", Time: "2025-01-01 10:00:00"},
+		"announcement":          domain.Announcement{Subject: "News", Text: "First line\nSecond line"},
+		"magic_link":            domain.MagicLinkNotification{Link: "http://localhost:3000/auth/magic-link?token=token", ExpiresIn: 15},
+	}
+
+	for _, locale := range i18n.Locales {
+		for name, data := range templates {
+			t.Run(locale+"/"+name, func(t *testing.T) {
+				text, html, err := render(locale, name, data)
+				assert.NoError(t, err)
+				assert.NotEmpty(t, text)
+				assert.Contains(t, html, `lang="`+locale+`"`)
+			})
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type broadcastRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewBroadcastRepo(db *sqlx.DB) *broadcastRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &broadcastRepo{db: db, qb: qb}
+}
+
+// CreateBroadcast sets ID and timestamps of broadcast
+func (r *broadcastRepo) CreateBroadcast(ctx context.Context, broadcast *domain.Broadcast) error {
+	query, args := r.qb.
+		Insert("broadcasts").
+		Columns("segment", "category", "subject", "text", "status", "created_by", "send_at").
+		Values(broadcast.Segment, broadcast.Category, broadcast.Subject, broadcast.Text, broadcast.Status, broadcast.CreatedBy, broadcast.SendAt).
+		Suffix("RETURNING *").
+		MustSql()
+
+	var entity Broadcast
+	if err := r.getContext(ctx, &entity, query, args...); err != nil {
+		return e.Wrap(err, "failed to create broadcast")
+	}
+	*broadcast = entity.ToDomain()
+	return nil
+}
+
+func (r *broadcastRepo) Broadcast(ctx context.Context, id int64) (domain.Broadcast, error) {
+	query, args := r.qb.Select("*").From("broadcasts").Where(sq.Eq{"id": id}).MustSql()
+	var entity Broadcast
+	err := r.getContext(ctx, &entity, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Broadcast{}, domain.ErrBroadcastNotFound
+	}
+	if err != nil {
+		return domain.Broadcast{}, e.Wrap(err, "failed to get broadcast")
+	}
+	return entity.ToDomain(), nil
+}
+
+// ClaimBroadcast marks due broadcast as running until lease with new claim, broadcasts with expired lease
+// are claimed again. Returns domain.ErrBroadcastNotFound if nothing is due
+func (r *broadcastRepo) ClaimBroadcast(ctx context.Context, now, leaseUntil time.Time) (domain.Broadcast, error) {
+	query, args := r.claimQuery(now, leaseUntil, uuid.New())
+	var entity Broadcast
+	err := r.getContext(ctx, &entity, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Broadcast{}, domain.ErrBroadcastNotFound
+	}
+	if err != nil {
+		return domain.Broadcast{}, e.Wrap(err, "failed to claim broadcast")
+	}
+	return entity.ToDomain(), nil
+}
+
+func (r *broadcastRepo) claimQuery(now, leaseUntil time.Time, claim uuid.UUID) (string, []any) {
+	// Subquery keeps ? placeholders, they are numbered once in outer query
+	due := sq.
+		Select("id").
+		From("broadcasts").
+		Where(sq.Or{
+			sq.And{sq.Eq{"status": domain.BroadcastStatusScheduled}, sq.LtOrEq{"send_at": now}},
+			sq.And{sq.Eq{"status": domain.BroadcastStatusRunning}, sq.Lt{"lease_until": now}},
+		}).
+		OrderBy("send_at").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	return r.qb.
+		Update("broadcasts").
+		Set("status", domain.BroadcastStatusRunning).
+		Set("lease_until", leaseUntil).
+		Set("claim", claim).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("id = (?)", due)).
+		Suffix("RETURNING *").
+		MustSql()
+}
+
+// SaveProgress updates status, cursor, lease and counters of broadcast.
+// Returns domain.ErrBroadcastLeaseLost if broadcast was claimed again since broadcast.Claim
+func (r *broadcastRepo) SaveProgress(ctx context.Context, broadcast domain.Broadcast) error {
+	query, args := r.qb.
+		Update("broadcasts").
+		Set("status", broadcast.Status).
+		Set("cursor", sql.NullString{String: broadcast.Cursor, Valid: broadcast.Cursor != ""}).
+		Set("lease_until", broadcast.LeaseUntil).
+		Set("total", broadcast.Total).
+		Set("processed", broadcast.Processed).
+		Set("sent", broadcast.Sent).
+		Set("failed", broadcast.Failed).
+		Set("skipped", broadcast.Skipped).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": broadcast.ID, "claim": broadcast.Claim}).
+		MustSql()
+
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return e.Wrap(err, "failed to save broadcast progress")
+	}
+	return checkClaim(res)
+}
+
+// RenewLease extends lease of running broadcast, returns domain.ErrBroadcastLeaseLost if broadcast was claimed again
+func (r *broadcastRepo) RenewLease(ctx context.Context, id int64, claim string, leaseUntil time.Time) error {
+	query, args := r.qb.
+		Update("broadcasts").
+		Set("lease_until", leaseUntil).
+		Where(sq.Eq{"id": id, "claim": claim, "status": domain.BroadcastStatusRunning}).
+		MustSql()
+
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return e.Wrap(err, "failed to renew broadcast lease")
+	}
+	return checkClaim(res)
+}
+
+func checkClaim(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return e.Wrap(err, "failed to get affected rows")
+	}
+	if affected == 0 {
+		return domain.ErrBroadcastLeaseLost
+	}
+	return nil
+}
+
+func (r *broadcastRepo) AddFailures(ctx context.Context, broadcastID int64, failures []domain.BroadcastFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	qb := r.qb.Insert("broadcast_failures").Columns("broadcast_id", "user_id", "channel", "error")
+	for _, failure := range failures {
+		qb = qb.Values(broadcastID, failure.UserID, failure.Channel, failure.Error)
+	}
+	query, args := qb.MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to add broadcast failures")
+}
+
+// Failures returns last failures of broadcast, newest first
+func (r *broadcastRepo) Failures(ctx context.Context, broadcastID int64, limit int) ([]domain.BroadcastFailure, error) {
+	query, args := r.qb.
+		Select("user_id", "channel", "error", "created_at").
+		From("broadcast_failures").
+		Where(sq.Eq{"broadcast_id": broadcastID}).
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		MustSql()
+
+	var entities []BroadcastFailure
+	if err := r.selectContext(ctx, &entities, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to get broadcast failures")
+	}
+	res := make([]domain.BroadcastFailure, len(entities))
+	for i, entity := range entities {
+		res[i] = domain.BroadcastFailure{
+			UserID:    entity.UserID.String(),
+			Channel:   entity.Channel,
+			Error:     entity.Error,
+			CreatedAt: entity.CreatedAt,
+		}
+	}
+	return res, nil
+}
+
+func (r *broadcastRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *broadcastRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
+
+func (r *broadcastRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
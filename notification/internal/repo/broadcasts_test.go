@@ -0,0 +1,27 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcastRepo_claimQuery(t *testing.T) {
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+	claim := uuid.New()
+
+	query, args := NewBroadcastRepo(nil).claimQuery(now, leaseUntil, claim)
+
+	// Placeholders of subquery continue numbering of outer query
+	assert.Equal(t, "UPDATE broadcasts SET status = $1, lease_until = $2, claim = $3, updated_at = NOW() "+
+		"WHERE id = (SELECT id FROM broadcasts WHERE ((status = $4 AND send_at <= $5) OR (status = $6 AND lease_until < $7)) "+
+		"ORDER BY send_at LIMIT 1 FOR UPDATE SKIP LOCKED) RETURNING *", query)
+	assert.Equal(t, []any{
+		domain.BroadcastStatusRunning, leaseUntil, claim,
+		domain.BroadcastStatusScheduled, now, domain.BroadcastStatusRunning, now,
+	}, args)
+}
@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type contactRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewContactRepo(db *sqlx.DB) *contactRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &contactRepo{db: db, qb: qb}
+}
+
+// SaveContact creates or replaces contact of the channel
+func (r *contactRepo) SaveContact(ctx context.Context, userID string, contact domain.Contact) error {
+	var secret any
+	if contact.Secret != "" {
+		secret = contact.Secret
+	}
+	query, args := r.qb.
+		Insert("channel_contacts").
+		Columns("user_id", "channel", "address", "secret").
+		Values(userID, contact.Channel, contact.Address, secret).
+		Suffix("ON CONFLICT (user_id, channel) DO UPDATE SET address = EXCLUDED.address, secret = EXCLUDED.secret").
+		MustSql()
+
+	_, err := r.execContext(ctx, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return domain.ErrAccountAlreadyExists
+	}
+	return e.WrapIfErr(err, "failed to save contact")
+}
+
+// DeleteContact removes contact with subscription to the channel
+func (r *contactRepo) DeleteContact(ctx context.Context, userID string, channel domain.SubscriptionType) error {
+	query, args := r.qb.
+		Delete("channel_contacts").
+		Where(sq.Eq{"user_id": userID, "channel": channel}).
+		MustSql()
+
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return e.Wrap(err, "failed to delete contact")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return e.Wrap(err, "failed to delete contact")
+	}
+	if affected == 0 {
+		return domain.ErrContactNotFound
+	}
+	return nil
+}
+
+func (r *contactRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
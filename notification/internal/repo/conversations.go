@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// Keys share hash tag, so they are in one cluster slot and popExpiredScript can touch all of them
+const (
+	conversationKeyPrefix = "{telegram_conversations}:"
+	// Sorted set of telegram IDs scored by expiration time in milliseconds
+	conversationsExpiryKey = "{telegram_conversations}"
+	// Expired conversation is kept until it is popped, otherwise locale of user is unknown
+	conversationGrace = time.Hour
+	// Max number of conversations popped at once
+	conversationsPopLimit = 100
+)
+
+// Removes IDs which are still expired from sorted set KEYS[1] and returns their conversations.
+// KEYS[i] is conversation of ID ARGV[i] for i > 1, ARGV[1] is now. Script is atomic, so every
+// conversation is popped by one replica only, even if candidates were read by several of them
+var popExpiredScript = redis.NewScript(`
+local result = {}
+for i = 2, #KEYS do
+	local score = redis.call('ZSCORE', KEYS[1], ARGV[i])
+	if score and tonumber(score) <= tonumber(ARGV[1]) then
+		redis.call('ZREM', KEYS[1], ARGV[i])
+		local data = redis.call('GET', KEYS[i])
+		if data then
+			redis.call('DEL', KEYS[i])
+			table.insert(result, data)
+		end
+	end
+end
+return result
+`)
+
+type conversationRepo struct {
+	db *redis.Client
+}
+
+func NewConversationRepo(db *redis.Client) *conversationRepo {
+	return &conversationRepo{db: db}
+}
+
+func (r *conversationRepo) Get(ctx context.Context, telegramID int64) (domain.Conversation, error) {
+	data, err := r.db.Get(ctx, conversationKey(telegramID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.Conversation{}, domain.ErrConversationNotFound
+	}
+	if err != nil {
+		return domain.Conversation{}, fmt.Errorf("failed to get conversation: %w", err)
+	}
+	var conv Conversation
+	if err := json.Unmarshal(data, &conv); err != nil {
+		return domain.Conversation{}, fmt.Errorf("failed to decode conversation: %w", err)
+	}
+	if !conv.ToDomain().ExpiresAt.After(time.Now()) {
+		return domain.Conversation{}, domain.ErrConversationNotFound
+	}
+	return conv.ToDomain(), nil
+}
+
+func (r *conversationRepo) Set(ctx context.Context, conv domain.Conversation) error {
+	data, err := json.Marshal(Conversation{
+		TelegramID: conv.TelegramID,
+		Step:       conv.Step,
+		Locale:     conv.Locale,
+		ExpiresAt:  conv.ExpiresAt.UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode conversation: %w", err)
+	}
+	_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, conversationKey(conv.TelegramID), data, time.Until(conv.ExpiresAt)+conversationGrace)
+		pipe.ZAdd(ctx, conversationsExpiryKey, redis.Z{Score: float64(conv.ExpiresAt.UnixMilli()), Member: conv.TelegramID})
+		return nil
+	})
+	return e.WrapIfErr(err, "failed to save conversation")
+}
+
+func (r *conversationRepo) Delete(ctx context.Context, telegramID int64) error {
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, conversationKey(telegramID))
+		pipe.ZRem(ctx, conversationsExpiryKey, telegramID)
+		return nil
+	})
+	return e.WrapIfErr(err, "failed to delete conversation")
+}
+
+func (r *conversationRepo) PopExpired(ctx context.Context, now time.Time) ([]domain.Conversation, error) {
+	ids, err := r.db.ZRangeByScore(ctx, conversationsExpiryKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.UnixMilli(), 10),
+		Count: conversationsPopLimit,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired conversations: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// Script declares every key it touches
+	keys := make([]string, 0, len(ids)+1)
+	args := make([]any, 0, len(ids)+1)
+	keys = append(keys, conversationsExpiryKey)
+	args = append(args, now.UnixMilli())
+	for _, id := range ids {
+		keys = append(keys, conversationKeyPrefix+id)
+		args = append(args, id)
+	}
+	data, err := popExpiredScript.Run(ctx, r.db, keys, args...).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop expired conversations: %w", err)
+	}
+	convs := make([]domain.Conversation, 0, len(data))
+	for _, item := range data {
+		var conv Conversation
+		if err := json.Unmarshal([]byte(item), &conv); err != nil {
+			return nil, fmt.Errorf("failed to decode conversation: %w", err)
+		}
+		convs = append(convs, conv.ToDomain())
+	}
+	return convs, nil
+}
+
+func conversationKey(telegramID int64) string {
+	return conversationKeyPrefix + strconv.FormatInt(telegramID, 10)
+}
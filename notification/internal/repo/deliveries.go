@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type deliveryRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewDeliveryRepo(db *sqlx.DB) *deliveryRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &deliveryRepo{db: db, qb: qb}
+}
+
+// CreateDelivery sets delivery ID, returns false if delivery for this message and channel already exists
+func (r *deliveryRepo) CreateDelivery(ctx context.Context, delivery *domain.Delivery) (bool, error) {
+	var payload any = delivery.Login
+	if delivery.IsDigest() {
+		payload = delivery.Digest
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false, e.Wrap(err, "failed to marshal notification")
+	}
+	query, args := r.qb.
+		Insert("deliveries").
+		Columns("message_key", "user_id", "channel", "category", "payload", "digest", "status", "next_attempt_at").
+		Values(delivery.Key, delivery.UserID, delivery.Channel, delivery.Category, data, delivery.IsDigest(), delivery.Status, delivery.NextAttemptAt).
+		Suffix("ON CONFLICT (message_key, channel) DO NOTHING RETURNING id").
+		MustSql()
+
+	err = r.getContext(ctx, &delivery.ID, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, e.Wrap(err, "failed to create delivery")
+	}
+	return true, nil
+}
+
+func (r *deliveryRepo) UpdateDelivery(ctx context.Context, delivery domain.Delivery) error {
+	query, args := r.qb.
+		Update("deliveries").
+		Set("status", delivery.Status).
+		Set("attempts", delivery.Attempts).
+		Set("last_error", sql.NullString{String: delivery.LastError, Valid: delivery.LastError != ""}).
+		Set("provider_message_id", sql.NullString{String: delivery.ProviderMessageID, Valid: delivery.ProviderMessageID != ""}).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": delivery.ID}).
+		MustSql()
+
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to update delivery")
+}
+
+// ClaimDeliveries postpones next attempt of pending deliveries due before now until claimedUntil and returns them,
+// so other instances skip deliveries while they are sent
+func (r *deliveryRepo) ClaimDeliveries(ctx context.Context, now, claimedUntil time.Time, limit int) ([]domain.Delivery, error) {
+	query, args := r.claimQuery(now, claimedUntil, limit)
+	res, err := r.selectDeliveries(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING keeps no order
+	slices.SortFunc(res, func(a, b domain.Delivery) int { return cmp.Compare(a.ID, b.ID) })
+	return res, nil
+}
+
+func (r *deliveryRepo) claimQuery(now, claimedUntil time.Time, limit int) (string, []any) {
+	// Subquery keeps ? placeholders, they are numbered once in outer query
+	due := sq.
+		Select("id").
+		From("deliveries").
+		Where(sq.Eq{"status": domain.DeliveryStatusPending}).
+		Where(sq.LtOrEq{"next_attempt_at": now}).
+		OrderBy("next_attempt_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	return r.qb.
+		Update("deliveries").
+		Set("next_attempt_at", claimedUntil).
+		Where(sq.Expr("id IN (?)", due)).
+		Suffix("RETURNING *").
+		MustSql()
+}
+
+func (r *deliveryRepo) DeliveriesByUser(ctx context.Context, userID string, limit int) ([]domain.Delivery, error) {
+	query, args := r.qb.
+		Select("*").
+		From("deliveries").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		MustSql()
+
+	return r.selectDeliveries(ctx, query, args...)
+}
+
+func (r *deliveryRepo) selectDeliveries(ctx context.Context, query string, args ...any) ([]domain.Delivery, error) {
+	var entities []Delivery
+	if err := r.selectContext(ctx, &entities, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to get deliveries")
+	}
+
+	res := make([]domain.Delivery, len(entities))
+	for i, entity := range entities {
+		res[i] = domain.Delivery{
+			ID:                entity.ID,
+			Key:               entity.Key,
+			UserID:            entity.UserID.String(),
+			Channel:           entity.Channel,
+			Category:          entity.Category,
+			Status:            entity.Status,
+			Attempts:          entity.Attempts,
+			LastError:         entity.LastError.String,
+			ProviderMessageID: entity.ProviderMessageID.String,
+			NextAttemptAt:     entity.NextAttemptAt,
+			CreatedAt:         entity.CreatedAt,
+			UpdatedAt:         entity.UpdatedAt,
+		}
+		var payload any = &res[i].Login
+		if entity.Digest {
+			payload = &res[i].Digest
+		}
+		if err := json.Unmarshal(entity.Payload, payload); err != nil {
+			return nil, e.Wrap(err, "failed to unmarshal notification")
+		}
+	}
+	return res, nil
+}
+
+func (r *deliveryRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *deliveryRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
+
+func (r *deliveryRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
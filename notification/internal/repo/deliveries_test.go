@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeliveryRepo_claimQuery(t *testing.T) {
+	now := time.Now()
+	claimedUntil := now.Add(time.Minute)
+
+	query, args := NewDeliveryRepo(nil).claimQuery(now, claimedUntil, 100)
+
+	// Placeholders of subquery continue numbering of outer query
+	assert.Equal(t, "UPDATE deliveries SET next_attempt_at = $1 "+
+		"WHERE id IN (SELECT id FROM deliveries WHERE status = $2 AND next_attempt_at <= $3 "+
+		"ORDER BY next_attempt_at LIMIT 100 FOR UPDATE SKIP LOCKED) RETURNING *", query)
+	assert.Equal(t, []any{claimedUntil, domain.DeliveryStatusPending, now}, args)
+}
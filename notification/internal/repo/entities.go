@@ -12,6 +12,7 @@ type User struct {
 	ID         uuid.UUID      `db:"user_id"`
 	Email      sql.NullString `db:"email"`
 	TelegramID sql.NullInt64  `db:"telegram_id"`
+	Locale     sql.NullString `db:"locale"`
 	CreatedAt  time.Time      `db:"created_at"`
 }
 
@@ -20,25 +21,169 @@ func (u User) ToDomain() domain.User {
 		ID:         u.ID.String(),
 		Email:      u.Email.String,
 		TelegramID: u.TelegramID.Int64,
+		Locale:     u.Locale.String,
 	}
 }
 
 type SubscriptionWithUser struct {
-	UserID     uuid.UUID               `db:"user_id"`
-	Email      sql.NullString          `db:"email"`
-	TelegramID sql.NullInt64           `db:"telegram_id"`
-	Type       domain.SubscriptionType `db:"type"`
-	Enabled    bool                    `db:"enabled"`
+	UserID  uuid.UUID               `db:"user_id"`
+	Locale  sql.NullString          `db:"locale"`
+	Type    domain.SubscriptionType `db:"type"`
+	Enabled bool                    `db:"enabled"`
+	Address string                  `db:"address"`
+	Secret  sql.NullString          `db:"secret"`
 }
 
 func (s SubscriptionWithUser) ToDomain() domain.Subscription {
 	return domain.Subscription{
 		User: domain.User{
-			ID:         s.UserID.String(),
-			Email:      s.Email.String,
-			TelegramID: s.TelegramID.Int64,
+			ID:     s.UserID.String(),
+			Locale: s.Locale.String,
 		},
 		Type:    s.Type,
 		Enabled: s.Enabled,
+		Contact: domain.Contact{
+			Channel: s.Type,
+			Address: s.Address,
+			Secret:  s.Secret.String,
+		},
+	}
+}
+
+type Preference struct {
+	Channel  domain.SubscriptionType `db:"channel"`
+	Category domain.EventCategory    `db:"category"`
+	Enabled  bool                    `db:"enabled"`
+}
+
+type Schedule struct {
+	UserID     uuid.UUID `db:"user_id"`
+	Timezone   string    `db:"timezone"`
+	QuietHours bool      `db:"quiet_hours"`
+	QuietFrom  int       `db:"quiet_from"`
+	QuietTo    int       `db:"quiet_to"`
+	Digest     bool      `db:"digest"`
+	DigestHour int       `db:"digest_hour"`
+}
+
+func (s Schedule) ToDomain() domain.Schedule {
+	return domain.Schedule{
+		Timezone:   s.Timezone,
+		QuietHours: s.QuietHours,
+		QuietFrom:  s.QuietFrom,
+		QuietTo:    s.QuietTo,
+		Digest:     s.Digest,
+		DigestHour: s.DigestHour,
+	}
+}
+
+type PendingNotification struct {
+	ID        int64                `db:"id"`
+	UserID    uuid.UUID            `db:"user_id"`
+	Category  domain.EventCategory `db:"category"`
+	Payload   []byte               `db:"payload"`
+	DeliverAt time.Time            `db:"deliver_at"`
+}
+
+type Delivery struct {
+	ID                int64                   `db:"id"`
+	Key               string                  `db:"message_key"`
+	UserID            uuid.UUID               `db:"user_id"`
+	Channel           domain.SubscriptionType `db:"channel"`
+	Category          domain.EventCategory    `db:"category"`
+	Payload           []byte                  `db:"payload"`
+	Digest            bool                    `db:"digest"`
+	Status            domain.DeliveryStatus   `db:"status"`
+	Attempts          int                     `db:"attempts"`
+	LastError         sql.NullString          `db:"last_error"`
+	ProviderMessageID sql.NullString          `db:"provider_message_id"`
+	NextAttemptAt     time.Time               `db:"next_attempt_at"`
+	CreatedAt         time.Time               `db:"created_at"`
+	UpdatedAt         time.Time               `db:"updated_at"`
+}
+
+type Broadcast struct {
+	ID         int64                   `db:"id"`
+	Segment    domain.BroadcastSegment `db:"segment"`
+	Category   domain.EventCategory    `db:"category"`
+	Subject    string                  `db:"subject"`
+	Text       string                  `db:"text"`
+	Status     domain.BroadcastStatus  `db:"status"`
+	CreatedBy  uuid.UUID               `db:"created_by"`
+	SendAt     time.Time               `db:"send_at"`
+	Cursor     uuid.NullUUID           `db:"cursor"`
+	LeaseUntil sql.NullTime            `db:"lease_until"`
+	Claim      uuid.NullUUID           `db:"claim"`
+	Total      int                     `db:"total"`
+	Processed  int                     `db:"processed"`
+	Sent       int                     `db:"sent"`
+	Failed     int                     `db:"failed"`
+	Skipped    int                     `db:"skipped"`
+	CreatedAt  time.Time               `db:"created_at"`
+	UpdatedAt  time.Time               `db:"updated_at"`
+}
+
+func (b Broadcast) ToDomain() domain.Broadcast {
+	res := domain.Broadcast{
+		ID:         b.ID,
+		Segment:    b.Segment,
+		Category:   b.Category,
+		Subject:    b.Subject,
+		Text:       b.Text,
+		Status:     b.Status,
+		CreatedBy:  b.CreatedBy.String(),
+		SendAt:     b.SendAt,
+		LeaseUntil: b.LeaseUntil.Time,
+		Total:      b.Total,
+		Processed:  b.Processed,
+		Sent:       b.Sent,
+		Failed:     b.Failed,
+		Skipped:    b.Skipped,
+		CreatedAt:  b.CreatedAt,
+		UpdatedAt:  b.UpdatedAt,
+	}
+	if b.Cursor.Valid {
+		res.Cursor = b.Cursor.UUID.String()
+	}
+	if b.Claim.Valid {
+		res.Claim = b.Claim.UUID.String()
+	}
+	return res
+}
+
+type BroadcastFailure struct {
+	UserID    uuid.UUID               `db:"user_id"`
+	Channel   domain.SubscriptionType `db:"channel"`
+	Error     string                  `db:"error"`
+	CreatedAt time.Time               `db:"created_at"`
+}
+
+type PushSubscription struct {
+	ID        int64     `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Endpoint  string    `db:"endpoint"`
+	P256dh    string    `db:"p256dh"`
+	Auth      string    `db:"auth"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (s PushSubscription) ToDomain() domain.PushSubscription {
+	return domain.PushSubscription{Endpoint: s.Endpoint, P256dh: s.P256dh, Auth: s.Auth}
+}
+
+// Conversation is stored in redis as JSON
+type Conversation struct {
+	TelegramID int64                   `json:"telegram_id"`
+	Step       domain.ConversationStep `json:"step"`
+	Locale     string                  `json:"locale"`
+	ExpiresAt  int64                   `json:"expires_at"`
+}
+
+func (c Conversation) ToDomain() domain.Conversation {
+	return domain.Conversation{
+		TelegramID: c.TelegramID,
+		Step:       c.Step,
+		Locale:     c.Locale,
+		ExpiresAt:  time.UnixMilli(c.ExpiresAt),
 	}
 }
@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type pushRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewPushRepo(db *sqlx.DB) *pushRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &pushRepo{db: db, qb: qb}
+}
+
+// SavePushSubscription moves endpoint to the user if browser was registered by another one
+func (r *pushRepo) SavePushSubscription(ctx context.Context, userID string, sub domain.PushSubscription) error {
+	query, args := r.qb.
+		Insert("push_subscriptions").
+		Columns("user_id", "endpoint", "p256dh", "auth").
+		Values(userID, sub.Endpoint, sub.P256dh, sub.Auth).
+		Suffix("ON CONFLICT (endpoint) DO UPDATE SET user_id = EXCLUDED.user_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth").
+		MustSql()
+
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to save push subscription")
+}
+
+func (r *pushRepo) PushSubscriptionsByUser(ctx context.Context, userID string) ([]domain.PushSubscription, error) {
+	query, args := r.qb.
+		Select("*").
+		From("push_subscriptions").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("id").
+		MustSql()
+
+	var entities []PushSubscription
+	if err := r.selectContext(ctx, &entities, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to get push subscriptions")
+	}
+	res := make([]domain.PushSubscription, len(entities))
+	for i, sub := range entities {
+		res[i] = sub.ToDomain()
+	}
+	return res, nil
+}
+
+func (r *pushRepo) DeletePushSubscription(ctx context.Context, userID, endpoint string) error {
+	query, args := r.qb.
+		Delete("push_subscriptions").
+		Where(sq.Eq{"user_id": userID, "endpoint": endpoint}).
+		MustSql()
+
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return e.Wrap(err, "failed to delete push subscription")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return e.Wrap(err, "failed to delete push subscription")
+	}
+	if affected == 0 {
+		return domain.ErrPushSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *pushRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *pushRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type scheduleRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewScheduleRepo(db *sqlx.DB) *scheduleRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &scheduleRepo{db: db, qb: qb}
+}
+
+// If user never changed schedule, returns domain.DefaultSchedule
+func (r *scheduleRepo) ScheduleByUser(ctx context.Context, userID string) (domain.Schedule, error) {
+	query, args := r.qb.Select("*").From("schedules").Where(sq.Eq{"user_id": userID}).MustSql()
+	var schedule Schedule
+	err := r.getContext(ctx, &schedule, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.DefaultSchedule, nil
+	}
+	if err != nil {
+		return domain.Schedule{}, e.Wrap(err, "failed to get schedule")
+	}
+	return schedule.ToDomain(), nil
+}
+
+func (r *scheduleRepo) SaveSchedule(ctx context.Context, userID string, schedule domain.Schedule) error {
+	query, args := r.qb.
+		Insert("schedules").
+		Columns("user_id", "timezone", "quiet_hours", "quiet_from", "quiet_to", "digest", "digest_hour").
+		Values(userID, schedule.Timezone, schedule.QuietHours, schedule.QuietFrom, schedule.QuietTo, schedule.Digest, schedule.DigestHour).
+		Suffix(`ON CONFLICT (user_id) DO UPDATE SET
+			timezone = EXCLUDED.timezone,
+			quiet_hours = EXCLUDED.quiet_hours,
+			quiet_from = EXCLUDED.quiet_from,
+			quiet_to = EXCLUDED.quiet_to,
+			digest = EXCLUDED.digest,
+			digest_hour = EXCLUDED.digest_hour`).
+		MustSql()
+
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to save schedule")
+}
+
+func (r *scheduleRepo) SavePending(ctx context.Context, notification domain.PendingNotification) error {
+	payload, err := json.Marshal(notification.Login)
+	if err != nil {
+		return e.Wrap(err, "failed to marshal notification")
+	}
+	query, args := r.qb.
+		Insert("pending_notifications").
+		Columns("user_id", "category", "payload", "deliver_at").
+		Values(notification.UserID, notification.Category, payload, notification.DeliverAt).
+		MustSql()
+
+	_, err = r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to save pending notification")
+}
+
+// ClaimPending moves deliver_at of due notifications to claimedUntil and returns them. Claimed rows are skipped
+// by other instances until they are deleted after delivery, or until claim expires if instance crashed
+func (r *scheduleRepo) ClaimPending(ctx context.Context, now, claimedUntil time.Time, limit int) ([]domain.PendingNotification, error) {
+	due := sq.
+		Select("id").
+		From("pending_notifications").
+		Where(sq.LtOrEq{"deliver_at": now}).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+	query, args := r.qb.
+		Update("pending_notifications").
+		Set("deliver_at", claimedUntil).
+		Where(sq.Expr("id IN (?)", due)).
+		Suffix("RETURNING id, user_id, category, payload, deliver_at").
+		MustSql()
+
+	var entities []PendingNotification
+	if err := r.selectContext(ctx, &entities, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to claim pending notifications")
+	}
+
+	res := make([]domain.PendingNotification, len(entities))
+	for i, entity := range entities {
+		res[i] = domain.PendingNotification{
+			ID:        entity.ID,
+			UserID:    entity.UserID.String(),
+			Category:  entity.Category,
+			DeliverAt: entity.DeliverAt,
+		}
+		if err := json.Unmarshal(entity.Payload, &res[i].Login); err != nil {
+			return nil, e.Wrap(err, "failed to unmarshal notification")
+		}
+	}
+	// RETURNING keeps no order
+	slices.SortFunc(res, func(a, b domain.PendingNotification) int { return cmp.Compare(a.ID, b.ID) })
+	return res, nil
+}
+
+func (r *scheduleRepo) DeletePending(ctx context.Context, ids []int64) error {
+	query, args := r.qb.Delete("pending_notifications").Where(sq.Eq{"id": ids}).MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to delete pending notifications")
+}
+
+func (r *scheduleRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *scheduleRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
+
+func (r *scheduleRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
@@ -24,10 +24,11 @@ func NewSubscriptionRepo(db *sqlx.DB) *subscriptionRepo {
 
 func (r *subscriptionRepo) SubscriptionsByUser(ctx context.Context, userID string) ([]domain.Subscription, error) {
 	query, args := r.qb.
-		Select("user_id", "email", "telegram_id", "type", "enabled").
-		From("subscriptions").
-		Join("users USING(user_id)").
-		Where(sq.Eq{"subscriptions.user_id": userID}).
+		Select("s.user_id", "u.locale", "s.type", "s.enabled", "c.address", "c.secret").
+		From("subscriptions s").
+		Join("users u ON u.user_id = s.user_id").
+		Join("channel_contacts c ON c.user_id = s.user_id AND c.channel = s.type").
+		Where(sq.Eq{"s.user_id": userID}).
 		MustSql()
 
 	var subscriptions []SubscriptionWithUser
@@ -83,6 +84,37 @@ func (r *subscriptionRepo) Delete(ctx context.Context, userID string, subType do
 	return e.WrapIfErr(err, "failed to delete subscription")
 }
 
+func (r *subscriptionRepo) PreferencesByUser(ctx context.Context, userID string) (domain.Preferences, error) {
+	query, args := r.qb.
+		Select("channel", "category", "enabled").
+		From("preferences").
+		Where(sq.Eq{"user_id": userID}).
+		MustSql()
+
+	var entities []Preference
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to get preferences")
+	}
+
+	prefs := make(domain.Preferences)
+	for _, pref := range entities {
+		prefs.Set(pref.Channel, pref.Category, pref.Enabled)
+	}
+	return prefs, nil
+}
+
+func (r *subscriptionRepo) SavePreference(ctx context.Context, userID string, channel domain.SubscriptionType, category domain.EventCategory, enabled bool) error {
+	query, args := r.qb.
+		Insert("preferences").
+		Columns("user_id", "channel", "category", "enabled").
+		Values(userID, channel, category, enabled).
+		Suffix("ON CONFLICT (user_id, channel, category) DO UPDATE SET enabled = EXCLUDED.enabled").
+		MustSql()
+
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to save preference")
+}
+
 func (r *subscriptionRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	tx := transaction.ExtractTx(ctx)
 	if tx != nil {
@@ -2,12 +2,12 @@ package repo
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
 
-	"github.com/SergeyBogomolovv/profile-manager/common/e"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
-	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -19,26 +19,79 @@ func NewTokenRepo(db *redis.Client) *tokenRepo {
 	return &tokenRepo{db: db}
 }
 
+// Create revokes previous token of the user, returns domain.ErrTooManyTokens if user exceeded domain.TokenLimit
 func (r *tokenRepo) Create(ctx context.Context, userID string) (string, error) {
-	token := uuid.NewString()
-	return token, r.db.Set(ctx, tokenKey(token), userID, domain.TokenTTL).Err()
+	if err := r.limit(ctx, userID); err != nil {
+		return "", err
+	}
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+	prev, err := r.db.Get(ctx, userTokenKey(userID)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("failed to get previous token: %w", err)
+	}
+	_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if prev != "" {
+			pipe.Del(ctx, tokenKey(prev))
+		}
+		pipe.Set(ctx, tokenKey(token), userID, domain.TokenTTL)
+		pipe.Set(ctx, userTokenKey(userID), token, domain.TokenTTL)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create token: %w", err)
+	}
+	return token, nil
 }
 
-func (r *tokenRepo) CheckUserID(ctx context.Context, token string) (string, error) {
-	userID, err := r.db.Get(ctx, tokenKey(token)).Result()
+// Consume returns user ID of the token and deletes it, so the token can be used only once
+func (r *tokenRepo) Consume(ctx context.Context, token string) (string, error) {
+	userID, err := r.db.GetDel(ctx, tokenKey(token)).Result()
 	if errors.Is(err, redis.Nil) {
 		return "", domain.ErrInvalidToken
 	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get user id: %w", err)
+		return "", fmt.Errorf("failed to consume token: %w", err)
 	}
 	return userID, nil
 }
 
-func (r *tokenRepo) Revoke(ctx context.Context, token string) error {
-	return e.WrapIfErr(r.db.Del(ctx, tokenKey(token)).Err(), "failed to revoke token")
+// Window of the limit starts with the first token
+func (r *tokenRepo) limit(ctx context.Context, userID string) error {
+	var count *redis.IntCmd
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, tokenLimitKey(userID))
+		pipe.ExpireNX(ctx, tokenLimitKey(userID), domain.TokenTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check token limit: %w", err)
+	}
+	if count.Val() > domain.TokenLimit {
+		return domain.ErrTooManyTokens
+	}
+	return nil
+}
+
+// 12 bytes are encoded into 16 characters, they fit into start parameter of Telegram deep link
+func generateToken() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
 func tokenKey(token string) string {
 	return fmt.Sprintf("telegram_token:%s", token)
 }
+
+func userTokenKey(userID string) string {
+	return fmt.Sprintf("telegram_user_token:%s", userID)
+}
+
+func tokenLimitKey(userID string) string {
+	return fmt.Sprintf("telegram_token_limit:%s", userID)
+}
@@ -4,39 +4,40 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strconv"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/SergeyBogomolovv/profile-manager/common/e"
 	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
 	"github.com/jmoiron/sqlx"
-	"github.com/lib/pq"
 )
 
+// Email and telegram ID of user are stored as contacts of their channels
 type userRepo struct {
-	db *sqlx.DB
-	qb sq.StatementBuilderType
+	db       *sqlx.DB
+	qb       sq.StatementBuilderType
+	contacts *contactRepo
 }
 
 func NewUserRepo(db *sqlx.DB) *userRepo {
 	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	return &userRepo{db: db, qb: qb}
+	return &userRepo{db: db, qb: qb, contacts: NewContactRepo(db)}
 }
 
 func (r *userRepo) Save(ctx context.Context, user domain.User) error {
 	m := map[string]any{
 		"user_id": user.ID,
 	}
-	if user.Email != "" {
-		m["email"] = user.Email
-	}
-	if user.TelegramID != 0 {
-		m["telegram_id"] = user.TelegramID
+	if user.Locale != "" {
+		m["locale"] = user.Locale
 	}
 	query, args := r.qb.Insert("users").SetMap(m).MustSql()
 
-	_, err := r.execContext(ctx, query, args...)
-	return e.WrapIfErr(err, "failed to save user")
+	if _, err := r.execContext(ctx, query, args...); err != nil {
+		return e.Wrap(err, "failed to save user")
+	}
+	return r.saveContacts(ctx, user)
 }
 
 func (r *userRepo) IsExists(ctx context.Context, userID string) (bool, error) {
@@ -50,7 +51,7 @@ func (r *userRepo) IsExists(ctx context.Context, userID string) (bool, error) {
 }
 
 func (r *userRepo) GetByID(ctx context.Context, userID string) (domain.User, error) {
-	query, args := r.qb.Select("*").From("users").Where(sq.Eq{"user_id": userID}).MustSql()
+	query, args := r.selectUsers().Where(sq.Eq{"u.user_id": userID}).MustSql()
 	var user User
 	err := r.getContext(ctx, &user, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -60,7 +61,7 @@ func (r *userRepo) GetByID(ctx context.Context, userID string) (domain.User, err
 }
 
 func (r *userRepo) GetByTelegramID(ctx context.Context, telegramID int64) (domain.User, error) {
-	query, args := r.qb.Select("*").From("users").Where(sq.Eq{"telegram_id": telegramID}).MustSql()
+	query, args := r.selectUsers().Where(sq.Eq{"t.address": strconv.FormatInt(telegramID, 10)}).MustSql()
 	var user User
 	err := r.getContext(ctx, &user, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -70,20 +71,92 @@ func (r *userRepo) GetByTelegramID(ctx context.Context, telegramID int64) (domai
 }
 
 func (r *userRepo) Update(ctx context.Context, user domain.User) error {
-	m := map[string]any{"email": user.Email, "telegram_id": user.TelegramID}
-	if user.Email == "" {
-		m["email"] = nil
+	var locale any
+	if user.Locale != "" {
+		locale = user.Locale
+	}
+	query, args := r.qb.Update("users").Set("locale", locale).Where(sq.Eq{"user_id": user.ID}).MustSql()
+	if _, err := r.execContext(ctx, query, args...); err != nil {
+		return e.Wrap(err, "failed to update user")
+	}
+	return r.saveContacts(ctx, user)
+}
+
+// BroadcastRecipients returns users of segment with ID greater than afterUserID, ordered by ID
+func (r *userRepo) BroadcastRecipients(ctx context.Context, segment domain.BroadcastSegment, afterUserID string, limit int) ([]domain.User, error) {
+	qb := r.selectUsers().Where(segmentFilter(segment)).OrderBy("u.user_id").Limit(uint64(limit))
+	if afterUserID != "" {
+		qb = qb.Where(sq.Gt{"u.user_id": afterUserID})
 	}
-	if user.TelegramID == 0 {
-		m["telegram_id"] = nil
+	query, args := qb.MustSql()
+
+	var users []User
+	if err := r.selectContext(ctx, &users, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to get broadcast recipients")
+	}
+	res := make([]domain.User, len(users))
+	for i, user := range users {
+		res[i] = user.ToDomain()
 	}
-	query, args := r.qb.Update("users").SetMap(m).Where(sq.Eq{"user_id": user.ID}).MustSql()
-	_, err := r.execContext(ctx, query, args...)
-	var pqErr *pq.Error
-	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
-		return domain.ErrAccountAlreadyExists
+	return res, nil
+}
+
+func (r *userRepo) CountBroadcastRecipients(ctx context.Context, segment domain.BroadcastSegment) (int, error) {
+	query, args := r.qb.
+		Select("COUNT(*)").
+		From("users u").
+		LeftJoin("channel_contacts e ON e.user_id = u.user_id AND e.channel = 'email'").
+		LeftJoin("channel_contacts t ON t.user_id = u.user_id AND t.channel = 'telegram'").
+		Where(segmentFilter(segment)).
+		MustSql()
+
+	var count int
+	err := r.getContext(ctx, &count, query, args...)
+	return count, e.WrapIfErr(err, "failed to count broadcast recipients")
+}
+
+// segmentFilter uses contacts joined by selectUsers
+func segmentFilter(segment domain.BroadcastSegment) sq.Sqlizer {
+	switch segment {
+	case domain.BroadcastSegmentTelegram:
+		return sq.NotEq{"t.address": nil}
+	case domain.BroadcastSegmentEmail:
+		return sq.NotEq{"e.address": nil}
+	default:
+		return sq.Expr("TRUE")
 	}
-	return e.WrapIfErr(err, "failed to update user")
+}
+
+func (r *userRepo) selectUsers() sq.SelectBuilder {
+	return r.qb.
+		Select("u.user_id", "u.locale", "u.created_at", "e.address AS email", "t.address::BIGINT AS telegram_id").
+		From("users u").
+		LeftJoin("channel_contacts e ON e.user_id = u.user_id AND e.channel = 'email'").
+		LeftJoin("channel_contacts t ON t.user_id = u.user_id AND t.channel = 'telegram'")
+}
+
+// Empty email or telegram ID removes contact together with its subscription
+func (r *userRepo) saveContacts(ctx context.Context, user domain.User) error {
+	contacts := map[domain.SubscriptionType]string{
+		domain.SubscriptionTypeEmail:    user.Email,
+		domain.SubscriptionTypeTelegram: "",
+	}
+	if user.TelegramID != 0 {
+		contacts[domain.SubscriptionTypeTelegram] = strconv.FormatInt(user.TelegramID, 10)
+	}
+	for channel, address := range contacts {
+		if address == "" {
+			err := r.contacts.DeleteContact(ctx, user.ID, channel)
+			if err != nil && !errors.Is(err, domain.ErrContactNotFound) {
+				return err
+			}
+			continue
+		}
+		if err := r.contacts.SaveContact(ctx, user.ID, domain.Contact{Channel: channel, Address: address}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *userRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
@@ -101,3 +174,11 @@ func (r *userRepo) getContext(ctx context.Context, dest any, query string, args
 	}
 	return r.db.GetContext(ctx, dest, query, args...)
 }
+
+func (r *userRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/logger"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+type BroadcastRepo interface {
+	CreateBroadcast(ctx context.Context, broadcast *domain.Broadcast) error
+	Broadcast(ctx context.Context, id int64) (domain.Broadcast, error)
+	ClaimBroadcast(ctx context.Context, now, leaseUntil time.Time) (domain.Broadcast, error)
+	SaveProgress(ctx context.Context, broadcast domain.Broadcast) error
+	RenewLease(ctx context.Context, id int64, claim string, leaseUntil time.Time) error
+	AddFailures(ctx context.Context, broadcastID int64, failures []domain.BroadcastFailure) error
+	Failures(ctx context.Context, broadcastID int64, limit int) ([]domain.BroadcastFailure, error)
+}
+
+type BroadcastUserRepo interface {
+	BroadcastRecipients(ctx context.Context, segment domain.BroadcastSegment, afterUserID string, limit int) ([]domain.User, error)
+	CountBroadcastRecipients(ctx context.Context, segment domain.BroadcastSegment) (int, error)
+}
+
+type BroadcastSubsRepo interface {
+	SubscriptionsByUser(ctx context.Context, userID string) ([]domain.Subscription, error)
+	PreferencesByUser(ctx context.Context, userID string) (domain.Preferences, error)
+	Update(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool) error
+}
+
+const (
+	broadcastPageSize     = 100
+	broadcastLease        = 5 * time.Minute
+	broadcastFailureLimit = 50
+)
+
+type broadcastService struct {
+	txManager  transaction.TxManager
+	broadcasts BroadcastRepo
+	users      BroadcastUserRepo
+	subs       BroadcastSubsRepo
+	channels   *channel.Registry
+	// Messages per second of all channels
+	rate int
+}
+
+func NewBroadcastService(txManager transaction.TxManager, broadcasts BroadcastRepo, users BroadcastUserRepo, subs BroadcastSubsRepo, channels *channel.Registry, rate int) *broadcastService {
+	return &broadcastService{txManager: txManager, broadcasts: broadcasts, users: users, subs: subs, channels: channels, rate: rate}
+}
+
+// Broadcast schedules broadcast created by admin, zero send time means now
+func (s *broadcastService) Broadcast(ctx context.Context, adminID string, broadcast domain.Broadcast) (domain.Broadcast, error) {
+	if broadcast.Segment.Channels() == nil {
+		return domain.Broadcast{}, fmt.Errorf("%w: unknown segment %q", domain.ErrInvalidBroadcast, broadcast.Segment)
+	}
+	// Login alerts are not announcements, users who keep them may have opted out of others
+	if broadcast.Category == domain.EventCategoryLogin || !slices.Contains(domain.EventCategories, broadcast.Category) {
+		return domain.Broadcast{}, fmt.Errorf("%w: invalid category %q", domain.ErrInvalidBroadcast, broadcast.Category)
+	}
+	if _, err := parseBroadcast(broadcast); err != nil {
+		return domain.Broadcast{}, err
+	}
+	if now := time.Now(); broadcast.SendAt.Before(now) {
+		broadcast.SendAt = now
+	}
+	broadcast.Status = domain.BroadcastStatusScheduled
+	broadcast.CreatedBy = adminID
+	if err := s.broadcasts.CreateBroadcast(ctx, &broadcast); err != nil {
+		return domain.Broadcast{}, err
+	}
+	return broadcast, nil
+}
+
+// GetBroadcast returns broadcast with its last failures
+func (s *broadcastService) GetBroadcast(ctx context.Context, id int64) (domain.Broadcast, []domain.BroadcastFailure, error) {
+	broadcast, err := s.broadcasts.Broadcast(ctx, id)
+	if err != nil {
+		return domain.Broadcast{}, nil, err
+	}
+	failures, err := s.broadcasts.Failures(ctx, id, broadcastFailureLimit)
+	if err != nil {
+		return domain.Broadcast{}, nil, err
+	}
+	return broadcast, failures, nil
+}
+
+// ProcessBroadcasts runs due broadcasts one by one until none is left.
+// Progress is saved after every page of users, so interrupted broadcast is continued by any
+// instance after its lease expires, users of unsaved page may receive the message twice.
+// Broadcast taken over by other instance is left to it
+func (s *broadcastService) ProcessBroadcasts(ctx context.Context) error {
+	for {
+		broadcast, err := s.broadcasts.ClaimBroadcast(ctx, time.Now(), time.Now().Add(broadcastLease))
+		if errors.Is(err, domain.ErrBroadcastNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = s.run(ctx, broadcast)
+		if errors.Is(err, domain.ErrBroadcastLeaseLost) {
+			logger.Extract(ctx).Warn("broadcast lease lost", "broadcast_id", broadcast.ID)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("broadcast %d: %w", broadcast.ID, err)
+		}
+	}
+}
+
+// run sends broadcast while lease is renewed in background, sending stops once lease is lost
+func (s *broadcastService) run(ctx context.Context, broadcast domain.Broadcast) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.keepLease(ctx, cancel, broadcast)
+	}()
+	defer func() {
+		cancel(nil)
+		<-done
+	}()
+
+	err := s.sendBroadcast(ctx, broadcast)
+	if cause := context.Cause(ctx); err != nil && errors.Is(cause, domain.ErrBroadcastLeaseLost) {
+		return cause
+	}
+	return err
+}
+
+// keepLease renews lease until ctx is done, so page sent slower than lease is not taken over
+func (s *broadcastService) keepLease(ctx context.Context, cancel context.CancelCauseFunc, broadcast domain.Broadcast) {
+	ticker := time.NewTicker(broadcastLease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		err := s.broadcasts.RenewLease(ctx, broadcast.ID, broadcast.Claim, time.Now().Add(broadcastLease))
+		if errors.Is(err, domain.ErrBroadcastLeaseLost) {
+			cancel(err)
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			logger.Extract(ctx).Error("failed to renew broadcast lease", "broadcast_id", broadcast.ID, "error", err)
+		}
+	}
+}
+
+func (s *broadcastService) sendBroadcast(ctx context.Context, broadcast domain.Broadcast) error {
+	tmpl, err := parseBroadcast(broadcast)
+	if err != nil {
+		return err
+	}
+	if broadcast.Cursor == "" {
+		if broadcast.Total, err = s.users.CountBroadcastRecipients(ctx, broadcast.Segment); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(s.rate))
+	defer ticker.Stop()
+	for {
+		users, err := s.users.BroadcastRecipients(ctx, broadcast.Segment, broadcast.Cursor, broadcastPageSize)
+		if err != nil {
+			return err
+		}
+		page, err := s.sendPage(ctx, ticker, &broadcast, tmpl, users)
+		if err != nil {
+			return err
+		}
+		if len(users) < broadcastPageSize {
+			broadcast.Status = domain.BroadcastStatusCompleted
+		} else {
+			broadcast.Cursor = users[len(users)-1].ID
+		}
+		broadcast.LeaseUntil = time.Now().Add(broadcastLease)
+		if err := s.savePage(ctx, broadcast, page); err != nil {
+			return err
+		}
+		if broadcast.Status == domain.BroadcastStatusCompleted {
+			logger.Extract(ctx).Info("broadcast completed", "broadcast_id", broadcast.ID,
+				"sent", broadcast.Sent, "failed", broadcast.Failed, "skipped", broadcast.Skipped)
+			return nil
+		}
+	}
+}
+
+// broadcastPage is result of one page, it is saved in one transaction with progress
+type broadcastPage struct {
+	failures    []domain.BroadcastFailure
+	unavailable []domain.BroadcastFailure
+}
+
+// sendPage sends announcement to users, one message per tick of ticker, and counts results in broadcast
+func (s *broadcastService) sendPage(ctx context.Context, ticker *time.Ticker, broadcast *domain.Broadcast, tmpl broadcastTemplate, users []domain.User) (broadcastPage, error) {
+	var (
+		page broadcastPage
+		eg   errgroup.Group
+		mu   sync.Mutex
+	)
+	fail := func(userID string, subType domain.SubscriptionType, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		broadcast.Failed++
+		failure := domain.BroadcastFailure{UserID: userID, Channel: subType, Error: err.Error()}
+		page.failures = append(page.failures, failure)
+		if errors.Is(err, domain.ErrRecipientUnavailable) {
+			page.unavailable = append(page.unavailable, failure)
+		}
+	}
+
+	for _, user := range users {
+		subscriptions, err := s.subs.SubscriptionsByUser(ctx, user.ID)
+		if err != nil {
+			return page, err
+		}
+		prefs, err := s.subs.PreferencesByUser(ctx, user.ID)
+		if err != nil {
+			return page, err
+		}
+		announcement, renderErr := tmpl.render(domain.BroadcastData{Email: user.Email, Locale: user.Locale})
+
+		for _, subType := range broadcast.Segment.Channels() {
+			sub, ok := findSubscription(subscriptions, subType)
+			if !ok || !sub.Enabled || !prefs.Enabled(subType, broadcast.Category) {
+				mu.Lock()
+				broadcast.Skipped++
+				mu.Unlock()
+				continue
+			}
+			if renderErr != nil {
+				fail(user.ID, subType, renderErr)
+				continue
+			}
+			announcer, err := s.channels.Announcer(subType)
+			if err != nil {
+				fail(user.ID, subType, err)
+				continue
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				eg.Wait()
+				return page, context.Cause(ctx)
+			}
+			eg.Go(func() error {
+				if err := announcer.SendAnnouncement(ctx, sub.Contact, user.Locale, announcement); err != nil {
+					fail(user.ID, subType, err)
+					return nil
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				broadcast.Sent++
+				return nil
+			})
+		}
+	}
+	eg.Wait()
+	broadcast.Processed += len(users)
+	return page, nil
+}
+
+func (s *broadcastService) savePage(ctx context.Context, broadcast domain.Broadcast, page broadcastPage) error {
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		if err := s.broadcasts.AddFailures(ctx, broadcast.ID, page.failures); err != nil {
+			return err
+		}
+		// Subscription is kept, user can enable it again after unblocking the bot
+		for _, failure := range page.unavailable {
+			if err := s.subs.Update(ctx, failure.UserID, failure.Channel, false); err != nil {
+				return err
+			}
+		}
+		return s.broadcasts.SaveProgress(ctx, broadcast)
+	})
+}
+
+type broadcastTemplate struct {
+	subject *template.Template
+	text    *template.Template
+}
+
+// parseBroadcast fails on unknown fields of domain.BroadcastData, so mistakes are reported to admin
+func parseBroadcast(broadcast domain.Broadcast) (broadcastTemplate, error) {
+	subject, err := template.New("subject").Option("missingkey=error").Parse(broadcast.Subject)
+	if err != nil {
+		return broadcastTemplate{}, fmt.Errorf("%w: %w", domain.ErrInvalidBroadcast, err)
+	}
+	text, err := template.New("text").Option("missingkey=error").Parse(broadcast.Text)
+	if err != nil {
+		return broadcastTemplate{}, fmt.Errorf("%w: %w", domain.ErrInvalidBroadcast, err)
+	}
+	tmpl := broadcastTemplate{subject: subject, text: text}
+	if _, err := tmpl.render(domain.BroadcastData{}); err != nil {
+		return broadcastTemplate{}, fmt.Errorf("%w: %w", domain.ErrInvalidBroadcast, err)
+	}
+	return tmpl, nil
+}
+
+func (t broadcastTemplate) render(data domain.BroadcastData) (domain.Announcement, error) {
+	var subject, text strings.Builder
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return domain.Announcement{}, err
+	}
+	if err := t.text.Execute(&text, data); err != nil {
+		return domain.Announcement{}, err
+	}
+	return domain.Announcement{Subject: subject.String(), Text: text.String()}, nil
+}
@@ -0,0 +1,271 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	txMocks "github.com/SergeyBogomolovv/profile-manager/common/transaction/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
+	chMocks "github.com/SergeyBogomolovv/profile-manager/notification/internal/channel/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// announcingChannel is a channel which delivers announcements too
+type announcingChannel struct {
+	*chMocks.Channel
+	*chMocks.Announcer
+}
+
+func TestBroadcastService_Broadcast(t *testing.T) {
+	valid := domain.Broadcast{
+		Segment:  domain.BroadcastSegmentAll,
+		Category: domain.EventCategoryMarketing,
+		Subject:  "News",
+		Text:     "Hello, {{ .Email }}",
+	}
+
+	testCases := []struct {
+		name         string
+		broadcast    func(b domain.Broadcast) domain.Broadcast
+		mockBehavior func(broadcasts *mocks.BroadcastRepo)
+		wantErr      error
+	}{
+		{
+			name:      "success",
+			broadcast: func(b domain.Broadcast) domain.Broadcast { return b },
+			mockBehavior: func(broadcasts *mocks.BroadcastRepo) {
+				broadcasts.EXPECT().CreateBroadcast(mock.Anything, mock.MatchedBy(func(b *domain.Broadcast) bool {
+					return b.Status == domain.BroadcastStatusScheduled && b.CreatedBy == "admin" &&
+						time.Since(b.SendAt) < time.Minute
+				})).Return(nil)
+			},
+		},
+		{
+			name: "scheduled",
+			broadcast: func(b domain.Broadcast) domain.Broadcast {
+				b.SendAt = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+				return b
+			},
+			mockBehavior: func(broadcasts *mocks.BroadcastRepo) {
+				broadcasts.EXPECT().CreateBroadcast(mock.Anything, mock.MatchedBy(func(b *domain.Broadcast) bool {
+					return b.SendAt.Equal(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC))
+				})).Return(nil)
+			},
+		},
+		{
+			name: "unknown segment",
+			broadcast: func(b domain.Broadcast) domain.Broadcast {
+				b.Segment = "sms"
+				return b
+			},
+			mockBehavior: func(broadcasts *mocks.BroadcastRepo) {},
+			wantErr:      domain.ErrInvalidBroadcast,
+		},
+		{
+			name: "login category",
+			broadcast: func(b domain.Broadcast) domain.Broadcast {
+				b.Category = domain.EventCategoryLogin
+				return b
+			},
+			mockBehavior: func(broadcasts *mocks.BroadcastRepo) {},
+			wantErr:      domain.ErrInvalidBroadcast,
+		},
+		{
+			name: "invalid template",
+			broadcast: func(b domain.Broadcast) domain.Broadcast {
+				b.Text = "Hello, {{ .Email"
+				return b
+			},
+			mockBehavior: func(broadcasts *mocks.BroadcastRepo) {},
+			wantErr:      domain.ErrInvalidBroadcast,
+		},
+		{
+			name: "unknown field",
+			broadcast: func(b domain.Broadcast) domain.Broadcast {
+				b.Subject = "News for {{ .Name }}"
+				return b
+			},
+			mockBehavior: func(broadcasts *mocks.BroadcastRepo) {},
+			wantErr:      domain.ErrInvalidBroadcast,
+		},
+		{
+			name:      "failed to create",
+			broadcast: func(b domain.Broadcast) domain.Broadcast { return b },
+			mockBehavior: func(broadcasts *mocks.BroadcastRepo) {
+				broadcasts.EXPECT().CreateBroadcast(mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			broadcasts := mocks.NewBroadcastRepo(t)
+			svc := service.NewBroadcastService(txMocks.NewTxManager(t), broadcasts, mocks.NewBroadcastUserRepo(t), mocks.NewBroadcastSubsRepo(t), channel.NewRegistry(), 1000)
+			tc.mockBehavior(broadcasts)
+			_, err := svc.Broadcast(context.Background(), "admin", tc.broadcast(valid))
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestBroadcastService_ProcessBroadcasts(t *testing.T) {
+	type MockBehavior func(
+		tx *txMocks.TxManager,
+		broadcasts *mocks.BroadcastRepo,
+		users *mocks.BroadcastUserRepo,
+		subs *mocks.BroadcastSubsRepo,
+		email *chMocks.Announcer,
+		telegram *chMocks.Announcer,
+	)
+
+	broadcast := domain.Broadcast{
+		ID:       1,
+		Segment:  domain.BroadcastSegmentAll,
+		Category: domain.EventCategoryMarketing,
+		Subject:  "News",
+		Text:     "Hello, {{ .Email }}",
+		Status:   domain.BroadcastStatusRunning,
+		Claim:    "claim",
+	}
+	emailContact := domain.Contact{Channel: domain.SubscriptionTypeEmail, Address: "user@example.com"}
+	telegramContact := domain.Contact{Channel: domain.SubscriptionTypeTelegram, Address: "123"}
+	optedIn := domain.Preferences{}
+	optedIn.Set(domain.SubscriptionTypeEmail, domain.EventCategoryMarketing, true)
+	optedIn.Set(domain.SubscriptionTypeTelegram, domain.EventCategoryMarketing, true)
+
+	runTx := func(tx *txMocks.TxManager) {
+		tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+			func(ctx context.Context, f func(context.Context) error) error {
+				return f(ctx)
+			},
+		)
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "nothing due",
+			mockBehavior: func(tx *txMocks.TxManager, broadcasts *mocks.BroadcastRepo, users *mocks.BroadcastUserRepo, subs *mocks.BroadcastSubsRepo, email, telegram *chMocks.Announcer) {
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(domain.Broadcast{}, domain.ErrBroadcastNotFound)
+			},
+		},
+		{
+			name: "sent respecting preferences",
+			mockBehavior: func(tx *txMocks.TxManager, broadcasts *mocks.BroadcastRepo, users *mocks.BroadcastUserRepo, subs *mocks.BroadcastSubsRepo, email, telegram *chMocks.Announcer) {
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(broadcast, nil).Once()
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(domain.Broadcast{}, domain.ErrBroadcastNotFound).Once()
+				users.EXPECT().CountBroadcastRecipients(mock.Anything, domain.BroadcastSegmentAll).Return(3, nil)
+				users.EXPECT().BroadcastRecipients(mock.Anything, domain.BroadcastSegmentAll, "", 100).Return([]domain.User{
+					{ID: "opted-in", Email: emailContact.Address, Locale: "en"},
+					{ID: "blocked", Locale: "ru"},
+					{ID: "default", Locale: "en"},
+				}, nil)
+
+				// Both channels are enabled
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "opted-in").Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+					{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "opted-in").Return(optedIn, nil)
+				announcement := domain.Announcement{Subject: "News", Text: "Hello, user@example.com"}
+				email.EXPECT().SendAnnouncement(mock.Anything, emailContact, "en", announcement).Return(nil)
+				telegram.EXPECT().SendAnnouncement(mock.Anything, telegramContact, "en", announcement).Return(nil)
+
+				// Email is disabled, bot is blocked
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "blocked").Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: false, Contact: emailContact},
+					{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "blocked").Return(optedIn, nil)
+				telegram.EXPECT().SendAnnouncement(mock.Anything, telegramContact, "ru", domain.Announcement{Subject: "News", Text: "Hello, "}).
+					Return(fmt.Errorf("%w: blocked", domain.ErrRecipientUnavailable))
+
+				// Marketing is opt-in
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "default").Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "default").Return(domain.Preferences{}, nil)
+
+				runTx(tx)
+				broadcasts.EXPECT().AddFailures(mock.Anything, int64(1), []domain.BroadcastFailure{
+					{UserID: "blocked", Channel: domain.SubscriptionTypeTelegram, Error: "recipient unavailable: blocked"},
+				}).Return(nil)
+				subs.EXPECT().Update(mock.Anything, "blocked", domain.SubscriptionTypeTelegram, false).Return(nil)
+				broadcasts.EXPECT().SaveProgress(mock.Anything, mock.MatchedBy(func(b domain.Broadcast) bool {
+					return b.Status == domain.BroadcastStatusCompleted && b.Total == 3 && b.Processed == 3 &&
+						b.Sent == 2 && b.Failed == 1 && b.Skipped == 3
+				})).Return(nil)
+			},
+		},
+		{
+			name: "resumed after cursor",
+			mockBehavior: func(tx *txMocks.TxManager, broadcasts *mocks.BroadcastRepo, users *mocks.BroadcastUserRepo, subs *mocks.BroadcastSubsRepo, email, telegram *chMocks.Announcer) {
+				resumed := broadcast
+				resumed.Cursor = "last"
+				resumed.Total = 100
+				resumed.Processed = 100
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(resumed, nil).Once()
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(domain.Broadcast{}, domain.ErrBroadcastNotFound).Once()
+				users.EXPECT().BroadcastRecipients(mock.Anything, domain.BroadcastSegmentAll, "last", 100).Return(nil, nil)
+
+				runTx(tx)
+				broadcasts.EXPECT().AddFailures(mock.Anything, int64(1), []domain.BroadcastFailure(nil)).Return(nil)
+				broadcasts.EXPECT().SaveProgress(mock.Anything, mock.MatchedBy(func(b domain.Broadcast) bool {
+					return b.Status == domain.BroadcastStatusCompleted && b.Total == 100 && b.Processed == 100
+				})).Return(nil)
+			},
+		},
+		{
+			name: "claimed by other instance",
+			mockBehavior: func(tx *txMocks.TxManager, broadcasts *mocks.BroadcastRepo, users *mocks.BroadcastUserRepo, subs *mocks.BroadcastSubsRepo, email, telegram *chMocks.Announcer) {
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(broadcast, nil).Once()
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(domain.Broadcast{}, domain.ErrBroadcastNotFound).Once()
+				users.EXPECT().CountBroadcastRecipients(mock.Anything, domain.BroadcastSegmentAll).Return(0, nil)
+				users.EXPECT().BroadcastRecipients(mock.Anything, domain.BroadcastSegmentAll, "", 100).Return(nil, nil)
+
+				runTx(tx)
+				broadcasts.EXPECT().AddFailures(mock.Anything, int64(1), []domain.BroadcastFailure(nil)).Return(nil)
+				broadcasts.EXPECT().SaveProgress(mock.Anything, mock.MatchedBy(func(b domain.Broadcast) bool {
+					return b.Claim == "claim"
+				})).Return(domain.ErrBroadcastLeaseLost)
+			},
+		},
+		{
+			name: "failed to get recipients",
+			mockBehavior: func(tx *txMocks.TxManager, broadcasts *mocks.BroadcastRepo, users *mocks.BroadcastUserRepo, subs *mocks.BroadcastSubsRepo, email, telegram *chMocks.Announcer) {
+				broadcasts.EXPECT().ClaimBroadcast(mock.Anything, mock.Anything, mock.Anything).Return(broadcast, nil)
+				users.EXPECT().CountBroadcastRecipients(mock.Anything, domain.BroadcastSegmentAll).Return(3, nil)
+				users.EXPECT().BroadcastRecipients(mock.Anything, domain.BroadcastSegmentAll, "", 100).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			broadcasts := mocks.NewBroadcastRepo(t)
+			users := mocks.NewBroadcastUserRepo(t)
+			subs := mocks.NewBroadcastSubsRepo(t)
+			email := chMocks.NewAnnouncer(t)
+			telegram := chMocks.NewAnnouncer(t)
+			channels := channel.NewRegistry()
+			channels.Register(domain.SubscriptionTypeEmail, announcingChannel{chMocks.NewChannel(t), email})
+			channels.Register(domain.SubscriptionTypeTelegram, announcingChannel{chMocks.NewChannel(t), telegram})
+			svc := service.NewBroadcastService(tx, broadcasts, users, subs, channels, 1000)
+			tc.mockBehavior(tx, broadcasts, users, subs, email, telegram)
+			err := svc.ProcessBroadcasts(context.Background())
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,405 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BroadcastRepo is an autogenerated mock type for the BroadcastRepo type
+type BroadcastRepo struct {
+	mock.Mock
+}
+
+type BroadcastRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BroadcastRepo) EXPECT() *BroadcastRepo_Expecter {
+	return &BroadcastRepo_Expecter{mock: &_m.Mock}
+}
+
+// AddFailures provides a mock function with given fields: ctx, broadcastID, failures
+func (_m *BroadcastRepo) AddFailures(ctx context.Context, broadcastID int64, failures []domain.BroadcastFailure) error {
+	ret := _m.Called(ctx, broadcastID, failures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domain.BroadcastFailure) error); ok {
+		r0 = rf(ctx, broadcastID, failures)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BroadcastRepo_AddFailures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFailures'
+type BroadcastRepo_AddFailures_Call struct {
+	*mock.Call
+}
+
+// AddFailures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - broadcastID int64
+//   - failures []domain.BroadcastFailure
+func (_e *BroadcastRepo_Expecter) AddFailures(ctx interface{}, broadcastID interface{}, failures interface{}) *BroadcastRepo_AddFailures_Call {
+	return &BroadcastRepo_AddFailures_Call{Call: _e.mock.On("AddFailures", ctx, broadcastID, failures)}
+}
+
+func (_c *BroadcastRepo_AddFailures_Call) Run(run func(ctx context.Context, broadcastID int64, failures []domain.BroadcastFailure)) *BroadcastRepo_AddFailures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]domain.BroadcastFailure))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_AddFailures_Call) Return(_a0 error) *BroadcastRepo_AddFailures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BroadcastRepo_AddFailures_Call) RunAndReturn(run func(context.Context, int64, []domain.BroadcastFailure) error) *BroadcastRepo_AddFailures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Broadcast provides a mock function with given fields: ctx, id
+func (_m *BroadcastRepo) Broadcast(ctx context.Context, id int64) (domain.Broadcast, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Broadcast")
+	}
+
+	var r0 domain.Broadcast
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Broadcast, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Broadcast); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Broadcast)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastRepo_Broadcast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Broadcast'
+type BroadcastRepo_Broadcast_Call struct {
+	*mock.Call
+}
+
+// Broadcast is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *BroadcastRepo_Expecter) Broadcast(ctx interface{}, id interface{}) *BroadcastRepo_Broadcast_Call {
+	return &BroadcastRepo_Broadcast_Call{Call: _e.mock.On("Broadcast", ctx, id)}
+}
+
+func (_c *BroadcastRepo_Broadcast_Call) Run(run func(ctx context.Context, id int64)) *BroadcastRepo_Broadcast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_Broadcast_Call) Return(_a0 domain.Broadcast, _a1 error) *BroadcastRepo_Broadcast_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastRepo_Broadcast_Call) RunAndReturn(run func(context.Context, int64) (domain.Broadcast, error)) *BroadcastRepo_Broadcast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimBroadcast provides a mock function with given fields: ctx, now, leaseUntil
+func (_m *BroadcastRepo) ClaimBroadcast(ctx context.Context, now time.Time, leaseUntil time.Time) (domain.Broadcast, error) {
+	ret := _m.Called(ctx, now, leaseUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimBroadcast")
+	}
+
+	var r0 domain.Broadcast
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (domain.Broadcast, error)); ok {
+		return rf(ctx, now, leaseUntil)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) domain.Broadcast); ok {
+		r0 = rf(ctx, now, leaseUntil)
+	} else {
+		r0 = ret.Get(0).(domain.Broadcast)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, now, leaseUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastRepo_ClaimBroadcast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimBroadcast'
+type BroadcastRepo_ClaimBroadcast_Call struct {
+	*mock.Call
+}
+
+// ClaimBroadcast is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - leaseUntil time.Time
+func (_e *BroadcastRepo_Expecter) ClaimBroadcast(ctx interface{}, now interface{}, leaseUntil interface{}) *BroadcastRepo_ClaimBroadcast_Call {
+	return &BroadcastRepo_ClaimBroadcast_Call{Call: _e.mock.On("ClaimBroadcast", ctx, now, leaseUntil)}
+}
+
+func (_c *BroadcastRepo_ClaimBroadcast_Call) Run(run func(ctx context.Context, now time.Time, leaseUntil time.Time)) *BroadcastRepo_ClaimBroadcast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_ClaimBroadcast_Call) Return(_a0 domain.Broadcast, _a1 error) *BroadcastRepo_ClaimBroadcast_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastRepo_ClaimBroadcast_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) (domain.Broadcast, error)) *BroadcastRepo_ClaimBroadcast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBroadcast provides a mock function with given fields: ctx, broadcast
+func (_m *BroadcastRepo) CreateBroadcast(ctx context.Context, broadcast *domain.Broadcast) error {
+	ret := _m.Called(ctx, broadcast)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBroadcast")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Broadcast) error); ok {
+		r0 = rf(ctx, broadcast)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BroadcastRepo_CreateBroadcast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBroadcast'
+type BroadcastRepo_CreateBroadcast_Call struct {
+	*mock.Call
+}
+
+// CreateBroadcast is a helper method to define mock.On call
+//   - ctx context.Context
+//   - broadcast *domain.Broadcast
+func (_e *BroadcastRepo_Expecter) CreateBroadcast(ctx interface{}, broadcast interface{}) *BroadcastRepo_CreateBroadcast_Call {
+	return &BroadcastRepo_CreateBroadcast_Call{Call: _e.mock.On("CreateBroadcast", ctx, broadcast)}
+}
+
+func (_c *BroadcastRepo_CreateBroadcast_Call) Run(run func(ctx context.Context, broadcast *domain.Broadcast)) *BroadcastRepo_CreateBroadcast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Broadcast))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_CreateBroadcast_Call) Return(_a0 error) *BroadcastRepo_CreateBroadcast_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BroadcastRepo_CreateBroadcast_Call) RunAndReturn(run func(context.Context, *domain.Broadcast) error) *BroadcastRepo_CreateBroadcast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Failures provides a mock function with given fields: ctx, broadcastID, limit
+func (_m *BroadcastRepo) Failures(ctx context.Context, broadcastID int64, limit int) ([]domain.BroadcastFailure, error) {
+	ret := _m.Called(ctx, broadcastID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Failures")
+	}
+
+	var r0 []domain.BroadcastFailure
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]domain.BroadcastFailure, error)); ok {
+		return rf(ctx, broadcastID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []domain.BroadcastFailure); ok {
+		r0 = rf(ctx, broadcastID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BroadcastFailure)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, broadcastID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastRepo_Failures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Failures'
+type BroadcastRepo_Failures_Call struct {
+	*mock.Call
+}
+
+// Failures is a helper method to define mock.On call
+//   - ctx context.Context
+//   - broadcastID int64
+//   - limit int
+func (_e *BroadcastRepo_Expecter) Failures(ctx interface{}, broadcastID interface{}, limit interface{}) *BroadcastRepo_Failures_Call {
+	return &BroadcastRepo_Failures_Call{Call: _e.mock.On("Failures", ctx, broadcastID, limit)}
+}
+
+func (_c *BroadcastRepo_Failures_Call) Run(run func(ctx context.Context, broadcastID int64, limit int)) *BroadcastRepo_Failures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_Failures_Call) Return(_a0 []domain.BroadcastFailure, _a1 error) *BroadcastRepo_Failures_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastRepo_Failures_Call) RunAndReturn(run func(context.Context, int64, int) ([]domain.BroadcastFailure, error)) *BroadcastRepo_Failures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewLease provides a mock function with given fields: ctx, id, claim, leaseUntil
+func (_m *BroadcastRepo) RenewLease(ctx context.Context, id int64, claim string, leaseUntil time.Time) error {
+	ret := _m.Called(ctx, id, claim, leaseUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) error); ok {
+		r0 = rf(ctx, id, claim, leaseUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BroadcastRepo_RenewLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewLease'
+type BroadcastRepo_RenewLease_Call struct {
+	*mock.Call
+}
+
+// RenewLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - claim string
+//   - leaseUntil time.Time
+func (_e *BroadcastRepo_Expecter) RenewLease(ctx interface{}, id interface{}, claim interface{}, leaseUntil interface{}) *BroadcastRepo_RenewLease_Call {
+	return &BroadcastRepo_RenewLease_Call{Call: _e.mock.On("RenewLease", ctx, id, claim, leaseUntil)}
+}
+
+func (_c *BroadcastRepo_RenewLease_Call) Run(run func(ctx context.Context, id int64, claim string, leaseUntil time.Time)) *BroadcastRepo_RenewLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_RenewLease_Call) Return(_a0 error) *BroadcastRepo_RenewLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BroadcastRepo_RenewLease_Call) RunAndReturn(run func(context.Context, int64, string, time.Time) error) *BroadcastRepo_RenewLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveProgress provides a mock function with given fields: ctx, broadcast
+func (_m *BroadcastRepo) SaveProgress(ctx context.Context, broadcast domain.Broadcast) error {
+	ret := _m.Called(ctx, broadcast)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Broadcast) error); ok {
+		r0 = rf(ctx, broadcast)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BroadcastRepo_SaveProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveProgress'
+type BroadcastRepo_SaveProgress_Call struct {
+	*mock.Call
+}
+
+// SaveProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - broadcast domain.Broadcast
+func (_e *BroadcastRepo_Expecter) SaveProgress(ctx interface{}, broadcast interface{}) *BroadcastRepo_SaveProgress_Call {
+	return &BroadcastRepo_SaveProgress_Call{Call: _e.mock.On("SaveProgress", ctx, broadcast)}
+}
+
+func (_c *BroadcastRepo_SaveProgress_Call) Run(run func(ctx context.Context, broadcast domain.Broadcast)) *BroadcastRepo_SaveProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Broadcast))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_SaveProgress_Call) Return(_a0 error) *BroadcastRepo_SaveProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BroadcastRepo_SaveProgress_Call) RunAndReturn(run func(context.Context, domain.Broadcast) error) *BroadcastRepo_SaveProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBroadcastRepo creates a new instance of BroadcastRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBroadcastRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BroadcastRepo {
+	mock := &BroadcastRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,204 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BroadcastSubsRepo is an autogenerated mock type for the BroadcastSubsRepo type
+type BroadcastSubsRepo struct {
+	mock.Mock
+}
+
+type BroadcastSubsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BroadcastSubsRepo) EXPECT() *BroadcastSubsRepo_Expecter {
+	return &BroadcastSubsRepo_Expecter{mock: &_m.Mock}
+}
+
+// PreferencesByUser provides a mock function with given fields: ctx, userID
+func (_m *BroadcastSubsRepo) PreferencesByUser(ctx context.Context, userID string) (domain.Preferences, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreferencesByUser")
+	}
+
+	var r0 domain.Preferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Preferences, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Preferences); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Preferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastSubsRepo_PreferencesByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreferencesByUser'
+type BroadcastSubsRepo_PreferencesByUser_Call struct {
+	*mock.Call
+}
+
+// PreferencesByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *BroadcastSubsRepo_Expecter) PreferencesByUser(ctx interface{}, userID interface{}) *BroadcastSubsRepo_PreferencesByUser_Call {
+	return &BroadcastSubsRepo_PreferencesByUser_Call{Call: _e.mock.On("PreferencesByUser", ctx, userID)}
+}
+
+func (_c *BroadcastSubsRepo_PreferencesByUser_Call) Run(run func(ctx context.Context, userID string)) *BroadcastSubsRepo_PreferencesByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BroadcastSubsRepo_PreferencesByUser_Call) Return(_a0 domain.Preferences, _a1 error) *BroadcastSubsRepo_PreferencesByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastSubsRepo_PreferencesByUser_Call) RunAndReturn(run func(context.Context, string) (domain.Preferences, error)) *BroadcastSubsRepo_PreferencesByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscriptionsByUser provides a mock function with given fields: ctx, userID
+func (_m *BroadcastSubsRepo) SubscriptionsByUser(ctx context.Context, userID string) ([]domain.Subscription, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscriptionsByUser")
+	}
+
+	var r0 []domain.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Subscription, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Subscription); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastSubsRepo_SubscriptionsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscriptionsByUser'
+type BroadcastSubsRepo_SubscriptionsByUser_Call struct {
+	*mock.Call
+}
+
+// SubscriptionsByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *BroadcastSubsRepo_Expecter) SubscriptionsByUser(ctx interface{}, userID interface{}) *BroadcastSubsRepo_SubscriptionsByUser_Call {
+	return &BroadcastSubsRepo_SubscriptionsByUser_Call{Call: _e.mock.On("SubscriptionsByUser", ctx, userID)}
+}
+
+func (_c *BroadcastSubsRepo_SubscriptionsByUser_Call) Run(run func(ctx context.Context, userID string)) *BroadcastSubsRepo_SubscriptionsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BroadcastSubsRepo_SubscriptionsByUser_Call) Return(_a0 []domain.Subscription, _a1 error) *BroadcastSubsRepo_SubscriptionsByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastSubsRepo_SubscriptionsByUser_Call) RunAndReturn(run func(context.Context, string) ([]domain.Subscription, error)) *BroadcastSubsRepo_SubscriptionsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, userID, subType, enabled
+func (_m *BroadcastSubsRepo) Update(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool) error {
+	ret := _m.Called(ctx, userID, subType, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionType, bool) error); ok {
+		r0 = rf(ctx, userID, subType, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BroadcastSubsRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type BroadcastSubsRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - subType domain.SubscriptionType
+//   - enabled bool
+func (_e *BroadcastSubsRepo_Expecter) Update(ctx interface{}, userID interface{}, subType interface{}, enabled interface{}) *BroadcastSubsRepo_Update_Call {
+	return &BroadcastSubsRepo_Update_Call{Call: _e.mock.On("Update", ctx, userID, subType, enabled)}
+}
+
+func (_c *BroadcastSubsRepo_Update_Call) Run(run func(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool)) *BroadcastSubsRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.SubscriptionType), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *BroadcastSubsRepo_Update_Call) Return(_a0 error) *BroadcastSubsRepo_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BroadcastSubsRepo_Update_Call) RunAndReturn(run func(context.Context, string, domain.SubscriptionType, bool) error) *BroadcastSubsRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBroadcastSubsRepo creates a new instance of BroadcastSubsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBroadcastSubsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BroadcastSubsRepo {
+	mock := &BroadcastSubsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BroadcastUserRepo is an autogenerated mock type for the BroadcastUserRepo type
+type BroadcastUserRepo struct {
+	mock.Mock
+}
+
+type BroadcastUserRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BroadcastUserRepo) EXPECT() *BroadcastUserRepo_Expecter {
+	return &BroadcastUserRepo_Expecter{mock: &_m.Mock}
+}
+
+// BroadcastRecipients provides a mock function with given fields: ctx, segment, afterUserID, limit
+func (_m *BroadcastUserRepo) BroadcastRecipients(ctx context.Context, segment domain.BroadcastSegment, afterUserID string, limit int) ([]domain.User, error) {
+	ret := _m.Called(ctx, segment, afterUserID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BroadcastRecipients")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastSegment, string, int) ([]domain.User, error)); ok {
+		return rf(ctx, segment, afterUserID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastSegment, string, int) []domain.User); ok {
+		r0 = rf(ctx, segment, afterUserID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BroadcastSegment, string, int) error); ok {
+		r1 = rf(ctx, segment, afterUserID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastUserRepo_BroadcastRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BroadcastRecipients'
+type BroadcastUserRepo_BroadcastRecipients_Call struct {
+	*mock.Call
+}
+
+// BroadcastRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - segment domain.BroadcastSegment
+//   - afterUserID string
+//   - limit int
+func (_e *BroadcastUserRepo_Expecter) BroadcastRecipients(ctx interface{}, segment interface{}, afterUserID interface{}, limit interface{}) *BroadcastUserRepo_BroadcastRecipients_Call {
+	return &BroadcastUserRepo_BroadcastRecipients_Call{Call: _e.mock.On("BroadcastRecipients", ctx, segment, afterUserID, limit)}
+}
+
+func (_c *BroadcastUserRepo_BroadcastRecipients_Call) Run(run func(ctx context.Context, segment domain.BroadcastSegment, afterUserID string, limit int)) *BroadcastUserRepo_BroadcastRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BroadcastSegment), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *BroadcastUserRepo_BroadcastRecipients_Call) Return(_a0 []domain.User, _a1 error) *BroadcastUserRepo_BroadcastRecipients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastUserRepo_BroadcastRecipients_Call) RunAndReturn(run func(context.Context, domain.BroadcastSegment, string, int) ([]domain.User, error)) *BroadcastUserRepo_BroadcastRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountBroadcastRecipients provides a mock function with given fields: ctx, segment
+func (_m *BroadcastUserRepo) CountBroadcastRecipients(ctx context.Context, segment domain.BroadcastSegment) (int, error) {
+	ret := _m.Called(ctx, segment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBroadcastRecipients")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastSegment) (int, error)); ok {
+		return rf(ctx, segment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastSegment) int); ok {
+		r0 = rf(ctx, segment)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BroadcastSegment) error); ok {
+		r1 = rf(ctx, segment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastUserRepo_CountBroadcastRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBroadcastRecipients'
+type BroadcastUserRepo_CountBroadcastRecipients_Call struct {
+	*mock.Call
+}
+
+// CountBroadcastRecipients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - segment domain.BroadcastSegment
+func (_e *BroadcastUserRepo_Expecter) CountBroadcastRecipients(ctx interface{}, segment interface{}) *BroadcastUserRepo_CountBroadcastRecipients_Call {
+	return &BroadcastUserRepo_CountBroadcastRecipients_Call{Call: _e.mock.On("CountBroadcastRecipients", ctx, segment)}
+}
+
+func (_c *BroadcastUserRepo_CountBroadcastRecipients_Call) Run(run func(ctx context.Context, segment domain.BroadcastSegment)) *BroadcastUserRepo_CountBroadcastRecipients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BroadcastSegment))
+	})
+	return _c
+}
+
+func (_c *BroadcastUserRepo_CountBroadcastRecipients_Call) Return(_a0 int, _a1 error) *BroadcastUserRepo_CountBroadcastRecipients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastUserRepo_CountBroadcastRecipients_Call) RunAndReturn(run func(context.Context, domain.BroadcastSegment) (int, error)) *BroadcastUserRepo_CountBroadcastRecipients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBroadcastUserRepo creates a new instance of BroadcastUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBroadcastUserRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BroadcastUserRepo {
+	mock := &BroadcastUserRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,264 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// NotifyDeliveryRepo is an autogenerated mock type for the NotifyDeliveryRepo type
+type NotifyDeliveryRepo struct {
+	mock.Mock
+}
+
+type NotifyDeliveryRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotifyDeliveryRepo) EXPECT() *NotifyDeliveryRepo_Expecter {
+	return &NotifyDeliveryRepo_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, now, claimedUntil, limit
+func (_m *NotifyDeliveryRepo) ClaimDeliveries(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]domain.Delivery, error) {
+	ret := _m.Called(ctx, now, claimedUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeliveries")
+	}
+
+	var r0 []domain.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]domain.Delivery, error)); ok {
+		return rf(ctx, now, claimedUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []domain.Delivery); ok {
+		r0 = rf(ctx, now, claimedUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, claimedUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDeliveryRepo_ClaimDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeliveries'
+type NotifyDeliveryRepo_ClaimDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - claimedUntil time.Time
+//   - limit int
+func (_e *NotifyDeliveryRepo_Expecter) ClaimDeliveries(ctx interface{}, now interface{}, claimedUntil interface{}, limit interface{}) *NotifyDeliveryRepo_ClaimDeliveries_Call {
+	return &NotifyDeliveryRepo_ClaimDeliveries_Call{Call: _e.mock.On("ClaimDeliveries", ctx, now, claimedUntil, limit)}
+}
+
+func (_c *NotifyDeliveryRepo_ClaimDeliveries_Call) Run(run func(ctx context.Context, now time.Time, claimedUntil time.Time, limit int)) *NotifyDeliveryRepo_ClaimDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_ClaimDeliveries_Call) Return(_a0 []domain.Delivery, _a1 error) *NotifyDeliveryRepo_ClaimDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_ClaimDeliveries_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]domain.Delivery, error)) *NotifyDeliveryRepo_ClaimDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDelivery provides a mock function with given fields: ctx, delivery
+func (_m *NotifyDeliveryRepo) CreateDelivery(ctx context.Context, delivery *domain.Delivery) (bool, error) {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDelivery")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Delivery) (bool, error)); ok {
+		return rf(ctx, delivery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Delivery) bool); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Delivery) error); ok {
+		r1 = rf(ctx, delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDeliveryRepo_CreateDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDelivery'
+type NotifyDeliveryRepo_CreateDelivery_Call struct {
+	*mock.Call
+}
+
+// CreateDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *domain.Delivery
+func (_e *NotifyDeliveryRepo_Expecter) CreateDelivery(ctx interface{}, delivery interface{}) *NotifyDeliveryRepo_CreateDelivery_Call {
+	return &NotifyDeliveryRepo_CreateDelivery_Call{Call: _e.mock.On("CreateDelivery", ctx, delivery)}
+}
+
+func (_c *NotifyDeliveryRepo_CreateDelivery_Call) Run(run func(ctx context.Context, delivery *domain.Delivery)) *NotifyDeliveryRepo_CreateDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Delivery))
+	})
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_CreateDelivery_Call) Return(_a0 bool, _a1 error) *NotifyDeliveryRepo_CreateDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_CreateDelivery_Call) RunAndReturn(run func(context.Context, *domain.Delivery) (bool, error)) *NotifyDeliveryRepo_CreateDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeliveriesByUser provides a mock function with given fields: ctx, userID, limit
+func (_m *NotifyDeliveryRepo) DeliveriesByUser(ctx context.Context, userID string, limit int) ([]domain.Delivery, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeliveriesByUser")
+	}
+
+	var r0 []domain.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.Delivery, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.Delivery); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDeliveryRepo_DeliveriesByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeliveriesByUser'
+type NotifyDeliveryRepo_DeliveriesByUser_Call struct {
+	*mock.Call
+}
+
+// DeliveriesByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - limit int
+func (_e *NotifyDeliveryRepo_Expecter) DeliveriesByUser(ctx interface{}, userID interface{}, limit interface{}) *NotifyDeliveryRepo_DeliveriesByUser_Call {
+	return &NotifyDeliveryRepo_DeliveriesByUser_Call{Call: _e.mock.On("DeliveriesByUser", ctx, userID, limit)}
+}
+
+func (_c *NotifyDeliveryRepo_DeliveriesByUser_Call) Run(run func(ctx context.Context, userID string, limit int)) *NotifyDeliveryRepo_DeliveriesByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_DeliveriesByUser_Call) Return(_a0 []domain.Delivery, _a1 error) *NotifyDeliveryRepo_DeliveriesByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_DeliveriesByUser_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.Delivery, error)) *NotifyDeliveryRepo_DeliveriesByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDelivery provides a mock function with given fields: ctx, delivery
+func (_m *NotifyDeliveryRepo) UpdateDelivery(ctx context.Context, delivery domain.Delivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Delivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyDeliveryRepo_UpdateDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDelivery'
+type NotifyDeliveryRepo_UpdateDelivery_Call struct {
+	*mock.Call
+}
+
+// UpdateDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery domain.Delivery
+func (_e *NotifyDeliveryRepo_Expecter) UpdateDelivery(ctx interface{}, delivery interface{}) *NotifyDeliveryRepo_UpdateDelivery_Call {
+	return &NotifyDeliveryRepo_UpdateDelivery_Call{Call: _e.mock.On("UpdateDelivery", ctx, delivery)}
+}
+
+func (_c *NotifyDeliveryRepo_UpdateDelivery_Call) Run(run func(ctx context.Context, delivery domain.Delivery)) *NotifyDeliveryRepo_UpdateDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Delivery))
+	})
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_UpdateDelivery_Call) Return(_a0 error) *NotifyDeliveryRepo_UpdateDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyDeliveryRepo_UpdateDelivery_Call) RunAndReturn(run func(context.Context, domain.Delivery) error) *NotifyDeliveryRepo_UpdateDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifyDeliveryRepo creates a new instance of NotifyDeliveryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifyDeliveryRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifyDeliveryRepo {
+	mock := &NotifyDeliveryRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
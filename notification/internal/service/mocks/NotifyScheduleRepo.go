@@ -0,0 +1,251 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// NotifyScheduleRepo is an autogenerated mock type for the NotifyScheduleRepo type
+type NotifyScheduleRepo struct {
+	mock.Mock
+}
+
+type NotifyScheduleRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotifyScheduleRepo) EXPECT() *NotifyScheduleRepo_Expecter {
+	return &NotifyScheduleRepo_Expecter{mock: &_m.Mock}
+}
+
+// ClaimPending provides a mock function with given fields: ctx, now, claimedUntil, limit
+func (_m *NotifyScheduleRepo) ClaimPending(ctx context.Context, now time.Time, claimedUntil time.Time, limit int) ([]domain.PendingNotification, error) {
+	ret := _m.Called(ctx, now, claimedUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimPending")
+	}
+
+	var r0 []domain.PendingNotification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]domain.PendingNotification, error)); ok {
+		return rf(ctx, now, claimedUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []domain.PendingNotification); ok {
+		r0 = rf(ctx, now, claimedUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, claimedUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyScheduleRepo_ClaimPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimPending'
+type NotifyScheduleRepo_ClaimPending_Call struct {
+	*mock.Call
+}
+
+// ClaimPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - claimedUntil time.Time
+//   - limit int
+func (_e *NotifyScheduleRepo_Expecter) ClaimPending(ctx interface{}, now interface{}, claimedUntil interface{}, limit interface{}) *NotifyScheduleRepo_ClaimPending_Call {
+	return &NotifyScheduleRepo_ClaimPending_Call{Call: _e.mock.On("ClaimPending", ctx, now, claimedUntil, limit)}
+}
+
+func (_c *NotifyScheduleRepo_ClaimPending_Call) Run(run func(ctx context.Context, now time.Time, claimedUntil time.Time, limit int)) *NotifyScheduleRepo_ClaimPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_ClaimPending_Call) Return(_a0 []domain.PendingNotification, _a1 error) *NotifyScheduleRepo_ClaimPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_ClaimPending_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]domain.PendingNotification, error)) *NotifyScheduleRepo_ClaimPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePending provides a mock function with given fields: ctx, ids
+func (_m *NotifyScheduleRepo) DeletePending(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyScheduleRepo_DeletePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePending'
+type NotifyScheduleRepo_DeletePending_Call struct {
+	*mock.Call
+}
+
+// DeletePending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *NotifyScheduleRepo_Expecter) DeletePending(ctx interface{}, ids interface{}) *NotifyScheduleRepo_DeletePending_Call {
+	return &NotifyScheduleRepo_DeletePending_Call{Call: _e.mock.On("DeletePending", ctx, ids)}
+}
+
+func (_c *NotifyScheduleRepo_DeletePending_Call) Run(run func(ctx context.Context, ids []int64)) *NotifyScheduleRepo_DeletePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_DeletePending_Call) Return(_a0 error) *NotifyScheduleRepo_DeletePending_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_DeletePending_Call) RunAndReturn(run func(context.Context, []int64) error) *NotifyScheduleRepo_DeletePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePending provides a mock function with given fields: ctx, notification
+func (_m *NotifyScheduleRepo) SavePending(ctx context.Context, notification domain.PendingNotification) error {
+	ret := _m.Called(ctx, notification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PendingNotification) error); ok {
+		r0 = rf(ctx, notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyScheduleRepo_SavePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePending'
+type NotifyScheduleRepo_SavePending_Call struct {
+	*mock.Call
+}
+
+// SavePending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notification domain.PendingNotification
+func (_e *NotifyScheduleRepo_Expecter) SavePending(ctx interface{}, notification interface{}) *NotifyScheduleRepo_SavePending_Call {
+	return &NotifyScheduleRepo_SavePending_Call{Call: _e.mock.On("SavePending", ctx, notification)}
+}
+
+func (_c *NotifyScheduleRepo_SavePending_Call) Run(run func(ctx context.Context, notification domain.PendingNotification)) *NotifyScheduleRepo_SavePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PendingNotification))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_SavePending_Call) Return(_a0 error) *NotifyScheduleRepo_SavePending_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_SavePending_Call) RunAndReturn(run func(context.Context, domain.PendingNotification) error) *NotifyScheduleRepo_SavePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleByUser provides a mock function with given fields: ctx, userID
+func (_m *NotifyScheduleRepo) ScheduleByUser(ctx context.Context, userID string) (domain.Schedule, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleByUser")
+	}
+
+	var r0 domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Schedule, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Schedule); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Schedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyScheduleRepo_ScheduleByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleByUser'
+type NotifyScheduleRepo_ScheduleByUser_Call struct {
+	*mock.Call
+}
+
+// ScheduleByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *NotifyScheduleRepo_Expecter) ScheduleByUser(ctx interface{}, userID interface{}) *NotifyScheduleRepo_ScheduleByUser_Call {
+	return &NotifyScheduleRepo_ScheduleByUser_Call{Call: _e.mock.On("ScheduleByUser", ctx, userID)}
+}
+
+func (_c *NotifyScheduleRepo_ScheduleByUser_Call) Run(run func(ctx context.Context, userID string)) *NotifyScheduleRepo_ScheduleByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_ScheduleByUser_Call) Return(_a0 domain.Schedule, _a1 error) *NotifyScheduleRepo_ScheduleByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyScheduleRepo_ScheduleByUser_Call) RunAndReturn(run func(context.Context, string) (domain.Schedule, error)) *NotifyScheduleRepo_ScheduleByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifyScheduleRepo creates a new instance of NotifyScheduleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifyScheduleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifyScheduleRepo {
+	mock := &NotifyScheduleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -128,6 +128,65 @@ func (_c *NotifySubsRepo_IsExists_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// PreferencesByUser provides a mock function with given fields: ctx, userID
+func (_m *NotifySubsRepo) PreferencesByUser(ctx context.Context, userID string) (domain.Preferences, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreferencesByUser")
+	}
+
+	var r0 domain.Preferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Preferences, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Preferences); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Preferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifySubsRepo_PreferencesByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreferencesByUser'
+type NotifySubsRepo_PreferencesByUser_Call struct {
+	*mock.Call
+}
+
+// PreferencesByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *NotifySubsRepo_Expecter) PreferencesByUser(ctx interface{}, userID interface{}) *NotifySubsRepo_PreferencesByUser_Call {
+	return &NotifySubsRepo_PreferencesByUser_Call{Call: _e.mock.On("PreferencesByUser", ctx, userID)}
+}
+
+func (_c *NotifySubsRepo_PreferencesByUser_Call) Run(run func(ctx context.Context, userID string)) *NotifySubsRepo_PreferencesByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifySubsRepo_PreferencesByUser_Call) Return(_a0 domain.Preferences, _a1 error) *NotifySubsRepo_PreferencesByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifySubsRepo_PreferencesByUser_Call) RunAndReturn(run func(context.Context, string) (domain.Preferences, error)) *NotifySubsRepo_PreferencesByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, userID, subType
 func (_m *NotifySubsRepo) Save(ctx context.Context, userID string, subType domain.SubscriptionType) error {
 	ret := _m.Called(ctx, userID, subType)
@@ -0,0 +1,133 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PushContactRepo is an autogenerated mock type for the PushContactRepo type
+type PushContactRepo struct {
+	mock.Mock
+}
+
+type PushContactRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PushContactRepo) EXPECT() *PushContactRepo_Expecter {
+	return &PushContactRepo_Expecter{mock: &_m.Mock}
+}
+
+// DeleteContact provides a mock function with given fields: ctx, userID, channel
+func (_m *PushContactRepo) DeleteContact(ctx context.Context, userID string, channel domain.SubscriptionType) error {
+	ret := _m.Called(ctx, userID, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionType) error); ok {
+		r0 = rf(ctx, userID, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PushContactRepo_DeleteContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContact'
+type PushContactRepo_DeleteContact_Call struct {
+	*mock.Call
+}
+
+// DeleteContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - channel domain.SubscriptionType
+func (_e *PushContactRepo_Expecter) DeleteContact(ctx interface{}, userID interface{}, channel interface{}) *PushContactRepo_DeleteContact_Call {
+	return &PushContactRepo_DeleteContact_Call{Call: _e.mock.On("DeleteContact", ctx, userID, channel)}
+}
+
+func (_c *PushContactRepo_DeleteContact_Call) Run(run func(ctx context.Context, userID string, channel domain.SubscriptionType)) *PushContactRepo_DeleteContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.SubscriptionType))
+	})
+	return _c
+}
+
+func (_c *PushContactRepo_DeleteContact_Call) Return(_a0 error) *PushContactRepo_DeleteContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PushContactRepo_DeleteContact_Call) RunAndReturn(run func(context.Context, string, domain.SubscriptionType) error) *PushContactRepo_DeleteContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveContact provides a mock function with given fields: ctx, userID, contact
+func (_m *PushContactRepo) SaveContact(ctx context.Context, userID string, contact domain.Contact) error {
+	ret := _m.Called(ctx, userID, contact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Contact) error); ok {
+		r0 = rf(ctx, userID, contact)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PushContactRepo_SaveContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveContact'
+type PushContactRepo_SaveContact_Call struct {
+	*mock.Call
+}
+
+// SaveContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - contact domain.Contact
+func (_e *PushContactRepo_Expecter) SaveContact(ctx interface{}, userID interface{}, contact interface{}) *PushContactRepo_SaveContact_Call {
+	return &PushContactRepo_SaveContact_Call{Call: _e.mock.On("SaveContact", ctx, userID, contact)}
+}
+
+func (_c *PushContactRepo_SaveContact_Call) Run(run func(ctx context.Context, userID string, contact domain.Contact)) *PushContactRepo_SaveContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Contact))
+	})
+	return _c
+}
+
+func (_c *PushContactRepo_SaveContact_Call) Return(_a0 error) *PushContactRepo_SaveContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PushContactRepo_SaveContact_Call) RunAndReturn(run func(context.Context, string, domain.Contact) error) *PushContactRepo_SaveContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPushContactRepo creates a new instance of PushContactRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPushContactRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PushContactRepo {
+	mock := &PushContactRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
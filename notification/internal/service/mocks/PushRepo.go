@@ -0,0 +1,192 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PushRepo is an autogenerated mock type for the PushRepo type
+type PushRepo struct {
+	mock.Mock
+}
+
+type PushRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PushRepo) EXPECT() *PushRepo_Expecter {
+	return &PushRepo_Expecter{mock: &_m.Mock}
+}
+
+// DeletePushSubscription provides a mock function with given fields: ctx, userID, endpoint
+func (_m *PushRepo) DeletePushSubscription(ctx context.Context, userID string, endpoint string) error {
+	ret := _m.Called(ctx, userID, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePushSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, endpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PushRepo_DeletePushSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePushSubscription'
+type PushRepo_DeletePushSubscription_Call struct {
+	*mock.Call
+}
+
+// DeletePushSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - endpoint string
+func (_e *PushRepo_Expecter) DeletePushSubscription(ctx interface{}, userID interface{}, endpoint interface{}) *PushRepo_DeletePushSubscription_Call {
+	return &PushRepo_DeletePushSubscription_Call{Call: _e.mock.On("DeletePushSubscription", ctx, userID, endpoint)}
+}
+
+func (_c *PushRepo_DeletePushSubscription_Call) Run(run func(ctx context.Context, userID string, endpoint string)) *PushRepo_DeletePushSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PushRepo_DeletePushSubscription_Call) Return(_a0 error) *PushRepo_DeletePushSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PushRepo_DeletePushSubscription_Call) RunAndReturn(run func(context.Context, string, string) error) *PushRepo_DeletePushSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PushSubscriptionsByUser provides a mock function with given fields: ctx, userID
+func (_m *PushRepo) PushSubscriptionsByUser(ctx context.Context, userID string) ([]domain.PushSubscription, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PushSubscriptionsByUser")
+	}
+
+	var r0 []domain.PushSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.PushSubscription, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.PushSubscription); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PushSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PushRepo_PushSubscriptionsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushSubscriptionsByUser'
+type PushRepo_PushSubscriptionsByUser_Call struct {
+	*mock.Call
+}
+
+// PushSubscriptionsByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PushRepo_Expecter) PushSubscriptionsByUser(ctx interface{}, userID interface{}) *PushRepo_PushSubscriptionsByUser_Call {
+	return &PushRepo_PushSubscriptionsByUser_Call{Call: _e.mock.On("PushSubscriptionsByUser", ctx, userID)}
+}
+
+func (_c *PushRepo_PushSubscriptionsByUser_Call) Run(run func(ctx context.Context, userID string)) *PushRepo_PushSubscriptionsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PushRepo_PushSubscriptionsByUser_Call) Return(_a0 []domain.PushSubscription, _a1 error) *PushRepo_PushSubscriptionsByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PushRepo_PushSubscriptionsByUser_Call) RunAndReturn(run func(context.Context, string) ([]domain.PushSubscription, error)) *PushRepo_PushSubscriptionsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePushSubscription provides a mock function with given fields: ctx, userID, sub
+func (_m *PushRepo) SavePushSubscription(ctx context.Context, userID string, sub domain.PushSubscription) error {
+	ret := _m.Called(ctx, userID, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePushSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PushSubscription) error); ok {
+		r0 = rf(ctx, userID, sub)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PushRepo_SavePushSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePushSubscription'
+type PushRepo_SavePushSubscription_Call struct {
+	*mock.Call
+}
+
+// SavePushSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sub domain.PushSubscription
+func (_e *PushRepo_Expecter) SavePushSubscription(ctx interface{}, userID interface{}, sub interface{}) *PushRepo_SavePushSubscription_Call {
+	return &PushRepo_SavePushSubscription_Call{Call: _e.mock.On("SavePushSubscription", ctx, userID, sub)}
+}
+
+func (_c *PushRepo_SavePushSubscription_Call) Run(run func(ctx context.Context, userID string, sub domain.PushSubscription)) *PushRepo_SavePushSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.PushSubscription))
+	})
+	return _c
+}
+
+func (_c *PushRepo_SavePushSubscription_Call) Return(_a0 error) *PushRepo_SavePushSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PushRepo_SavePushSubscription_Call) RunAndReturn(run func(context.Context, string, domain.PushSubscription) error) *PushRepo_SavePushSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPushRepo creates a new instance of PushRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPushRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PushRepo {
+	mock := &PushRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
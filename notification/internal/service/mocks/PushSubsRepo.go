@@ -0,0 +1,143 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PushSubsRepo is an autogenerated mock type for the PushSubsRepo type
+type PushSubsRepo struct {
+	mock.Mock
+}
+
+type PushSubsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PushSubsRepo) EXPECT() *PushSubsRepo_Expecter {
+	return &PushSubsRepo_Expecter{mock: &_m.Mock}
+}
+
+// IsExists provides a mock function with given fields: ctx, userID, subType
+func (_m *PushSubsRepo) IsExists(ctx context.Context, userID string, subType domain.SubscriptionType) (bool, error) {
+	ret := _m.Called(ctx, userID, subType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionType) (bool, error)); ok {
+		return rf(ctx, userID, subType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionType) bool); ok {
+		r0 = rf(ctx, userID, subType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.SubscriptionType) error); ok {
+		r1 = rf(ctx, userID, subType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PushSubsRepo_IsExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsExists'
+type PushSubsRepo_IsExists_Call struct {
+	*mock.Call
+}
+
+// IsExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - subType domain.SubscriptionType
+func (_e *PushSubsRepo_Expecter) IsExists(ctx interface{}, userID interface{}, subType interface{}) *PushSubsRepo_IsExists_Call {
+	return &PushSubsRepo_IsExists_Call{Call: _e.mock.On("IsExists", ctx, userID, subType)}
+}
+
+func (_c *PushSubsRepo_IsExists_Call) Run(run func(ctx context.Context, userID string, subType domain.SubscriptionType)) *PushSubsRepo_IsExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.SubscriptionType))
+	})
+	return _c
+}
+
+func (_c *PushSubsRepo_IsExists_Call) Return(_a0 bool, _a1 error) *PushSubsRepo_IsExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PushSubsRepo_IsExists_Call) RunAndReturn(run func(context.Context, string, domain.SubscriptionType) (bool, error)) *PushSubsRepo_IsExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, userID, subType
+func (_m *PushSubsRepo) Save(ctx context.Context, userID string, subType domain.SubscriptionType) error {
+	ret := _m.Called(ctx, userID, subType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionType) error); ok {
+		r0 = rf(ctx, userID, subType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PushSubsRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type PushSubsRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - subType domain.SubscriptionType
+func (_e *PushSubsRepo_Expecter) Save(ctx interface{}, userID interface{}, subType interface{}) *PushSubsRepo_Save_Call {
+	return &PushSubsRepo_Save_Call{Call: _e.mock.On("Save", ctx, userID, subType)}
+}
+
+func (_c *PushSubsRepo_Save_Call) Run(run func(ctx context.Context, userID string, subType domain.SubscriptionType)) *PushSubsRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.SubscriptionType))
+	})
+	return _c
+}
+
+func (_c *PushSubsRepo_Save_Call) Return(_a0 error) *PushSubsRepo_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PushSubsRepo_Save_Call) RunAndReturn(run func(context.Context, string, domain.SubscriptionType) error) *PushSubsRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPushSubsRepo creates a new instance of PushSubsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPushSubsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PushSubsRepo {
+	mock := &PushSubsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
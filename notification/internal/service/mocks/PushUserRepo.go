@@ -0,0 +1,93 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PushUserRepo is an autogenerated mock type for the PushUserRepo type
+type PushUserRepo struct {
+	mock.Mock
+}
+
+type PushUserRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PushUserRepo) EXPECT() *PushUserRepo_Expecter {
+	return &PushUserRepo_Expecter{mock: &_m.Mock}
+}
+
+// IsExists provides a mock function with given fields: ctx, userID
+func (_m *PushUserRepo) IsExists(ctx context.Context, userID string) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PushUserRepo_IsExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsExists'
+type PushUserRepo_IsExists_Call struct {
+	*mock.Call
+}
+
+// IsExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PushUserRepo_Expecter) IsExists(ctx interface{}, userID interface{}) *PushUserRepo_IsExists_Call {
+	return &PushUserRepo_IsExists_Call{Call: _e.mock.On("IsExists", ctx, userID)}
+}
+
+func (_c *PushUserRepo_IsExists_Call) Run(run func(ctx context.Context, userID string)) *PushUserRepo_IsExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PushUserRepo_IsExists_Call) Return(_a0 bool, _a1 error) *PushUserRepo_IsExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PushUserRepo_IsExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *PushUserRepo_IsExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPushUserRepo creates a new instance of PushUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPushUserRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PushUserRepo {
+	mock := &PushUserRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
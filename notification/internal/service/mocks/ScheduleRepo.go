@@ -0,0 +1,142 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScheduleRepo is an autogenerated mock type for the ScheduleRepo type
+type ScheduleRepo struct {
+	mock.Mock
+}
+
+type ScheduleRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ScheduleRepo) EXPECT() *ScheduleRepo_Expecter {
+	return &ScheduleRepo_Expecter{mock: &_m.Mock}
+}
+
+// SaveSchedule provides a mock function with given fields: ctx, userID, schedule
+func (_m *ScheduleRepo) SaveSchedule(ctx context.Context, userID string, schedule domain.Schedule) error {
+	ret := _m.Called(ctx, userID, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.Schedule) error); ok {
+		r0 = rf(ctx, userID, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleRepo_SaveSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSchedule'
+type ScheduleRepo_SaveSchedule_Call struct {
+	*mock.Call
+}
+
+// SaveSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - schedule domain.Schedule
+func (_e *ScheduleRepo_Expecter) SaveSchedule(ctx interface{}, userID interface{}, schedule interface{}) *ScheduleRepo_SaveSchedule_Call {
+	return &ScheduleRepo_SaveSchedule_Call{Call: _e.mock.On("SaveSchedule", ctx, userID, schedule)}
+}
+
+func (_c *ScheduleRepo_SaveSchedule_Call) Run(run func(ctx context.Context, userID string, schedule domain.Schedule)) *ScheduleRepo_SaveSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.Schedule))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepo_SaveSchedule_Call) Return(_a0 error) *ScheduleRepo_SaveSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScheduleRepo_SaveSchedule_Call) RunAndReturn(run func(context.Context, string, domain.Schedule) error) *ScheduleRepo_SaveSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleByUser provides a mock function with given fields: ctx, userID
+func (_m *ScheduleRepo) ScheduleByUser(ctx context.Context, userID string) (domain.Schedule, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleByUser")
+	}
+
+	var r0 domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Schedule, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Schedule); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Schedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleRepo_ScheduleByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleByUser'
+type ScheduleRepo_ScheduleByUser_Call struct {
+	*mock.Call
+}
+
+// ScheduleByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ScheduleRepo_Expecter) ScheduleByUser(ctx interface{}, userID interface{}) *ScheduleRepo_ScheduleByUser_Call {
+	return &ScheduleRepo_ScheduleByUser_Call{Call: _e.mock.On("ScheduleByUser", ctx, userID)}
+}
+
+func (_c *ScheduleRepo_ScheduleByUser_Call) Run(run func(ctx context.Context, userID string)) *ScheduleRepo_ScheduleByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepo_ScheduleByUser_Call) Return(_a0 domain.Schedule, _a1 error) *ScheduleRepo_ScheduleByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleRepo_ScheduleByUser_Call) RunAndReturn(run func(context.Context, string) (domain.Schedule, error)) *ScheduleRepo_ScheduleByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewScheduleRepo creates a new instance of ScheduleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduleRepo {
+	mock := &ScheduleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScheduleUserRepo is an autogenerated mock type for the ScheduleUserRepo type
+type ScheduleUserRepo struct {
+	mock.Mock
+}
+
+type ScheduleUserRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ScheduleUserRepo) EXPECT() *ScheduleUserRepo_Expecter {
+	return &ScheduleUserRepo_Expecter{mock: &_m.Mock}
+}
+
+// IsExists provides a mock function with given fields: ctx, userID
+func (_m *ScheduleUserRepo) IsExists(ctx context.Context, userID string) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleUserRepo_IsExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsExists'
+type ScheduleUserRepo_IsExists_Call struct {
+	*mock.Call
+}
+
+// IsExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ScheduleUserRepo_Expecter) IsExists(ctx interface{}, userID interface{}) *ScheduleUserRepo_IsExists_Call {
+	return &ScheduleUserRepo_IsExists_Call{Call: _e.mock.On("IsExists", ctx, userID)}
+}
+
+func (_c *ScheduleUserRepo_IsExists_Call) Run(run func(ctx context.Context, userID string)) *ScheduleUserRepo_IsExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ScheduleUserRepo_IsExists_Call) Return(_a0 bool, _a1 error) *ScheduleUserRepo_IsExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleUserRepo_IsExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *ScheduleUserRepo_IsExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewScheduleUserRepo creates a new instance of ScheduleUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduleUserRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduleUserRepo {
+	mock := &ScheduleUserRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionClient is an autogenerated mock type for the SessionClient type
+type SessionClient struct {
+	mock.Mock
+}
+
+type SessionClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SessionClient) EXPECT() *SessionClient_Expecter {
+	return &SessionClient_Expecter{mock: &_m.Mock}
+}
+
+// Sessions provides a mock function with given fields: ctx, userID
+func (_m *SessionClient) Sessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionClient_Sessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sessions'
+type SessionClient_Sessions_Call struct {
+	*mock.Call
+}
+
+// Sessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *SessionClient_Expecter) Sessions(ctx interface{}, userID interface{}) *SessionClient_Sessions_Call {
+	return &SessionClient_Sessions_Call{Call: _e.mock.On("Sessions", ctx, userID)}
+}
+
+func (_c *SessionClient_Sessions_Call) Run(run func(ctx context.Context, userID string)) *SessionClient_Sessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SessionClient_Sessions_Call) Return(_a0 []domain.Session, _a1 error) *SessionClient_Sessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionClient_Sessions_Call) RunAndReturn(run func(context.Context, string) ([]domain.Session, error)) *SessionClient_Sessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TerminateSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *SessionClient) TerminateSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TerminateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionClient_TerminateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TerminateSession'
+type SessionClient_TerminateSession_Call struct {
+	*mock.Call
+}
+
+// TerminateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+func (_e *SessionClient_Expecter) TerminateSession(ctx interface{}, userID interface{}, sessionID interface{}) *SessionClient_TerminateSession_Call {
+	return &SessionClient_TerminateSession_Call{Call: _e.mock.On("TerminateSession", ctx, userID, sessionID)}
+}
+
+func (_c *SessionClient_TerminateSession_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *SessionClient_TerminateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SessionClient_TerminateSession_Call) Return(_a0 error) *SessionClient_TerminateSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SessionClient_TerminateSession_Call) RunAndReturn(run func(context.Context, string, string) error) *SessionClient_TerminateSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSessionClient creates a new instance of SessionClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionClient {
+	mock := &SessionClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionUserRepo is an autogenerated mock type for the SessionUserRepo type
+type SessionUserRepo struct {
+	mock.Mock
+}
+
+type SessionUserRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SessionUserRepo) EXPECT() *SessionUserRepo_Expecter {
+	return &SessionUserRepo_Expecter{mock: &_m.Mock}
+}
+
+// GetByTelegramID provides a mock function with given fields: ctx, telegramID
+func (_m *SessionUserRepo) GetByTelegramID(ctx context.Context, telegramID int64) (domain.User, error) {
+	ret := _m.Called(ctx, telegramID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTelegramID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.User, error)); ok {
+		return rf(ctx, telegramID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.User); ok {
+		r0 = rf(ctx, telegramID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, telegramID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionUserRepo_GetByTelegramID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTelegramID'
+type SessionUserRepo_GetByTelegramID_Call struct {
+	*mock.Call
+}
+
+// GetByTelegramID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - telegramID int64
+func (_e *SessionUserRepo_Expecter) GetByTelegramID(ctx interface{}, telegramID interface{}) *SessionUserRepo_GetByTelegramID_Call {
+	return &SessionUserRepo_GetByTelegramID_Call{Call: _e.mock.On("GetByTelegramID", ctx, telegramID)}
+}
+
+func (_c *SessionUserRepo_GetByTelegramID_Call) Run(run func(ctx context.Context, telegramID int64)) *SessionUserRepo_GetByTelegramID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *SessionUserRepo_GetByTelegramID_Call) Return(_a0 domain.User, _a1 error) *SessionUserRepo_GetByTelegramID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SessionUserRepo_GetByTelegramID_Call) RunAndReturn(run func(context.Context, int64) (domain.User, error)) *SessionUserRepo_GetByTelegramID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSessionUserRepo creates a new instance of SessionUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionUserRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SessionUserRepo {
+	mock := &SessionUserRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
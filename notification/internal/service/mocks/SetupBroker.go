@@ -0,0 +1,81 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	events "github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SetupBroker is an autogenerated mock type for the SetupBroker type
+type SetupBroker struct {
+	mock.Mock
+}
+
+type SetupBroker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SetupBroker) EXPECT() *SetupBroker_Expecter {
+	return &SetupBroker_Expecter{mock: &_m.Mock}
+}
+
+// PublishTelegramLinked provides a mock function with given fields: data
+func (_m *SetupBroker) PublishTelegramLinked(data events.TelegramLinked) error {
+	ret := _m.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishTelegramLinked")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(events.TelegramLinked) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetupBroker_PublishTelegramLinked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishTelegramLinked'
+type SetupBroker_PublishTelegramLinked_Call struct {
+	*mock.Call
+}
+
+// PublishTelegramLinked is a helper method to define mock.On call
+//   - data events.TelegramLinked
+func (_e *SetupBroker_Expecter) PublishTelegramLinked(data interface{}) *SetupBroker_PublishTelegramLinked_Call {
+	return &SetupBroker_PublishTelegramLinked_Call{Call: _e.mock.On("PublishTelegramLinked", data)}
+}
+
+func (_c *SetupBroker_PublishTelegramLinked_Call) Run(run func(data events.TelegramLinked)) *SetupBroker_PublishTelegramLinked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(events.TelegramLinked))
+	})
+	return _c
+}
+
+func (_c *SetupBroker_PublishTelegramLinked_Call) Return(_a0 error) *SetupBroker_PublishTelegramLinked_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SetupBroker_PublishTelegramLinked_Call) RunAndReturn(run func(events.TelegramLinked) error) *SetupBroker_PublishTelegramLinked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSetupBroker creates a new instance of SetupBroker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSetupBroker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SetupBroker {
+	mock := &SetupBroker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -128,6 +128,65 @@ func (_c *SetupSubsRepo_IsExists_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// PreferencesByUser provides a mock function with given fields: ctx, userID
+func (_m *SetupSubsRepo) PreferencesByUser(ctx context.Context, userID string) (domain.Preferences, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreferencesByUser")
+	}
+
+	var r0 domain.Preferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Preferences, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Preferences); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Preferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetupSubsRepo_PreferencesByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreferencesByUser'
+type SetupSubsRepo_PreferencesByUser_Call struct {
+	*mock.Call
+}
+
+// PreferencesByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *SetupSubsRepo_Expecter) PreferencesByUser(ctx interface{}, userID interface{}) *SetupSubsRepo_PreferencesByUser_Call {
+	return &SetupSubsRepo_PreferencesByUser_Call{Call: _e.mock.On("PreferencesByUser", ctx, userID)}
+}
+
+func (_c *SetupSubsRepo_PreferencesByUser_Call) Run(run func(ctx context.Context, userID string)) *SetupSubsRepo_PreferencesByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SetupSubsRepo_PreferencesByUser_Call) Return(_a0 domain.Preferences, _a1 error) *SetupSubsRepo_PreferencesByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SetupSubsRepo_PreferencesByUser_Call) RunAndReturn(run func(context.Context, string) (domain.Preferences, error)) *SetupSubsRepo_PreferencesByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, userID, subType
 func (_m *SetupSubsRepo) Save(ctx context.Context, userID string, subType domain.SubscriptionType) error {
 	ret := _m.Called(ctx, userID, subType)
@@ -176,6 +235,115 @@ func (_c *SetupSubsRepo_Save_Call) RunAndReturn(run func(context.Context, string
 	return _c
 }
 
+// SavePreference provides a mock function with given fields: ctx, userID, channel, category, enabled
+func (_m *SetupSubsRepo) SavePreference(ctx context.Context, userID string, channel domain.SubscriptionType, category domain.EventCategory, enabled bool) error {
+	ret := _m.Called(ctx, userID, channel, category, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePreference")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.SubscriptionType, domain.EventCategory, bool) error); ok {
+		r0 = rf(ctx, userID, channel, category, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetupSubsRepo_SavePreference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePreference'
+type SetupSubsRepo_SavePreference_Call struct {
+	*mock.Call
+}
+
+// SavePreference is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - channel domain.SubscriptionType
+//   - category domain.EventCategory
+//   - enabled bool
+func (_e *SetupSubsRepo_Expecter) SavePreference(ctx interface{}, userID interface{}, channel interface{}, category interface{}, enabled interface{}) *SetupSubsRepo_SavePreference_Call {
+	return &SetupSubsRepo_SavePreference_Call{Call: _e.mock.On("SavePreference", ctx, userID, channel, category, enabled)}
+}
+
+func (_c *SetupSubsRepo_SavePreference_Call) Run(run func(ctx context.Context, userID string, channel domain.SubscriptionType, category domain.EventCategory, enabled bool)) *SetupSubsRepo_SavePreference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.SubscriptionType), args[3].(domain.EventCategory), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *SetupSubsRepo_SavePreference_Call) Return(_a0 error) *SetupSubsRepo_SavePreference_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SetupSubsRepo_SavePreference_Call) RunAndReturn(run func(context.Context, string, domain.SubscriptionType, domain.EventCategory, bool) error) *SetupSubsRepo_SavePreference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscriptionsByUser provides a mock function with given fields: ctx, userID
+func (_m *SetupSubsRepo) SubscriptionsByUser(ctx context.Context, userID string) ([]domain.Subscription, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscriptionsByUser")
+	}
+
+	var r0 []domain.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Subscription, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Subscription); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetupSubsRepo_SubscriptionsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscriptionsByUser'
+type SetupSubsRepo_SubscriptionsByUser_Call struct {
+	*mock.Call
+}
+
+// SubscriptionsByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *SetupSubsRepo_Expecter) SubscriptionsByUser(ctx interface{}, userID interface{}) *SetupSubsRepo_SubscriptionsByUser_Call {
+	return &SetupSubsRepo_SubscriptionsByUser_Call{Call: _e.mock.On("SubscriptionsByUser", ctx, userID)}
+}
+
+func (_c *SetupSubsRepo_SubscriptionsByUser_Call) Run(run func(ctx context.Context, userID string)) *SetupSubsRepo_SubscriptionsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SetupSubsRepo_SubscriptionsByUser_Call) Return(_a0 []domain.Subscription, _a1 error) *SetupSubsRepo_SubscriptionsByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SetupSubsRepo_SubscriptionsByUser_Call) RunAndReturn(run func(context.Context, string) ([]domain.Subscription, error)) *SetupSubsRepo_SubscriptionsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, userID, subType, enabled
 func (_m *SetupSubsRepo) Update(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool) error {
 	ret := _m.Called(ctx, userID, subType, enabled)
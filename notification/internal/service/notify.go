@@ -2,18 +2,22 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	"github.com/SergeyBogomolovv/profile-manager/common/logger"
 	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/mailer"
-	"golang.org/x/sync/errgroup"
 )
 
-type Sender interface {
-	SendLoginNotification(telegramID int64, data domain.LoginNotification) error
-}
-
 type NotifyUserRepo interface {
 	Save(ctx context.Context, user domain.User) error
 	IsExists(ctx context.Context, userID string) (bool, error)
@@ -24,58 +28,379 @@ type NotifyUserRepo interface {
 
 type NotifySubsRepo interface {
 	SubscriptionsByUser(ctx context.Context, userID string) ([]domain.Subscription, error)
+	PreferencesByUser(ctx context.Context, userID string) (domain.Preferences, error)
 	Save(ctx context.Context, userID string, subType domain.SubscriptionType) error
 	IsExists(ctx context.Context, userID string, subType domain.SubscriptionType) (bool, error)
 	Update(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool) error
 	Delete(ctx context.Context, userID string, subType domain.SubscriptionType) error
 }
 
+type NotifyScheduleRepo interface {
+	ScheduleByUser(ctx context.Context, userID string) (domain.Schedule, error)
+	SavePending(ctx context.Context, notification domain.PendingNotification) error
+	ClaimPending(ctx context.Context, now, claimedUntil time.Time, limit int) ([]domain.PendingNotification, error)
+	DeletePending(ctx context.Context, ids []int64) error
+}
+
+type NotifyDeliveryRepo interface {
+	CreateDelivery(ctx context.Context, delivery *domain.Delivery) (bool, error)
+	UpdateDelivery(ctx context.Context, delivery domain.Delivery) error
+	ClaimDeliveries(ctx context.Context, now, claimedUntil time.Time, limit int) ([]domain.Delivery, error)
+	DeliveriesByUser(ctx context.Context, userID string, limit int) ([]domain.Delivery, error)
+}
+
 type service struct {
-	txManager transaction.TxManager
-	mailer    mailer.Mailer
-	users     NotifyUserRepo
-	sender    Sender
-	subs      NotifySubsRepo
+	txManager  transaction.TxManager
+	mailer     mailer.Mailer
+	channels   *channel.Registry
+	users      NotifyUserRepo
+	subs       NotifySubsRepo
+	schedules  NotifyScheduleRepo
+	deliveries NotifyDeliveryRepo
+	// Page which signs user in by token of magic link
+	magicLinkURL string
 }
 
-func NewNotifyService(txManager transaction.TxManager, mailer mailer.Mailer, sender Sender, users NotifyUserRepo, subs NotifySubsRepo) *service {
-	return &service{mailer: mailer, users: users, txManager: txManager, sender: sender, subs: subs}
+// Mailer sends transactional emails, notifications of categories are sent through channels
+func NewNotifyService(txManager transaction.TxManager, mailer mailer.Mailer, channels *channel.Registry, users NotifyUserRepo, subs NotifySubsRepo, schedules NotifyScheduleRepo, deliveries NotifyDeliveryRepo, magicLinkURL string) *service {
+	return &service{mailer: mailer, users: users, txManager: txManager, channels: channels, subs: subs, schedules: schedules, deliveries: deliveries, magicLinkURL: magicLinkURL}
 }
 
 func (s *service) SendLoginNotification(ctx context.Context, data events.UserLogin) error {
-	subscriptions, err := s.subs.SubscriptionsByUser(ctx, data.ID)
+	notification := domain.LoginNotification{IP: data.IP, Time: data.Time.Format("2006-01-02 15:04:05"), Type: data.Type}
+
+	schedule, err := s.schedules.ScheduleByUser(ctx, data.ID)
 	if err != nil {
 		return err
 	}
+	if now := time.Now(); schedule.Deferred(now) {
+		return s.schedules.SavePending(ctx, domain.PendingNotification{
+			UserID:    data.ID,
+			Category:  domain.EventCategoryLogin,
+			Login:     notification,
+			DeliverAt: schedule.NextDelivery(now),
+		})
+	}
 
-	eg, ctx := errgroup.WithContext(ctx)
-	notification := domain.LoginNotification{IP: data.IP, Time: data.Time.Format("2006-01-02 15:04:05"), Type: data.Type}
+	subscriptions, err := s.activeSubscriptions(ctx, data.ID, domain.EventCategoryLogin)
+	if err != nil {
+		return err
+	}
+
+	// Redelivered event skips channels which already have delivery for this message
+	key := fmt.Sprintf("%s:%s:%d", domain.EventCategoryLogin, data.ID, data.Time.UnixNano())
+	var wg sync.WaitGroup
 	for _, sub := range subscriptions {
-		if sub.Enabled {
-			switch sub.Type {
-			case domain.SubscriptionTypeEmail:
-				eg.Go(func() error {
-					return s.mailer.SendLoginEmail(sub.User.Email, notification)
-				})
-			case domain.SubscriptionTypeTelegram:
-				eg.Go(func() error {
-					return s.sender.SendLoginNotification(sub.User.TelegramID, notification)
-				})
+		delivery := domain.Delivery{
+			Key:      key,
+			UserID:   data.ID,
+			Channel:  sub.Type,
+			Category: domain.EventCategoryLogin,
+			Login:    notification,
+			Status:   domain.DeliveryStatusPending,
+			// Retry worker picks delivery up only if this attempt is lost
+			NextAttemptAt: time.Now().Add(domain.DeliveryBackoff(1)),
+		}
+		created, err := s.deliveries.CreateDelivery(ctx, &delivery)
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.deliver(ctx, &delivery, sub)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// deliver makes one attempt and records its result, failed channels are retried by RetryDeliveries
+func (s *service) deliver(ctx context.Context, delivery *domain.Delivery, sub domain.Subscription) {
+	var providerID string
+	ch, err := s.channels.Get(sub.Type)
+	if err == nil {
+		if delivery.IsDigest() {
+			err = ch.SendDigest(ctx, sub.Contact, sub.User.Locale, delivery.Digest)
+		} else {
+			providerID, err = ch.SendLogin(ctx, sub.Contact, sub.User.Locale, delivery.Login)
+		}
+	}
+	switch {
+	case errors.Is(err, domain.ErrRecipientUnavailable):
+		logger.Extract(ctx).Warn("recipient unavailable, disabling subscription", "delivery_id", delivery.ID, "channel", delivery.Channel, "error", err)
+		delivery.Abort(err.Error())
+		s.disableSubscription(ctx, delivery.UserID, sub.Type)
+	case err != nil:
+		logger.Extract(ctx).Error("failed to deliver notification", "delivery_id", delivery.ID, "channel", delivery.Channel, "error", err)
+		delivery.Fail(err, time.Now())
+	default:
+		delivery.Succeed(providerID)
+	}
+	if err := s.deliveries.UpdateDelivery(ctx, *delivery); err != nil {
+		logger.Extract(ctx).Error("failed to update delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// disableSubscription keeps subscription, user can enable it again after unblocking the bot
+func (s *service) disableSubscription(ctx context.Context, userID string, subType domain.SubscriptionType) {
+	if err := s.subs.Update(ctx, userID, subType, false); err != nil {
+		logger.Extract(ctx).Error("failed to disable subscription", "user_id", userID, "channel", subType, "error", err)
+	}
+}
+
+const (
+	retryBatchSize = 100
+	// Claimed deliveries are skipped by other instances, attempt result replaces claim much earlier
+	retryClaim = 10 * time.Minute
+)
+
+// RetryDeliveries makes next attempt for failed deliveries whose backoff has passed.
+// No transaction is held while sending, deliveries are claimed and updated by separate statements
+func (s *service) RetryDeliveries(ctx context.Context) error {
+	now := time.Now()
+	due, err := s.deliveries.ClaimDeliveries(ctx, now, now.Add(retryClaim), retryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	subscriptions := make(map[string][]domain.Subscription)
+	for _, delivery := range due {
+		subs, ok := subscriptions[delivery.UserID]
+		if !ok {
+			if subs, err = s.subs.SubscriptionsByUser(ctx, delivery.UserID); err != nil {
+				return err
 			}
+			subscriptions[delivery.UserID] = subs
+		}
+		sub, ok := findSubscription(subs, delivery.Channel)
+		if !ok || !sub.Enabled {
+			delivery.Abort("subscription disabled")
+			if err := s.deliveries.UpdateDelivery(ctx, delivery); err != nil {
+				return err
+			}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.deliver(ctx, &delivery, sub)
+		}()
+	}
+	return nil
+}
+
+func (s *service) ListDeliveries(ctx context.Context, userID string, limit int) ([]domain.Delivery, error) {
+	isExists, err := s.users.IsExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isExists {
+		return nil, domain.ErrUserNotFound
+	}
+	return s.deliveries.DeliveriesByUser(ctx, userID, limit)
+}
+
+func findSubscription(subscriptions []domain.Subscription, channel domain.SubscriptionType) (domain.Subscription, bool) {
+	for _, sub := range subscriptions {
+		if sub.Type == channel {
+			return sub, true
 		}
 	}
+	return domain.Subscription{}, false
+}
 
-	return eg.Wait()
+// activeSubscriptions returns enabled subscriptions which accept events of category
+func (s *service) activeSubscriptions(ctx context.Context, userID string, category domain.EventCategory) ([]domain.Subscription, error) {
+	subscriptions, err := s.subs.SubscriptionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := s.subs.PreferencesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]domain.Subscription, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub.Enabled && prefs.Enabled(sub.Type, category) {
+			active = append(active, sub)
+		}
+	}
+	return active, nil
+}
+
+const (
+	digestBatchSize = 500
+	// Claimed notifications are hidden from other instances, unless instance crashed it deletes them much earlier
+	digestClaim = 10 * time.Minute
+)
+
+// SendDigests delivers due pending notifications as one message per user and channel.
+// No transaction is held while sending, notifications are claimed and deleted by separate statements
+func (s *service) SendDigests(ctx context.Context) error {
+	now := time.Now()
+	due, err := s.schedules.ClaimPending(ctx, now, now.Add(digestClaim), digestBatchSize)
+	if err != nil || len(due) == 0 {
+		return err
+	}
+	byUser := make(map[string][]domain.PendingNotification)
+	for _, notification := range due {
+		byUser[notification.UserID] = append(byUser[notification.UserID], notification)
+	}
+
+	delivered := make([]int64, 0, len(due))
+	for userID, notifications := range byUser {
+		// Notifications stay pending if deliveries were not created, they are claimed again when claim expires
+		if err := s.sendDigest(ctx, userID, notifications); err != nil {
+			logger.Extract(ctx).Error("failed to send digest", "user_id", userID, "error", err)
+			continue
+		}
+		for _, notification := range notifications {
+			delivered = append(delivered, notification.ID)
+		}
+	}
+	if len(delivered) == 0 {
+		return nil
+	}
+	return s.schedules.DeletePending(ctx, delivered)
+}
+
+// sendDigest creates delivery of digest for every channel, failed channels are retried by RetryDeliveries
+func (s *service) sendDigest(ctx context.Context, userID string, notifications []domain.PendingNotification) error {
+	subscriptions, err := s.subs.SubscriptionsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	prefs, err := s.subs.PreferencesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Notifications claimed again after failure have same first ID, so channels with delivery are skipped
+	key := fmt.Sprintf("digest:%s:%d", userID, notifications[0].ID)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, sub := range subscriptions {
+		if !sub.Enabled {
+			continue
+		}
+		var digest domain.Digest
+		for _, notification := range notifications {
+			if prefs.Enabled(sub.Type, notification.Category) {
+				digest.Logins = append(digest.Logins, notification.Login)
+			}
+		}
+		if digest.IsEmpty() {
+			continue
+		}
+		delivery := domain.Delivery{
+			Key:     key,
+			UserID:  userID,
+			Channel: sub.Type,
+			// Digest consists of login notifications only
+			Category:      domain.EventCategoryLogin,
+			Digest:        digest,
+			Status:        domain.DeliveryStatusPending,
+			NextAttemptAt: time.Now().Add(domain.DeliveryBackoff(1)),
+		}
+		created, err := s.deliveries.CreateDelivery(ctx, &delivery)
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.deliver(ctx, &delivery, sub)
+		}()
+	}
+	return nil
 }
 
 func (s *service) HandleRegister(ctx context.Context, data events.UserRegister) error {
+	locale := i18n.Match(data.Locale)
 	return s.txManager.Run(ctx, func(ctx context.Context) error {
-		if err := s.users.Save(ctx, domain.User{ID: data.ID, Email: data.Email}); err != nil {
+		if err := s.users.Save(ctx, domain.User{ID: data.ID, Email: data.Email, Locale: locale}); err != nil {
 			return err
 		}
 		if err := s.subs.Save(ctx, data.ID, domain.SubscriptionTypeEmail); err != nil {
 			return err
 		}
-		return s.mailer.SendRegisterEmail(data.Email)
+		return s.mailer.SendRegisterEmail(ctx, data.Email, locale)
+	})
+}
+
+// HandleProfileUpdated applies locale chosen in profile, it has priority over other sources
+func (s *service) HandleProfileUpdated(ctx context.Context, data events.ProfileUpdated) error {
+	locale, ok := data.Changes[events.ProfileFieldLocale]
+	if !ok {
+		return nil
+	}
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByID(ctx, data.ID)
+		// Profile may be updated before register event is handled
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		user.Locale = i18n.Match(locale)
+		return s.users.Update(ctx, user)
 	})
 }
+
+// HandleTelegramLinked confirms linking by email, so user notices if someone else linked the account
+func (s *service) HandleTelegramLinked(ctx context.Context, data events.TelegramLinked) error {
+	user, err := s.users.GetByID(ctx, data.ID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	account := strconv.FormatInt(data.TelegramID, 10)
+	if data.Username != "" {
+		account = "@" + data.Username
+	}
+	notification := domain.TelegramLinkNotification{Account: account, Time: data.Time.Format("2006-01-02 15:04:05")}
+	return s.mailer.SendTelegramLinkedEmail(ctx, user.Email, user.Locale, notification)
+}
+
+// HandleMagicLink emails sign in link, email may belong to user who is not registered yet
+func (s *service) HandleMagicLink(ctx context.Context, data events.MagicLink) error {
+	// Link expires soon, so redelivered event is dropped instead of sending expired link
+	if time.Now().After(data.ExpiresAt) {
+		return nil
+	}
+	locale := i18n.Match(data.Locale)
+	if data.ID != "" {
+		user, err := s.users.GetByID(ctx, data.ID)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+		if err == nil {
+			locale = user.Locale
+		}
+	}
+	link, err := url.Parse(s.magicLinkURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse magic link url: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", data.Token)
+	link.RawQuery = query.Encode()
+
+	notification := domain.MagicLinkNotification{
+		Link:      link.String(),
+		ExpiresIn: int(time.Until(data.ExpiresAt).Round(time.Minute).Minutes()),
+	}
+	return s.mailer.SendMagicLinkEmail(ctx, data.Email, locale, notification)
+}
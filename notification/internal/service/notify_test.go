@@ -3,9 +3,12 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
 	txMocks "github.com/SergeyBogomolovv/profile-manager/common/transaction/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/channel"
+	chMocks "github.com/SergeyBogomolovv/profile-manager/notification/internal/channel/mocks"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
 	mailMocks "github.com/SergeyBogomolovv/profile-manager/notification/internal/mailer/mocks"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service"
@@ -32,8 +35,9 @@ func TestService_HandleRegister(t *testing.T) {
 		{
 			name: "success",
 			data: events.UserRegister{
-				ID:    "user123",
-				Email: "user@example.com",
+				ID:     "user123",
+				Email:  "user@example.com",
+				Locale: "en-GB,en;q=0.9",
 			},
 			mockBehavior: func(
 				tx *txMocks.TxManager,
@@ -48,8 +52,8 @@ func TestService_HandleRegister(t *testing.T) {
 					},
 				)
 				subscriptions.EXPECT().Save(mock.Anything, data.ID, domain.SubscriptionTypeEmail).Return(nil)
-				users.EXPECT().Save(mock.Anything, domain.User{ID: data.ID, Email: data.Email}).Return(nil)
-				mailer.EXPECT().SendRegisterEmail(data.Email).Return(nil)
+				users.EXPECT().Save(mock.Anything, domain.User{ID: data.ID, Email: data.Email, Locale: "en"}).Return(nil)
+				mailer.EXPECT().SendRegisterEmail(mock.Anything, data.Email, "en").Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -106,8 +110,9 @@ func TestService_HandleRegister(t *testing.T) {
 			users := mocks.NewNotifyUserRepo(t)
 			subs := mocks.NewNotifySubsRepo(t)
 			mailer := mailMocks.NewMailer(t)
-			sender := mocks.NewSender(t)
-			svc := service.NewNotifyService(tx, mailer, sender, users, subs)
+			schedules := mocks.NewNotifyScheduleRepo(t)
+			deliveries := mocks.NewNotifyDeliveryRepo(t)
+			svc := service.NewNotifyService(tx, mailer, channel.NewRegistry(), users, subs, schedules, deliveries, "")
 			tc.mockBehavior(tx, subs, users, mailer, tc.data)
 			err := svc.HandleRegister(context.Background(), tc.data)
 			assert.ErrorIs(t, err, tc.wantErr)
@@ -115,8 +120,204 @@ func TestService_HandleRegister(t *testing.T) {
 	}
 }
 
+func TestService_HandleProfileUpdated(t *testing.T) {
+	type MockBehavior func(tx *txMocks.TxManager, users *mocks.NotifyUserRepo)
+
+	testCases := []struct {
+		name         string
+		data         events.ProfileUpdated
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "locale changed",
+			data: events.ProfileUpdated{ID: "user123", Changes: map[string]string{events.ProfileFieldLocale: "en"}},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.NotifyUserRepo) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(domain.User{ID: "user123", Locale: "ru"}, nil)
+				users.EXPECT().Update(mock.Anything, domain.User{ID: "user123", Locale: "en"}).Return(nil)
+			},
+		},
+		{
+			name:         "locale not changed",
+			data:         events.ProfileUpdated{ID: "user123", Changes: map[string]string{"username": "user"}},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.NotifyUserRepo) {},
+		},
+		{
+			name: "user not found",
+			data: events.ProfileUpdated{ID: "user123", Changes: map[string]string{events.ProfileFieldLocale: "en"}},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.NotifyUserRepo) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(domain.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name: "failed to update user",
+			data: events.ProfileUpdated{ID: "user123", Changes: map[string]string{events.ProfileFieldLocale: "en"}},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.NotifyUserRepo) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(domain.User{ID: "user123"}, nil)
+				users.EXPECT().Update(mock.Anything, domain.User{ID: "user123", Locale: "en"}).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewNotifyUserRepo(t)
+			svc := service.NewNotifyService(tx, mailMocks.NewMailer(t), channel.NewRegistry(), users, mocks.NewNotifySubsRepo(t), mocks.NewNotifyScheduleRepo(t), mocks.NewNotifyDeliveryRepo(t), "")
+			tc.mockBehavior(tx, users)
+			err := svc.HandleProfileUpdated(context.Background(), tc.data)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestService_HandleTelegramLinked(t *testing.T) {
+	type MockBehavior func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer)
+
+	linkedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	user := domain.User{ID: "user123", Email: "user@example.com", Locale: "en"}
+
+	testCases := []struct {
+		name         string
+		data         events.TelegramLinked
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "with username",
+			data: events.TelegramLinked{ID: "user123", TelegramID: 123, Username: "user", Time: linkedAt},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(user, nil)
+				mailer.EXPECT().SendTelegramLinkedEmail(mock.Anything, "user@example.com", "en",
+					domain.TelegramLinkNotification{Account: "@user", Time: "2025-01-01 10:00:00"}).Return(nil)
+			},
+		},
+		{
+			name: "without username",
+			data: events.TelegramLinked{ID: "user123", TelegramID: 123, Time: linkedAt},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(user, nil)
+				mailer.EXPECT().SendTelegramLinkedEmail(mock.Anything, "user@example.com", "en",
+					domain.TelegramLinkNotification{Account: "123", Time: "2025-01-01 10:00:00"}).Return(nil)
+			},
+		},
+		{
+			name: "user not found",
+			data: events.TelegramLinked{ID: "user123", TelegramID: 123, Time: linkedAt},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(domain.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name: "failed to send email",
+			data: events.TelegramLinked{ID: "user123", TelegramID: 123, Time: linkedAt},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(user, nil)
+				mailer.EXPECT().SendTelegramLinkedEmail(mock.Anything, "user@example.com", "en", mock.Anything).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewNotifyUserRepo(t)
+			mailer := mailMocks.NewMailer(t)
+			svc := service.NewNotifyService(txMocks.NewTxManager(t), mailer, channel.NewRegistry(), users, mocks.NewNotifySubsRepo(t), mocks.NewNotifyScheduleRepo(t), mocks.NewNotifyDeliveryRepo(t), "")
+			tc.mockBehavior(users, mailer)
+			err := svc.HandleTelegramLinked(context.Background(), tc.data)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestService_HandleMagicLink(t *testing.T) {
+	type MockBehavior func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer)
+
+	const link = "http://localhost:3000/auth/magic-link?token=token"
+	expiresAt := time.Now().Add(15 * time.Minute)
+	user := domain.User{ID: "user123", Email: "user@example.com", Locale: "ru"}
+
+	testCases := []struct {
+		name         string
+		data         events.MagicLink
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "registered user",
+			data: events.MagicLink{ID: "user123", Email: "user@example.com", Token: "token", Locale: "en", ExpiresAt: expiresAt},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {
+				users.EXPECT().GetByID(mock.Anything, "user123").Return(user, nil)
+				mailer.EXPECT().SendMagicLinkEmail(mock.Anything, "user@example.com", "ru",
+					domain.MagicLinkNotification{Link: link, ExpiresIn: 15}).Return(nil)
+			},
+		},
+		{
+			name: "new email",
+			data: events.MagicLink{Email: "new@example.com", Token: "token", Locale: "ru-RU,ru;q=0.9", ExpiresAt: expiresAt},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {
+				mailer.EXPECT().SendMagicLinkEmail(mock.Anything, "new@example.com", "ru",
+					domain.MagicLinkNotification{Link: link, ExpiresIn: 15}).Return(nil)
+			},
+		},
+		{
+			name:         "expired link",
+			data:         events.MagicLink{Email: "new@example.com", Token: "token", ExpiresAt: time.Now().Add(-time.Minute)},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {},
+		},
+		{
+			name: "failed to send email",
+			data: events.MagicLink{Email: "new@example.com", Token: "token", ExpiresAt: expiresAt},
+			mockBehavior: func(users *mocks.NotifyUserRepo, mailer *mailMocks.Mailer) {
+				mailer.EXPECT().SendMagicLinkEmail(mock.Anything, "new@example.com", mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewNotifyUserRepo(t)
+			mailer := mailMocks.NewMailer(t)
+			svc := service.NewNotifyService(txMocks.NewTxManager(t), mailer, channel.NewRegistry(), users, mocks.NewNotifySubsRepo(t), mocks.NewNotifyScheduleRepo(t), mocks.NewNotifyDeliveryRepo(t), "http://localhost:3000/auth/magic-link")
+			tc.mockBehavior(users, mailer)
+			err := svc.HandleMagicLink(context.Background(), tc.data)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+var (
+	emailContact    = domain.Contact{Channel: domain.SubscriptionTypeEmail, Address: "user@example.com"}
+	telegramContact = domain.Contact{Channel: domain.SubscriptionTypeTelegram, Address: "123"}
+)
+
+func newChannels(email, telegram channel.Channel) *channel.Registry {
+	channels := channel.NewRegistry()
+	channels.Register(domain.SubscriptionTypeEmail, email)
+	channels.Register(domain.SubscriptionTypeTelegram, telegram)
+	return channels
+}
+
 func TestService_SendLoginNotification(t *testing.T) {
-	type MockBehavior func(subs *mocks.NotifySubsRepo, sender *mocks.Sender, mailer *mailMocks.Mailer, data events.UserLogin)
+	type MockBehavior func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin)
 
 	testCases := []struct {
 		name         string
@@ -129,18 +330,44 @@ func TestService_SendLoginNotification(t *testing.T) {
 			data: events.UserLogin{
 				ID: "user123",
 			},
-			mockBehavior: func(subs *mocks.NotifySubsRepo, sender *mocks.Sender, mailer *mailMocks.Mailer, data events.UserLogin) {
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
 				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
-					{User: domain.User{Email: "user@example.com"}, Type: domain.SubscriptionTypeEmail, Enabled: true},
-					{User: domain.User{TelegramID: 123}, Type: domain.SubscriptionTypeTelegram, Enabled: true},
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+					{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
 				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil).Times(2)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Status == domain.DeliveryStatusSent && d.Attempts == 1 && d.ProviderMessageID != ""
+				})).Return(nil).Times(2)
 				noti := domain.LoginNotification{
 					IP:   data.IP,
 					Time: data.Time.Format("2006-01-02 15:04:05"),
 					Type: data.Type,
 				}
-				mailer.EXPECT().SendLoginEmail("user@example.com", noti).Return(nil)
-				sender.EXPECT().SendLoginNotification(int64(123), noti).Return(nil)
+				email.EXPECT().SendLogin(mock.Anything, emailContact, "", noti).Return("<1@smtp>", nil)
+				telegram.EXPECT().SendLogin(mock.Anything, telegramContact, "", noti).Return("1", nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "telegram blocked",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil)
+				telegram.EXPECT().SendLogin(mock.Anything, telegramContact, "", mock.Anything).Return("", domain.ErrRecipientUnavailable)
+				subs.EXPECT().Update(mock.Anything, data.ID, domain.SubscriptionTypeTelegram, false).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Status == domain.DeliveryStatusFailed && d.Attempts == 0
+				})).Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -149,16 +376,20 @@ func TestService_SendLoginNotification(t *testing.T) {
 			data: events.UserLogin{
 				ID: "user123",
 			},
-			mockBehavior: func(subs *mocks.NotifySubsRepo, sender *mocks.Sender, mailer *mailMocks.Mailer, data events.UserLogin) {
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
 				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
-					{User: domain.User{Email: "user@example.com"}, Type: domain.SubscriptionTypeEmail, Enabled: true},
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
 				}, nil)
-				mailer.EXPECT().SendLoginEmail("user@example.com",
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.Anything).Return(nil)
+				email.EXPECT().SendLogin(mock.Anything, emailContact, "",
 					domain.LoginNotification{
 						IP:   data.IP,
 						Time: data.Time.Format("2006-01-02 15:04:05"),
 						Type: data.Type,
-					}).Return(nil)
+					}).Return("1", nil)
 			},
 			wantErr: nil,
 		},
@@ -167,16 +398,47 @@ func TestService_SendLoginNotification(t *testing.T) {
 			data: events.UserLogin{
 				ID: "user123",
 			},
-			mockBehavior: func(subs *mocks.NotifySubsRepo, sender *mocks.Sender, mailer *mailMocks.Mailer, data events.UserLogin) {
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
 				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
-					{User: domain.User{TelegramID: 123}, Type: domain.SubscriptionTypeTelegram, Enabled: true},
+					{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
 				}, nil)
-				sender.EXPECT().SendLoginNotification(int64(123),
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.Anything).Return(nil)
+				telegram.EXPECT().SendLogin(mock.Anything, telegramContact, "",
 					domain.LoginNotification{
 						IP:   data.IP,
 						Time: data.Time.Format("2006-01-02 15:04:05"),
 						Type: data.Type,
-					}).Return(nil)
+					}).Return("1", nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "login alerts disabled for email",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+					{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{
+					domain.SubscriptionTypeEmail: {domain.EventCategoryLogin: false},
+				}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.MatchedBy(func(d *domain.Delivery) bool {
+					return d.Channel == domain.SubscriptionTypeTelegram
+				})).Return(true, nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.Anything).Return(nil)
+				telegram.EXPECT().SendLogin(mock.Anything, telegramContact, "",
+					domain.LoginNotification{
+						IP:   data.IP,
+						Time: data.Time.Format("2006-01-02 15:04:05"),
+						Type: data.Type,
+					}).Return("1", nil)
 			},
 			wantErr: nil,
 		},
@@ -185,11 +447,110 @@ func TestService_SendLoginNotification(t *testing.T) {
 			data: events.UserLogin{
 				ID: "user123",
 			},
-			mockBehavior: func(subs *mocks.NotifySubsRepo, sender *mocks.Sender, mailer *mailMocks.Mailer, data events.UserLogin) {
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
 				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return(nil, assert.AnError)
 			},
 			wantErr: assert.AnError,
 		},
+		{
+			name: "failed channel is retried separately",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+					{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil).Times(2)
+				email.EXPECT().SendLogin(mock.Anything, emailContact, "", mock.Anything).Return("", assert.AnError)
+				telegram.EXPECT().SendLogin(mock.Anything, telegramContact, "", mock.Anything).Return("1", nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Channel == domain.SubscriptionTypeEmail && d.Status == domain.DeliveryStatusPending &&
+						d.Attempts == 1 && d.LastError == assert.AnError.Error()
+				})).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Channel == domain.SubscriptionTypeTelegram && d.Status == domain.DeliveryStatusSent
+				})).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "channel is not configured",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeSMS, Enabled: true, Contact: domain.Contact{Channel: domain.SubscriptionTypeSMS, Address: "+79990000000"}},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Channel == domain.SubscriptionTypeSMS && d.LastError == domain.ErrUnknownChannel.Error()
+				})).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "redelivered message",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(false, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "failed to create delivery",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.DefaultSchedule, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, data.ID).Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+				}, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, data.ID).Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(false, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "deferred to digest",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedule := domain.DefaultSchedule
+				schedule.Digest = true
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(schedule, nil)
+				schedules.EXPECT().SavePending(mock.Anything, mock.MatchedBy(func(n domain.PendingNotification) bool {
+					return n.UserID == data.ID && n.Category == domain.EventCategoryLogin && n.DeliverAt.After(time.Now())
+				})).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "failed to get schedule",
+			data: events.UserLogin{
+				ID: "user123",
+			},
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, telegram *chMocks.Channel, email *chMocks.Channel, data events.UserLogin) {
+				schedules.EXPECT().ScheduleByUser(mock.Anything, data.ID).Return(domain.Schedule{}, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -197,12 +558,284 @@ func TestService_SendLoginNotification(t *testing.T) {
 			tx := txMocks.NewTxManager(t)
 			users := mocks.NewNotifyUserRepo(t)
 			subs := mocks.NewNotifySubsRepo(t)
-			mailer := mailMocks.NewMailer(t)
-			sender := mocks.NewSender(t)
-			svc := service.NewNotifyService(tx, mailer, sender, users, subs)
-			tc.mockBehavior(subs, sender, mailer, tc.data)
+			email := chMocks.NewChannel(t)
+			telegram := chMocks.NewChannel(t)
+			schedules := mocks.NewNotifyScheduleRepo(t)
+			deliveries := mocks.NewNotifyDeliveryRepo(t)
+			svc := service.NewNotifyService(tx, mailMocks.NewMailer(t), newChannels(email, telegram), users, subs, schedules, deliveries, "")
+			tc.mockBehavior(schedules, deliveries, subs, telegram, email, tc.data)
 			err := svc.SendLoginNotification(context.Background(), tc.data)
 			assert.ErrorIs(t, err, tc.wantErr)
 		})
 	}
 }
+
+func TestService_SendDigests(t *testing.T) {
+	type MockBehavior func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel)
+
+	login := domain.LoginNotification{IP: "127.0.0.1", Time: "2025-01-01 10:00:00", Type: "password"}
+	pending := []domain.PendingNotification{
+		{ID: 1, UserID: "user123", Category: domain.EventCategoryLogin, Login: login},
+		{ID: 2, UserID: "user123", Category: domain.EventCategoryLogin, Login: login},
+	}
+	digest := domain.Digest{Logins: []domain.LoginNotification{login, login}}
+	subscriptions := []domain.Subscription{
+		{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+		{Type: domain.SubscriptionTypeTelegram, Enabled: true, Contact: telegramContact},
+	}
+	isDigest := func(channel domain.SubscriptionType) any {
+		return mock.MatchedBy(func(d *domain.Delivery) bool {
+			return d.Key == "digest:user123:1" && d.Channel == channel && assert.ObjectsAreEqual(digest, d.Digest)
+		})
+	}
+	hasStatus := func(channel domain.SubscriptionType, status domain.DeliveryStatus) any {
+		return mock.MatchedBy(func(d domain.Delivery) bool {
+			return d.Channel == channel && d.Status == status && d.Attempts == 1
+		})
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel) {
+				schedules.EXPECT().ClaimPending(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pending, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return(subscriptions, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "user123").Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, isDigest(domain.SubscriptionTypeEmail)).Return(true, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, isDigest(domain.SubscriptionTypeTelegram)).Return(true, nil)
+				email.EXPECT().SendDigest(mock.Anything, emailContact, "", digest).Return(nil)
+				telegram.EXPECT().SendDigest(mock.Anything, telegramContact, "", digest).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, hasStatus(domain.SubscriptionTypeEmail, domain.DeliveryStatusSent)).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, hasStatus(domain.SubscriptionTypeTelegram, domain.DeliveryStatusSent)).Return(nil)
+				schedules.EXPECT().DeletePending(mock.Anything, []int64{1, 2}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "failed channel is left for retry",
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel) {
+				schedules.EXPECT().ClaimPending(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pending, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return(subscriptions, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "user123").Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil).Times(2)
+				email.EXPECT().SendDigest(mock.Anything, emailContact, "", digest).Return(nil)
+				telegram.EXPECT().SendDigest(mock.Anything, telegramContact, "", digest).Return(assert.AnError)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, hasStatus(domain.SubscriptionTypeEmail, domain.DeliveryStatusSent)).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, hasStatus(domain.SubscriptionTypeTelegram, domain.DeliveryStatusPending)).Return(nil)
+				// Retry worker owns failed delivery, so notifications are not sent to email again
+				schedules.EXPECT().DeletePending(mock.Anything, []int64{1, 2}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "claimed again after crash",
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel) {
+				schedules.EXPECT().ClaimPending(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pending, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return(subscriptions, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "user123").Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, isDigest(domain.SubscriptionTypeEmail)).Return(false, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, isDigest(domain.SubscriptionTypeTelegram)).Return(true, nil)
+				telegram.EXPECT().SendDigest(mock.Anything, telegramContact, "", digest).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, hasStatus(domain.SubscriptionTypeTelegram, domain.DeliveryStatusSent)).Return(nil)
+				schedules.EXPECT().DeletePending(mock.Anything, []int64{1, 2}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "telegram blocked",
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel) {
+				schedules.EXPECT().ClaimPending(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pending[:1], nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return(subscriptions, nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "user123").Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(true, nil).Times(2)
+				email.EXPECT().SendDigest(mock.Anything, emailContact, "", mock.Anything).Return(nil)
+				telegram.EXPECT().SendDigest(mock.Anything, telegramContact, "", mock.Anything).Return(domain.ErrRecipientUnavailable)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, hasStatus(domain.SubscriptionTypeEmail, domain.DeliveryStatusSent)).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Channel == domain.SubscriptionTypeTelegram && d.Status == domain.DeliveryStatusFailed
+				})).Return(nil)
+				subs.EXPECT().Update(mock.Anything, "user123", domain.SubscriptionTypeTelegram, false).Return(nil)
+				schedules.EXPECT().DeletePending(mock.Anything, []int64{1}).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "nothing due",
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel) {
+				schedules.EXPECT().ClaimPending(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "failed to create delivery",
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel) {
+				schedules.EXPECT().ClaimPending(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(pending[:1], nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return(subscriptions[:1], nil)
+				subs.EXPECT().PreferencesByUser(mock.Anything, "user123").Return(domain.Preferences{}, nil)
+				deliveries.EXPECT().CreateDelivery(mock.Anything, mock.Anything).Return(false, assert.AnError)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "failed to claim pending",
+			mockBehavior: func(schedules *mocks.NotifyScheduleRepo, subs *mocks.NotifySubsRepo, deliveries *mocks.NotifyDeliveryRepo, telegram *chMocks.Channel, email *chMocks.Channel) {
+				schedules.EXPECT().ClaimPending(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewNotifyUserRepo(t)
+			subs := mocks.NewNotifySubsRepo(t)
+			email := chMocks.NewChannel(t)
+			telegram := chMocks.NewChannel(t)
+			schedules := mocks.NewNotifyScheduleRepo(t)
+			deliveries := mocks.NewNotifyDeliveryRepo(t)
+			svc := service.NewNotifyService(tx, mailMocks.NewMailer(t), newChannels(email, telegram), users, subs, schedules, deliveries, "")
+			tc.mockBehavior(schedules, subs, deliveries, telegram, email)
+			err := svc.SendDigests(context.Background())
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestSchedule_IsQuiet(t *testing.T) {
+	schedule := domain.Schedule{Timezone: "Europe/Moscow", QuietHours: true, QuietFrom: 23, QuietTo: 8}
+
+	testCases := []struct {
+		name string
+		time time.Time
+		want bool
+	}{
+		{name: "before midnight", time: time.Date(2025, 1, 1, 20, 30, 0, 0, time.UTC), want: true},
+		{name: "after midnight", time: time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC), want: true},
+		{name: "window end", time: time.Date(2025, 1, 1, 5, 0, 0, 0, time.UTC), want: false},
+		{name: "day", time: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, schedule.IsQuiet(tc.time))
+		})
+	}
+}
+
+func TestSchedule_NextDelivery(t *testing.T) {
+	schedule := domain.Schedule{Timezone: "UTC", QuietHours: true, QuietFrom: 23, QuietTo: 8, DigestHour: 9}
+
+	now := time.Date(2025, 1, 1, 23, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC), schedule.NextDelivery(now).UTC())
+
+	schedule.Digest = true
+	now = time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), schedule.NextDelivery(now).UTC())
+}
+
+func TestService_RetryDeliveries(t *testing.T) {
+	type MockBehavior func(deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, email *chMocks.Channel)
+
+	delivery := domain.Delivery{ID: 1, UserID: "user123", Channel: domain.SubscriptionTypeEmail, Status: domain.DeliveryStatusPending, Attempts: 1}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, email *chMocks.Channel) {
+				deliveries.EXPECT().ClaimDeliveries(mock.Anything, mock.Anything, mock.Anything, 100).Return([]domain.Delivery{delivery}, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+				}, nil)
+				email.EXPECT().SendLogin(mock.Anything, emailContact, "", delivery.Login).Return("<1@smtp>", nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Status == domain.DeliveryStatusSent && d.Attempts == 2
+				})).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "digest",
+			mockBehavior: func(deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, email *chMocks.Channel) {
+				digest := delivery
+				digest.Digest = domain.Digest{Logins: []domain.LoginNotification{{IP: "127.0.0.1"}}}
+				deliveries.EXPECT().ClaimDeliveries(mock.Anything, mock.Anything, mock.Anything, 100).Return([]domain.Delivery{digest}, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+				}, nil)
+				email.EXPECT().SendDigest(mock.Anything, emailContact, "", digest.Digest).Return(nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Status == domain.DeliveryStatusSent && d.Attempts == 2
+				})).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "last attempt failed",
+			mockBehavior: func(deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, email *chMocks.Channel) {
+				last := delivery
+				last.Attempts = domain.DeliveryMaxAttempts - 1
+				deliveries.EXPECT().ClaimDeliveries(mock.Anything, mock.Anything, mock.Anything, 100).Return([]domain.Delivery{last}, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: true, Contact: emailContact},
+				}, nil)
+				email.EXPECT().SendLogin(mock.Anything, emailContact, "", delivery.Login).Return("", assert.AnError)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Status == domain.DeliveryStatusFailed && d.Attempts == domain.DeliveryMaxAttempts
+				})).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "subscription disabled",
+			mockBehavior: func(deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, email *chMocks.Channel) {
+				deliveries.EXPECT().ClaimDeliveries(mock.Anything, mock.Anything, mock.Anything, 100).Return([]domain.Delivery{delivery}, nil)
+				subs.EXPECT().SubscriptionsByUser(mock.Anything, "user123").Return([]domain.Subscription{
+					{Type: domain.SubscriptionTypeEmail, Enabled: false, Contact: emailContact},
+				}, nil)
+				deliveries.EXPECT().UpdateDelivery(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+					return d.Status == domain.DeliveryStatusFailed && d.Attempts == 1
+				})).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "failed to load deliveries",
+			mockBehavior: func(deliveries *mocks.NotifyDeliveryRepo, subs *mocks.NotifySubsRepo, email *chMocks.Channel) {
+				deliveries.EXPECT().ClaimDeliveries(mock.Anything, mock.Anything, mock.Anything, 100).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewNotifyUserRepo(t)
+			subs := mocks.NewNotifySubsRepo(t)
+			email := chMocks.NewChannel(t)
+			telegram := chMocks.NewChannel(t)
+			schedules := mocks.NewNotifyScheduleRepo(t)
+			deliveries := mocks.NewNotifyDeliveryRepo(t)
+			svc := service.NewNotifyService(tx, mailMocks.NewMailer(t), newChannels(email, telegram), users, subs, schedules, deliveries, "")
+			tc.mockBehavior(deliveries, subs, email)
+			err := svc.RetryDeliveries(context.Background())
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestDeliveryBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, domain.DeliveryBackoff(1))
+	assert.Equal(t, time.Minute, domain.DeliveryBackoff(2))
+	assert.Equal(t, 4*time.Minute, domain.DeliveryBackoff(4))
+	assert.Equal(t, time.Hour, domain.DeliveryBackoff(20))
+}
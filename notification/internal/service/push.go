@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+)
+
+type PushUserRepo interface {
+	IsExists(ctx context.Context, userID string) (bool, error)
+}
+
+type PushSubsRepo interface {
+	IsExists(ctx context.Context, userID string, subType domain.SubscriptionType) (bool, error)
+	Save(ctx context.Context, userID string, subType domain.SubscriptionType) error
+}
+
+type PushContactRepo interface {
+	SaveContact(ctx context.Context, userID string, contact domain.Contact) error
+	DeleteContact(ctx context.Context, userID string, channel domain.SubscriptionType) error
+}
+
+type PushRepo interface {
+	SavePushSubscription(ctx context.Context, userID string, sub domain.PushSubscription) error
+	PushSubscriptionsByUser(ctx context.Context, userID string) ([]domain.PushSubscription, error)
+	DeletePushSubscription(ctx context.Context, userID, endpoint string) error
+}
+
+type pushService struct {
+	txManager transaction.TxManager
+	users     PushUserRepo
+	subs      PushSubsRepo
+	contacts  PushContactRepo
+	pushes    PushRepo
+	publicKey string
+	// Hosts of push services which endpoints may belong to, empty list allows any host
+	hosts []string
+}
+
+// Empty VAPID public key means web push is not configured
+func NewPushService(txManager transaction.TxManager, users PushUserRepo, subs PushSubsRepo, contacts PushContactRepo, pushes PushRepo, publicKey string, hosts []string) *pushService {
+	return &pushService{txManager: txManager, users: users, subs: subs, contacts: contacts, pushes: pushes, publicKey: publicKey, hosts: hosts}
+}
+
+// PublicKey returns VAPID key which web client passes to browser when subscribing
+func (s *pushService) PublicKey() (string, error) {
+	if s.publicKey == "" {
+		return "", domain.ErrUnknownChannel
+	}
+	return s.publicKey, nil
+}
+
+// AddPushSubscription registers browser of user and subscribes user to web push
+func (s *pushService) AddPushSubscription(ctx context.Context, userID string, sub domain.PushSubscription) error {
+	if s.publicKey == "" {
+		return domain.ErrUnknownChannel
+	}
+	if _, _, err := sub.Keys(); err != nil {
+		return err
+	}
+	if !sub.FromPushService(s.hosts) {
+		return domain.ErrUnknownPushService
+	}
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		isExists, err := s.users.IsExists(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !isExists {
+			return domain.ErrUserNotFound
+		}
+		if err := s.pushes.SavePushSubscription(ctx, userID, sub); err != nil {
+			return err
+		}
+		contact := domain.Contact{Channel: domain.SubscriptionTypeWebPush, Address: userID}
+		if err := s.contacts.SaveContact(ctx, userID, contact); err != nil {
+			return err
+		}
+		subExists, err := s.subs.IsExists(ctx, userID, domain.SubscriptionTypeWebPush)
+		if err != nil {
+			return err
+		}
+		if subExists {
+			return nil
+		}
+		return s.subs.Save(ctx, userID, domain.SubscriptionTypeWebPush)
+	})
+}
+
+// RemovePushSubscription unregisters browser, subscription to web push is removed with the last browser
+func (s *pushService) RemovePushSubscription(ctx context.Context, userID, endpoint string) error {
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		isExists, err := s.users.IsExists(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !isExists {
+			return domain.ErrUserNotFound
+		}
+		if err := s.pushes.DeletePushSubscription(ctx, userID, endpoint); err != nil {
+			return err
+		}
+		remaining, err := s.pushes.PushSubscriptionsByUser(ctx, userID)
+		if err != nil || len(remaining) > 0 {
+			return err
+		}
+		err = s.contacts.DeleteContact(ctx, userID, domain.SubscriptionTypeWebPush)
+		if errors.Is(err, domain.ErrContactNotFound) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,203 @@
+package service_test
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	txMocks "github.com/SergeyBogomolovv/profile-manager/common/transaction/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPushSubscription(t *testing.T) domain.PushSubscription {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return domain.PushSubscription{
+		Endpoint: "https://push.example.com/send/1",
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}
+}
+
+func TestService_AddPushSubscription(t *testing.T) {
+	type MockBehavior func(tx *txMocks.TxManager, users *mocks.PushUserRepo, subs *mocks.PushSubsRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo, sub domain.PushSubscription)
+
+	valid := newPushSubscription(t)
+	invalid := valid
+	invalid.Auth = "short"
+	unknown := valid
+	unknown.Endpoint = "https://example.org/send/1"
+
+	testCases := []struct {
+		name         string
+		sub          domain.PushSubscription
+		publicKey    string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name:      "need to create subscription",
+			sub:       valid,
+			publicKey: "public",
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.PushUserRepo, subs *mocks.PushSubsRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo, sub domain.PushSubscription) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+				pushes.EXPECT().SavePushSubscription(mock.Anything, "user_id", sub).Return(nil)
+				contacts.EXPECT().SaveContact(mock.Anything, "user_id", domain.Contact{Channel: domain.SubscriptionTypeWebPush, Address: "user_id"}).Return(nil)
+				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeWebPush).Return(false, nil)
+				subs.EXPECT().Save(mock.Anything, "user_id", domain.SubscriptionTypeWebPush).Return(nil)
+			},
+		},
+		{
+			name:      "subscription exists",
+			sub:       valid,
+			publicKey: "public",
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.PushUserRepo, subs *mocks.PushSubsRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo, sub domain.PushSubscription) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+				pushes.EXPECT().SavePushSubscription(mock.Anything, "user_id", sub).Return(nil)
+				contacts.EXPECT().SaveContact(mock.Anything, "user_id", domain.Contact{Channel: domain.SubscriptionTypeWebPush, Address: "user_id"}).Return(nil)
+				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeWebPush).Return(true, nil)
+			},
+		},
+		{
+			name:      "user not found",
+			sub:       valid,
+			publicKey: "public",
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.PushUserRepo, subs *mocks.PushSubsRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo, sub domain.PushSubscription) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(false, nil)
+			},
+			want: domain.ErrUserNotFound,
+		},
+		{
+			name:      "invalid keys",
+			sub:       invalid,
+			publicKey: "public",
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.PushUserRepo, subs *mocks.PushSubsRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo, sub domain.PushSubscription) {
+			},
+			want: domain.ErrInvalidPushKeys,
+		},
+		{
+			name:      "unknown push service",
+			sub:       unknown,
+			publicKey: "public",
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.PushUserRepo, subs *mocks.PushSubsRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo, sub domain.PushSubscription) {
+			},
+			want: domain.ErrUnknownPushService,
+		},
+		{
+			name: "web push is not configured",
+			sub:  valid,
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.PushUserRepo, subs *mocks.PushSubsRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo, sub domain.PushSubscription) {
+			},
+			want: domain.ErrUnknownChannel,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewPushUserRepo(t)
+			subs := mocks.NewPushSubsRepo(t)
+			contacts := mocks.NewPushContactRepo(t)
+			pushes := mocks.NewPushRepo(t)
+			svc := service.NewPushService(tx, users, subs, contacts, pushes, tc.publicKey, []string{"example.com"})
+			tc.mockBehavior(tx, users, subs, contacts, pushes, tc.sub)
+			assert.ErrorIs(t, svc.AddPushSubscription(context.Background(), "user_id", tc.sub), tc.want)
+		})
+	}
+}
+
+func TestService_RemovePushSubscription(t *testing.T) {
+	type MockBehavior func(users *mocks.PushUserRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo)
+
+	const endpoint = "https://push.example.com/send/1"
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "last browser",
+			mockBehavior: func(users *mocks.PushUserRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo) {
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+				pushes.EXPECT().DeletePushSubscription(mock.Anything, "user_id", endpoint).Return(nil)
+				pushes.EXPECT().PushSubscriptionsByUser(mock.Anything, "user_id").Return(nil, nil)
+				contacts.EXPECT().DeleteContact(mock.Anything, "user_id", domain.SubscriptionTypeWebPush).Return(nil)
+			},
+		},
+		{
+			name: "other browsers remain",
+			mockBehavior: func(users *mocks.PushUserRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo) {
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+				pushes.EXPECT().DeletePushSubscription(mock.Anything, "user_id", endpoint).Return(nil)
+				pushes.EXPECT().PushSubscriptionsByUser(mock.Anything, "user_id").Return([]domain.PushSubscription{{Endpoint: "https://push.example.com/send/2"}}, nil)
+			},
+		},
+		{
+			name: "push subscription not found",
+			mockBehavior: func(users *mocks.PushUserRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo) {
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+				pushes.EXPECT().DeletePushSubscription(mock.Anything, "user_id", endpoint).Return(domain.ErrPushSubscriptionNotFound)
+			},
+			want: domain.ErrPushSubscriptionNotFound,
+		},
+		{
+			name: "user not found",
+			mockBehavior: func(users *mocks.PushUserRepo, contacts *mocks.PushContactRepo, pushes *mocks.PushRepo) {
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(false, nil)
+			},
+			want: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewPushUserRepo(t)
+			contacts := mocks.NewPushContactRepo(t)
+			pushes := mocks.NewPushRepo(t)
+			svc := service.NewPushService(tx, users, mocks.NewPushSubsRepo(t), contacts, pushes, "public", nil)
+			tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+				func(ctx context.Context, f func(context.Context) error) error {
+					return f(ctx)
+				},
+			)
+			tc.mockBehavior(users, contacts, pushes)
+			assert.ErrorIs(t, svc.RemovePushSubscription(context.Background(), "user_id", endpoint), tc.want)
+		})
+	}
+}
+
+func TestService_PushPublicKey(t *testing.T) {
+	svc := service.NewPushService(nil, nil, nil, nil, nil, "public", nil)
+	key, err := svc.PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, "public", key)
+
+	_, err = service.NewPushService(nil, nil, nil, nil, nil, "", nil).PublicKey()
+	assert.ErrorIs(t, err, domain.ErrUnknownChannel)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+)
+
+type ScheduleUserRepo interface {
+	IsExists(ctx context.Context, userID string) (bool, error)
+}
+
+type ScheduleRepo interface {
+	ScheduleByUser(ctx context.Context, userID string) (domain.Schedule, error)
+	SaveSchedule(ctx context.Context, userID string, schedule domain.Schedule) error
+}
+
+type scheduleService struct {
+	users     ScheduleUserRepo
+	schedules ScheduleRepo
+}
+
+func NewScheduleService(users ScheduleUserRepo, schedules ScheduleRepo) *scheduleService {
+	return &scheduleService{users: users, schedules: schedules}
+}
+
+func (s *scheduleService) Schedule(ctx context.Context, userID string) (domain.Schedule, error) {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return domain.Schedule{}, err
+	}
+	return s.schedules.ScheduleByUser(ctx, userID)
+}
+
+func (s *scheduleService) UpdateSchedule(ctx context.Context, userID string, schedule domain.Schedule) error {
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil || schedule.Timezone == "" {
+		return domain.ErrInvalidTimezone
+	}
+	if err := s.checkUser(ctx, userID); err != nil {
+		return err
+	}
+	return s.schedules.SaveSchedule(ctx, userID, schedule)
+}
+
+func (s *scheduleService) checkUser(ctx context.Context, userID string) error {
+	isExists, err := s.users.IsExists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isExists {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
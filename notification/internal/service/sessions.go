@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+)
+
+type SessionUserRepo interface {
+	GetByTelegramID(ctx context.Context, telegramID int64) (domain.User, error)
+}
+
+type SessionClient interface {
+	Sessions(ctx context.Context, userID string) ([]domain.Session, error)
+	TerminateSession(ctx context.Context, userID, sessionID string) error
+}
+
+type sessionService struct {
+	users SessionUserRepo
+	sso   SessionClient
+}
+
+// NewSessionService manages sso sessions of users from Telegram
+func NewSessionService(users SessionUserRepo, sso SessionClient) *sessionService {
+	return &sessionService{users: users, sso: sso}
+}
+
+// TelegramSessions returns active logins of user linked to the Telegram account, newest first
+func (s *sessionService) TelegramSessions(ctx context.Context, telegramID int64) ([]domain.Session, error) {
+	user, err := s.users.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	return s.sso.Sessions(ctx, user.ID)
+}
+
+// TerminateTelegramSession terminates the session only if it belongs to user linked to the Telegram account
+func (s *sessionService) TerminateTelegramSession(ctx context.Context, telegramID int64, sessionID string) error {
+	user, err := s.users.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return err
+	}
+	return s.sso.TerminateSession(ctx, user.ID, sessionID)
+}
@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_TelegramSessions(t *testing.T) {
+	sessions := []domain.Session{{ID: "session_id", IP: "127.0.0.1", Type: "google", CreatedAt: time.Now()}}
+
+	t.Run("success", func(t *testing.T) {
+		users := mocks.NewSessionUserRepo(t)
+		sso := mocks.NewSessionClient(t)
+		svc := service.NewSessionService(users, sso)
+		users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(domain.User{ID: "user_id"}, nil)
+		sso.EXPECT().Sessions(mock.Anything, "user_id").Return(sessions, nil)
+		got, err := svc.TelegramSessions(context.Background(), 123)
+		require.NoError(t, err)
+		assert.Equal(t, sessions, got)
+	})
+
+	t.Run("telegram is not linked", func(t *testing.T) {
+		users := mocks.NewSessionUserRepo(t)
+		svc := service.NewSessionService(users, mocks.NewSessionClient(t))
+		users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(domain.User{}, domain.ErrUserNotFound)
+		_, err := svc.TelegramSessions(context.Background(), 123)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestService_TerminateTelegramSession(t *testing.T) {
+	type MockBehavior func(users *mocks.SessionUserRepo, sso *mocks.SessionClient)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(users *mocks.SessionUserRepo, sso *mocks.SessionClient) {
+				users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(domain.User{ID: "user_id"}, nil)
+				sso.EXPECT().TerminateSession(mock.Anything, "user_id", "session_id").Return(nil)
+			},
+		},
+		{
+			name: "session not found",
+			mockBehavior: func(users *mocks.SessionUserRepo, sso *mocks.SessionClient) {
+				users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(domain.User{ID: "user_id"}, nil)
+				sso.EXPECT().TerminateSession(mock.Anything, "user_id", "session_id").Return(domain.ErrSessionNotFound)
+			},
+			want: domain.ErrSessionNotFound,
+		},
+		{
+			name: "telegram is not linked",
+			mockBehavior: func(users *mocks.SessionUserRepo, sso *mocks.SessionClient) {
+				users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(domain.User{}, domain.ErrUserNotFound)
+			},
+			want: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewSessionUserRepo(t)
+			sso := mocks.NewSessionClient(t)
+			svc := service.NewSessionService(users, sso)
+			tc.mockBehavior(users, sso)
+			err := svc.TerminateTelegramSession(context.Background(), 123, "session_id")
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
@@ -2,16 +2,22 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	"github.com/SergeyBogomolovv/profile-manager/common/logger"
 	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
 )
 
 type SetupTokenRepo interface {
 	Create(ctx context.Context, userID string) (string, error)
-	CheckUserID(ctx context.Context, token string) (string, error)
-	Revoke(ctx context.Context, token string) error
+	Consume(ctx context.Context, token string) (string, error)
 }
 
 type SetupUserRepo interface {
@@ -22,26 +28,45 @@ type SetupUserRepo interface {
 }
 
 type SetupSubsRepo interface {
+	SubscriptionsByUser(ctx context.Context, userID string) ([]domain.Subscription, error)
+	PreferencesByUser(ctx context.Context, userID string) (domain.Preferences, error)
+	SavePreference(ctx context.Context, userID string, channel domain.SubscriptionType, category domain.EventCategory, enabled bool) error
 	Save(ctx context.Context, userID string, subType domain.SubscriptionType) error
 	IsExists(ctx context.Context, userID string, subType domain.SubscriptionType) (bool, error)
 	Update(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool) error
 	Delete(ctx context.Context, userID string, subType domain.SubscriptionType) error
 }
 
+type SetupContactRepo interface {
+	SaveContact(ctx context.Context, userID string, contact domain.Contact) error
+	DeleteContact(ctx context.Context, userID string, channel domain.SubscriptionType) error
+}
+
+type SetupBroker interface {
+	PublishTelegramLinked(data events.TelegramLinked) error
+}
+
 type setupService struct {
 	txManager transaction.TxManager
 	users     SetupUserRepo
 	tokens    SetupTokenRepo
 	subs      SetupSubsRepo
+	contacts  SetupContactRepo
+	broker    SetupBroker
+	// Username of the bot used in deep links
+	botUsername string
 }
 
-func NewSetupService(txManager transaction.TxManager, users SetupUserRepo, tokens SetupTokenRepo, subs SetupSubsRepo) *setupService {
-	return &setupService{txManager: txManager, users: users, tokens: tokens, subs: subs}
+func NewSetupService(txManager transaction.TxManager, users SetupUserRepo, tokens SetupTokenRepo, subs SetupSubsRepo, contacts SetupContactRepo, broker SetupBroker, botUsername string) *setupService {
+	return &setupService{txManager: txManager, users: users, tokens: tokens, subs: subs, contacts: contacts, broker: broker, botUsername: botUsername}
 }
 
-func (s *setupService) LinkTelegram(ctx context.Context, token string, telegramID int64) error {
-	return s.txManager.Run(ctx, func(ctx context.Context) error {
-		userID, err := s.tokens.CheckUserID(ctx, token)
+// LinkTelegram uses Telegram language code as user's locale if it is still unknown.
+// Token is consumed even if linking fails, user has to generate a new one
+func (s *setupService) LinkTelegram(ctx context.Context, token string, account domain.TelegramAccount) error {
+	var userID string
+	err := s.txManager.Run(ctx, func(ctx context.Context) (err error) {
+		userID, err = s.tokens.Consume(ctx, token)
 		if err != nil {
 			return err
 		}
@@ -49,10 +74,13 @@ func (s *setupService) LinkTelegram(ctx context.Context, token string, telegramI
 		if err != nil {
 			return err
 		}
-		if user.TelegramID == telegramID {
+		if user.TelegramID == account.ID {
 			return domain.ErrActionDontNeeded
 		}
-		user.TelegramID = telegramID
+		user.TelegramID = account.ID
+		if user.Locale == "" {
+			user.Locale = i18n.Match(account.LanguageCode)
+		}
 		if err := s.users.Update(ctx, user); err != nil {
 			return err
 		}
@@ -60,13 +88,26 @@ func (s *setupService) LinkTelegram(ctx context.Context, token string, telegramI
 		if err != nil {
 			return err
 		}
-		if !subExists {
-			if err := s.subs.Save(ctx, userID, domain.SubscriptionTypeTelegram); err != nil {
-				return err
-			}
+		if subExists {
+			return nil
 		}
-		return s.tokens.Revoke(ctx, token)
+		return s.subs.Save(ctx, userID, domain.SubscriptionTypeTelegram)
+	})
+	if err != nil {
+		return err
+	}
+	// Confirmation is published after commit, so it is never sent for rolled back link.
+	// Account is linked at this point, so failed publishing is not an error of linking
+	err = s.broker.PublishTelegramLinked(events.TelegramLinked{
+		ID:         userID,
+		TelegramID: account.ID,
+		Username:   account.Username,
+		Time:       time.Now(),
 	})
+	if err != nil {
+		logger.Extract(ctx).Error("failed to publish telegram linked", "user_id", userID, "error", err)
+	}
+	return nil
 }
 
 func (s *setupService) UnlinkTelegram(ctx context.Context, telegramID int64) error {
@@ -78,14 +119,62 @@ func (s *setupService) UnlinkTelegram(ctx context.Context, telegramID int64) err
 		if err != nil {
 			return err
 		}
-		if err := s.subs.Delete(ctx, user.ID, domain.SubscriptionTypeTelegram); err != nil {
+		return s.unlinkTelegram(ctx, user)
+	})
+}
+
+// UnlinkUserTelegram unlinks telegram account of the user, used from web clients
+func (s *setupService) UnlinkUserTelegram(ctx context.Context, userID string) error {
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		user, err := s.users.GetByID(ctx, userID)
+		if err != nil {
 			return err
 		}
-		user.TelegramID = 0
-		return s.users.Update(ctx, user)
+		if user.TelegramID == 0 {
+			return domain.ErrActionDontNeeded
+		}
+		return s.unlinkTelegram(ctx, user)
 	})
 }
 
+func (s *setupService) unlinkTelegram(ctx context.Context, user domain.User) error {
+	if err := s.subs.Delete(ctx, user.ID, domain.SubscriptionTypeTelegram); err != nil {
+		return err
+	}
+	user.TelegramID = 0
+	return s.users.Update(ctx, user)
+}
+
+func (s *setupService) ListSubscriptions(ctx context.Context, userID string) ([]domain.Subscription, error) {
+	isExists, err := s.users.IsExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isExists {
+		return nil, domain.ErrUserNotFound
+	}
+	return s.subs.SubscriptionsByUser(ctx, userID)
+}
+
+// UpdateSubscription enables or disables existing subscription, telegram subscription exists only after linking
+func (s *setupService) UpdateSubscription(ctx context.Context, userID string, subType domain.SubscriptionType, enabled bool) error {
+	isExists, err := s.users.IsExists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isExists {
+		return domain.ErrUserNotFound
+	}
+	subExists, err := s.subs.IsExists(ctx, userID, subType)
+	if err != nil {
+		return err
+	}
+	if !subExists {
+		return domain.ErrSubscriptionNotFound
+	}
+	return s.subs.Update(ctx, userID, subType, enabled)
+}
+
 func (s *setupService) UpdateSubscriptionStatus(ctx context.Context, telegramID int64, subType domain.SubscriptionType, enabled bool) error {
 	user, err := s.users.GetByTelegramID(ctx, telegramID)
 	if err != nil {
@@ -94,13 +183,141 @@ func (s *setupService) UpdateSubscriptionStatus(ctx context.Context, telegramID
 	return s.subs.Update(ctx, user.ID, subType, enabled)
 }
 
-func (s *setupService) GenerateToken(ctx context.Context, userID string) (string, error) {
+// GenerateToken revokes previous token of the user, returns domain.ErrTooManyTokens if limit is exceeded
+func (s *setupService) GenerateToken(ctx context.Context, userID string) (domain.TelegramLink, error) {
 	isExists, err := s.users.IsExists(ctx, userID)
 	if err != nil {
-		return "", err
+		return domain.TelegramLink{}, err
+	}
+	if !isExists {
+		return domain.TelegramLink{}, domain.ErrUserNotFound
+	}
+	token, err := s.tokens.Create(ctx, userID)
+	if err != nil {
+		return domain.TelegramLink{}, err
+	}
+	return domain.TelegramLink{
+		Token: token,
+		Link:  fmt.Sprintf("https://t.me/%s?start=%s", s.botUsername, token),
+	}, nil
+}
+
+func (s *setupService) Preferences(ctx context.Context, userID string) (domain.Preferences, error) {
+	isExists, err := s.users.IsExists(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 	if !isExists {
-		return "", domain.ErrUserNotFound
+		return nil, domain.ErrUserNotFound
+	}
+	return s.subs.PreferencesByUser(ctx, userID)
+}
+
+func (s *setupService) UpdatePreference(ctx context.Context, userID string, channel domain.SubscriptionType, category domain.EventCategory, enabled bool) (domain.Preferences, error) {
+	isExists, err := s.users.IsExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isExists {
+		return nil, domain.ErrUserNotFound
+	}
+	if err := s.subs.SavePreference(ctx, userID, channel, category, enabled); err != nil {
+		return nil, err
+	}
+	return s.subs.PreferencesByUser(ctx, userID)
+}
+
+func (s *setupService) TelegramPreferences(ctx context.Context, telegramID int64) (domain.Preferences, error) {
+	user, err := s.users.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	return s.subs.PreferencesByUser(ctx, user.ID)
+}
+
+// TelegramStatus returns user linked to the Telegram account with all subscriptions
+func (s *setupService) TelegramStatus(ctx context.Context, telegramID int64) (domain.User, []domain.Subscription, error) {
+	user, err := s.users.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return domain.User{}, nil, err
+	}
+	subs, err := s.subs.SubscriptionsByUser(ctx, user.ID)
+	if err != nil {
+		return domain.User{}, nil, err
+	}
+	return user, subs, nil
+}
+
+// ToggleTelegramPreference switches category for telegram channel, returns new value
+func (s *setupService) ToggleTelegramPreference(ctx context.Context, telegramID int64, category domain.EventCategory) (bool, error) {
+	user, err := s.users.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		return false, err
+	}
+	prefs, err := s.subs.PreferencesByUser(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	enabled := !prefs.Enabled(domain.SubscriptionTypeTelegram, category)
+	if err := s.subs.SavePreference(ctx, user.ID, domain.SubscriptionTypeTelegram, category, enabled); err != nil {
+		return false, err
+	}
+	return enabled, nil
+}
+
+// SetContact saves address of user in the channel and subscribes user to it.
+// Webhook gets new signing secret, it is returned only here
+func (s *setupService) SetContact(ctx context.Context, userID string, contact domain.Contact) (domain.Contact, error) {
+	contact.Secret = ""
+	if contact.Channel == domain.SubscriptionTypeWebhook {
+		secret, err := generateSecret()
+		if err != nil {
+			return domain.Contact{}, err
+		}
+		contact.Secret = secret
+	}
+	err := s.txManager.Run(ctx, func(ctx context.Context) error {
+		isExists, err := s.users.IsExists(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !isExists {
+			return domain.ErrUserNotFound
+		}
+		if err := s.contacts.SaveContact(ctx, userID, contact); err != nil {
+			return err
+		}
+		subExists, err := s.subs.IsExists(ctx, userID, contact.Channel)
+		if err != nil {
+			return err
+		}
+		if subExists {
+			return nil
+		}
+		return s.subs.Save(ctx, userID, contact.Channel)
+	})
+	if err != nil {
+		return domain.Contact{}, err
+	}
+	return contact, nil
+}
+
+// DeleteContact removes address of user in the channel, subscription is removed with it
+func (s *setupService) DeleteContact(ctx context.Context, userID string, channel domain.SubscriptionType) error {
+	isExists, err := s.users.IsExists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isExists {
+		return domain.ErrUserNotFound
+	}
+	return s.contacts.DeleteContact(ctx, userID, channel)
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-	return s.tokens.Create(ctx, userID)
+	return hex.EncodeToString(b), nil
 }
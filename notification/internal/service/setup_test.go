@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
 	txMocks "github.com/SergeyBogomolovv/profile-manager/common/transaction/mocks"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/service"
@@ -16,14 +17,17 @@ import (
 
 func TestService_VerifyTelegram(t *testing.T) {
 	type args struct {
-		token      string
-		telegramID int64
+		token        string
+		telegramID   int64
+		languageCode string
+		username     string
 	}
 	type MockBehavior func(
 		tx *txMocks.TxManager,
 		users *mocks.SetupUserRepo,
 		subs *mocks.SetupSubsRepo,
 		tokens *mocks.SetupTokenRepo,
+		broker *mocks.SetupBroker,
 		args args,
 	)
 
@@ -36,43 +40,93 @@ func TestService_VerifyTelegram(t *testing.T) {
 		{
 			name: "need to create subscription",
 			args: args{
-				token:      "token",
-				telegramID: 123,
+				token:        "token",
+				telegramID:   123,
+				languageCode: "en-US",
+				username:     "user",
 			},
 			want: nil,
-			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, args args) {
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, broker *mocks.SetupBroker, args args) {
 				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
 					func(ctx context.Context, f func(context.Context) error) error {
 						return f(ctx)
 					},
 				)
-				tokens.EXPECT().CheckUserID(mock.Anything, args.token).Return("user_id", nil)
+				tokens.EXPECT().Consume(mock.Anything, args.token).Return("user_id", nil)
 				users.EXPECT().GetByID(mock.Anything, "user_id").Return(domain.User{ID: "user_id"}, nil)
-				users.EXPECT().Update(mock.Anything, domain.User{ID: "user_id", TelegramID: 123}).Return(nil)
+				users.EXPECT().Update(mock.Anything, domain.User{ID: "user_id", TelegramID: 123, Locale: "en"}).Return(nil)
 				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeTelegram).Return(false, nil)
 				subs.EXPECT().Save(mock.Anything, "user_id", domain.SubscriptionTypeTelegram).Return(nil)
-				tokens.EXPECT().Revoke(mock.Anything, args.token).Return(nil)
+				broker.EXPECT().PublishTelegramLinked(mock.MatchedBy(func(data events.TelegramLinked) bool {
+					return data.ID == "user_id" && data.TelegramID == args.telegramID && data.Username == args.username && !data.Time.IsZero()
+				})).Return(nil)
 			},
 		},
 		{
 			name: "subscription exists",
+			args: args{
+				token:        "token",
+				telegramID:   123,
+				languageCode: "en",
+			},
+			want: nil,
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, broker *mocks.SetupBroker, args args) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				tokens.EXPECT().Consume(mock.Anything, args.token).Return("user_id", nil)
+				users.EXPECT().GetByID(mock.Anything, "user_id").Return(domain.User{ID: "user_id", Locale: "ru"}, nil)
+				users.EXPECT().Update(mock.Anything, domain.User{ID: "user_id", TelegramID: 123, Locale: "ru"}).Return(nil)
+				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeTelegram).Return(true, nil)
+				broker.EXPECT().PublishTelegramLinked(mock.MatchedBy(func(data events.TelegramLinked) bool {
+					return data.ID == "user_id" && data.TelegramID == args.telegramID && data.Username == args.username && !data.Time.IsZero()
+				})).Return(nil)
+			},
+		},
+		{
+			name: "commit failed",
 			args: args{
 				token:      "token",
 				telegramID: 123,
 			},
-			want: nil,
-			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, args args) {
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, broker *mocks.SetupBroker, args args) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						if err := f(ctx); err != nil {
+							return err
+						}
+						return assert.AnError
+					},
+				)
+				tokens.EXPECT().Consume(mock.Anything, args.token).Return("user_id", nil)
+				users.EXPECT().GetByID(mock.Anything, "user_id").Return(domain.User{ID: "user_id", Locale: "ru"}, nil)
+				users.EXPECT().Update(mock.Anything, domain.User{ID: "user_id", TelegramID: 123, Locale: "ru"}).Return(nil)
+				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeTelegram).Return(true, nil)
+				// Confirmation is not published for rolled back link
+			},
+			want: assert.AnError,
+		},
+		{
+			name: "failed to publish confirmation",
+			args: args{
+				token:      "token",
+				telegramID: 123,
+			},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, broker *mocks.SetupBroker, args args) {
 				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
 					func(ctx context.Context, f func(context.Context) error) error {
 						return f(ctx)
 					},
 				)
-				tokens.EXPECT().CheckUserID(mock.Anything, args.token).Return("user_id", nil)
-				users.EXPECT().GetByID(mock.Anything, "user_id").Return(domain.User{ID: "user_id"}, nil)
-				users.EXPECT().Update(mock.Anything, domain.User{ID: "user_id", TelegramID: 123}).Return(nil)
+				tokens.EXPECT().Consume(mock.Anything, args.token).Return("user_id", nil)
+				users.EXPECT().GetByID(mock.Anything, "user_id").Return(domain.User{ID: "user_id", Locale: "ru"}, nil)
+				users.EXPECT().Update(mock.Anything, domain.User{ID: "user_id", TelegramID: 123, Locale: "ru"}).Return(nil)
 				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeTelegram).Return(true, nil)
-				tokens.EXPECT().Revoke(mock.Anything, args.token).Return(nil)
+				broker.EXPECT().PublishTelegramLinked(mock.Anything).Return(assert.AnError)
 			},
+			want: nil,
 		},
 		{
 			name: "invalid token",
@@ -80,13 +134,13 @@ func TestService_VerifyTelegram(t *testing.T) {
 				token:      "token",
 				telegramID: 123,
 			},
-			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, args args) {
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, tokens *mocks.SetupTokenRepo, broker *mocks.SetupBroker, args args) {
 				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
 					func(ctx context.Context, f func(context.Context) error) error {
 						return f(ctx)
 					},
 				)
-				tokens.EXPECT().CheckUserID(mock.Anything, args.token).Return("", domain.ErrInvalidToken)
+				tokens.EXPECT().Consume(mock.Anything, args.token).Return("", domain.ErrInvalidToken)
 			},
 			want: domain.ErrInvalidToken,
 		},
@@ -98,9 +152,11 @@ func TestService_VerifyTelegram(t *testing.T) {
 			users := mocks.NewSetupUserRepo(t)
 			subs := mocks.NewSetupSubsRepo(t)
 			tokens := mocks.NewSetupTokenRepo(t)
-			svc := service.NewSetupService(tx, users, tokens, subs)
-			tc.mockBehavior(tx, users, subs, tokens, tc.args)
-			err := svc.LinkTelegram(context.Background(), tc.args.token, tc.args.telegramID)
+			broker := mocks.NewSetupBroker(t)
+			svc := service.NewSetupService(tx, users, tokens, subs, mocks.NewSetupContactRepo(t), broker, "profile_bot")
+			tc.mockBehavior(tx, users, subs, tokens, broker, tc.args)
+			account := domain.TelegramAccount{ID: tc.args.telegramID, Username: tc.args.username, LanguageCode: tc.args.languageCode}
+			err := svc.LinkTelegram(context.Background(), tc.args.token, account)
 			assert.ErrorIs(t, err, tc.want)
 		})
 	}
@@ -117,7 +173,7 @@ func TestService_GenerateToken(t *testing.T) {
 		name         string
 		userID       string
 		mockBehavior MockBehavior
-		want         string
+		want         domain.TelegramLink
 		wantErr      error
 	}{
 		{
@@ -127,16 +183,24 @@ func TestService_GenerateToken(t *testing.T) {
 				users.EXPECT().IsExists(mock.Anything, userID).Return(true, nil)
 				tokens.EXPECT().Create(mock.Anything, userID).Return("token", nil)
 			},
-			want:    "token",
+			want:    domain.TelegramLink{Token: "token", Link: "https://t.me/profile_bot?start=token"},
 			wantErr: nil,
 		},
+		{
+			name:   "too many tokens",
+			userID: uuid.NewString(),
+			mockBehavior: func(users *mocks.SetupUserRepo, tokens *mocks.SetupTokenRepo, userID string) {
+				users.EXPECT().IsExists(mock.Anything, userID).Return(true, nil)
+				tokens.EXPECT().Create(mock.Anything, userID).Return("", domain.ErrTooManyTokens)
+			},
+			wantErr: domain.ErrTooManyTokens,
+		},
 		{
 			name:   "user not found",
 			userID: uuid.NewString(),
 			mockBehavior: func(users *mocks.SetupUserRepo, tokens *mocks.SetupTokenRepo, userID string) {
 				users.EXPECT().IsExists(mock.Anything, userID).Return(false, nil)
 			},
-			want:    "",
 			wantErr: domain.ErrUserNotFound,
 		},
 	}
@@ -147,15 +211,326 @@ func TestService_GenerateToken(t *testing.T) {
 			users := mocks.NewSetupUserRepo(t)
 			subs := mocks.NewSetupSubsRepo(t)
 			tokens := mocks.NewSetupTokenRepo(t)
-			svc := service.NewSetupService(tx, users, tokens, subs)
+			svc := service.NewSetupService(tx, users, tokens, subs, mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
 			tc.mockBehavior(users, tokens, tc.userID)
-			token, err := svc.GenerateToken(context.Background(), tc.userID)
+			link, err := svc.GenerateToken(context.Background(), tc.userID)
 			if tc.wantErr != nil {
 				assert.ErrorIs(t, err, tc.wantErr)
 				return
 			}
 			require.NoError(t, err)
-			assert.Equal(t, token, tc.want)
+			assert.Equal(t, tc.want, link)
+		})
+	}
+}
+
+func TestService_UpdateSubscription(t *testing.T) {
+	type args struct {
+		userID  string
+		subType domain.SubscriptionType
+		enabled bool
+	}
+	type MockBehavior func(users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "success",
+			args: args{userID: "user_id", subType: domain.SubscriptionTypeEmail, enabled: false},
+			mockBehavior: func(users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, args args) {
+				users.EXPECT().IsExists(mock.Anything, args.userID).Return(true, nil)
+				subs.EXPECT().IsExists(mock.Anything, args.userID, args.subType).Return(true, nil)
+				subs.EXPECT().Update(mock.Anything, args.userID, args.subType, args.enabled).Return(nil)
+			},
+		},
+		{
+			name: "telegram not linked",
+			args: args{userID: "user_id", subType: domain.SubscriptionTypeTelegram, enabled: true},
+			mockBehavior: func(users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, args args) {
+				users.EXPECT().IsExists(mock.Anything, args.userID).Return(true, nil)
+				subs.EXPECT().IsExists(mock.Anything, args.userID, args.subType).Return(false, nil)
+			},
+			want: domain.ErrSubscriptionNotFound,
+		},
+		{
+			name: "user not found",
+			args: args{userID: "user_id", subType: domain.SubscriptionTypeEmail, enabled: true},
+			mockBehavior: func(users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, args args) {
+				users.EXPECT().IsExists(mock.Anything, args.userID).Return(false, nil)
+			},
+			want: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewSetupUserRepo(t)
+			subs := mocks.NewSetupSubsRepo(t)
+			tokens := mocks.NewSetupTokenRepo(t)
+			svc := service.NewSetupService(tx, users, tokens, subs, mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+			tc.mockBehavior(users, subs, tc.args)
+			err := svc.UpdateSubscription(context.Background(), tc.args.userID, tc.args.subType, tc.args.enabled)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestService_ListSubscriptions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		subs := mocks.NewSetupSubsRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), subs, mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+		want := []domain.Subscription{{User: domain.User{ID: "user_id"}, Type: domain.SubscriptionTypeEmail, Enabled: true}}
+		users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+		subs.EXPECT().SubscriptionsByUser(mock.Anything, "user_id").Return(want, nil)
+		got, err := svc.ListSubscriptions(context.Background(), "user_id")
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), mocks.NewSetupSubsRepo(t), mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+		users.EXPECT().IsExists(mock.Anything, "user_id").Return(false, nil)
+		_, err := svc.ListSubscriptions(context.Background(), "user_id")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestService_UnlinkUserTelegram(t *testing.T) {
+	type MockBehavior func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, userID string)
+
+	testCases := []struct {
+		name         string
+		userID       string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name:   "success",
+			userID: "user_id",
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, userID string) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID, TelegramID: 123}, nil)
+				subs.EXPECT().Delete(mock.Anything, userID, domain.SubscriptionTypeTelegram).Return(nil)
+				users.EXPECT().Update(mock.Anything, domain.User{ID: userID}).Return(nil)
+			},
+		},
+		{
+			name:   "not linked",
+			userID: "user_id",
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, userID string) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+			},
+			want: domain.ErrActionDontNeeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewSetupUserRepo(t)
+			subs := mocks.NewSetupSubsRepo(t)
+			tokens := mocks.NewSetupTokenRepo(t)
+			svc := service.NewSetupService(tx, users, tokens, subs, mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+			tc.mockBehavior(tx, users, subs, tc.userID)
+			err := svc.UnlinkUserTelegram(context.Background(), tc.userID)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestService_UpdatePreference(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		subs := mocks.NewSetupSubsRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), subs, mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+		want := domain.Preferences{domain.SubscriptionTypeEmail: {domain.EventCategoryMarketing: true}}
+		users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+		subs.EXPECT().SavePreference(mock.Anything, "user_id", domain.SubscriptionTypeEmail, domain.EventCategoryMarketing, true).Return(nil)
+		subs.EXPECT().PreferencesByUser(mock.Anything, "user_id").Return(want, nil)
+		got, err := svc.UpdatePreference(context.Background(), "user_id", domain.SubscriptionTypeEmail, domain.EventCategoryMarketing, true)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), mocks.NewSetupSubsRepo(t), mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+		users.EXPECT().IsExists(mock.Anything, "user_id").Return(false, nil)
+		_, err := svc.UpdatePreference(context.Background(), "user_id", domain.SubscriptionTypeEmail, domain.EventCategoryLogin, false)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestService_ToggleTelegramPreference(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefs    domain.Preferences
+		category domain.EventCategory
+		want     bool
+	}{
+		{
+			name:     "disable default",
+			prefs:    domain.Preferences{},
+			category: domain.EventCategoryLogin,
+			want:     false,
+		},
+		{
+			name:     "enable marketing",
+			prefs:    domain.Preferences{},
+			category: domain.EventCategoryMarketing,
+			want:     true,
+		},
+		{
+			name:     "enable disabled",
+			prefs:    domain.Preferences{domain.SubscriptionTypeTelegram: {domain.EventCategorySecurity: false}},
+			category: domain.EventCategorySecurity,
+			want:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewSetupUserRepo(t)
+			subs := mocks.NewSetupSubsRepo(t)
+			svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), subs, mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+			users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(domain.User{ID: "user_id", TelegramID: 123}, nil)
+			subs.EXPECT().PreferencesByUser(mock.Anything, "user_id").Return(tc.prefs, nil)
+			subs.EXPECT().SavePreference(mock.Anything, "user_id", domain.SubscriptionTypeTelegram, tc.category, tc.want).Return(nil)
+			got, err := svc.ToggleTelegramPreference(context.Background(), 123, tc.category)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestService_TelegramStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		subs := mocks.NewSetupSubsRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), subs, mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+		user := domain.User{ID: "user_id", Email: "user@example.com", TelegramID: 123}
+		subscriptions := []domain.Subscription{{User: user, Type: domain.SubscriptionTypeTelegram, Enabled: true}}
+		users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(user, nil)
+		subs.EXPECT().SubscriptionsByUser(mock.Anything, "user_id").Return(subscriptions, nil)
+		gotUser, gotSubs, err := svc.TelegramStatus(context.Background(), 123)
+		require.NoError(t, err)
+		assert.Equal(t, user, gotUser)
+		assert.Equal(t, subscriptions, gotSubs)
+	})
+
+	t.Run("telegram is not linked", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), mocks.NewSetupSubsRepo(t), mocks.NewSetupContactRepo(t), mocks.NewSetupBroker(t), "profile_bot")
+		users.EXPECT().GetByTelegramID(mock.Anything, int64(123)).Return(domain.User{}, domain.ErrUserNotFound)
+		_, _, err := svc.TelegramStatus(context.Background(), 123)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestService_SetContact(t *testing.T) {
+	type MockBehavior func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, contacts *mocks.SetupContactRepo, contact domain.Contact)
+
+	testCases := []struct {
+		name         string
+		contact      domain.Contact
+		mockBehavior MockBehavior
+		wantSecret   bool
+		want         error
+	}{
+		{
+			name:    "webhook",
+			contact: domain.Contact{Channel: domain.SubscriptionTypeWebhook, Address: "https://example.com/hook"},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, contacts *mocks.SetupContactRepo, contact domain.Contact) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+				contacts.EXPECT().SaveContact(mock.Anything, "user_id", mock.MatchedBy(func(c domain.Contact) bool {
+					return c.Address == contact.Address && len(c.Secret) == 64
+				})).Return(nil)
+				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeWebhook).Return(false, nil)
+				subs.EXPECT().Save(mock.Anything, "user_id", domain.SubscriptionTypeWebhook).Return(nil)
+			},
+			wantSecret: true,
+		},
+		{
+			name:    "sms subscription exists",
+			contact: domain.Contact{Channel: domain.SubscriptionTypeSMS, Address: "+79990000000", Secret: "ignored"},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, contacts *mocks.SetupContactRepo, contact domain.Contact) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+				contacts.EXPECT().SaveContact(mock.Anything, "user_id", domain.Contact{Channel: domain.SubscriptionTypeSMS, Address: contact.Address}).Return(nil)
+				subs.EXPECT().IsExists(mock.Anything, "user_id", domain.SubscriptionTypeSMS).Return(true, nil)
+			},
+		},
+		{
+			name:    "user not found",
+			contact: domain.Contact{Channel: domain.SubscriptionTypeSMS, Address: "+79990000000"},
+			mockBehavior: func(tx *txMocks.TxManager, users *mocks.SetupUserRepo, subs *mocks.SetupSubsRepo, contacts *mocks.SetupContactRepo, contact domain.Contact) {
+				tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+					func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					},
+				)
+				users.EXPECT().IsExists(mock.Anything, "user_id").Return(false, nil)
+			},
+			want: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			users := mocks.NewSetupUserRepo(t)
+			subs := mocks.NewSetupSubsRepo(t)
+			contacts := mocks.NewSetupContactRepo(t)
+			svc := service.NewSetupService(tx, users, mocks.NewSetupTokenRepo(t), subs, contacts, mocks.NewSetupBroker(t), "profile_bot")
+			tc.mockBehavior(tx, users, subs, contacts, tc.contact)
+			got, err := svc.SetContact(context.Background(), "user_id", tc.contact)
+			assert.ErrorIs(t, err, tc.want)
+			assert.Equal(t, tc.wantSecret, got.Secret != "")
 		})
 	}
 }
+
+func TestService_DeleteContact(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		contacts := mocks.NewSetupContactRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), mocks.NewSetupSubsRepo(t), contacts, mocks.NewSetupBroker(t), "profile_bot")
+		users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+		contacts.EXPECT().DeleteContact(mock.Anything, "user_id", domain.SubscriptionTypeWebhook).Return(nil)
+		assert.NoError(t, svc.DeleteContact(context.Background(), "user_id", domain.SubscriptionTypeWebhook))
+	})
+
+	t.Run("contact not found", func(t *testing.T) {
+		users := mocks.NewSetupUserRepo(t)
+		contacts := mocks.NewSetupContactRepo(t)
+		svc := service.NewSetupService(txMocks.NewTxManager(t), users, mocks.NewSetupTokenRepo(t), mocks.NewSetupSubsRepo(t), contacts, mocks.NewSetupBroker(t), "profile_bot")
+		users.EXPECT().IsExists(mock.Anything, "user_id").Return(true, nil)
+		contacts.EXPECT().DeleteContact(mock.Anything, "user_id", domain.SubscriptionTypeSMS).Return(domain.ErrContactNotFound)
+		assert.ErrorIs(t, svc.DeleteContact(context.Background(), "user_id", domain.SubscriptionTypeSMS), domain.ErrContactNotFound)
+	})
+}
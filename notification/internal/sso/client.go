@@ -0,0 +1,72 @@
+package sso
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Service token is signed for every call, so it is short lived
+const serviceTokenTTL = time.Minute
+
+type client struct {
+	api    pb.SSOClient
+	secret []byte
+}
+
+// NewClient manages sessions of users in sso, calls are authenticated by service token signed with secret
+func NewClient(api pb.SSOClient, secret []byte) *client {
+	return &client{api: api, secret: secret}
+}
+
+func (c *client) Sessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	ctx, err := c.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.api.ListSessions(ctx, &pb.ListSessionsRequest{UserId: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	sessions := make([]domain.Session, len(resp.Sessions))
+	for i, s := range resp.Sessions {
+		sessions[i] = domain.Session{
+			ID:        s.Id,
+			IP:        s.Ip,
+			Type:      s.Type,
+			CreatedAt: time.Unix(s.CreatedAt, 0),
+		}
+	}
+	return sessions, nil
+}
+
+func (c *client) TerminateSession(ctx context.Context, userID, sessionID string) error {
+	ctx, err := c.authorize(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = c.api.TerminateSession(ctx, &pb.TerminateSessionRequest{UserId: userID, SessionId: sessionID})
+	if status.Code(err) == codes.NotFound {
+		return domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to terminate session: %w", err)
+	}
+	return nil
+}
+
+// authorize adds service token which lets bot manage sessions of users who linked telegram
+func (c *client) authorize(ctx context.Context) (context.Context, error) {
+	token, err := auth.SignJWT("", nil, []string{auth.PermissionManageSessions}, c.secret, serviceTokenTTL, "notification")
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign service token: %w", err)
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+}
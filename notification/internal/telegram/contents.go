@@ -1,20 +1,134 @@
 package telegram
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
 	tele "gopkg.in/telebot.v4"
 )
 
+// Reply keyboards of old bot versions may still be shown in chats
+var clearMenu = &tele.ReplyMarkup{
+	RemoveKeyboard: true,
+}
+
+// Inline buttons, payload of callback is joined with "|"
+var (
+	// Channel and "1" or "0", view to refresh after the change
+	btnSubscription = &tele.Btn{Unique: "sub"}
+	// Category to toggle in telegram channel
+	btnPreference = &tele.Btn{Unique: "pref"}
+	// Session ID
+	btnTerminate = &tele.Btn{Unique: "term"}
+)
+
+// Views which are re-rendered after pressing their buttons
 const (
-	AccountTypeTelegram = "Телеграм"
-	AccountTypeEmail    = "Почта"
+	viewNone   = ""
+	viewStatus = "status"
 )
 
-var clearMenu = &tele.ReplyMarkup{
-	RemoveKeyboard: true,
+// Names of subscription channels shown in bot
+var channelNames = map[domain.SubscriptionType]i18n.Key{
+	domain.SubscriptionTypeEmail:    i18n.ChannelEmail,
+	domain.SubscriptionTypeTelegram: i18n.ChannelTelegram,
+	domain.SubscriptionTypeWebhook:  i18n.ChannelWebhook,
+	domain.SubscriptionTypeSMS:      i18n.ChannelSMS,
+	domain.SubscriptionTypeWebPush:  i18n.ChannelWebPush,
+}
+
+// Channels which can be enabled from bot without setting a contact
+var botChannels = []domain.SubscriptionType{domain.SubscriptionTypeEmail, domain.SubscriptionTypeTelegram}
+
+func channelMenu(locale string, enabled bool) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	row := make(tele.Row, len(botChannels))
+	for i, channel := range botChannels {
+		row[i] = menu.Data(i18n.T(locale, channelNames[channel]), btnSubscription.Unique, string(channel), flag(enabled), viewNone)
+	}
+	menu.Inline(row)
+	return menu
+}
+
+// Names of event categories shown in bot
+var categoryNames = map[domain.EventCategory]i18n.Key{
+	domain.EventCategoryLogin:     i18n.CategoryLogin,
+	domain.EventCategorySecurity:  i18n.CategorySecurity,
+	domain.EventCategoryAccount:   i18n.CategoryAccount,
+	domain.EventCategoryMarketing: i18n.CategoryMarketing,
+}
+
+// Every button shows state of the category and toggles it
+func preferencesMenu(locale string, prefs domain.Preferences) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	btns := make([]tele.Btn, len(domain.EventCategories))
+	for i, category := range domain.EventCategories {
+		text := mark(prefs.Enabled(domain.SubscriptionTypeTelegram, category)) + " " + i18n.T(locale, categoryNames[category])
+		btns[i] = menu.Data(text, btnPreference.Unique, string(category))
+	}
+	menu.Inline(menu.Split(2, btns)...)
+	return menu
+}
+
+func preferencesMessage(locale string) string {
+	return i18n.T(locale, i18n.BotPreferencesTitle) + "\n" + i18n.T(locale, i18n.BotChooseCategory)
+}
+
+func statusMessage(locale string, user domain.User, subs []domain.Subscription) string {
+	msg := i18n.T(locale, i18n.BotStatusAccount, user.Email)
+	if len(subs) == 0 {
+		return msg + "\n\n" + i18n.T(locale, i18n.BotStatusNoChannels)
+	}
+	return msg + "\n\n" + i18n.T(locale, i18n.BotStatusChannels)
+}
+
+// Every button shows state of the subscription and toggles it
+func statusMenu(locale string, subs []domain.Subscription) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	btns := make([]tele.Btn, len(subs))
+	for i, sub := range subs {
+		text := mark(sub.Enabled) + " " + i18n.T(locale, channelNames[sub.Type])
+		btns[i] = menu.Data(text, btnSubscription.Unique, string(sub.Type), flag(!sub.Enabled), viewStatus)
+	}
+	menu.Inline(menu.Split(2, btns)...)
+	return menu
+}
+
+func sessionsMessage(locale string, sessions []domain.Session) string {
+	if len(sessions) == 0 {
+		return i18n.T(locale, i18n.BotNoSessions)
+	}
+	var b strings.Builder
+	b.WriteString(i18n.T(locale, i18n.BotSessionsTitle))
+	for i, s := range sessions {
+		fmt.Fprintf(&b, "\n%s", i18n.T(locale, i18n.BotSession, i+1, s.CreatedAt.UTC().Format("2006-01-02 15:04"), s.IP, s.Type))
+	}
+	return b.String()
+}
+
+// Buttons are numbered as sessions in message
+func sessionsMenu(locale string, sessions []domain.Session) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	btns := make([]tele.Btn, len(sessions))
+	for i, s := range sessions {
+		btns[i] = menu.Data(i18n.T(locale, i18n.BotTerminateSession, i+1), btnTerminate.Unique, s.ID)
+	}
+	menu.Inline(menu.Split(3, btns)...)
+	return menu
+}
+
+func mark(enabled bool) string {
+	if enabled {
+		return "✅"
+	}
+	return "❌"
 }
 
-var accountTypeMenu = &tele.ReplyMarkup{
-	ReplyKeyboard: [][]tele.ReplyButton{
-		{{Text: AccountTypeEmail}, {Text: AccountTypeTelegram}},
-	},
+func flag(enabled bool) string {
+	if enabled {
+		return "1"
+	}
+	return "0"
 }
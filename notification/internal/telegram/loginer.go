@@ -4,27 +4,40 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/logger"
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
 	tele "gopkg.in/telebot.v4"
 )
 
 type SetupService interface {
-	LinkTelegram(ctx context.Context, token string, telegramID int64) error
+	LinkTelegram(ctx context.Context, token string, account domain.TelegramAccount) error
 	UnlinkTelegram(ctx context.Context, telegramID int64) error
 	UpdateSubscriptionStatus(ctx context.Context, telegramID int64, subType domain.SubscriptionType, enabled bool) error
+	TelegramPreferences(ctx context.Context, telegramID int64) (domain.Preferences, error)
+	ToggleTelegramPreference(ctx context.Context, telegramID int64, category domain.EventCategory) (bool, error)
+	TelegramStatus(ctx context.Context, telegramID int64) (domain.User, []domain.Subscription, error)
+}
+
+type SessionService interface {
+	TelegramSessions(ctx context.Context, telegramID int64) ([]domain.Session, error)
+	TerminateTelegramSession(ctx context.Context, telegramID int64, sessionID string) error
 }
 
 type loginer struct {
-	logger  *slog.Logger
-	bot     *tele.Bot
-	service SetupService
-	state   *state
+	logger *slog.Logger
+	bot    *tele.Bot
+	// Messages which are not replies to user are sent through queue
+	queue    *Queue
+	service  SetupService
+	sessions SessionService
+	states   StateStore
 }
 
-func NewLoginer(logger *slog.Logger, bot *tele.Bot, service SetupService) *loginer {
-	return &loginer{logger: logger, bot: bot, service: service, state: NewState()}
+func NewLoginer(logger *slog.Logger, bot *tele.Bot, queue *Queue, service SetupService, sessions SessionService, states StateStore) *loginer {
+	return &loginer{logger: logger, bot: bot, queue: queue, service: service, sessions: sessions, states: states}
 }
 
 func (l *loginer) Init() {
@@ -34,141 +47,323 @@ func (l *loginer) Init() {
 	l.bot.Handle("/cancel", l.handleCancel)
 	l.bot.Handle("/enable", l.startEnableNotifications)
 	l.bot.Handle("/disable", l.startDisableNotifications)
+	l.bot.Handle("/preferences", l.startPreferences)
+	l.bot.Handle("/status", l.handleStatus)
+	l.bot.Handle("/sessions", l.handleSessions)
+	l.bot.Handle(btnSubscription, l.handleSubscriptionButton)
+	l.bot.Handle(btnPreference, l.handlePreferenceButton)
+	l.bot.Handle(btnTerminate, l.handleTerminateButton)
 	l.bot.Handle(tele.OnText, l.handleMessage)
 }
 
+// Deep link https://t.me/<bot>?start=<token> sends token as payload of /start
 func (l *loginer) handleStart(c tele.Context) error {
-	return c.Send("Привет! Используйте /link для привязки аккаунта.")
+	token := c.Message().Payload
+	if token == "" {
+		return c.Send(t(c, i18n.BotStart))
+	}
+	err := l.linkTelegram(c, token)
+	if errors.Is(err, domain.ErrInvalidToken) {
+		return c.Send(t(c, i18n.BotInvalidLink))
+	}
+	return err
 }
 
 func (l *loginer) handleMessage(c tele.Context) error {
-	state, _ := l.state.Get(c.Sender().ID)
+	step, err := l.step(c)
+	if err != nil {
+		l.logger.Error("failed to get conversation", "error", err)
+		return c.Send(t(c, i18n.BotUnexpectedError))
+	}
 
-	switch state {
-	case stateWaitingToken:
+	switch step {
+	case stepWaitingToken:
 		return l.handleLinkTelegram(c)
-	case stateWaitingSubTypeEnable:
-		return l.handleEnableNotifications(c)
-	case stateWaitingSubTypeDisable:
-		return l.handleDisableNotifications(c)
 	default:
-		return c.Send("Команда не распознана.")
+		return c.Send(t(c, i18n.BotUnknownCommand))
 	}
 }
 
 func (l *loginer) handleCancel(c tele.Context) error {
-	_, ok := l.state.Get(c.Sender().ID)
-	if !ok {
-		return c.Send("Активные действия не найдены.")
+	step, err := l.step(c)
+	if err != nil {
+		l.logger.Error("failed to get conversation", "error", err)
+		return c.Send(t(c, i18n.BotUnexpectedError))
+	}
+	if step == stepIdle {
+		return c.Send(t(c, i18n.BotNoActiveAction))
 	}
-	return l.sendAndClear(c, "Действие отменено.")
+	return l.sendAndClear(c, t(c, i18n.BotCancelled))
 }
 
 func (l *loginer) startLinkTg(c tele.Context) error {
-	l.state.Set(c.Sender().ID, stateWaitingToken)
-	return c.Send("Введите токен. Для отмены используйте /cancel")
+	if err := l.enter(c, stepWaitingToken); err != nil {
+		l.logger.Error("failed to save conversation", "error", err)
+		return c.Send(t(c, i18n.BotUnexpectedError))
+	}
+	return c.Send(t(c, i18n.BotEnterToken))
 }
 
+// User stays in the dialog after invalid token to enter it again
 func (l *loginer) handleLinkTelegram(c tele.Context) error {
-	token := c.Message().Text
-	userID := c.Sender().ID
-	err := l.service.LinkTelegram(l.loggerCtx(), token, userID)
+	err := l.linkTelegram(c, c.Message().Text)
 	if errors.Is(err, domain.ErrInvalidToken) {
-		return c.Send("Неверный токен.")
+		return c.Send(t(c, i18n.BotInvalidToken))
+	}
+	return err
+}
+
+// linkTelegram replies to every result except domain.ErrInvalidToken, it is returned to caller
+func (l *loginer) linkTelegram(c tele.Context, token string) error {
+	account := domain.TelegramAccount{
+		ID:           c.Sender().ID,
+		Username:     c.Sender().Username,
+		LanguageCode: c.Sender().LanguageCode,
+	}
+	err := l.service.LinkTelegram(l.loggerCtx(), token, account)
+	if errors.Is(err, domain.ErrInvalidToken) {
+		return err
 	}
 	if errors.Is(err, domain.ErrAccountAlreadyExists) {
-		return l.sendAndClear(c, "Вы можете привязать только 1 аккаунт.")
+		return l.sendAndClear(c, t(c, i18n.BotAccountLimit))
 	}
 	if errors.Is(err, domain.ErrActionDontNeeded) {
-		return l.sendAndClear(c, "Аккаунт уже привязан.")
+		return l.sendAndClear(c, t(c, i18n.BotAlreadyLinked))
 	}
 	if err != nil {
 		l.logger.Error("failed to link telegram", "error", err)
-		return c.Send("Произошла непредвиденная ошибка.")
+		return c.Send(t(c, i18n.BotUnexpectedError))
 	}
-	return l.sendAndClear(c, "Аккаунт успешно привязан!")
+	return l.sendAndClear(c, t(c, i18n.BotLinked))
 }
 
 func (l *loginer) handleUnlinkTelegram(c tele.Context) error {
 	err := l.service.UnlinkTelegram(l.loggerCtx(), c.Sender().ID)
 	if errors.Is(err, domain.ErrActionDontNeeded) {
-		return c.Send("Аккаунт не привязан.")
+		return c.Send(t(c, i18n.BotNotLinked))
 	}
 	if err != nil {
 		l.logger.Error("failed to unlink telegram", "error", err)
-		return c.Send("Произошла непредвиденная ошибка.")
+		return c.Send(t(c, i18n.BotUnexpectedError))
 	}
-	return c.Send("Аккаунт успешно отвязан!")
+	return c.Send(t(c, i18n.BotUnlinked))
 }
 
 func (l *loginer) startEnableNotifications(c tele.Context) error {
-	l.state.Set(c.Sender().ID, stateWaitingSubTypeEnable)
-	return c.Send("Выберите тип уведомлений. Для отмены используйте /cancel", accountTypeMenu)
+	return c.Send(t(c, i18n.BotChooseChannel), channelMenu(locale(c), true))
 }
 
-func (l *loginer) handleEnableNotifications(c tele.Context) error {
-	userID := c.Sender().ID
-	subType, err := getSubscriptionType(c.Message().Text)
-	if err != nil {
-		return c.Send("Выберите тип уведомлений: Телеграм или Почта.")
+func (l *loginer) startDisableNotifications(c tele.Context) error {
+	return c.Send(t(c, i18n.BotChooseChannel), channelMenu(locale(c), false))
+}
+
+// Enables or disables channel from /enable, /disable or /status buttons
+func (l *loginer) handleSubscriptionButton(c tele.Context) error {
+	args := c.Args()
+	if len(args) != 3 {
+		return c.Respond()
 	}
+	subType, enabled, view := domain.SubscriptionType(args[0]), args[1] == "1", args[2]
 
-	err = l.service.UpdateSubscriptionStatus(l.loggerCtx(), userID, subType, true)
-	if errors.Is(err, domain.ErrActionDontNeeded) {
-		return l.sendAndClear(c, "Уведомления уже подключены.")
+	var text string
+	err := l.service.UpdateSubscriptionStatus(l.loggerCtx(), c.Sender().ID, subType, enabled)
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return l.respond(c, t(c, i18n.BotLinkRequired))
+	case errors.Is(err, domain.ErrActionDontNeeded) && enabled:
+		text = t(c, i18n.BotAlreadyEnabled)
+	case errors.Is(err, domain.ErrActionDontNeeded):
+		text = t(c, i18n.BotAlreadyDisabled)
+	case err != nil:
+		l.logger.Error("failed to update subscription", "error", err)
+		return l.respond(c, t(c, i18n.BotUnexpectedError))
+	case enabled:
+		text = t(c, i18n.BotEnabled)
+	default:
+		text = t(c, i18n.BotDisabled)
+	}
+
+	if err := l.respond(c, text); err != nil {
+		return err
 	}
+	if view == viewStatus {
+		return l.refreshStatus(c)
+	}
+	return c.Edit(text)
+}
+
+func (l *loginer) startPreferences(c tele.Context) error {
+	prefs, err := l.service.TelegramPreferences(l.loggerCtx(), c.Sender().ID)
 	if errors.Is(err, domain.ErrUserNotFound) {
-		return l.sendAndClear(c, "Привяжите аккаунт с помощью команды /link")
+		return c.Send(t(c, i18n.BotLinkRequired))
 	}
 	if err != nil {
-		l.logger.Error("failed to enable notifications", "error", err)
-		return c.Send("Произошла непредвиденная ошибка.")
+		l.logger.Error("failed to get preferences", "error", err)
+		return c.Send(t(c, i18n.BotUnexpectedError))
 	}
-	return l.sendAndClear(c, "Уведомления успешно подключены.")
+	return c.Send(preferencesMessage(locale(c)), preferencesMenu(locale(c), prefs))
 }
 
-func (l *loginer) startDisableNotifications(c tele.Context) error {
-	l.state.Set(c.Sender().ID, stateWaitingSubTypeDisable)
-	return c.Send("Выберите тип уведомлений. Для отмены используйте /cancel", accountTypeMenu)
+func (l *loginer) handlePreferenceButton(c tele.Context) error {
+	category := domain.EventCategory(c.Callback().Data)
+	if _, ok := categoryNames[category]; !ok {
+		return c.Respond()
+	}
+	enabled, err := l.service.ToggleTelegramPreference(l.loggerCtx(), c.Sender().ID, category)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return l.respond(c, t(c, i18n.BotLinkRequired))
+	}
+	if err != nil {
+		l.logger.Error("failed to toggle preference", "error", err)
+		return l.respond(c, t(c, i18n.BotUnexpectedError))
+	}
+
+	name := t(c, categoryNames[category])
+	text := t(c, i18n.BotCategoryDisabled, name)
+	if enabled {
+		text = t(c, i18n.BotCategoryEnabled, name)
+	}
+	if err := l.respond(c, text); err != nil {
+		return err
+	}
+	prefs, err := l.service.TelegramPreferences(l.loggerCtx(), c.Sender().ID)
+	if err != nil {
+		l.logger.Error("failed to get preferences", "error", err)
+		return nil
+	}
+	return l.edit(c, preferencesMessage(locale(c)), preferencesMenu(locale(c), prefs))
 }
 
-func (l *loginer) handleDisableNotifications(c tele.Context) error {
-	userID := c.Sender().ID
-	subType, err := getSubscriptionType(c.Message().Text)
+func (l *loginer) handleStatus(c tele.Context) error {
+	user, subs, err := l.service.TelegramStatus(l.loggerCtx(), c.Sender().ID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.Send(t(c, i18n.BotLinkRequired))
+	}
 	if err != nil {
-		return c.Send("Выберите тип уведомлений: Телеграм или Почта.")
+		l.logger.Error("failed to get status", "error", err)
+		return c.Send(t(c, i18n.BotUnexpectedError))
 	}
-	err = l.service.UpdateSubscriptionStatus(l.loggerCtx(), userID, subType, false)
-	if errors.Is(err, domain.ErrActionDontNeeded) {
-		return l.sendAndClear(c, "Уведомления уже отключены.")
+	return c.Send(statusMessage(locale(c), user, subs), statusMenu(locale(c), subs))
+}
+
+func (l *loginer) refreshStatus(c tele.Context) error {
+	user, subs, err := l.service.TelegramStatus(l.loggerCtx(), c.Sender().ID)
+	if err != nil {
+		l.logger.Error("failed to get status", "error", err)
+		return nil
 	}
+	return l.edit(c, statusMessage(locale(c), user, subs), statusMenu(locale(c), subs))
+}
+
+func (l *loginer) handleSessions(c tele.Context) error {
+	sessions, err := l.sessions.TelegramSessions(l.loggerCtx(), c.Sender().ID)
 	if errors.Is(err, domain.ErrUserNotFound) {
-		return l.sendAndClear(c, "Привяжите аккаунт с помощью команды /link")
+		return c.Send(t(c, i18n.BotLinkRequired))
 	}
 	if err != nil {
-		l.logger.Error("failed to disable notifications", "error", err)
-		return c.Send("Произошла непредвиденная ошибка.")
+		l.logger.Error("failed to get sessions", "error", err)
+		return c.Send(t(c, i18n.BotUnexpectedError))
 	}
-
-	return l.sendAndClear(c, "Уведомления успешно отключены.")
+	return c.Send(sessionsMessage(locale(c), sessions), sessionsMenu(locale(c), sessions))
 }
 
-func getSubscriptionType(text string) (domain.SubscriptionType, error) {
-	switch text {
-	case AccountTypeTelegram:
-		return domain.SubscriptionTypeTelegram, nil
-	case AccountTypeEmail:
-		return domain.SubscriptionTypeEmail, nil
+func (l *loginer) handleTerminateButton(c tele.Context) error {
+	err := l.sessions.TerminateTelegramSession(l.loggerCtx(), c.Sender().ID, c.Callback().Data)
+	var text string
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return l.respond(c, t(c, i18n.BotLinkRequired))
+	case errors.Is(err, domain.ErrSessionNotFound):
+		text = t(c, i18n.BotSessionNotFound)
+	case err != nil:
+		l.logger.Error("failed to terminate session", "error", err)
+		return l.respond(c, t(c, i18n.BotUnexpectedError))
 	default:
-		return "", errors.New("unknown subscription type")
+		text = t(c, i18n.BotSessionTerminated)
+	}
+	if err := l.respond(c, text); err != nil {
+		return err
+	}
+
+	sessions, err := l.sessions.TelegramSessions(l.loggerCtx(), c.Sender().ID)
+	if err != nil {
+		l.logger.Error("failed to get sessions", "error", err)
+		return nil
+	}
+	return l.edit(c, sessionsMessage(locale(c), sessions), sessionsMenu(locale(c), sessions))
+}
+
+// Answers callback query, text is shown as notification in Telegram client
+func (l *loginer) respond(c tele.Context, text string) error {
+	return c.Respond(&tele.CallbackResponse{Text: text})
+}
+
+// Re-renders message with buttons, nothing happens if it didn't change
+func (l *loginer) edit(c tele.Context, text string, menu *tele.ReplyMarkup) error {
+	err := c.Edit(text, menu)
+	if errors.Is(err, tele.ErrSameMessageContent) {
+		return nil
 	}
+	return err
+}
+
+// Bot replies in language of Telegram client
+func locale(c tele.Context) string {
+	return i18n.Match(c.Sender().LanguageCode)
+}
+
+func t(c tele.Context, key i18n.Key, args ...any) string {
+	return i18n.T(locale(c), key, args...)
 }
 
 func (l *loginer) loggerCtx() context.Context {
 	return logger.Inject(context.Background(), l.logger)
 }
 
+// ExpireConversations returns users who didn't answer in time to idle and notifies them
+func (l *loginer) ExpireConversations(ctx context.Context) error {
+	expired, err := l.states.PopExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, conv := range expired {
+		_, err := l.queue.Send(ctx, conv.TelegramID, i18n.T(conv.Locale, i18n.BotTimedOut), clearMenu)
+		if isUnavailable(err) {
+			continue
+		}
+		if err != nil {
+			l.logger.Error("failed to notify about cancelled conversation", "error", err, "telegram_id", conv.TelegramID)
+		}
+	}
+	return nil
+}
+
+// Current step of user, idle if there is no unfinished dialog
+func (l *loginer) step(c tele.Context) (domain.ConversationStep, error) {
+	conv, err := l.states.Get(l.loggerCtx(), c.Sender().ID)
+	if errors.Is(err, domain.ErrConversationNotFound) {
+		return stepIdle, nil
+	}
+	if err != nil {
+		return stepIdle, err
+	}
+	return conv.Step, nil
+}
+
+// Moves user to the step, previous unfinished dialog is replaced
+func (l *loginer) enter(c tele.Context, step domain.ConversationStep) error {
+	return l.states.Set(l.loggerCtx(), domain.Conversation{
+		TelegramID: c.Sender().ID,
+		Step:       step,
+		Locale:     locale(c),
+		ExpiresAt:  time.Now().Add(stepTimeouts[step]),
+	})
+}
+
+// Finishes dialog, user returns to idle
 func (l *loginer) sendAndClear(c tele.Context, message string) error {
-	l.state.Delete(c.Sender().ID)
+	if err := l.states.Delete(l.loggerCtx(), c.Sender().ID); err != nil {
+		l.logger.Error("failed to delete conversation", "error", err)
+	}
 	return c.Send(message, clearMenu)
 }
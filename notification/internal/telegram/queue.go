@@ -0,0 +1,235 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync"
+	"time"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Counters of outbound queue, served by expvar handler
+var metrics = expvar.NewMap("telegram_sender")
+
+// Limits of outbound messages, Telegram allows about 30 messages per second
+// and 1 message per second to one chat
+type Limits struct {
+	// Messages per second to all chats
+	Global int
+	// Minimal interval between messages to one chat
+	ChatInterval time.Duration
+	// Number of messages sent concurrently
+	Workers int
+	// Attempts of a message which is rate limited by Telegram
+	Attempts int
+}
+
+// Queue sends messages to chats within limits, it waits for retry_after
+// of flood errors before sending anything else
+type Queue struct {
+	bot    *tele.Bot
+	limits Limits
+	jobs   chan job
+	global *bucket
+
+	mu    sync.Mutex
+	chats map[int64]*bucket
+	// Sending is paused until this time after flood error
+	pausedUntil time.Time
+}
+
+type job struct {
+	ctx    context.Context
+	chatID int64
+	what   any
+	opts   []any
+	result chan result
+}
+
+type result struct {
+	msg *tele.Message
+	err error
+}
+
+func NewQueue(bot *tele.Bot, limits Limits) *Queue {
+	return &Queue{
+		bot:    bot,
+		limits: limits,
+		jobs:   make(chan job),
+		global: newBucket(time.Second/time.Duration(limits.Global), limits.Global),
+		chats:  make(map[int64]*bucket),
+	}
+}
+
+// Send waits until message is sent by the queue, it returns ctx error if ctx is done first
+func (q *Queue) Send(ctx context.Context, chatID int64, what any, opts ...any) (*tele.Message, error) {
+	j := job{ctx: ctx, chatID: chatID, what: what, opts: opts, result: make(chan result, 1)}
+	metrics.Add("queued", 1)
+	defer metrics.Add("queued", -1)
+	select {
+	case q.jobs <- j:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case r := <-j.result:
+		return r.msg, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+const sweepInterval = time.Minute
+
+// Run sends queued messages until ctx is done
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range q.limits.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+			q.sweep(time.Now())
+		}
+	}
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-q.jobs:
+			msg, err := q.send(j)
+			j.result <- result{msg: msg, err: err}
+		}
+	}
+}
+
+func (q *Queue) send(j job) (*tele.Message, error) {
+	for attempt := 1; ; attempt++ {
+		if err := q.wait(j.ctx, j.chatID); err != nil {
+			return nil, err
+		}
+		msg, err := q.bot.Send(tele.ChatID(j.chatID), j.what, j.opts...)
+		var flood tele.FloodError
+		if errors.As(err, &flood) && attempt < q.limits.Attempts {
+			metrics.Add("flood_waits", 1)
+			q.pause(time.Now().Add(time.Duration(flood.RetryAfter) * time.Second))
+			continue
+		}
+		if err != nil {
+			metrics.Add("failed", 1)
+			return nil, err
+		}
+		metrics.Add("sent", 1)
+		return msg, nil
+	}
+}
+
+// wait blocks until message can be sent to the chat without exceeding limits
+func (q *Queue) wait(ctx context.Context, chatID int64) error {
+	start := time.Now()
+	defer func() {
+		metrics.Add("wait_ms", time.Since(start).Milliseconds())
+	}()
+
+	q.mu.Lock()
+	paused := time.Until(q.pausedUntil)
+	q.mu.Unlock()
+	if err := sleep(ctx, paused); err != nil {
+		return err
+	}
+	if err := sleep(ctx, q.chat(chatID).reserve(time.Now())); err != nil {
+		return err
+	}
+	return sleep(ctx, q.global.reserve(time.Now()))
+}
+
+func (q *Queue) pause(until time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if until.After(q.pausedUntil) {
+		q.pausedUntil = until
+	}
+}
+
+func (q *Queue) chat(chatID int64) *bucket {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	b, ok := q.chats[chatID]
+	if !ok {
+		b = newBucket(q.limits.ChatInterval, 1)
+		q.chats[chatID] = b
+	}
+	return b
+}
+
+// sweep removes buckets of chats which are full again
+func (q *Queue) sweep(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for chatID, b := range q.chats {
+		if b.idle(now) {
+			delete(q.chats, chatID)
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// bucket is a token bucket which gets a token every interval and holds up to burst tokens.
+// It stores time when the bucket would be full, so it doesn't need a timer
+type bucket struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	full     time.Time
+}
+
+func newBucket(interval time.Duration, burst int) *bucket {
+	return &bucket{interval: interval, burst: burst}
+}
+
+// reserve takes a token and returns how long to wait until it can be used
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.full.Before(now) {
+		b.full = now
+	}
+	at := b.full.Add(-time.Duration(b.burst-1) * b.interval)
+	b.full = b.full.Add(b.interval)
+	return max(at.Sub(now), 0)
+}
+
+func (b *bucket) idle(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.full.After(now)
+}
@@ -0,0 +1,185 @@
+package telegram_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/telegram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v4"
+)
+
+// fakeAPI answers sendMessage with queued errors first, then with sent messages
+type fakeAPI struct {
+	mu     sync.Mutex
+	errors []string
+	sent   map[int64][]time.Time
+}
+
+func newQueue(t *testing.T, limits telegram.Limits, errors ...string) (*telegram.Queue, *fakeAPI) {
+	api := &fakeAPI{errors: errors, sent: make(map[int64][]time.Time)}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	bot, err := tele.NewBot(tele.Settings{Token: "123:token", URL: srv.URL, Offline: true})
+	require.NoError(t, err)
+
+	queue := telegram.NewQueue(bot, limits)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go queue.Run(ctx)
+	return queue, api
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ChatID string `json:"chat_id"`
+	}
+	json.NewDecoder(r.Body).Decode(&params)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.errors) > 0 {
+		resp := a.errors[0]
+		a.errors = a.errors[1:]
+		w.Write([]byte(resp))
+		return
+	}
+	var chatID int64
+	json.Unmarshal([]byte(params.ChatID), &chatID)
+	a.sent[chatID] = append(a.sent[chatID], time.Now())
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": tele.Message{ID: len(a.sent[chatID]), Chat: &tele.Chat{ID: chatID}}})
+}
+
+func (a *fakeAPI) Sent(chatID int64) []time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sent[chatID]
+}
+
+const (
+	floodError   = `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`
+	blockedError = `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`
+	chatError    = `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`
+)
+
+var limits = telegram.Limits{Global: 30, ChatInterval: 300 * time.Millisecond, Workers: 4, Attempts: 3}
+
+func TestQueue_ChatInterval(t *testing.T) {
+	queue, api := newQueue(t, limits)
+
+	var wg sync.WaitGroup
+	for _, chatID := range []int64{1, 1, 1, 2} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := queue.Send(context.Background(), chatID, "text")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	sent := api.Sent(1)
+	require.Len(t, sent, 3)
+	for i := 1; i < len(sent); i++ {
+		assert.GreaterOrEqual(t, sent[i].Sub(sent[i-1]), limits.ChatInterval-10*time.Millisecond)
+	}
+	// Other chats are not delayed by busy chat
+	require.Len(t, api.Sent(2), 1)
+	assert.Less(t, api.Sent(2)[0].Sub(sent[0]), limits.ChatInterval)
+}
+
+func TestQueue_GlobalLimit(t *testing.T) {
+	queue, api := newQueue(t, telegram.Limits{Global: 5, ChatInterval: time.Millisecond, Workers: 10, Attempts: 3})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for chatID := range int64(10) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := queue.Send(context.Background(), chatID, "text")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// 5 messages are sent at once, next 5 get a token every 200ms
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	for chatID := range int64(10) {
+		assert.Len(t, api.Sent(chatID), 1)
+	}
+}
+
+func TestQueue_FloodWait(t *testing.T) {
+	t.Run("retried after wait", func(t *testing.T) {
+		queue, api := newQueue(t, limits, floodError)
+		floodWaits := metric("flood_waits")
+
+		start := time.Now()
+		msg, err := queue.Send(context.Background(), 1, "text")
+		require.NoError(t, err)
+		assert.Equal(t, 1, msg.ID)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Len(t, api.Sent(1), 1)
+		assert.Equal(t, floodWaits+1, metric("flood_waits"))
+	})
+
+	t.Run("attempts exceeded", func(t *testing.T) {
+		queue, _ := newQueue(t, telegram.Limits{Global: 30, ChatInterval: time.Millisecond, Workers: 1, Attempts: 1}, floodError)
+
+		_, err := queue.Send(context.Background(), 1, "text")
+		var flood tele.FloodError
+		require.ErrorAs(t, err, &flood)
+		assert.Equal(t, 1, flood.RetryAfter)
+	})
+
+	t.Run("context cancelled during wait", func(t *testing.T) {
+		queue, _ := newQueue(t, limits, floodError)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := queue.Send(ctx, 1, "text")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func metric(name string) int64 {
+	v, ok := expvar.Get("telegram_sender").(*expvar.Map).Get(name).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+func TestSender_Unavailable(t *testing.T) {
+	for name, resp := range map[string]string{"blocked": blockedError, "chat not found": chatError} {
+		t.Run(name, func(t *testing.T) {
+			queue, _ := newQueue(t, limits, resp, resp)
+			sender := telegram.NewSender(queue)
+
+			_, err := sender.SendLoginNotification(context.Background(), 1, "en", domain.LoginNotification{})
+			assert.ErrorIs(t, err, domain.ErrRecipientUnavailable)
+			err = sender.SendDigest(context.Background(), 1, "en", domain.Digest{})
+			assert.ErrorIs(t, err, domain.ErrRecipientUnavailable)
+		})
+	}
+
+	t.Run("other error", func(t *testing.T) {
+		queue, _ := newQueue(t, limits, strings.Replace(chatError, "chat not found", "message is too long", 1))
+		sender := telegram.NewSender(queue)
+
+		_, err := sender.SendLoginNotification(context.Background(), 1, "en", domain.LoginNotification{})
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, domain.ErrRecipientUnavailable)
+	})
+}
@@ -1,36 +1,77 @@
 package telegram
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/i18n"
 	tele "gopkg.in/telebot.v4"
 )
 
 type Sender interface {
-	SendLoginNotification(telegramID int64, data domain.LoginNotification) error
+	// SendLoginNotification returns ID of sent message
+	SendLoginNotification(ctx context.Context, telegramID int64, locale string, data domain.LoginNotification) (string, error)
+	SendDigest(ctx context.Context, telegramID int64, locale string, digest domain.Digest) error
+	SendAnnouncement(ctx context.Context, telegramID int64, data domain.Announcement) error
 }
 
 type sender struct {
-	bot *tele.Bot
+	queue *Queue
 }
 
-func NewSender(bot *tele.Bot) Sender {
-	return &sender{bot: bot}
+// NewSender returns domain.ErrRecipientUnavailable if user can't receive messages from the bot
+func NewSender(queue *Queue) Sender {
+	return &sender{queue: queue}
 }
 
-func (s *sender) SendLoginNotification(telegramID int64, data domain.LoginNotification) error {
-	_, err := s.bot.Send(tele.ChatID(telegramID), loginMessage(data))
-	if errors.Is(err, tele.ErrBlockedByUser) {
-		return nil
+func (s *sender) SendLoginNotification(ctx context.Context, telegramID int64, locale string, data domain.LoginNotification) (string, error) {
+	msg, err := s.queue.Send(ctx, telegramID, loginMessage(locale, data))
+	if err != nil {
+		return "", sendError(err)
 	}
-	if errors.Is(err, tele.ErrChatNotFound) {
-		return nil
+	return strconv.Itoa(msg.ID), nil
+}
+
+func (s *sender) SendDigest(ctx context.Context, telegramID int64, locale string, digest domain.Digest) error {
+	_, err := s.queue.Send(ctx, telegramID, digestMessage(locale, digest))
+	return sendError(err)
+}
+
+func (s *sender) SendAnnouncement(ctx context.Context, telegramID int64, data domain.Announcement) error {
+	_, err := s.queue.Send(ctx, telegramID, data.Subject+"\n\n"+data.Text)
+	return sendError(err)
+}
+
+func sendError(err error) error {
+	if isUnavailable(err) {
+		return fmt.Errorf("%w: %w", domain.ErrRecipientUnavailable, err)
 	}
 	return err
 }
 
-func loginMessage(data domain.LoginNotification) string {
-	return fmt.Sprintf("⚠️Произведен вход в аккаунт⚠️\n\nIP: %s\nВремя: %s\nТип: %s", data.IP, data.Time, data.Type)
+// User blocked the bot or deleted account, messages to the chat will never be delivered
+func isUnavailable(err error) bool {
+	return errors.Is(err, tele.ErrBlockedByUser) ||
+		errors.Is(err, tele.ErrChatNotFound) ||
+		errors.Is(err, tele.ErrUserIsDeactivated)
+}
+
+func digestMessage(locale string, digest domain.Digest) string {
+	var b strings.Builder
+	b.WriteString(i18n.T(locale, i18n.TelegramDigestTitle))
+	if len(digest.Logins) > 0 {
+		b.WriteString("\n\n" + i18n.T(locale, i18n.TelegramDigestLogins, len(digest.Logins)))
+		for _, login := range digest.Logins {
+			fmt.Fprintf(&b, "\n• %s, IP: %s, %s", login.Time, login.IP, login.Type)
+		}
+	}
+	return b.String()
+}
+
+func loginMessage(locale string, data domain.LoginNotification) string {
+	return i18n.T(locale, i18n.TelegramLogin, data.IP, data.Time, data.Type)
 }
@@ -1,37 +1,78 @@
 package telegram
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"time"
 
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+)
+
+// Every dialog has one step: command moves user from idle to the step, and answer,
+// /cancel or timeout of the step move user back to idle
 const (
-	stateWaitingToken          = "waiting_token"
-	stateWaitingSubTypeEnable  = "waiting_sub_type_enable"
-	stateWaitingSubTypeDisable = "waiting_sub_type_disable"
+	stepIdle         domain.ConversationStep = ""
+	stepWaitingToken domain.ConversationStep = "waiting_token"
 )
 
-type state struct {
-	data map[int64]string
-	mu   sync.RWMutex
+// How long bot waits for answer on each step, token can't be entered after it expires anyway
+var stepTimeouts = map[domain.ConversationStep]time.Duration{
+	stepWaitingToken: domain.TokenTTL,
+}
+
+// StateStore keeps unfinished dialogs, it is shared between bot replicas
+type StateStore interface {
+	// Get returns domain.ErrConversationNotFound if user is idle or conversation expired
+	Get(ctx context.Context, telegramID int64) (domain.Conversation, error)
+	Set(ctx context.Context, conv domain.Conversation) error
+	Delete(ctx context.Context, telegramID int64) error
+	// PopExpired removes conversations expired by now and returns them, every conversation is returned only once
+	PopExpired(ctx context.Context, now time.Time) ([]domain.Conversation, error)
+}
+
+type memoryState struct {
+	data map[int64]domain.Conversation
+	mu   sync.Mutex
 }
 
-func NewState() *state {
-	return &state{data: make(map[int64]string)}
+// NewMemoryState keeps dialogs in memory of one replica, they are lost on restart
+func NewMemoryState() StateStore {
+	return &memoryState{data: make(map[int64]domain.Conversation)}
 }
 
-func (s *state) Set(telegramID int64, data string) {
+func (s *memoryState) Get(ctx context.Context, telegramID int64) (domain.Conversation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.data[telegramID] = data
+	conv, ok := s.data[telegramID]
+	if !ok || !conv.ExpiresAt.After(time.Now()) {
+		return domain.Conversation{}, domain.ErrConversationNotFound
+	}
+	return conv, nil
 }
 
-func (s *state) Get(telegramID int64) (string, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	data, ok := s.data[telegramID]
-	return data, ok
+func (s *memoryState) Set(ctx context.Context, conv domain.Conversation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[conv.TelegramID] = conv
+	return nil
 }
 
-func (s *state) Delete(telegramID int64) {
+func (s *memoryState) Delete(ctx context.Context, telegramID int64) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	delete(s.data, telegramID)
+	return nil
+}
+
+func (s *memoryState) PopExpired(ctx context.Context, now time.Time) ([]domain.Conversation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var expired []domain.Conversation
+	for id, conv := range s.data {
+		if !conv.ExpiresAt.After(now) {
+			expired = append(expired, conv)
+			delete(s.data, id)
+		}
+	}
+	return expired, nil
 }
@@ -0,0 +1,51 @@
+package telegram_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/notification/internal/telegram"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryState(t *testing.T) {
+	ctx := context.Background()
+	states := telegram.NewMemoryState()
+	active := domain.Conversation{TelegramID: 1, Step: "waiting_token", Locale: "en", ExpiresAt: time.Now().Add(time.Minute)}
+	expired := domain.Conversation{TelegramID: 2, Step: "waiting_category", Locale: "ru", ExpiresAt: time.Now().Add(-time.Second)}
+	require.NoError(t, states.Set(ctx, active))
+	require.NoError(t, states.Set(ctx, expired))
+
+	t.Run("get active", func(t *testing.T) {
+		got, err := states.Get(ctx, active.TelegramID)
+		require.NoError(t, err)
+		assert.Equal(t, active, got)
+	})
+
+	t.Run("expired is not returned", func(t *testing.T) {
+		_, err := states.Get(ctx, expired.TelegramID)
+		assert.ErrorIs(t, err, domain.ErrConversationNotFound)
+	})
+
+	t.Run("pop expired once", func(t *testing.T) {
+		got, err := states.PopExpired(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Conversation{expired}, got)
+
+		got, err = states.PopExpired(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, states.Delete(ctx, active.TelegramID))
+		_, err := states.Get(ctx, active.TelegramID)
+		assert.ErrorIs(t, err, domain.ErrConversationNotFound)
+		got, err := states.PopExpired(ctx, active.ExpiresAt)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
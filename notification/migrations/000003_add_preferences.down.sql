@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS preferences;
+DROP TYPE IF EXISTS event_category;
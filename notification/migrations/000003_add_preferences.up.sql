@@ -0,0 +1,10 @@
+CREATE TYPE event_category AS ENUM ('login', 'security', 'account', 'marketing');
+
+-- Only explicitly changed preferences are stored, defaults are defined in code
+CREATE TABLE IF NOT EXISTS preferences (
+  user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+  channel subscription_type NOT NULL,
+  category event_category NOT NULL,
+  enabled BOOLEAN NOT NULL,
+  PRIMARY KEY (user_id, channel, category)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS schedules (
+  user_id UUID PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+  timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+  quiet_hours BOOLEAN NOT NULL DEFAULT FALSE,
+  quiet_from SMALLINT NOT NULL DEFAULT 23 CHECK (quiet_from BETWEEN 0 AND 23),
+  quiet_to SMALLINT NOT NULL DEFAULT 8 CHECK (quiet_to BETWEEN 0 AND 23),
+  digest BOOLEAN NOT NULL DEFAULT FALSE,
+  digest_hour SMALLINT NOT NULL DEFAULT 9 CHECK (digest_hour BETWEEN 0 AND 23)
+);
+
+CREATE TABLE IF NOT EXISTS pending_notifications (
+  id BIGSERIAL PRIMARY KEY,
+  user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+  category event_category NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  deliver_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS pending_notifications_deliver_at_idx ON pending_notifications (deliver_at);
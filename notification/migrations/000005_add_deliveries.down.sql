@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS deliveries;
+
+DROP TYPE IF EXISTS delivery_status;
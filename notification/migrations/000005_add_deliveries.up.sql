@@ -0,0 +1,22 @@
+CREATE TYPE delivery_status AS ENUM ('pending', 'sent', 'failed');
+
+-- One row per message and channel, message_key makes redelivered events idempotent
+CREATE TABLE IF NOT EXISTS deliveries (
+  id BIGSERIAL PRIMARY KEY,
+  message_key VARCHAR(255) NOT NULL,
+  user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+  channel subscription_type NOT NULL,
+  category event_category NOT NULL,
+  payload JSONB NOT NULL,
+  status delivery_status NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT,
+  provider_message_id VARCHAR(255),
+  next_attempt_at TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (message_key, channel)
+);
+
+CREATE INDEX IF NOT EXISTS deliveries_retry_idx ON deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS deliveries_user_idx ON deliveries (user_id, created_at DESC);
@@ -0,0 +1,2 @@
+-- NULL means locale is unknown, default one is used
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(8);
@@ -0,0 +1,38 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email VARCHAR(255) UNIQUE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS telegram_id BIGINT UNIQUE;
+
+UPDATE users u SET email = c.address
+FROM channel_contacts c WHERE c.user_id = u.user_id AND c.channel = 'email';
+
+UPDATE users u SET telegram_id = c.address::BIGINT
+FROM channel_contacts c WHERE c.user_id = u.user_id AND c.channel = 'telegram';
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_contact_fkey;
+
+-- Values of enum can't be dropped, rows of new channels are removed instead
+DELETE FROM subscriptions WHERE type IN ('webhook', 'sms');
+DELETE FROM preferences WHERE channel IN ('webhook', 'sms');
+DELETE FROM deliveries WHERE channel IN ('webhook', 'sms');
+
+DROP TABLE IF EXISTS channel_contacts;
+
+CREATE OR REPLACE FUNCTION check_subscription_requirements()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.type = 'telegram' THEN
+        IF (SELECT telegram_id FROM users WHERE user_id = NEW.user_id) IS NULL THEN
+            RAISE EXCEPTION 'User must have a telegram_id to subscribe to telegram notifications';
+        END IF;
+    ELSIF NEW.type = 'email' THEN
+        IF (SELECT email FROM users WHERE user_id = NEW.user_id) IS NULL THEN
+            RAISE EXCEPTION 'User must have an email to subscribe to email notifications';
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER before_insert_subscription
+BEFORE INSERT ON subscriptions
+FOR EACH ROW
+EXECUTE FUNCTION check_subscription_requirements();
@@ -0,0 +1,36 @@
+ALTER TYPE subscription_type ADD VALUE IF NOT EXISTS 'webhook';
+ALTER TYPE subscription_type ADD VALUE IF NOT EXISTS 'sms';
+
+-- One address per user and channel
+CREATE TABLE IF NOT EXISTS channel_contacts (
+  user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+  channel subscription_type NOT NULL,
+  address VARCHAR(2048) NOT NULL,
+  secret VARCHAR(255),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, channel)
+);
+
+-- Email and telegram identify account, webhook may be shared by several users
+CREATE UNIQUE INDEX IF NOT EXISTS channel_contacts_account_idx ON channel_contacts (channel, address)
+  WHERE channel IN ('email', 'telegram');
+
+INSERT INTO channel_contacts (user_id, channel, address)
+SELECT user_id, 'email', email FROM users WHERE email IS NOT NULL;
+
+INSERT INTO channel_contacts (user_id, channel, address)
+SELECT user_id, 'telegram', telegram_id::TEXT FROM users WHERE telegram_id IS NOT NULL;
+
+-- Subscription requires contact of its channel, replaces check of user columns
+DROP TRIGGER IF EXISTS before_insert_subscription ON subscriptions;
+DROP FUNCTION IF EXISTS check_subscription_requirements();
+
+DELETE FROM subscriptions s WHERE NOT EXISTS (
+  SELECT 1 FROM channel_contacts c WHERE c.user_id = s.user_id AND c.channel = s.type
+);
+
+ALTER TABLE subscriptions ADD CONSTRAINT subscriptions_contact_fkey
+  FOREIGN KEY (user_id, type) REFERENCES channel_contacts(user_id, channel) ON DELETE CASCADE;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email;
+ALTER TABLE users DROP COLUMN IF EXISTS telegram_id;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS push_subscriptions;
+
+-- Values of enum can't be dropped, rows of the channel are removed instead
+DELETE FROM subscriptions WHERE type = 'webpush';
+DELETE FROM preferences WHERE channel = 'webpush';
+DELETE FROM deliveries WHERE channel = 'webpush';
+DELETE FROM channel_contacts WHERE channel = 'webpush';
@@ -0,0 +1,13 @@
+ALTER TYPE subscription_type ADD VALUE IF NOT EXISTS 'webpush';
+
+-- Browsers of user, endpoint is unique across users since it identifies browser profile
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+  id BIGSERIAL PRIMARY KEY,
+  user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+  endpoint VARCHAR(2048) UNIQUE NOT NULL,
+  p256dh VARCHAR(255) NOT NULL,
+  auth VARCHAR(255) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS push_subscriptions_user_idx ON push_subscriptions (user_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS broadcast_failures;
+DROP TABLE IF EXISTS broadcasts;
+DROP TYPE IF EXISTS broadcast_status;
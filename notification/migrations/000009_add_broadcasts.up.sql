@@ -0,0 +1,35 @@
+CREATE TYPE broadcast_status AS ENUM ('scheduled', 'running', 'completed');
+
+CREATE TABLE IF NOT EXISTS broadcasts (
+  id BIGSERIAL PRIMARY KEY,
+  segment VARCHAR(32) NOT NULL,
+  category event_category NOT NULL,
+  subject TEXT NOT NULL,
+  text TEXT NOT NULL,
+  status broadcast_status NOT NULL DEFAULT 'scheduled',
+  created_by UUID NOT NULL,
+  send_at TIMESTAMPTZ NOT NULL,
+  -- Last processed user, users are processed in order of ID
+  cursor UUID,
+  lease_until TIMESTAMPTZ,
+  total INT NOT NULL DEFAULT 0,
+  processed INT NOT NULL DEFAULT 0,
+  sent INT NOT NULL DEFAULT 0,
+  failed INT NOT NULL DEFAULT 0,
+  skipped INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS broadcasts_due_idx ON broadcasts (send_at) WHERE status <> 'completed';
+
+CREATE TABLE IF NOT EXISTS broadcast_failures (
+  id BIGSERIAL PRIMARY KEY,
+  broadcast_id BIGINT REFERENCES broadcasts(id) ON DELETE CASCADE NOT NULL,
+  user_id UUID NOT NULL,
+  channel subscription_type NOT NULL,
+  error TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS broadcast_failures_broadcast_idx ON broadcast_failures (broadcast_id, id DESC);
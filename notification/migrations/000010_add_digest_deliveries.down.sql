@@ -0,0 +1,2 @@
+DELETE FROM deliveries WHERE digest;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS digest;
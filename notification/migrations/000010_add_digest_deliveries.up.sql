@@ -0,0 +1,2 @@
+-- Payload of digest delivery is digest of pending notifications instead of single notification
+ALTER TABLE deliveries ADD COLUMN IF NOT EXISTS digest BOOLEAN NOT NULL DEFAULT FALSE;
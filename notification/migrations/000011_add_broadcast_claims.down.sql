@@ -0,0 +1 @@
+ALTER TABLE broadcasts DROP COLUMN IF EXISTS claim;
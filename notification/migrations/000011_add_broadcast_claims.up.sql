@@ -0,0 +1,2 @@
+-- Changed on every claim, progress is saved only by instance which holds current claim
+ALTER TABLE broadcasts ADD COLUMN IF NOT EXISTS claim UUID;
@@ -1,20 +1,49 @@
 package bot
 
 import (
+	"fmt"
 	"log"
 	"time"
 
 	tele "gopkg.in/telebot.v4"
 )
 
-func MustNew(token string) *tele.Bot {
+func MustNew(token string, poller tele.Poller) *tele.Bot {
+	bot, err := New(token, "", poller)
+	if err != nil {
+		log.Fatalf("error creating bot: %v", err)
+	}
+
+	return bot
+}
+
+// New creates bot receiving updates from the poller, default Bot API server is used if url is empty
+func New(token, url string, poller tele.Poller) (*tele.Bot, error) {
 	bot, err := tele.NewBot(tele.Settings{
 		Token:  token,
-		Poller: &tele.LongPoller{Timeout: 10 * time.Second},
+		URL:    url,
+		Poller: poller,
 	})
 	if err != nil {
-		log.Fatalf("error creating bot: %v", err)
+		return nil, fmt.Errorf("failed to create bot: %w", err)
 	}
+	return bot, nil
+}
 
-	return bot
+// Poller receives updates by long polling
+type Poller struct {
+	tele.LongPoller
+}
+
+func NewPoller(timeout time.Duration) *Poller {
+	return &Poller{LongPoller: tele.LongPoller{Timeout: timeout}}
+}
+
+// Poll removes webhook first, Telegram doesn't return updates while it is set,
+// so bot can be switched back from webhook mode
+func (p *Poller) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	if err := b.RemoveWebhook(); err != nil {
+		b.OnError(fmt.Errorf("failed to remove webhook: %w", err), nil)
+	}
+	p.LongPoller.Poll(b, dest, stop)
 }
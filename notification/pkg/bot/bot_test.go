@@ -0,0 +1,166 @@
+package bot_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/notification/pkg/bot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v4"
+)
+
+const token = "123:token"
+
+// fakeAPI is a Bot API server which records called methods and returns queued updates once
+type fakeAPI struct {
+	mu      sync.Mutex
+	calls   map[string][]map[string]any
+	updates []tele.Update
+}
+
+func newFakeAPI(t *testing.T, updates ...tele.Update) (*fakeAPI, *httptest.Server) {
+	api := &fakeAPI{calls: make(map[string][]map[string]any), updates: updates}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	return api, srv
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot"+token+"/")
+	if !ok {
+		http.Error(w, `{"ok":false,"error_code":404,"description":"Not Found"}`, http.StatusNotFound)
+		return
+	}
+	params := make(map[string]any)
+	json.NewDecoder(r.Body).Decode(&params)
+
+	a.mu.Lock()
+	a.calls[method] = append(a.calls[method], params)
+	var result any = true
+	switch method {
+	case "getMe":
+		result = tele.User{ID: 123, IsBot: true, Username: "profile_bot"}
+	case "getUpdates":
+		updates := a.updates
+		a.updates = nil
+		// Long polling request would wait for new updates
+		if len(updates) == 0 {
+			defer time.Sleep(10 * time.Millisecond)
+		}
+		result = updates
+	}
+	a.mu.Unlock()
+
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func (a *fakeAPI) Calls(method string) []map[string]any {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.calls[method]
+}
+
+func startBot(t *testing.T, b *tele.Bot) <-chan string {
+	received := make(chan string, 1)
+	b.Handle("/ping", func(c tele.Context) error {
+		received <- c.Text()
+		return nil
+	})
+	go b.Start()
+	t.Cleanup(b.Stop)
+	return received
+}
+
+func pingUpdate() tele.Update {
+	return tele.Update{ID: 1, Message: &tele.Message{
+		ID:     1,
+		Text:   "/ping",
+		Sender: &tele.User{ID: 1},
+		Chat:   &tele.Chat{ID: 1},
+	}}
+}
+
+func TestPoller(t *testing.T) {
+	api, srv := newFakeAPI(t, pingUpdate())
+	b, err := bot.New(token, srv.URL, bot.NewPoller(time.Second))
+	require.NoError(t, err)
+
+	received := startBot(t, b)
+
+	select {
+	case text := <-received:
+		assert.Equal(t, "/ping", text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("update was not received")
+	}
+	assert.Len(t, api.Calls("deleteWebhook"), 1)
+	assert.Empty(t, api.Calls("setWebhook"))
+}
+
+func TestWebhook(t *testing.T) {
+	api, srv := newFakeAPI(t)
+	webhook := bot.NewWebhook("https://example.com/telegram", "secret")
+	b, err := bot.New(token, srv.URL, webhook)
+	require.NoError(t, err)
+
+	received := startBot(t, b)
+
+	require.Eventually(t, func() bool {
+		return len(api.Calls("setWebhook")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	params := api.Calls("setWebhook")[0]
+	assert.Equal(t, "https://example.com/telegram", params["url"])
+	assert.Equal(t, "secret", params["secret_token"])
+	assert.Empty(t, api.Calls("getUpdates"))
+
+	body, err := json.Marshal(pingUpdate())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		method   string
+		secret   string
+		body     string
+		wantCode int
+	}{
+		{name: "missing secret", method: http.MethodPost, body: string(body), wantCode: http.StatusUnauthorized},
+		{name: "invalid secret", method: http.MethodPost, secret: "invalid", body: string(body), wantCode: http.StatusUnauthorized},
+		{name: "invalid method", method: http.MethodGet, secret: "secret", wantCode: http.StatusMethodNotAllowed},
+		{name: "invalid update", method: http.MethodPost, secret: "secret", body: "{", wantCode: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/telegram", strings.NewReader(tc.body))
+			if tc.secret != "" {
+				req.Header.Set("X-Telegram-Bot-Api-Secret-Token", tc.secret)
+			}
+			rec := httptest.NewRecorder()
+			webhook.ServeHTTP(rec, req)
+			assert.Equal(t, tc.wantCode, rec.Code)
+		})
+	}
+	select {
+	case <-received:
+		t.Fatal("rejected update was processed")
+	default:
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(string(body)))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "secret")
+	rec := httptest.NewRecorder()
+	webhook.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	select {
+	case text := <-received:
+		assert.Equal(t, "/ping", text)
+	case <-time.After(5 * time.Second):
+		t.Fatal("update was not received")
+	}
+}
@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// Telegram sends secret token set with webhook in this header
+const secretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// Webhook receives updates from Telegram on its HTTP handler, so several replicas
+// of the bot can run behind a load balancer. It is registered when bot starts.
+type Webhook struct {
+	url     string
+	secret  string
+	updates chan tele.Update
+}
+
+// NewWebhook creates webhook with public HTTPS url of the handler, secret may contain only A-Z, a-z, 0-9, _ and -
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{url: url, secret: secret, updates: make(chan tele.Update)}
+}
+
+// Poll registers webhook and forwards updates from the handler until bot is stopped.
+// Telegram keeps previously registered webhook, so updates are forwarded even if registration failed
+func (w *Webhook) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	err := b.SetWebhook(&tele.Webhook{
+		SecretToken: w.secret,
+		Endpoint:    &tele.WebhookEndpoint{PublicURL: w.url},
+	})
+	if err != nil {
+		b.OnError(fmt.Errorf("failed to set webhook: %w", err), nil)
+	}
+
+	for {
+		select {
+		case <-stop:
+			return
+		case update := <-w.updates:
+			select {
+			case dest <- update:
+			case <-stop:
+				return
+			}
+		}
+	}
+}
+
+// ServeHTTP responds after update is passed to bot, Telegram retries request if it fails
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(secretHeader)), []byte(w.secret)) != 1 {
+		http.Error(rw, "invalid secret token", http.StatusUnauthorized)
+		return
+	}
+	var update tele.Update
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(rw, "invalid update", http.StatusBadRequest)
+		return
+	}
+	select {
+	case w.updates <- update:
+	case <-r.Context().Done():
+		http.Error(rw, "bot is not running", http.StatusServiceUnavailable)
+	}
+}
@@ -3,13 +3,17 @@ package main
 import (
 	"context"
 	"flag"
+	"log"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
+	ssoPb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
 	"github.com/SergeyBogomolovv/profile-manager/common/postgres"
 	"github.com/SergeyBogomolovv/profile-manager/common/rabbitmq"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/app"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/broker"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/config"
@@ -17,6 +21,8 @@ import (
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/repo"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/service"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -29,25 +35,35 @@ func main() {
 	amqpConn := rabbitmq.MustNew(conf.RabbitmqURL)
 	defer amqpConn.Close()
 
+	ssoConn, err := grpc.NewClient(conf.SsoAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect to sso: %v", err)
+	}
+	defer ssoConn.Close()
+
 	logger := newLogger()
 
 	imageRepo := repo.MustNewImageRepo(conf.S3)
 	profileRepo := repo.NewProfileRepo(postgres)
-	profileSvc := service.NewProfileService(profileRepo, imageRepo)
+	txManager := transaction.NewTxManager(postgres)
+	profileSvc := service.NewProfileService(txManager, profileRepo, imageRepo)
 	grpcController := controller.NewGRPCController(profileSvc)
 
-	broker := broker.MustNew(logger, amqpConn, profileSvc)
+	consumer := broker.MustNew(logger, amqpConn, profileSvc)
+	publisher := broker.MustNewPublisher(logger, amqpConn, txManager, profileRepo, conf.Outbox)
 
-	app := app.New(logger, conf, grpcController)
+	app := app.New(logger, conf, grpcController, auth.NewSSOVerifier(ssoPb.NewPersonalTokensClient(ssoConn)))
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	broker.Consume(ctx)
+	consumer.Consume(ctx)
+	publisher.Start(ctx)
 	app.Start()
 	<-ctx.Done()
 	app.Stop()
-	broker.Close()
+	consumer.Close()
+	publisher.Close()
 }
 
 func init() {
@@ -22,11 +22,11 @@ type GRPCController interface {
 	Init(srv *grpc.Server)
 }
 
-func New(log *slog.Logger, conf *config.Config, grpcController GRPCController) *app {
+func New(log *slog.Logger, conf *config.Config, grpcController GRPCController, tokens auth.TokenVerifier) *app {
 	grpcSrv := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			logger.LoggerInterceptor(log),
-			auth.JwtInterceptor([]byte(conf.JwtSecret)),
+			auth.JwtInterceptor([]byte(conf.JwtSecret), auth.WithPersonalTokens(tokens)),
 		),
 	)
 	grpcController.Init(grpcSrv)
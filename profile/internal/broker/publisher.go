@@ -0,0 +1,132 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/profile/internal/config"
+	"github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type OutboxRepo interface {
+	PendingEvents(ctx context.Context, limit int) ([]domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, ids []int64) error
+	DeletePublished(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// publisher relays events saved in outbox to the user exchange
+type publisher struct {
+	ch        *amqp.Channel
+	txManager transaction.TxManager
+	outbox    OutboxRepo
+	logger    *slog.Logger
+	conf      config.Outbox
+}
+
+func MustNewPublisher(logger *slog.Logger, conn *amqp.Connection, txManager transaction.TxManager, outbox OutboxRepo, conf config.Outbox) *publisher {
+	ch, err := conn.Channel()
+	if err != nil {
+		log.Fatalf("failed to open a channel: %v", err)
+	}
+	if err := ch.ExchangeDeclare(events.UserExchange, "topic", true, false, false, false, nil); err != nil {
+		log.Fatalf("failed to declare exchange: %v", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		log.Fatalf("failed to enable publisher confirms: %v", err)
+	}
+
+	return &publisher{ch: ch, txManager: txManager, outbox: outbox, logger: logger, conf: conf}
+}
+
+func (p *publisher) Close() error {
+	return p.ch.Close()
+}
+
+// Non blocking operation
+func (p *publisher) Start(ctx context.Context) {
+	go p.run(ctx)
+}
+
+func (p *publisher) run(ctx context.Context) {
+	ticker := time.NewTicker(p.conf.Interval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(p.conf.CleanupInterval)
+	defer cleanup.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.publishPending(ctx); err != nil {
+				p.logger.Error("failed to publish events", "error", err)
+			}
+		case <-cleanup.C:
+			if err := p.deletePublished(ctx); err != nil {
+				p.logger.Error("failed to delete published events", "error", err)
+			}
+		}
+	}
+}
+
+// Published events are kept for retention only, they are deleted in batches to keep transactions short
+func (p *publisher) deletePublished(ctx context.Context) error {
+	before := time.Now().Add(-p.conf.Retention)
+	for {
+		n, err := p.outbox.DeletePublished(ctx, before, p.conf.BatchSize)
+		if err != nil || n < int64(p.conf.BatchSize) {
+			return err
+		}
+	}
+}
+
+// Events are locked until transaction ends, so several instances do not publish the same event
+func (p *publisher) publishPending(ctx context.Context) error {
+	return p.txManager.Run(ctx, func(ctx context.Context) error {
+		pending, err := p.outbox.PendingEvents(ctx, p.conf.BatchSize)
+		if err != nil || len(pending) == 0 {
+			return err
+		}
+		published := make([]int64, 0, len(pending))
+		for _, event := range pending {
+			// Keep events order, the rest is published on next tick
+			if err := p.publish(ctx, event); err != nil {
+				p.logger.Error("failed to publish event", "id", event.ID, "topic", event.Topic, "error", err)
+				break
+			}
+			published = append(published, event.ID)
+		}
+		if len(published) == 0 {
+			return nil
+		}
+		return p.outbox.MarkPublished(ctx, published)
+	})
+}
+
+func (p *publisher) publish(ctx context.Context, event domain.OutboxEvent) error {
+	msg := amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    strconv.FormatInt(event.ID, 10),
+		Body:         event.Payload,
+	}
+	confirm, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, events.UserExchange, event.Topic, false, false, msg)
+	if err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+	ok, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for confirm: %w", err)
+	}
+	if !ok {
+		return errors.New("event was rejected by broker")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -11,7 +12,16 @@ type Config struct {
 	PostgresURL string `mapstructure:"postgres_url"`
 	RabbitmqURL string `mapstructure:"rabbitmq_url"`
 	JwtSecret   string `mapstructure:"jwt_secret"`
+	SsoAddr     string `mapstructure:"sso_addr"`
 	S3          S3     `mapstructure:"s3"`
+	Outbox      Outbox `mapstructure:"outbox"`
+}
+
+type Outbox struct {
+	Interval        time.Duration `mapstructure:"interval"`
+	BatchSize       int           `mapstructure:"batch_size"`
+	Retention       time.Duration `mapstructure:"retention"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
 }
 
 type S3 struct {
@@ -25,6 +35,11 @@ type S3 struct {
 func MustLoadConfig(path string) *Config {
 	viper.SetConfigFile(path)
 
+	viper.SetDefault("outbox.interval", time.Second)
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.retention", 7*24*time.Hour)
+	viper.SetDefault("outbox.cleanup_interval", time.Hour)
+
 	viper.BindEnv("postgres_url", "POSTGRES_URL")
 	viper.BindEnv("rabbitmq_url", "RABBITMQ_URL")
 	viper.BindEnv("jwt_secret", "JWT_SECRET")
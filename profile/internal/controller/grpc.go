@@ -16,7 +16,13 @@ import (
 
 type ProfileService interface {
 	GetProfile(ctx context.Context, userID string) (domain.Profile, error)
+	GetUserProfile(ctx context.Context, viewerID, userID string) (domain.Profile, error)
+	GetProfileByUsername(ctx context.Context, viewerID, username string) (domain.Profile, error)
 	Update(ctx context.Context, userID string, dto domain.UpdateProfileDTO) (domain.Profile, error)
+	UpdatePrivacy(ctx context.Context, userID string, dto domain.UpdatePrivacyDTO) (domain.PrivacySettings, error)
+	ListContacts(ctx context.Context, userID string) ([]string, error)
+	AddContact(ctx context.Context, userID, contactID string) error
+	DeleteContact(ctx context.Context, userID, contactID string) error
 }
 
 type gRPCController struct {
@@ -50,14 +56,55 @@ func (c *gRPCController) GetProfile(ctx context.Context, req *pb.GetProfileReque
 	return domainToGRPC(profile), nil
 }
 
+func (c *gRPCController) GetUserProfile(ctx context.Context, req *pb.GetUserProfileRequest) (*pb.ProfileResponse, error) {
+	viewerID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(viewerID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid requested user id")
+	}
+	profile, err := c.svc.GetUserProfile(ctx, viewerID, req.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			return nil, status.Errorf(codes.NotFound, "profile not found")
+		}
+		logger.Extract(ctx).Error("failed to get user profile", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get profile")
+	}
+	return domainToGRPC(profile), nil
+}
+
+func (c *gRPCController) GetProfileByUsername(ctx context.Context, req *pb.GetProfileByUsernameRequest) (*pb.ProfileResponse, error) {
+	viewerID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(viewerID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Username, "required"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	profile, err := c.svc.GetProfileByUsername(ctx, viewerID, req.Username)
+	if err != nil {
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			return nil, status.Errorf(codes.NotFound, "profile not found")
+		}
+		logger.Extract(ctx).Error("failed to get profile by username", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get profile")
+	}
+	return domainToGRPC(profile), nil
+}
+
 func (c *gRPCController) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.ProfileResponse, error) {
 	dto := domain.UpdateProfileDTO{
-		Username:  req.Username,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		BirthDate: req.BirthDate,
-		Gender:    domain.UserGender(req.Gender),
-		Avatar:    req.Avatar,
+		Username:        req.Username,
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		BirthDate:       req.BirthDate,
+		Gender:          domain.UserGender(req.Gender),
+		Avatar:          req.Avatar,
+		Locale:          req.Locale,
+		Mask:            req.GetUpdateMask().GetPaths(),
+		ExpectedVersion: req.ExpectedVersion,
 	}
 	if err := c.validate.Struct(dto); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -76,13 +123,104 @@ func (c *gRPCController) UpdateProfile(ctx context.Context, req *pb.UpdateProfil
 		if errors.Is(err, domain.ErrUsernameExists) {
 			return nil, status.Errorf(codes.AlreadyExists, "username already exists")
 		}
+		if errors.Is(err, domain.ErrUsernameEmpty) {
+			return nil, status.Error(codes.InvalidArgument, "username can not be empty")
+		}
+		if errors.Is(err, domain.ErrInvalidUsername) || errors.Is(err, domain.ErrUsernameReserved) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrUsernameCooldown) {
+			return nil, status.Error(codes.FailedPrecondition, "username was changed recently")
+		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return nil, status.Error(codes.Aborted, "profile was modified, reload and retry")
+		}
 		logger.Extract(ctx).Error("failed to update profile", "error", err)
 		return nil, status.Error(codes.Internal, "failed to update profile")
 	}
 	return domainToGRPC(profile), nil
 }
 
+func (c *gRPCController) UpdatePrivacy(ctx context.Context, req *pb.UpdatePrivacyRequest) (*pb.PrivacySettings, error) {
+	dto := domain.UpdatePrivacyDTO{
+		FullName:  domain.PrivacyLevel(req.FullName),
+		BirthDate: domain.PrivacyLevel(req.BirthDate),
+		Gender:    domain.PrivacyLevel(req.Gender),
+	}
+	if err := c.validate.Struct(dto); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	settings, err := c.svc.UpdatePrivacy(ctx, userID, dto)
+	if err != nil {
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			return nil, status.Errorf(codes.NotFound, "profile not found")
+		}
+		logger.Extract(ctx).Error("failed to update privacy", "error", err)
+		return nil, status.Error(codes.Internal, "failed to update privacy")
+	}
+	return privacyToGRPC(settings), nil
+}
+
+func (c *gRPCController) ListContacts(ctx context.Context, req *pb.ListContactsRequest) (*pb.ListContactsResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	ids, err := c.svc.ListContacts(ctx, userID)
+	if err != nil {
+		logger.Extract(ctx).Error("failed to list contacts", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list contacts")
+	}
+	return &pb.ListContactsResponse{UserIds: ids}, nil
+}
+
+func (c *gRPCController) AddContact(ctx context.Context, req *pb.AddContactRequest) (*pb.AddContactResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid contact id")
+	}
+	if err := c.svc.AddContact(ctx, userID, req.UserId); err != nil {
+		if errors.Is(err, domain.ErrContactSelf) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			return nil, status.Errorf(codes.NotFound, "profile not found")
+		}
+		logger.Extract(ctx).Error("failed to add contact", "error", err)
+		return nil, status.Error(codes.Internal, "failed to add contact")
+	}
+	return &pb.AddContactResponse{}, nil
+}
+
+func (c *gRPCController) DeleteContact(ctx context.Context, req *pb.DeleteContactRequest) (*pb.DeleteContactResponse, error) {
+	userID := auth.ExtractUserID(ctx)
+	if err := c.validate.Var(userID, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid contact id")
+	}
+	if err := c.svc.DeleteContact(ctx, userID, req.UserId); err != nil {
+		logger.Extract(ctx).Error("failed to delete contact", "error", err)
+		return nil, status.Error(codes.Internal, "failed to delete contact")
+	}
+	return &pb.DeleteContactResponse{}, nil
+}
+
 func domainToGRPC(profile domain.Profile) *pb.ProfileResponse {
+	var privacy *pb.PrivacySettings
+	if profile.Privacy != (domain.PrivacySettings{}) {
+		privacy = privacyToGRPC(profile.Privacy)
+	}
 	return &pb.ProfileResponse{
 		UserId:    profile.UserID,
 		Username:  profile.Username,
@@ -91,5 +229,16 @@ func domainToGRPC(profile domain.Profile) *pb.ProfileResponse {
 		BirthDate: profile.BirthDate,
 		Gender:    string(profile.Gender),
 		Avatar:    profile.Avatar,
+		Locale:    profile.Locale,
+		Privacy:   privacy,
+		Version:   profile.Version,
+	}
+}
+
+func privacyToGRPC(settings domain.PrivacySettings) *pb.PrivacySettings {
+	return &pb.PrivacySettings{
+		FullName:  string(settings.FullName),
+		BirthDate: string(settings.BirthDate),
+		Gender:    string(settings.Gender),
 	}
 }
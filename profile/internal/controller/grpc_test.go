@@ -13,7 +13,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestGRPCController_GetProfile(t *testing.T) {
@@ -96,6 +99,39 @@ func TestGRPCController_UpdateProfile(t *testing.T) {
 			wantErr:      true,
 			mockBehavior: func(svc *mocks.ProfileService, args args) {},
 		},
+		{
+			name:         "unknown mask path",
+			args:         args{req: &pb.UpdateProfileRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}}}},
+			wantErr:      true,
+			mockBehavior: func(svc *mocks.ProfileService, args args) {},
+		},
+		{
+			name:    "with mask",
+			args:    args{req: &pb.UpdateProfileRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"last_name"}}}, userID: uuid.NewString()},
+			wantErr: false,
+			mockBehavior: func(svc *mocks.ProfileService, args args) {
+				svc.EXPECT().Update(mock.Anything, args.userID, domain.UpdateProfileDTO{Mask: []string{"last_name"}}).
+					Return(domain.Profile{}, nil).Once()
+			},
+		},
+		{
+			name:    "username changed recently",
+			args:    args{req: &pb.UpdateProfileRequest{Username: "new"}, userID: uuid.NewString()},
+			wantErr: true,
+			mockBehavior: func(svc *mocks.ProfileService, args args) {
+				svc.EXPECT().Update(mock.Anything, args.userID, domain.UpdateProfileDTO{Username: args.req.Username}).
+					Return(domain.Profile{}, domain.ErrUsernameCooldown).Once()
+			},
+		},
+		{
+			name:    "version mismatch",
+			args:    args{req: &pb.UpdateProfileRequest{FirstName: "John", ExpectedVersion: 1}, userID: uuid.NewString()},
+			wantErr: true,
+			mockBehavior: func(svc *mocks.ProfileService, args args) {
+				svc.EXPECT().Update(mock.Anything, args.userID, domain.UpdateProfileDTO{FirstName: "John", ExpectedVersion: 1}).
+					Return(domain.Profile{}, domain.ErrVersionMismatch).Once()
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -115,3 +151,122 @@ func TestGRPCController_UpdateProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCController_UpdatePrivacy(t *testing.T) {
+	type args struct {
+		req    *pb.UpdatePrivacyRequest
+		userID string
+	}
+	type MockBehavior func(svc *mocks.ProfileService, args args)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		args         args
+		want         *pb.PrivacySettings
+		wantErr      bool
+	}{
+		{
+			name: "success",
+			args: args{req: &pb.UpdatePrivacyRequest{Gender: "private"}, userID: uuid.NewString()},
+			want: &pb.PrivacySettings{FullName: "public", BirthDate: "contacts", Gender: "private"},
+			mockBehavior: func(svc *mocks.ProfileService, args args) {
+				svc.EXPECT().UpdatePrivacy(mock.Anything, args.userID, domain.UpdatePrivacyDTO{Gender: domain.PrivacyLevelPrivate}).
+					Return(domain.PrivacySettings{
+						FullName:  domain.PrivacyLevelPublic,
+						BirthDate: domain.PrivacyLevelContacts,
+						Gender:    domain.PrivacyLevelPrivate,
+					}, nil).Once()
+			},
+		},
+		{
+			name:         "invalid level",
+			args:         args{req: &pb.UpdatePrivacyRequest{FullName: "friends"}, userID: uuid.NewString()},
+			wantErr:      true,
+			mockBehavior: func(svc *mocks.ProfileService, args args) {},
+		},
+		{
+			name:         "invalid id",
+			args:         args{req: &pb.UpdatePrivacyRequest{}, userID: "invalid"},
+			wantErr:      true,
+			mockBehavior: func(svc *mocks.ProfileService, args args) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewProfileService(t)
+			tc.mockBehavior(svc, tc.args)
+			c := controller.NewGRPCController(svc)
+			md := metadata.New(map[string]string{auth.UserIdKey: tc.args.userID})
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			got, err := c.UpdatePrivacy(ctx, tc.args.req)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want.FullName, got.FullName)
+			assert.Equal(t, tc.want.BirthDate, got.BirthDate)
+			assert.Equal(t, tc.want.Gender, got.Gender)
+		})
+	}
+}
+
+func TestGRPCController_AddContact(t *testing.T) {
+	type args struct {
+		req    *pb.AddContactRequest
+		userID string
+	}
+	type MockBehavior func(svc *mocks.ProfileService, args args)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		args         args
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			args: args{req: &pb.AddContactRequest{UserId: uuid.NewString()}, userID: uuid.NewString()},
+			mockBehavior: func(svc *mocks.ProfileService, args args) {
+				svc.EXPECT().AddContact(mock.Anything, args.userID, args.req.UserId).Return(nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "contact not found",
+			args: args{req: &pb.AddContactRequest{UserId: uuid.NewString()}, userID: uuid.NewString()},
+			mockBehavior: func(svc *mocks.ProfileService, args args) {
+				svc.EXPECT().AddContact(mock.Anything, args.userID, args.req.UserId).Return(domain.ErrProfileNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "self",
+			args: args{req: &pb.AddContactRequest{UserId: "1a7b5c3e-0d6f-4a4e-9b1c-2f3e4d5a6b7c"}, userID: "1a7b5c3e-0d6f-4a4e-9b1c-2f3e4d5a6b7c"},
+			mockBehavior: func(svc *mocks.ProfileService, args args) {
+				svc.EXPECT().AddContact(mock.Anything, args.userID, args.req.UserId).Return(domain.ErrContactSelf).Once()
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:         "invalid contact id",
+			args:         args{req: &pb.AddContactRequest{UserId: "invalid"}, userID: uuid.NewString()},
+			mockBehavior: func(svc *mocks.ProfileService, args args) {},
+			wantCode:     codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewProfileService(t)
+			tc.mockBehavior(svc, tc.args)
+			c := controller.NewGRPCController(svc)
+			md := metadata.New(map[string]string{auth.UserIdKey: tc.args.userID})
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			_, err := c.AddContact(ctx, tc.args.req)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
@@ -23,6 +23,102 @@ func (_m *ProfileService) EXPECT() *ProfileService_Expecter {
 	return &ProfileService_Expecter{mock: &_m.Mock}
 }
 
+// AddContact provides a mock function with given fields: ctx, userID, contactID
+func (_m *ProfileService) AddContact(ctx context.Context, userID string, contactID string) error {
+	ret := _m.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProfileService_AddContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddContact'
+type ProfileService_AddContact_Call struct {
+	*mock.Call
+}
+
+// AddContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - contactID string
+func (_e *ProfileService_Expecter) AddContact(ctx interface{}, userID interface{}, contactID interface{}) *ProfileService_AddContact_Call {
+	return &ProfileService_AddContact_Call{Call: _e.mock.On("AddContact", ctx, userID, contactID)}
+}
+
+func (_c *ProfileService_AddContact_Call) Run(run func(ctx context.Context, userID string, contactID string)) *ProfileService_AddContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileService_AddContact_Call) Return(_a0 error) *ProfileService_AddContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProfileService_AddContact_Call) RunAndReturn(run func(context.Context, string, string) error) *ProfileService_AddContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteContact provides a mock function with given fields: ctx, userID, contactID
+func (_m *ProfileService) DeleteContact(ctx context.Context, userID string, contactID string) error {
+	ret := _m.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProfileService_DeleteContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContact'
+type ProfileService_DeleteContact_Call struct {
+	*mock.Call
+}
+
+// DeleteContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - contactID string
+func (_e *ProfileService_Expecter) DeleteContact(ctx interface{}, userID interface{}, contactID interface{}) *ProfileService_DeleteContact_Call {
+	return &ProfileService_DeleteContact_Call{Call: _e.mock.On("DeleteContact", ctx, userID, contactID)}
+}
+
+func (_c *ProfileService_DeleteContact_Call) Run(run func(ctx context.Context, userID string, contactID string)) *ProfileService_DeleteContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileService_DeleteContact_Call) Return(_a0 error) *ProfileService_DeleteContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProfileService_DeleteContact_Call) RunAndReturn(run func(context.Context, string, string) error) *ProfileService_DeleteContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProfile provides a mock function with given fields: ctx, userID
 func (_m *ProfileService) GetProfile(ctx context.Context, userID string) (domain.Profile, error) {
 	ret := _m.Called(ctx, userID)
@@ -80,6 +176,181 @@ func (_c *ProfileService_GetProfile_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetProfileByUsername provides a mock function with given fields: ctx, viewerID, username
+func (_m *ProfileService) GetProfileByUsername(ctx context.Context, viewerID string, username string) (domain.Profile, error) {
+	ret := _m.Called(ctx, viewerID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfileByUsername")
+	}
+
+	var r0 domain.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Profile, error)); ok {
+		return rf(ctx, viewerID, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Profile); ok {
+		r0 = rf(ctx, viewerID, username)
+	} else {
+		r0 = ret.Get(0).(domain.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, viewerID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileService_GetProfileByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfileByUsername'
+type ProfileService_GetProfileByUsername_Call struct {
+	*mock.Call
+}
+
+// GetProfileByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerID string
+//   - username string
+func (_e *ProfileService_Expecter) GetProfileByUsername(ctx interface{}, viewerID interface{}, username interface{}) *ProfileService_GetProfileByUsername_Call {
+	return &ProfileService_GetProfileByUsername_Call{Call: _e.mock.On("GetProfileByUsername", ctx, viewerID, username)}
+}
+
+func (_c *ProfileService_GetProfileByUsername_Call) Run(run func(ctx context.Context, viewerID string, username string)) *ProfileService_GetProfileByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileService_GetProfileByUsername_Call) Return(_a0 domain.Profile, _a1 error) *ProfileService_GetProfileByUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileService_GetProfileByUsername_Call) RunAndReturn(run func(context.Context, string, string) (domain.Profile, error)) *ProfileService_GetProfileByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserProfile provides a mock function with given fields: ctx, viewerID, userID
+func (_m *ProfileService) GetUserProfile(ctx context.Context, viewerID string, userID string) (domain.Profile, error) {
+	ret := _m.Called(ctx, viewerID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserProfile")
+	}
+
+	var r0 domain.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Profile, error)); ok {
+		return rf(ctx, viewerID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Profile); ok {
+		r0 = rf(ctx, viewerID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, viewerID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileService_GetUserProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserProfile'
+type ProfileService_GetUserProfile_Call struct {
+	*mock.Call
+}
+
+// GetUserProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerID string
+//   - userID string
+func (_e *ProfileService_Expecter) GetUserProfile(ctx interface{}, viewerID interface{}, userID interface{}) *ProfileService_GetUserProfile_Call {
+	return &ProfileService_GetUserProfile_Call{Call: _e.mock.On("GetUserProfile", ctx, viewerID, userID)}
+}
+
+func (_c *ProfileService_GetUserProfile_Call) Run(run func(ctx context.Context, viewerID string, userID string)) *ProfileService_GetUserProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileService_GetUserProfile_Call) Return(_a0 domain.Profile, _a1 error) *ProfileService_GetUserProfile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileService_GetUserProfile_Call) RunAndReturn(run func(context.Context, string, string) (domain.Profile, error)) *ProfileService_GetUserProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListContacts provides a mock function with given fields: ctx, userID
+func (_m *ProfileService) ListContacts(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListContacts")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileService_ListContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListContacts'
+type ProfileService_ListContacts_Call struct {
+	*mock.Call
+}
+
+// ListContacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ProfileService_Expecter) ListContacts(ctx interface{}, userID interface{}) *ProfileService_ListContacts_Call {
+	return &ProfileService_ListContacts_Call{Call: _e.mock.On("ListContacts", ctx, userID)}
+}
+
+func (_c *ProfileService_ListContacts_Call) Run(run func(ctx context.Context, userID string)) *ProfileService_ListContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileService_ListContacts_Call) Return(_a0 []string, _a1 error) *ProfileService_ListContacts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileService_ListContacts_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *ProfileService_ListContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, userID, dto
 func (_m *ProfileService) Update(ctx context.Context, userID string, dto domain.UpdateProfileDTO) (domain.Profile, error) {
 	ret := _m.Called(ctx, userID, dto)
@@ -138,6 +409,64 @@ func (_c *ProfileService_Update_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
+// UpdatePrivacy provides a mock function with given fields: ctx, userID, dto
+func (_m *ProfileService) UpdatePrivacy(ctx context.Context, userID string, dto domain.UpdatePrivacyDTO) (domain.PrivacySettings, error) {
+	ret := _m.Called(ctx, userID, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrivacy")
+	}
+
+	var r0 domain.PrivacySettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.UpdatePrivacyDTO) (domain.PrivacySettings, error)); ok {
+		return rf(ctx, userID, dto)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.UpdatePrivacyDTO) domain.PrivacySettings); ok {
+		r0 = rf(ctx, userID, dto)
+	} else {
+		r0 = ret.Get(0).(domain.PrivacySettings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.UpdatePrivacyDTO) error); ok {
+		r1 = rf(ctx, userID, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileService_UpdatePrivacy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrivacy'
+type ProfileService_UpdatePrivacy_Call struct {
+	*mock.Call
+}
+
+// UpdatePrivacy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - dto domain.UpdatePrivacyDTO
+func (_e *ProfileService_Expecter) UpdatePrivacy(ctx interface{}, userID interface{}, dto interface{}) *ProfileService_UpdatePrivacy_Call {
+	return &ProfileService_UpdatePrivacy_Call{Call: _e.mock.On("UpdatePrivacy", ctx, userID, dto)}
+}
+
+func (_c *ProfileService_UpdatePrivacy_Call) Run(run func(ctx context.Context, userID string, dto domain.UpdatePrivacyDTO)) *ProfileService_UpdatePrivacy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.UpdatePrivacyDTO))
+	})
+	return _c
+}
+
+func (_c *ProfileService_UpdatePrivacy_Call) Return(_a0 domain.PrivacySettings, _a1 error) *ProfileService_UpdatePrivacy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileService_UpdatePrivacy_Call) RunAndReturn(run func(context.Context, string, domain.UpdatePrivacyDTO) (domain.PrivacySettings, error)) *ProfileService_UpdatePrivacy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewProfileService creates a new instance of ProfileService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProfileService(t interface {
@@ -0,0 +1,7 @@
+package domain
+
+type OutboxEvent struct {
+	ID      int64
+	Topic   string
+	Payload []byte
+}
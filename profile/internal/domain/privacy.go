@@ -0,0 +1,44 @@
+package domain
+
+import "errors"
+
+var ErrContactSelf = errors.New("own profile can not be added to contacts")
+
+type PrivacyLevel string
+
+const (
+	PrivacyLevelPublic   PrivacyLevel = "public"
+	PrivacyLevelContacts PrivacyLevel = "contacts"
+	PrivacyLevelPrivate  PrivacyLevel = "private"
+)
+
+// Visible reports whether a field with this level can be shown to another user
+func (l PrivacyLevel) Visible(isContact bool) bool {
+	switch l {
+	case PrivacyLevelPublic:
+		return true
+	case PrivacyLevelContacts:
+		return isContact
+	default:
+		return false
+	}
+}
+
+type PrivacySettings struct {
+	FullName  PrivacyLevel
+	BirthDate PrivacyLevel
+	Gender    PrivacyLevel
+}
+
+// Used when user never changed privacy settings, must match defaults in migration
+var DefaultPrivacySettings = PrivacySettings{
+	FullName:  PrivacyLevelPublic,
+	BirthDate: PrivacyLevelContacts,
+	Gender:    PrivacyLevelContacts,
+}
+
+type UpdatePrivacyDTO struct {
+	FullName  PrivacyLevel `validate:"omitempty,oneof=public contacts private"`
+	BirthDate PrivacyLevel `validate:"omitempty,oneof=public contacts private"`
+	Gender    PrivacyLevel `validate:"omitempty,oneof=public contacts private"`
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"slices"
+)
 
 type UserGender string
 
@@ -18,8 +21,67 @@ type Profile struct {
 	BirthDate string
 	Gender    UserGender
 	Avatar    string
+	// BCP 47 language tag of notifications, empty means not chosen
+	Locale  string
+	Privacy PrivacySettings
+	// Incremented on every update, used for optimistic concurrency control
+	Version int64
+}
+
+// Restrict hides fields that viewer is not allowed to see, privacy settings are hidden too
+func (p Profile) Restrict(settings PrivacySettings, isContact bool) Profile {
+	if !settings.FullName.Visible(isContact) {
+		p.FirstName = ""
+		p.LastName = ""
+	}
+	if !settings.BirthDate.Visible(isContact) {
+		p.BirthDate = ""
+	}
+	if !settings.Gender.Visible(isContact) {
+		p.Gender = ""
+	}
+	p.Locale = ""
+	p.Privacy = PrivacySettings{}
+	return p
+}
+
+// Changes returns fields that differ from old profile, keys are Field* names
+func (p Profile) Changes(old Profile) map[string]string {
+	changes := make(map[string]string)
+	if p.Username != old.Username {
+		changes[FieldUsername] = p.Username
+	}
+	if p.FirstName != old.FirstName {
+		changes[FieldFirstName] = p.FirstName
+	}
+	if p.LastName != old.LastName {
+		changes[FieldLastName] = p.LastName
+	}
+	if p.BirthDate != old.BirthDate {
+		changes[FieldBirthDate] = p.BirthDate
+	}
+	if p.Gender != old.Gender {
+		changes[FieldGender] = string(p.Gender)
+	}
+	if p.Avatar != old.Avatar {
+		changes[FieldAvatar] = p.Avatar
+	}
+	if p.Locale != old.Locale {
+		changes[FieldLocale] = p.Locale
+	}
+	return changes
 }
 
+const (
+	FieldUsername  = "username"
+	FieldFirstName = "first_name"
+	FieldLastName  = "last_name"
+	FieldBirthDate = "birth_date"
+	FieldGender    = "gender"
+	FieldAvatar    = "avatar"
+	FieldLocale    = "locale"
+)
+
 type UpdateProfileDTO struct {
 	Username  string
 	FirstName string
@@ -27,9 +89,43 @@ type UpdateProfileDTO struct {
 	BirthDate string     `validate:"omitempty,datetime=2006-01-02"`
 	Gender    UserGender `validate:"omitempty,oneof=male female"`
 	Avatar    []byte
+	Locale    string `validate:"omitempty,bcp47_language_tag"`
+	// Fields explicitly set by client, empty values of these fields clear them.
+	// If mask is empty, only non-empty values are applied
+	Mask []string `validate:"dive,oneof=username first_name last_name birth_date gender avatar locale"`
+	// Version of profile known to client, zero means no check
+	ExpectedVersion int64 `validate:"gte=0"`
+}
+
+// IsSet reports whether field must be applied to profile
+func (dto UpdateProfileDTO) IsSet(field string) bool {
+	if len(dto.Mask) != 0 {
+		return slices.Contains(dto.Mask, field)
+	}
+	switch field {
+	case FieldUsername:
+		return dto.Username != ""
+	case FieldFirstName:
+		return dto.FirstName != ""
+	case FieldLastName:
+		return dto.LastName != ""
+	case FieldBirthDate:
+		return dto.BirthDate != ""
+	case FieldGender:
+		return dto.Gender != ""
+	case FieldAvatar:
+		return dto.Avatar != nil
+	case FieldLocale:
+		return dto.Locale != ""
+	default:
+		return false
+	}
 }
 
 var (
 	ErrProfileNotFound = errors.New("profile not found")
+	ErrProfileExists   = errors.New("profile already exists")
 	ErrUsernameExists  = errors.New("username already exists")
+	ErrUsernameEmpty   = errors.New("username can not be empty")
+	ErrVersionMismatch = errors.New("profile was modified concurrently")
 )
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 32
+	// Minimal time between two username changes
+	UsernameChangeCooldown = time.Hour * 24 * 14
+	// Time during which old username can not be taken by another user
+	UsernameReservationPeriod = time.Hour * 24 * 30
+)
+
+var usernameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_.]*$`)
+
+var ReservedUsernames = []string{
+	"admin", "administrator", "root", "system", "support", "help", "moderator",
+	"api", "auth", "sso", "profile", "profiles", "notification", "notifications",
+	"settings", "account", "accounts", "login", "logout", "register", "me", "my",
+	"null", "undefined", "anonymous",
+}
+
+var (
+	ErrInvalidUsername  = errors.New("username must be 3-32 characters of latin letters, digits, underscores and dots")
+	ErrUsernameReserved = errors.New("username is reserved")
+	ErrUsernameCooldown = errors.New("username was changed recently")
+)
+
+// ValidateUsername checks format of username and that it is not reserved
+func ValidateUsername(username string) error {
+	if len(username) < UsernameMinLength || len(username) > UsernameMaxLength {
+		return ErrInvalidUsername
+	}
+	if !usernameRegexp.MatchString(username) {
+		return ErrInvalidUsername
+	}
+	if slices.Contains(ReservedUsernames, username) {
+		return ErrUsernameReserved
+	}
+	return nil
+}
+
+// NormalizeUsername makes valid username base from arbitrary string, for example email local part
+func NormalizeUsername(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	username := strings.TrimLeft(b.String(), "_.")
+	if len(username) > UsernameMaxLength {
+		username = username[:UsernameMaxLength]
+	}
+	if len(username) < UsernameMinLength || slices.Contains(ReservedUsernames, username) {
+		username = "user_" + username
+	}
+	return username
+}
@@ -14,6 +14,8 @@ type Profile struct {
 	BirthDate sql.NullString `db:"birth_date"`
 	Gender    string         `db:"gender"`
 	Avatar    sql.NullString `db:"avatar"`
+	Locale    sql.NullString `db:"locale"`
+	Version   int64          `db:"version"`
 }
 
 func (p Profile) ToDomain() domain.Profile {
@@ -25,5 +27,36 @@ func (p Profile) ToDomain() domain.Profile {
 		BirthDate: p.BirthDate.String,
 		Gender:    domain.UserGender(p.Gender),
 		Avatar:    p.Avatar.String,
+		Locale:    p.Locale.String,
+		Version:   p.Version,
+	}
+}
+
+type PrivacySettings struct {
+	UserID    string `db:"user_id"`
+	FullName  string `db:"full_name"`
+	BirthDate string `db:"birth_date"`
+	Gender    string `db:"gender"`
+}
+
+func (p PrivacySettings) ToDomain() domain.PrivacySettings {
+	return domain.PrivacySettings{
+		FullName:  domain.PrivacyLevel(p.FullName),
+		BirthDate: domain.PrivacyLevel(p.BirthDate),
+		Gender:    domain.PrivacyLevel(p.Gender),
+	}
+}
+
+type OutboxEvent struct {
+	ID      int64  `db:"id"`
+	Topic   string `db:"topic"`
+	Payload []byte `db:"payload"`
+}
+
+func (e OutboxEvent) ToDomain() domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:      e.ID,
+		Topic:   e.Topic,
+		Payload: e.Payload,
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/e"
 	conf "github.com/SergeyBogomolovv/profile-manager/profile/internal/config"
@@ -15,6 +16,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
 )
 
 type imageRepo struct {
@@ -38,6 +40,7 @@ func MustNewImageRepo(conf conf.S3) *imageRepo {
 	return &imageRepo{manager, client, conf.Bucket}
 }
 
+// UploadAvatar stores image under new key, so previous avatar stays available until it is deleted
 func (u *imageRepo) UploadAvatar(ctx context.Context, userID string, body []byte) (string, error) {
 	hash := sha256.Sum256(body)
 	sha256Hex := hex.EncodeToString(hash[:])
@@ -55,10 +58,15 @@ func (u *imageRepo) UploadAvatar(ctx context.Context, userID string, body []byte
 	return result.Location, nil
 }
 
-func (u *imageRepo) DeleteAvatar(ctx context.Context, userID string) error {
+// DeleteAvatar deletes avatar by its url, avatars stored outside of bucket are ignored
+func (u *imageRepo) DeleteAvatar(ctx context.Context, url string) error {
+	i := strings.Index(url, folder+"/")
+	if i < 0 {
+		return nil
+	}
 	_, err := u.client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(u.bucket),
-		Key:    aws.String(avatarKey(userID)),
+		Key:    aws.String(url[i:]),
 	})
 	return e.WrapIfErr(err, "failed to delete avatar")
 }
@@ -66,5 +74,5 @@ func (u *imageRepo) DeleteAvatar(ctx context.Context, userID string) error {
 const folder = "avatars"
 
 func avatarKey(userID string) string {
-	return fmt.Sprintf("%s/%s.jpg", folder, userID)
+	return fmt.Sprintf("%s/%s/%s.jpg", folder, userID, uuid.NewString())
 }
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
+)
+
+// SaveEvent stores event in outbox, call it in the same transaction as the change
+func (r *profileRepo) SaveEvent(ctx context.Context, topic string, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return e.Wrap(err, "failed to marshal event")
+	}
+	query, args := r.qb.Insert("outbox").Columns("topic", "payload").Values(topic, payload).MustSql()
+	_, err = r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to save event")
+}
+
+// PendingEvents locks unpublished events, rows locked by other instances are skipped
+func (r *profileRepo) PendingEvents(ctx context.Context, limit int) ([]domain.OutboxEvent, error) {
+	query, args := r.qb.Select("id", "topic", "payload").
+		From("outbox").
+		Where(sq.Eq{"published_at": nil}).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		MustSql()
+	var entities []OutboxEvent
+	if err := r.selectContext(ctx, &entities, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to get pending events")
+	}
+	events := make([]domain.OutboxEvent, len(entities))
+	for i, entity := range entities {
+		events[i] = entity.ToDomain()
+	}
+	return events, nil
+}
+
+func (r *profileRepo) MarkPublished(ctx context.Context, ids []int64) error {
+	query, args := r.qb.Update("outbox").
+		Set("published_at", time.Now()).
+		Where(sq.Eq{"id": ids}).
+		MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to mark events published")
+}
+
+// DeletePublished deletes at most limit events published before given time and returns number of deleted events
+func (r *profileRepo) DeletePublished(ctx context.Context, before time.Time, limit int) (int64, error) {
+	// Subquery keeps ? placeholders, they are numbered once in outer query
+	expired := sq.Select("id").
+		From("outbox").
+		Where(sq.Lt{"published_at": before}).
+		OrderBy("id").
+		Limit(uint64(limit))
+	query, args := r.qb.Delete("outbox").Where(sq.Expr("id IN (?)", expired)).MustSql()
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, e.Wrap(err, "failed to delete published events")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, e.Wrap(err, "failed to get affected rows")
+	}
+	return n, nil
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
+	"github.com/lib/pq"
+)
+
+// If user never changed settings, returns domain.DefaultPrivacySettings
+func (r *profileRepo) PrivacyByID(ctx context.Context, userID string) (domain.PrivacySettings, error) {
+	query, args := r.qb.Select("*").From("privacy_settings").Where(sq.Eq{"user_id": userID}).MustSql()
+	var settings PrivacySettings
+	if err := r.getContext(ctx, &settings, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.DefaultPrivacySettings, nil
+		}
+		return domain.PrivacySettings{}, e.Wrap(err, "failed to get privacy settings")
+	}
+	return settings.ToDomain(), nil
+}
+
+func (r *profileRepo) UpdatePrivacy(ctx context.Context, userID string, settings domain.PrivacySettings) error {
+	query, args := r.qb.Insert("privacy_settings").
+		Columns("user_id", "full_name", "birth_date", "gender").
+		Values(userID, settings.FullName, settings.BirthDate, settings.Gender).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET full_name = EXCLUDED.full_name, birth_date = EXCLUDED.birth_date, gender = EXCLUDED.gender").
+		MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to update privacy settings")
+}
+
+func (r *profileRepo) IsContact(ctx context.Context, userID, contactID string) (bool, error) {
+	query, args := r.qb.Select("TRUE").From("contacts").Where(sq.Eq{"user_id": userID, "contact_id": contactID}).MustSql()
+	var ok bool
+	err := r.getContext(ctx, &ok, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, e.Wrap(err, "failed to check contact")
+	}
+	return ok, nil
+}
+
+// AddContact returns domain.ErrProfileNotFound if user or contact has no profile, existing contact is kept
+func (r *profileRepo) AddContact(ctx context.Context, userID, contactID string) error {
+	query, args := r.qb.Insert("contacts").
+		Columns("user_id", "contact_id").
+		Values(userID, contactID).
+		Suffix("ON CONFLICT (user_id, contact_id) DO NOTHING").
+		MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "foreign_key_violation" {
+		return domain.ErrProfileNotFound
+	}
+	return e.WrapIfErr(err, "failed to add contact")
+}
+
+func (r *profileRepo) DeleteContact(ctx context.Context, userID, contactID string) error {
+	query, args := r.qb.Delete("contacts").Where(sq.Eq{"user_id": userID, "contact_id": contactID}).MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to delete contact")
+}
+
+// Contacts returns IDs of user's contacts, recently added first
+func (r *profileRepo) Contacts(ctx context.Context, userID string) ([]string, error) {
+	query, args := r.qb.Select("contact_id").From("contacts").Where(sq.Eq{"user_id": userID}).OrderBy("created_at DESC").MustSql()
+	var ids []string
+	if err := r.selectContext(ctx, &ids, query, args...); err != nil {
+		return nil, e.Wrap(err, "failed to get contacts")
+	}
+	return ids, nil
+}
@@ -7,8 +7,10 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type profileRepo struct {
@@ -34,15 +36,30 @@ func (r *profileRepo) Create(ctx context.Context, profile domain.Profile) error
 		m["avatar"] = profile.Avatar
 	}
 
-	query, args := r.qb.Insert("profiles").SetMap(m).MustSql()
-	_, err := r.db.ExecContext(ctx, query, args...)
-	return e.WrapIfErr(err, "failed to create profile")
+	// Profile can be already created if register event was redelivered
+	query, args := r.qb.Insert("profiles").SetMap(m).Suffix("ON CONFLICT (user_id) DO NOTHING").MustSql()
+	res, err := r.execContext(ctx, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return domain.ErrUsernameExists
+	}
+	if err != nil {
+		return e.Wrap(err, "failed to create profile")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return e.Wrap(err, "failed to create profile")
+	}
+	if n == 0 {
+		return domain.ErrProfileExists
+	}
+	return nil
 }
 
 func (r *profileRepo) ProfileByID(ctx context.Context, id string) (domain.Profile, error) {
 	query, args := r.qb.Select("*").From("profiles").Where(sq.Eq{"user_id": id}).MustSql()
 	var profile Profile
-	if err := r.db.GetContext(ctx, &profile, query, args...); err != nil {
+	if err := r.getContext(ctx, &profile, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Profile{}, domain.ErrProfileNotFound
 		}
@@ -51,30 +68,47 @@ func (r *profileRepo) ProfileByID(ctx context.Context, id string) (domain.Profil
 	return profile.ToDomain(), nil
 }
 
+// Default name of UNIQUE constraint on profiles.username
+const usernameConstraint = "profiles_username_key"
+
 func (r *profileRepo) Update(ctx context.Context, profile *domain.Profile) error {
-	q := r.qb.Update("profiles").
+	query, args := r.qb.Update("profiles").
 		Set("username", profile.Username).
-		Set("first_name", profile.FirstName).
-		Set("last_name", profile.LastName).
+		Set("first_name", nullString(profile.FirstName)).
+		Set("last_name", nullString(profile.LastName)).
+		Set("birth_date", nullString(profile.BirthDate)).
 		Set("gender", profile.Gender).
-		Set("avatar", profile.Avatar)
-
-	if profile.BirthDate != "" {
-		q = q.Set("birth_date", profile.BirthDate)
-	}
-	query, args := q.Where(sq.Eq{"user_id": profile.UserID}).Suffix("RETURNING *").MustSql()
+		Set("avatar", nullString(profile.Avatar)).
+		Set("locale", nullString(profile.Locale)).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"user_id": profile.UserID, "version": profile.Version}).
+		Suffix("RETURNING *").MustSql()
 	var p Profile
-	if err := r.db.GetContext(ctx, &p, query, args...); err != nil {
+	if err := r.getContext(ctx, &p, query, args...); err != nil {
+		// Profile was changed since it was read
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrVersionMismatch
+		}
+		// Username was taken by concurrent update after it was checked
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" && pqErr.Constraint == usernameConstraint {
+			return domain.ErrUsernameExists
+		}
 		return e.Wrap(err, "failed to update profile")
 	}
 	*profile = p.ToDomain()
 	return nil
 }
 
+// Empty strings are stored as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 func (r *profileRepo) UsernameExists(ctx context.Context, username string) (bool, error) {
 	query, args := r.qb.Select("TRUE").From("profiles").Where(sq.Eq{"username": username}).MustSql()
 	var ex bool
-	err := r.db.GetContext(ctx, &ex, query, args...)
+	err := r.getContext(ctx, &ex, query, args...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return false, nil
 	}
@@ -83,3 +117,27 @@ func (r *profileRepo) UsernameExists(ctx context.Context, username string) (bool
 	}
 	return ex, nil
 }
+
+func (r *profileRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *profileRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
+
+func (r *profileRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
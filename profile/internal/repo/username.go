@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/e"
+	"github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
+)
+
+func (r *profileRepo) UserIDByUsername(ctx context.Context, username string) (string, error) {
+	query, args := r.qb.Select("user_id").From("profiles").Where(sq.Eq{"username": username}).MustSql()
+	var userID string
+	if err := r.getContext(ctx, &userID, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrProfileNotFound
+		}
+		return "", e.Wrap(err, "failed to get user id by username")
+	}
+	return userID, nil
+}
+
+func (r *profileRepo) SaveUsernameHistory(ctx context.Context, userID, username string) error {
+	query, args := r.qb.Insert("username_history").Columns("user_id", "username").Values(userID, username).MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return e.WrapIfErr(err, "failed to save username history")
+}
+
+// Returns user who used username after since, if there is no such user returns empty string
+func (r *profileRepo) UsernameReservedBy(ctx context.Context, username string, since time.Time) (string, error) {
+	query, args := r.qb.Select("user_id").From("username_history").
+		Where(sq.Eq{"username": username}).
+		Where(sq.Gt{"changed_at": since}).
+		OrderBy("changed_at DESC").
+		Limit(1).
+		MustSql()
+	var userID string
+	err := r.getContext(ctx, &userID, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", e.Wrap(err, "failed to check username reservation")
+	}
+	return userID, nil
+}
+
+// Returns zero time if username was never changed
+func (r *profileRepo) LastUsernameChange(ctx context.Context, userID string) (time.Time, error) {
+	query, args := r.qb.Select("MAX(changed_at)").From("username_history").Where(sq.Eq{"user_id": userID}).MustSql()
+	var changedAt sql.NullTime
+	if err := r.getContext(ctx, &changedAt, query, args...); err != nil {
+		return time.Time{}, e.Wrap(err, "failed to get last username change")
+	}
+	return changedAt.Time, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ProfileRepo is an autogenerated mock type for the ProfileRepo type
@@ -22,6 +24,113 @@ func (_m *ProfileRepo) EXPECT() *ProfileRepo_Expecter {
 	return &ProfileRepo_Expecter{mock: &_m.Mock}
 }
 
+// AddContact provides a mock function with given fields: ctx, userID, contactID
+func (_m *ProfileRepo) AddContact(ctx context.Context, userID string, contactID string) error {
+	ret := _m.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProfileRepo_AddContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddContact'
+type ProfileRepo_AddContact_Call struct {
+	*mock.Call
+}
+
+// AddContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - contactID string
+func (_e *ProfileRepo_Expecter) AddContact(ctx interface{}, userID interface{}, contactID interface{}) *ProfileRepo_AddContact_Call {
+	return &ProfileRepo_AddContact_Call{Call: _e.mock.On("AddContact", ctx, userID, contactID)}
+}
+
+func (_c *ProfileRepo_AddContact_Call) Run(run func(ctx context.Context, userID string, contactID string)) *ProfileRepo_AddContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_AddContact_Call) Return(_a0 error) *ProfileRepo_AddContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProfileRepo_AddContact_Call) RunAndReturn(run func(context.Context, string, string) error) *ProfileRepo_AddContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Contacts provides a mock function with given fields: ctx, userID
+func (_m *ProfileRepo) Contacts(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Contacts")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileRepo_Contacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Contacts'
+type ProfileRepo_Contacts_Call struct {
+	*mock.Call
+}
+
+// Contacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ProfileRepo_Expecter) Contacts(ctx interface{}, userID interface{}) *ProfileRepo_Contacts_Call {
+	return &ProfileRepo_Contacts_Call{Call: _e.mock.On("Contacts", ctx, userID)}
+}
+
+func (_c *ProfileRepo_Contacts_Call) Run(run func(ctx context.Context, userID string)) *ProfileRepo_Contacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_Contacts_Call) Return(_a0 []string, _a1 error) *ProfileRepo_Contacts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileRepo_Contacts_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *ProfileRepo_Contacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, profile
 func (_m *ProfileRepo) Create(ctx context.Context, profile domain.Profile) error {
 	ret := _m.Called(ctx, profile)
@@ -69,6 +178,226 @@ func (_c *ProfileRepo_Create_Call) RunAndReturn(run func(context.Context, domain
 	return _c
 }
 
+// DeleteContact provides a mock function with given fields: ctx, userID, contactID
+func (_m *ProfileRepo) DeleteContact(ctx context.Context, userID string, contactID string) error {
+	ret := _m.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContact")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProfileRepo_DeleteContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContact'
+type ProfileRepo_DeleteContact_Call struct {
+	*mock.Call
+}
+
+// DeleteContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - contactID string
+func (_e *ProfileRepo_Expecter) DeleteContact(ctx interface{}, userID interface{}, contactID interface{}) *ProfileRepo_DeleteContact_Call {
+	return &ProfileRepo_DeleteContact_Call{Call: _e.mock.On("DeleteContact", ctx, userID, contactID)}
+}
+
+func (_c *ProfileRepo_DeleteContact_Call) Run(run func(ctx context.Context, userID string, contactID string)) *ProfileRepo_DeleteContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_DeleteContact_Call) Return(_a0 error) *ProfileRepo_DeleteContact_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProfileRepo_DeleteContact_Call) RunAndReturn(run func(context.Context, string, string) error) *ProfileRepo_DeleteContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsContact provides a mock function with given fields: ctx, userID, contactID
+func (_m *ProfileRepo) IsContact(ctx context.Context, userID string, contactID string) (bool, error) {
+	ret := _m.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsContact")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, contactID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, contactID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, contactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileRepo_IsContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsContact'
+type ProfileRepo_IsContact_Call struct {
+	*mock.Call
+}
+
+// IsContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - contactID string
+func (_e *ProfileRepo_Expecter) IsContact(ctx interface{}, userID interface{}, contactID interface{}) *ProfileRepo_IsContact_Call {
+	return &ProfileRepo_IsContact_Call{Call: _e.mock.On("IsContact", ctx, userID, contactID)}
+}
+
+func (_c *ProfileRepo_IsContact_Call) Run(run func(ctx context.Context, userID string, contactID string)) *ProfileRepo_IsContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_IsContact_Call) Return(_a0 bool, _a1 error) *ProfileRepo_IsContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileRepo_IsContact_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *ProfileRepo_IsContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LastUsernameChange provides a mock function with given fields: ctx, userID
+func (_m *ProfileRepo) LastUsernameChange(ctx context.Context, userID string) (time.Time, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastUsernameChange")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileRepo_LastUsernameChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastUsernameChange'
+type ProfileRepo_LastUsernameChange_Call struct {
+	*mock.Call
+}
+
+// LastUsernameChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ProfileRepo_Expecter) LastUsernameChange(ctx interface{}, userID interface{}) *ProfileRepo_LastUsernameChange_Call {
+	return &ProfileRepo_LastUsernameChange_Call{Call: _e.mock.On("LastUsernameChange", ctx, userID)}
+}
+
+func (_c *ProfileRepo_LastUsernameChange_Call) Run(run func(ctx context.Context, userID string)) *ProfileRepo_LastUsernameChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_LastUsernameChange_Call) Return(_a0 time.Time, _a1 error) *ProfileRepo_LastUsernameChange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileRepo_LastUsernameChange_Call) RunAndReturn(run func(context.Context, string) (time.Time, error)) *ProfileRepo_LastUsernameChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PrivacyByID provides a mock function with given fields: ctx, userID
+func (_m *ProfileRepo) PrivacyByID(ctx context.Context, userID string) (domain.PrivacySettings, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrivacyByID")
+	}
+
+	var r0 domain.PrivacySettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.PrivacySettings, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.PrivacySettings); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.PrivacySettings)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileRepo_PrivacyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrivacyByID'
+type ProfileRepo_PrivacyByID_Call struct {
+	*mock.Call
+}
+
+// PrivacyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ProfileRepo_Expecter) PrivacyByID(ctx interface{}, userID interface{}) *ProfileRepo_PrivacyByID_Call {
+	return &ProfileRepo_PrivacyByID_Call{Call: _e.mock.On("PrivacyByID", ctx, userID)}
+}
+
+func (_c *ProfileRepo_PrivacyByID_Call) Run(run func(ctx context.Context, userID string)) *ProfileRepo_PrivacyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_PrivacyByID_Call) Return(_a0 domain.PrivacySettings, _a1 error) *ProfileRepo_PrivacyByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileRepo_PrivacyByID_Call) RunAndReturn(run func(context.Context, string) (domain.PrivacySettings, error)) *ProfileRepo_PrivacyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProfileByID provides a mock function with given fields: ctx, id
 func (_m *ProfileRepo) ProfileByID(ctx context.Context, id string) (domain.Profile, error) {
 	ret := _m.Called(ctx, id)
@@ -126,6 +455,102 @@ func (_c *ProfileRepo_ProfileByID_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// SaveEvent provides a mock function with given fields: ctx, topic, event
+func (_m *ProfileRepo) SaveEvent(ctx context.Context, topic string, event any) error {
+	ret := _m.Called(ctx, topic, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
+		r0 = rf(ctx, topic, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProfileRepo_SaveEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveEvent'
+type ProfileRepo_SaveEvent_Call struct {
+	*mock.Call
+}
+
+// SaveEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topic string
+//   - event any
+func (_e *ProfileRepo_Expecter) SaveEvent(ctx interface{}, topic interface{}, event interface{}) *ProfileRepo_SaveEvent_Call {
+	return &ProfileRepo_SaveEvent_Call{Call: _e.mock.On("SaveEvent", ctx, topic, event)}
+}
+
+func (_c *ProfileRepo_SaveEvent_Call) Run(run func(ctx context.Context, topic string, event any)) *ProfileRepo_SaveEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(any))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_SaveEvent_Call) Return(_a0 error) *ProfileRepo_SaveEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProfileRepo_SaveEvent_Call) RunAndReturn(run func(context.Context, string, any) error) *ProfileRepo_SaveEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveUsernameHistory provides a mock function with given fields: ctx, userID, username
+func (_m *ProfileRepo) SaveUsernameHistory(ctx context.Context, userID string, username string) error {
+	ret := _m.Called(ctx, userID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUsernameHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProfileRepo_SaveUsernameHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUsernameHistory'
+type ProfileRepo_SaveUsernameHistory_Call struct {
+	*mock.Call
+}
+
+// SaveUsernameHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - username string
+func (_e *ProfileRepo_Expecter) SaveUsernameHistory(ctx interface{}, userID interface{}, username interface{}) *ProfileRepo_SaveUsernameHistory_Call {
+	return &ProfileRepo_SaveUsernameHistory_Call{Call: _e.mock.On("SaveUsernameHistory", ctx, userID, username)}
+}
+
+func (_c *ProfileRepo_SaveUsernameHistory_Call) Run(run func(ctx context.Context, userID string, username string)) *ProfileRepo_SaveUsernameHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_SaveUsernameHistory_Call) Return(_a0 error) *ProfileRepo_SaveUsernameHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProfileRepo_SaveUsernameHistory_Call) RunAndReturn(run func(context.Context, string, string) error) *ProfileRepo_SaveUsernameHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, profile
 func (_m *ProfileRepo) Update(ctx context.Context, profile *domain.Profile) error {
 	ret := _m.Called(ctx, profile)
@@ -173,6 +598,111 @@ func (_c *ProfileRepo_Update_Call) RunAndReturn(run func(context.Context, *domai
 	return _c
 }
 
+// UpdatePrivacy provides a mock function with given fields: ctx, userID, settings
+func (_m *ProfileRepo) UpdatePrivacy(ctx context.Context, userID string, settings domain.PrivacySettings) error {
+	ret := _m.Called(ctx, userID, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrivacy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.PrivacySettings) error); ok {
+		r0 = rf(ctx, userID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ProfileRepo_UpdatePrivacy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrivacy'
+type ProfileRepo_UpdatePrivacy_Call struct {
+	*mock.Call
+}
+
+// UpdatePrivacy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - settings domain.PrivacySettings
+func (_e *ProfileRepo_Expecter) UpdatePrivacy(ctx interface{}, userID interface{}, settings interface{}) *ProfileRepo_UpdatePrivacy_Call {
+	return &ProfileRepo_UpdatePrivacy_Call{Call: _e.mock.On("UpdatePrivacy", ctx, userID, settings)}
+}
+
+func (_c *ProfileRepo_UpdatePrivacy_Call) Run(run func(ctx context.Context, userID string, settings domain.PrivacySettings)) *ProfileRepo_UpdatePrivacy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.PrivacySettings))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_UpdatePrivacy_Call) Return(_a0 error) *ProfileRepo_UpdatePrivacy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ProfileRepo_UpdatePrivacy_Call) RunAndReturn(run func(context.Context, string, domain.PrivacySettings) error) *ProfileRepo_UpdatePrivacy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserIDByUsername provides a mock function with given fields: ctx, username
+func (_m *ProfileRepo) UserIDByUsername(ctx context.Context, username string) (string, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserIDByUsername")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileRepo_UserIDByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserIDByUsername'
+type ProfileRepo_UserIDByUsername_Call struct {
+	*mock.Call
+}
+
+// UserIDByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *ProfileRepo_Expecter) UserIDByUsername(ctx interface{}, username interface{}) *ProfileRepo_UserIDByUsername_Call {
+	return &ProfileRepo_UserIDByUsername_Call{Call: _e.mock.On("UserIDByUsername", ctx, username)}
+}
+
+func (_c *ProfileRepo_UserIDByUsername_Call) Run(run func(ctx context.Context, username string)) *ProfileRepo_UserIDByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_UserIDByUsername_Call) Return(_a0 string, _a1 error) *ProfileRepo_UserIDByUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileRepo_UserIDByUsername_Call) RunAndReturn(run func(context.Context, string) (string, error)) *ProfileRepo_UserIDByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UsernameExists provides a mock function with given fields: ctx, username
 func (_m *ProfileRepo) UsernameExists(ctx context.Context, username string) (bool, error) {
 	ret := _m.Called(ctx, username)
@@ -230,6 +760,64 @@ func (_c *ProfileRepo_UsernameExists_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// UsernameReservedBy provides a mock function with given fields: ctx, username, since
+func (_m *ProfileRepo) UsernameReservedBy(ctx context.Context, username string, since time.Time) (string, error) {
+	ret := _m.Called(ctx, username, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsernameReservedBy")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (string, error)); ok {
+		return rf(ctx, username, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) string); ok {
+		r0 = rf(ctx, username, since)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, username, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileRepo_UsernameReservedBy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsernameReservedBy'
+type ProfileRepo_UsernameReservedBy_Call struct {
+	*mock.Call
+}
+
+// UsernameReservedBy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - since time.Time
+func (_e *ProfileRepo_Expecter) UsernameReservedBy(ctx interface{}, username interface{}, since interface{}) *ProfileRepo_UsernameReservedBy_Call {
+	return &ProfileRepo_UsernameReservedBy_Call{Call: _e.mock.On("UsernameReservedBy", ctx, username, since)}
+}
+
+func (_c *ProfileRepo_UsernameReservedBy_Call) Run(run func(ctx context.Context, username string, since time.Time)) *ProfileRepo_UsernameReservedBy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ProfileRepo_UsernameReservedBy_Call) Return(_a0 string, _a1 error) *ProfileRepo_UsernameReservedBy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileRepo_UsernameReservedBy_Call) RunAndReturn(run func(context.Context, string, time.Time) (string, error)) *ProfileRepo_UsernameReservedBy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewProfileRepo creates a new instance of ProfileRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewProfileRepo(t interface {
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
 	"strings"
+	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
 )
 
@@ -18,30 +23,127 @@ type ProfileRepo interface {
 	ProfileByID(ctx context.Context, id string) (domain.Profile, error)
 	Update(ctx context.Context, profile *domain.Profile) error
 	UsernameExists(ctx context.Context, username string) (bool, error)
+	PrivacyByID(ctx context.Context, userID string) (domain.PrivacySettings, error)
+	UpdatePrivacy(ctx context.Context, userID string, settings domain.PrivacySettings) error
+	IsContact(ctx context.Context, userID, contactID string) (bool, error)
+	AddContact(ctx context.Context, userID, contactID string) error
+	DeleteContact(ctx context.Context, userID, contactID string) error
+	Contacts(ctx context.Context, userID string) ([]string, error)
+	UserIDByUsername(ctx context.Context, username string) (string, error)
+	SaveUsernameHistory(ctx context.Context, userID, username string) error
+	UsernameReservedBy(ctx context.Context, username string, since time.Time) (string, error)
+	LastUsernameChange(ctx context.Context, userID string) (time.Time, error)
+	SaveEvent(ctx context.Context, topic string, event any) error
 }
 
 type profileService struct {
-	repo   ProfileRepo
-	images ImageRepo
+	txManager transaction.TxManager
+	repo      ProfileRepo
+	images    ImageRepo
 }
 
-func NewProfileService(repo ProfileRepo, images ImageRepo) *profileService {
-	return &profileService{repo: repo, images: images}
+func NewProfileService(txManager transaction.TxManager, repo ProfileRepo, images ImageRepo) *profileService {
+	return &profileService{txManager: txManager, repo: repo, images: images}
 }
 
 func (s *profileService) Create(ctx context.Context, user events.UserRegister) error {
-	username, _, _ := strings.Cut(user.Email, "@")
+	username, err := s.generateUsername(ctx, user.ID, user.Email)
+	if err != nil {
+		return err
+	}
 	profile := domain.Profile{
 		UserID:    user.ID,
 		Username:  username,
 		FirstName: user.Name,
 		Avatar:    user.Avatar,
 	}
-	return s.repo.Create(ctx, profile)
+	err = s.txManager.Run(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, profile); err != nil {
+			return err
+		}
+		return s.repo.SaveEvent(ctx, events.ProfileCreatedTopic, events.ProfileCreated{
+			ID:        profile.UserID,
+			Username:  profile.Username,
+			FirstName: profile.FirstName,
+			Avatar:    profile.Avatar,
+		})
+	})
+	// Register event was redelivered, event for this profile is already saved
+	if errors.Is(err, domain.ErrProfileExists) {
+		return nil
+	}
+	return err
 }
 
 func (s *profileService) GetProfile(ctx context.Context, userID string) (domain.Profile, error) {
-	return s.repo.ProfileByID(ctx, userID)
+	profile, err := s.repo.ProfileByID(ctx, userID)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	profile.Privacy, err = s.repo.PrivacyByID(ctx, userID)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	return profile, nil
+}
+
+// GetUserProfile returns profile of another user with applied privacy settings
+func (s *profileService) GetUserProfile(ctx context.Context, viewerID, userID string) (domain.Profile, error) {
+	if viewerID == userID {
+		return s.GetProfile(ctx, userID)
+	}
+	profile, err := s.repo.ProfileByID(ctx, userID)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	settings, err := s.repo.PrivacyByID(ctx, userID)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	isContact, err := s.repo.IsContact(ctx, userID, viewerID)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	return profile.Restrict(settings, isContact), nil
+}
+
+func (s *profileService) UpdatePrivacy(ctx context.Context, userID string, dto domain.UpdatePrivacyDTO) (domain.PrivacySettings, error) {
+	if _, err := s.repo.ProfileByID(ctx, userID); err != nil {
+		return domain.PrivacySettings{}, err
+	}
+	settings, err := s.repo.PrivacyByID(ctx, userID)
+	if err != nil {
+		return domain.PrivacySettings{}, err
+	}
+	if dto.FullName != "" {
+		settings.FullName = dto.FullName
+	}
+	if dto.BirthDate != "" {
+		settings.BirthDate = dto.BirthDate
+	}
+	if dto.Gender != "" {
+		settings.Gender = dto.Gender
+	}
+	if err := s.repo.UpdatePrivacy(ctx, userID, settings); err != nil {
+		return domain.PrivacySettings{}, err
+	}
+	return settings, nil
+}
+
+func (s *profileService) ListContacts(ctx context.Context, userID string) ([]string, error) {
+	return s.repo.Contacts(ctx, userID)
+}
+
+// AddContact allows contact to see fields of user with contacts privacy level
+func (s *profileService) AddContact(ctx context.Context, userID, contactID string) error {
+	if userID == contactID {
+		return domain.ErrContactSelf
+	}
+	return s.repo.AddContact(ctx, userID, contactID)
+}
+
+func (s *profileService) DeleteContact(ctx context.Context, userID, contactID string) error {
+	return s.repo.DeleteContact(ctx, userID, contactID)
 }
 
 func (s *profileService) Update(ctx context.Context, userID string, dto domain.UpdateProfileDTO) (domain.Profile, error) {
@@ -49,44 +151,155 @@ func (s *profileService) Update(ctx context.Context, userID string, dto domain.U
 	if err != nil {
 		return domain.Profile{}, err
 	}
-	if dto.Username != "" && profile.Username != dto.Username {
-		if err := s.checkUsername(ctx, dto.Username); err != nil {
+	if dto.ExpectedVersion != 0 && dto.ExpectedVersion != profile.Version {
+		return domain.Profile{}, domain.ErrVersionMismatch
+	}
+	old := profile
+	var previousUsername string
+	if dto.IsSet(domain.FieldUsername) && profile.Username != dto.Username {
+		if dto.Username == "" {
+			return domain.Profile{}, domain.ErrUsernameEmpty
+		}
+		if err := s.checkUsernameChange(ctx, userID, dto.Username); err != nil {
 			return domain.Profile{}, err
 		}
+		previousUsername = profile.Username
 		profile.Username = dto.Username
 	}
-	if dto.BirthDate != "" {
+	if dto.IsSet(domain.FieldBirthDate) {
 		profile.BirthDate = dto.BirthDate
 	}
-	if dto.FirstName != "" {
+	if dto.IsSet(domain.FieldFirstName) {
 		profile.FirstName = dto.FirstName
 	}
-	if dto.LastName != "" {
+	if dto.IsSet(domain.FieldLastName) {
 		profile.LastName = dto.LastName
 	}
-	if dto.Gender != "" {
-		profile.Gender = domain.UserGender(dto.Gender)
+	if dto.IsSet(domain.FieldGender) {
+		profile.Gender = dto.Gender
+		if profile.Gender == "" {
+			profile.Gender = domain.UserGenderNotSpecified
+		}
 	}
-	if dto.Avatar != nil {
-		profile.Avatar, err = s.images.UploadAvatar(ctx, profile.UserID, dto.Avatar)
-		if err != nil {
-			return domain.Profile{}, err
+	if dto.IsSet(domain.FieldLocale) {
+		profile.Locale = dto.Locale
+	}
+	// Storage is changed around transaction: new avatar is uploaded under new key before it,
+	// replaced one is deleted only after commit, so profile never points to missing object
+	var uploaded, replaced string
+	if dto.IsSet(domain.FieldAvatar) {
+		if len(dto.Avatar) != 0 {
+			uploaded, err = s.images.UploadAvatar(ctx, profile.UserID, dto.Avatar)
+			if err != nil {
+				return domain.Profile{}, err
+			}
 		}
+		replaced = profile.Avatar
+		profile.Avatar = uploaded
 	}
 
-	if err := s.repo.Update(ctx, &profile); err != nil {
+	err = s.txManager.Run(ctx, func(ctx context.Context) error {
+		if previousUsername != "" {
+			if err := s.repo.SaveUsernameHistory(ctx, userID, previousUsername); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.Update(ctx, &profile); err != nil {
+			return err
+		}
+		changes := profile.Changes(old)
+		if len(changes) == 0 {
+			return nil
+		}
+		return s.repo.SaveEvent(ctx, events.ProfileUpdatedTopic, events.ProfileUpdated{
+			ID:      profile.UserID,
+			Version: profile.Version,
+			Changes: changes,
+		})
+	})
+	if err != nil {
+		if uploaded != "" {
+			s.deleteAvatar(ctx, uploaded)
+		}
 		return domain.Profile{}, err
 	}
+	if replaced != "" {
+		s.deleteAvatar(ctx, replaced)
+	}
 	return profile, nil
 }
 
-func (s *profileService) checkUsername(ctx context.Context, username string) error {
-	ex, err := s.repo.UsernameExists(ctx, username)
+// deleteAvatar removes unreferenced avatar, failure only leaves orphaned object in storage
+func (s *profileService) deleteAvatar(ctx context.Context, url string) {
+	_ = s.images.DeleteAvatar(context.WithoutCancel(ctx), url)
+}
+
+// GetProfileByUsername resolves username to user, old usernames are resolved during reservation period
+func (s *profileService) GetProfileByUsername(ctx context.Context, viewerID, username string) (domain.Profile, error) {
+	userID, err := s.repo.UserIDByUsername(ctx, username)
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		userID, err = s.repo.UsernameReservedBy(ctx, username, time.Now().Add(-domain.UsernameReservationPeriod))
+		if err == nil && userID == "" {
+			err = domain.ErrProfileNotFound
+		}
+	}
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	return s.GetUserProfile(ctx, viewerID, userID)
+}
+
+func (s *profileService) checkUsernameChange(ctx context.Context, userID, username string) error {
+	if err := domain.ValidateUsername(username); err != nil {
+		return err
+	}
+	changedAt, err := s.repo.LastUsernameChange(ctx, userID)
 	if err != nil {
 		return err
 	}
-	if ex {
+	if time.Since(changedAt) < domain.UsernameChangeCooldown {
+		return domain.ErrUsernameCooldown
+	}
+	available, err := s.isUsernameAvailable(ctx, userID, username)
+	if err != nil {
+		return err
+	}
+	if !available {
 		return domain.ErrUsernameExists
 	}
 	return nil
 }
+
+// Username is available if nobody uses it and it is not reserved by another user
+func (s *profileService) isUsernameAvailable(ctx context.Context, userID, username string) (bool, error) {
+	ex, err := s.repo.UsernameExists(ctx, username)
+	if err != nil || ex {
+		return false, err
+	}
+	holder, err := s.repo.UsernameReservedBy(ctx, username, time.Now().Add(-domain.UsernameReservationPeriod))
+	if err != nil {
+		return false, err
+	}
+	return holder == "" || holder == userID, nil
+}
+
+const usernameAttempts = 10
+
+// Generates available username from email local part, adds random suffix on collisions
+func (s *profileService) generateUsername(ctx context.Context, userID, email string) (string, error) {
+	local, _, _ := strings.Cut(email, "@")
+	base := domain.NormalizeUsername(local)
+	username := base
+	for range usernameAttempts {
+		available, err := s.isUsernameAvailable(ctx, userID, username)
+		if err != nil {
+			return "", err
+		}
+		if available {
+			return username, nil
+		}
+		suffix := fmt.Sprintf("_%04d", rand.IntN(10000))
+		username = base[:min(len(base), domain.UsernameMaxLength-len(suffix))] + suffix
+	}
+	return "", fmt.Errorf("failed to generate username for %s: %w", email, domain.ErrUsernameExists)
+}
@@ -3,8 +3,10 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	txMocks "github.com/SergeyBogomolovv/profile-manager/common/transaction/mocks"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/domain"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/service"
 	"github.com/SergeyBogomolovv/profile-manager/profile/internal/service/mocks"
@@ -14,7 +16,7 @@ import (
 )
 
 func TestProfileService_Create(t *testing.T) {
-	type MockBehavior func(profiles *mocks.ProfileRepo, data events.UserRegister)
+	type MockBehavior func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, data events.UserRegister)
 
 	testCases := []struct {
 		name         string
@@ -28,11 +30,54 @@ func TestProfileService_Create(t *testing.T) {
 				ID:    "id",
 				Email: "user@email.com",
 			},
-			mockBehavior: func(profiles *mocks.ProfileRepo, data events.UserRegister) {
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, data events.UserRegister) {
+				expectTx(tx)
+				profiles.EXPECT().UsernameExists(mock.Anything, "user").Return(false, nil)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, "user", mock.Anything).Return("", nil)
 				profiles.EXPECT().Create(mock.Anything, domain.Profile{
 					UserID:   data.ID,
 					Username: "user",
 				}).Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileCreatedTopic, events.ProfileCreated{
+					ID:       data.ID,
+					Username: "user",
+				}).Return(nil)
+			},
+			want: nil,
+		},
+		{
+			name: "normalized username",
+			data: events.UserRegister{
+				ID:    "id",
+				Email: "John.Doe+tag@email.com",
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, data events.UserRegister) {
+				expectTx(tx)
+				profiles.EXPECT().UsernameExists(mock.Anything, "john.doe_tag").Return(false, nil)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, "john.doe_tag", mock.Anything).Return("", nil)
+				profiles.EXPECT().Create(mock.Anything, domain.Profile{
+					UserID:   data.ID,
+					Username: "john.doe_tag",
+				}).Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileCreatedTopic, mock.AnythingOfType("events.ProfileCreated")).Return(nil)
+			},
+			want: nil,
+		},
+		{
+			name: "username taken",
+			data: events.UserRegister{
+				ID:    "id",
+				Email: "user@email.com",
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, data events.UserRegister) {
+				expectTx(tx)
+				profiles.EXPECT().UsernameExists(mock.Anything, "user").Return(true, nil)
+				profiles.EXPECT().UsernameExists(mock.Anything, mock.AnythingOfType("string")).Return(false, nil)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return("", nil)
+				profiles.EXPECT().Create(mock.Anything, mock.MatchedBy(func(p domain.Profile) bool {
+					return p.UserID == data.ID && len(p.Username) == len("user_0000") && domain.ValidateUsername(p.Username) == nil
+				})).Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileCreatedTopic, mock.AnythingOfType("events.ProfileCreated")).Return(nil)
 			},
 			want: nil,
 		},
@@ -42,7 +87,10 @@ func TestProfileService_Create(t *testing.T) {
 				ID:    "id",
 				Email: "user@email.com",
 			},
-			mockBehavior: func(profiles *mocks.ProfileRepo, data events.UserRegister) {
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, data events.UserRegister) {
+				expectTx(tx)
+				profiles.EXPECT().UsernameExists(mock.Anything, "user").Return(false, nil)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, "user", mock.Anything).Return("", nil)
 				profiles.EXPECT().Create(mock.Anything, domain.Profile{
 					UserID:   data.ID,
 					Username: "user",
@@ -50,13 +98,31 @@ func TestProfileService_Create(t *testing.T) {
 			},
 			want: assert.AnError,
 		},
+		{
+			name: "already created",
+			data: events.UserRegister{
+				ID:    "id",
+				Email: "user@email.com",
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, data events.UserRegister) {
+				expectTx(tx)
+				profiles.EXPECT().UsernameExists(mock.Anything, "user").Return(false, nil)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, "user", mock.Anything).Return("", nil)
+				profiles.EXPECT().Create(mock.Anything, domain.Profile{
+					UserID:   data.ID,
+					Username: "user",
+				}).Return(domain.ErrProfileExists)
+			},
+			want: nil,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
 			profiles := mocks.NewProfileRepo(t)
-			svc := service.NewProfileService(profiles, nil)
-			tc.mockBehavior(profiles, tc.data)
+			svc := service.NewProfileService(tx, profiles, nil)
+			tc.mockBehavior(tx, profiles, tc.data)
 			err := svc.Create(context.Background(), tc.data)
 			assert.ErrorIs(t, err, tc.want)
 		})
@@ -69,7 +135,7 @@ func TestProfileService_Update(t *testing.T) {
 		dto    domain.UpdateProfileDTO
 	}
 
-	type MockBehavior func(profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args)
+	type MockBehavior func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args)
 	testCases := []struct {
 		name         string
 		args         args
@@ -85,10 +151,17 @@ func TestProfileService_Update(t *testing.T) {
 					Username: "username",
 				},
 			},
-			mockBehavior: func(profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
 				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{Username: "user"}, nil)
+				profiles.EXPECT().LastUsernameChange(mock.Anything, args.userID).Return(time.Time{}, nil)
 				profiles.EXPECT().UsernameExists(mock.Anything, args.dto.Username).Return(false, nil)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, args.dto.Username, mock.Anything).Return("", nil)
+				profiles.EXPECT().SaveUsernameHistory(mock.Anything, args.userID, "user").Return(nil)
 				profiles.EXPECT().Update(mock.Anything, &domain.Profile{Username: "username"}).Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileUpdatedTopic, events.ProfileUpdated{
+					Changes: map[string]string{domain.FieldUsername: "username"},
+				}).Return(nil)
 			},
 			want:    domain.Profile{Username: "username"},
 			wantErr: nil,
@@ -98,27 +171,115 @@ func TestProfileService_Update(t *testing.T) {
 			args: args{
 				userID: "not valid",
 			},
-			mockBehavior: func(profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
 				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{}, domain.ErrProfileNotFound)
 			},
 			want:    domain.Profile{},
 			wantErr: domain.ErrProfileNotFound,
 		},
+		{
+			name: "stale version",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{FirstName: "John", ExpectedVersion: 1},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{UserID: args.userID, Version: 2}, nil)
+			},
+			wantErr: domain.ErrVersionMismatch,
+		},
+		{
+			name: "concurrent update",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{FirstName: "John", ExpectedVersion: 2},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{UserID: args.userID, Version: 2}, nil)
+				profiles.EXPECT().
+					Update(mock.Anything, &domain.Profile{UserID: args.userID, FirstName: "John", Version: 2}).
+					Return(domain.ErrVersionMismatch)
+			},
+			wantErr: domain.ErrVersionMismatch,
+		},
+		{
+			name: "nothing changed",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{FirstName: "John"},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{UserID: args.userID, FirstName: "John"}, nil)
+				profiles.EXPECT().Update(mock.Anything, &domain.Profile{UserID: args.userID, FirstName: "John"}).Return(nil)
+			},
+			want: domain.Profile{UserID: "id", FirstName: "John"},
+		},
 		{
 			name: "username already exists",
 			args: args{
 				userID: "id",
 				dto: domain.UpdateProfileDTO{
-					Username: "new user",
+					Username: "new_user",
 				},
 			},
-			mockBehavior: func(profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
 				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{Username: "user"}, nil)
+				profiles.EXPECT().LastUsernameChange(mock.Anything, args.userID).Return(time.Time{}, nil)
 				profiles.EXPECT().UsernameExists(mock.Anything, args.dto.Username).Return(true, nil)
 			},
 			want:    domain.Profile{},
 			wantErr: domain.ErrUsernameExists,
 		},
+		{
+			name: "username reserved by another user",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Username: "old_name"},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{Username: "user"}, nil)
+				profiles.EXPECT().LastUsernameChange(mock.Anything, args.userID).Return(time.Time{}, nil)
+				profiles.EXPECT().UsernameExists(mock.Anything, args.dto.Username).Return(false, nil)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, args.dto.Username, mock.Anything).Return("another", nil)
+			},
+			wantErr: domain.ErrUsernameExists,
+		},
+		{
+			name: "changed recently",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Username: "new_user"},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{Username: "user"}, nil)
+				profiles.EXPECT().LastUsernameChange(mock.Anything, args.userID).Return(time.Now().Add(-time.Hour), nil)
+			},
+			wantErr: domain.ErrUsernameCooldown,
+		},
+		{
+			name: "invalid username",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Username: "new user"},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{Username: "user"}, nil)
+			},
+			wantErr: domain.ErrInvalidUsername,
+		},
+		{
+			name: "reserved username",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Username: "admin"},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{Username: "user"}, nil)
+			},
+			wantErr: domain.ErrUsernameReserved,
+		},
 		{
 			name: "with image",
 			args: args{
@@ -127,7 +288,8 @@ func TestProfileService_Update(t *testing.T) {
 					Avatar: []byte("image"),
 				},
 			},
-			mockBehavior: func(profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
 				user := domain.Profile{UserID: args.userID}
 				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(user, nil)
 				images.EXPECT().UploadAvatar(mock.Anything, args.userID, args.dto.Avatar).Return("url", nil)
@@ -138,17 +300,125 @@ func TestProfileService_Update(t *testing.T) {
 						Avatar: "url",
 					}).
 					Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileUpdatedTopic, events.ProfileUpdated{
+					ID:      args.userID,
+					Changes: map[string]string{domain.FieldAvatar: "url"},
+				}).Return(nil)
 			},
 			want: domain.Profile{UserID: "id", Avatar: "url"},
 		},
+		{
+			name: "replace image",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Avatar: []byte("image")},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{UserID: args.userID, Avatar: "old_url"}, nil)
+				images.EXPECT().UploadAvatar(mock.Anything, args.userID, args.dto.Avatar).Return("url", nil)
+				profiles.EXPECT().Update(mock.Anything, &domain.Profile{UserID: args.userID, Avatar: "url"}).Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileUpdatedTopic, events.ProfileUpdated{
+					ID:      args.userID,
+					Changes: map[string]string{domain.FieldAvatar: "url"},
+				}).Return(nil)
+				images.EXPECT().DeleteAvatar(mock.Anything, "old_url").Return(nil).Once()
+			},
+			want: domain.Profile{UserID: "id", Avatar: "url"},
+		},
+		{
+			name: "image with version conflict",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Avatar: []byte("image")},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{UserID: args.userID, Avatar: "old_url"}, nil)
+				images.EXPECT().UploadAvatar(mock.Anything, args.userID, args.dto.Avatar).Return("url", nil)
+				profiles.EXPECT().Update(mock.Anything, &domain.Profile{UserID: args.userID, Avatar: "url"}).Return(domain.ErrVersionMismatch)
+				// only uploaded avatar is deleted, profile still references old one
+				images.EXPECT().DeleteAvatar(mock.Anything, "url").Return(nil).Once()
+			},
+			wantErr: domain.ErrVersionMismatch,
+		},
+		{
+			name: "clear fields with mask",
+			args: args{
+				userID: "id",
+				dto: domain.UpdateProfileDTO{
+					FirstName: "John",
+					Mask:      []string{domain.FieldFirstName, domain.FieldLastName, domain.FieldBirthDate, domain.FieldGender},
+				},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
+				user := domain.Profile{
+					UserID:    args.userID,
+					Username:  "user",
+					LastName:  "Doe",
+					BirthDate: "2000-01-01",
+					Gender:    domain.UserGenderMale,
+				}
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(user, nil)
+				profiles.EXPECT().
+					Update(mock.Anything, &domain.Profile{
+						UserID:    args.userID,
+						Username:  "user",
+						FirstName: "John",
+						Gender:    domain.UserGenderNotSpecified,
+					}).
+					Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileUpdatedTopic, events.ProfileUpdated{
+					ID: args.userID,
+					Changes: map[string]string{
+						domain.FieldFirstName: "John",
+						domain.FieldLastName:  "",
+						domain.FieldBirthDate: "",
+						domain.FieldGender:    string(domain.UserGenderNotSpecified),
+					},
+				}).Return(nil)
+			},
+			want: domain.Profile{UserID: "id", Username: "user", FirstName: "John", Gender: domain.UserGenderNotSpecified},
+		},
+		{
+			name: "remove avatar",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Mask: []string{domain.FieldAvatar}},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				expectTx(tx)
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{UserID: args.userID, Avatar: "url"}, nil)
+				images.EXPECT().DeleteAvatar(mock.Anything, "url").Return(nil)
+				profiles.EXPECT().Update(mock.Anything, &domain.Profile{UserID: args.userID}).Return(nil)
+				profiles.EXPECT().SaveEvent(mock.Anything, events.ProfileUpdatedTopic, events.ProfileUpdated{
+					ID:      args.userID,
+					Changes: map[string]string{domain.FieldAvatar: ""},
+				}).Return(nil)
+			},
+			want: domain.Profile{UserID: "id"},
+		},
+		{
+			name: "clear username",
+			args: args{
+				userID: "id",
+				dto:    domain.UpdateProfileDTO{Mask: []string{domain.FieldUsername}},
+			},
+			mockBehavior: func(tx *txMocks.TxManager, profiles *mocks.ProfileRepo, images *mocks.ImageRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{Username: "user"}, nil)
+			},
+			wantErr: domain.ErrUsernameEmpty,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
 			profiles := mocks.NewProfileRepo(t)
 			images := mocks.NewImageRepo(t)
-			svc := service.NewProfileService(profiles, images)
-			tc.mockBehavior(profiles, images, tc.args)
+			svc := service.NewProfileService(tx, profiles, images)
+			tc.mockBehavior(tx, profiles, images, tc.args)
 			got, err := svc.Update(context.Background(), tc.args.userID, tc.args.dto)
 			if tc.wantErr != nil {
 				assert.ErrorIs(t, err, tc.wantErr)
@@ -159,3 +429,254 @@ func TestProfileService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestProfileService_GetUserProfile(t *testing.T) {
+	type args struct {
+		viewerID string
+		userID   string
+	}
+
+	profile := domain.Profile{
+		UserID:    "id",
+		Username:  "user",
+		FirstName: "John",
+		LastName:  "Doe",
+		BirthDate: "2000-01-01",
+		Gender:    domain.UserGenderMale,
+	}
+
+	type MockBehavior func(profiles *mocks.ProfileRepo, args args)
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         domain.Profile
+		wantErr      error
+	}{
+		{
+			name: "stranger",
+			args: args{viewerID: "viewer", userID: "id"},
+			mockBehavior: func(profiles *mocks.ProfileRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(profile, nil)
+				profiles.EXPECT().PrivacyByID(mock.Anything, args.userID).Return(domain.DefaultPrivacySettings, nil)
+				profiles.EXPECT().IsContact(mock.Anything, args.userID, args.viewerID).Return(false, nil)
+			},
+			want: domain.Profile{UserID: "id", Username: "user", FirstName: "John", LastName: "Doe"},
+		},
+		{
+			name: "contact",
+			args: args{viewerID: "viewer", userID: "id"},
+			mockBehavior: func(profiles *mocks.ProfileRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(profile, nil)
+				profiles.EXPECT().PrivacyByID(mock.Anything, args.userID).Return(domain.PrivacySettings{
+					FullName:  domain.PrivacyLevelPrivate,
+					BirthDate: domain.PrivacyLevelContacts,
+					Gender:    domain.PrivacyLevelPublic,
+				}, nil)
+				profiles.EXPECT().IsContact(mock.Anything, args.userID, args.viewerID).Return(true, nil)
+			},
+			want: domain.Profile{UserID: "id", Username: "user", BirthDate: "2000-01-01", Gender: domain.UserGenderMale},
+		},
+		{
+			name: "own profile",
+			args: args{viewerID: "id", userID: "id"},
+			mockBehavior: func(profiles *mocks.ProfileRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(profile, nil)
+				profiles.EXPECT().PrivacyByID(mock.Anything, args.userID).Return(domain.DefaultPrivacySettings, nil)
+			},
+			want: func() domain.Profile {
+				p := profile
+				p.Privacy = domain.DefaultPrivacySettings
+				return p
+			}(),
+		},
+		{
+			name: "profile not found",
+			args: args{viewerID: "viewer", userID: "id"},
+			mockBehavior: func(profiles *mocks.ProfileRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{}, domain.ErrProfileNotFound)
+			},
+			wantErr: domain.ErrProfileNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profiles := mocks.NewProfileRepo(t)
+			svc := service.NewProfileService(nil, profiles, nil)
+			tc.mockBehavior(profiles, tc.args)
+			got, err := svc.GetUserProfile(context.Background(), tc.args.viewerID, tc.args.userID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestProfileService_UpdatePrivacy(t *testing.T) {
+	type args struct {
+		userID string
+		dto    domain.UpdatePrivacyDTO
+	}
+
+	type MockBehavior func(profiles *mocks.ProfileRepo, args args)
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         domain.PrivacySettings
+		wantErr      error
+	}{
+		{
+			name: "success",
+			args: args{userID: "id", dto: domain.UpdatePrivacyDTO{BirthDate: domain.PrivacyLevelPrivate}},
+			mockBehavior: func(profiles *mocks.ProfileRepo, args args) {
+				want := domain.DefaultPrivacySettings
+				want.BirthDate = domain.PrivacyLevelPrivate
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{UserID: args.userID}, nil)
+				profiles.EXPECT().PrivacyByID(mock.Anything, args.userID).Return(domain.DefaultPrivacySettings, nil)
+				profiles.EXPECT().UpdatePrivacy(mock.Anything, args.userID, want).Return(nil)
+			},
+			want: domain.PrivacySettings{
+				FullName:  domain.DefaultPrivacySettings.FullName,
+				BirthDate: domain.PrivacyLevelPrivate,
+				Gender:    domain.DefaultPrivacySettings.Gender,
+			},
+		},
+		{
+			name: "profile not found",
+			args: args{userID: "id"},
+			mockBehavior: func(profiles *mocks.ProfileRepo, args args) {
+				profiles.EXPECT().ProfileByID(mock.Anything, args.userID).Return(domain.Profile{}, domain.ErrProfileNotFound)
+			},
+			wantErr: domain.ErrProfileNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profiles := mocks.NewProfileRepo(t)
+			svc := service.NewProfileService(nil, profiles, nil)
+			tc.mockBehavior(profiles, tc.args)
+			got, err := svc.UpdatePrivacy(context.Background(), tc.args.userID, tc.args.dto)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestProfileService_AddContact(t *testing.T) {
+	type MockBehavior func(profiles *mocks.ProfileRepo)
+	testCases := []struct {
+		name         string
+		contactID    string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name:      "success",
+			contactID: "contact",
+			mockBehavior: func(profiles *mocks.ProfileRepo) {
+				profiles.EXPECT().AddContact(mock.Anything, "id", "contact").Return(nil)
+			},
+		},
+		{
+			name:         "self",
+			contactID:    "id",
+			mockBehavior: func(profiles *mocks.ProfileRepo) {},
+			wantErr:      domain.ErrContactSelf,
+		},
+		{
+			name:      "contact not found",
+			contactID: "contact",
+			mockBehavior: func(profiles *mocks.ProfileRepo) {
+				profiles.EXPECT().AddContact(mock.Anything, "id", "contact").Return(domain.ErrProfileNotFound)
+			},
+			wantErr: domain.ErrProfileNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profiles := mocks.NewProfileRepo(t)
+			svc := service.NewProfileService(nil, profiles, nil)
+			tc.mockBehavior(profiles)
+			err := svc.AddContact(context.Background(), "id", tc.contactID)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestProfileService_GetProfileByUsername(t *testing.T) {
+	type MockBehavior func(profiles *mocks.ProfileRepo, username string)
+	testCases := []struct {
+		name         string
+		username     string
+		mockBehavior MockBehavior
+		want         domain.Profile
+		wantErr      error
+	}{
+		{
+			name:     "current username",
+			username: "user",
+			mockBehavior: func(profiles *mocks.ProfileRepo, username string) {
+				profiles.EXPECT().UserIDByUsername(mock.Anything, username).Return("id", nil)
+				profiles.EXPECT().ProfileByID(mock.Anything, "id").Return(domain.Profile{UserID: "id", Username: username}, nil)
+				profiles.EXPECT().PrivacyByID(mock.Anything, "id").Return(domain.DefaultPrivacySettings, nil)
+				profiles.EXPECT().IsContact(mock.Anything, "id", "viewer").Return(false, nil)
+			},
+			want: domain.Profile{UserID: "id", Username: "user"},
+		},
+		{
+			name:     "old username",
+			username: "old_user",
+			mockBehavior: func(profiles *mocks.ProfileRepo, username string) {
+				profiles.EXPECT().UserIDByUsername(mock.Anything, username).Return("", domain.ErrProfileNotFound)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, username, mock.Anything).Return("id", nil)
+				profiles.EXPECT().ProfileByID(mock.Anything, "id").Return(domain.Profile{UserID: "id", Username: "user"}, nil)
+				profiles.EXPECT().PrivacyByID(mock.Anything, "id").Return(domain.DefaultPrivacySettings, nil)
+				profiles.EXPECT().IsContact(mock.Anything, "id", "viewer").Return(false, nil)
+			},
+			want: domain.Profile{UserID: "id", Username: "user"},
+		},
+		{
+			name:     "not found",
+			username: "unknown",
+			mockBehavior: func(profiles *mocks.ProfileRepo, username string) {
+				profiles.EXPECT().UserIDByUsername(mock.Anything, username).Return("", domain.ErrProfileNotFound)
+				profiles.EXPECT().UsernameReservedBy(mock.Anything, username, mock.Anything).Return("", nil)
+			},
+			wantErr: domain.ErrProfileNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profiles := mocks.NewProfileRepo(t)
+			svc := service.NewProfileService(nil, profiles, nil)
+			tc.mockBehavior(profiles, tc.username)
+			got, err := svc.GetProfileByUsername(context.Background(), "viewer", tc.username)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func expectTx(tx *txMocks.TxManager) {
+	tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+		func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		},
+	)
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS contacts;
+DROP TABLE IF EXISTS privacy_settings;
+DROP TYPE IF EXISTS privacy_level;
\ No newline at end of file
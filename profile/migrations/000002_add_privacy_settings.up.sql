@@ -0,0 +1,15 @@
+CREATE TYPE privacy_level AS ENUM ('public', 'contacts', 'private');
+
+CREATE TABLE IF NOT EXISTS privacy_settings (
+  user_id UUID PRIMARY KEY REFERENCES profiles(user_id) ON DELETE CASCADE,
+  full_name privacy_level NOT NULL DEFAULT 'public',
+  birth_date privacy_level NOT NULL DEFAULT 'contacts',
+  gender privacy_level NOT NULL DEFAULT 'contacts'
+);
+
+CREATE TABLE IF NOT EXISTS contacts (
+  user_id UUID REFERENCES profiles(user_id) ON DELETE CASCADE,
+  contact_id UUID REFERENCES profiles(user_id) ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT NOW(),
+  PRIMARY KEY (user_id, contact_id)
+);
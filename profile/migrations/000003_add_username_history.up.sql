@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS username_history (
+  id SERIAL PRIMARY KEY,
+  user_id UUID REFERENCES profiles(user_id) ON DELETE CASCADE NOT NULL,
+  username VARCHAR(255) NOT NULL,
+  changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS username_history_username_idx ON username_history (username, changed_at);
+CREATE INDEX IF NOT EXISTS username_history_user_id_idx ON username_history (user_id, changed_at);
@@ -0,0 +1,2 @@
+-- Published events are deleted after retention, unpublished ones are served by outbox_unpublished_idx
+CREATE INDEX IF NOT EXISTS outbox_published_idx ON outbox (published_at) WHERE published_at IS NOT NULL;
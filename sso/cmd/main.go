@@ -3,11 +3,14 @@ package main
 import (
 	"context"
 	"flag"
+	"log"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
+	profilePb "github.com/SergeyBogomolovv/profile-manager/common/api/profile"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
 	"github.com/SergeyBogomolovv/profile-manager/common/postgres"
 	"github.com/SergeyBogomolovv/profile-manager/common/rabbitmq"
 	"github.com/SergeyBogomolovv/profile-manager/common/redis"
@@ -16,9 +19,12 @@ import (
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/broker"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/config"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/profile"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/repo"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -37,15 +43,42 @@ func main() {
 
 	userRepo := repo.NewUserRepo(postgres)
 	tokenRepo := repo.NewTokensRepo(redis)
+	linkRepo := repo.NewMagicLinkRepo(redis)
 	txManager := transaction.NewTxManager(postgres)
 
-	authSvc := service.NewAuthService(broker, txManager, userRepo, tokenRepo, []byte(conf.JwtSecret))
+	profileConn, err := grpc.NewClient(conf.ProfileAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect to profile: %v", err)
+	}
+	defer profileConn.Close()
+
+	profileClient := profile.NewClient(profilePb.NewProfileClient(profileConn), []byte(conf.JwtSecret))
+	roleRepo := repo.NewRoleRepo(postgres)
+	auditRepo := repo.NewAuditRepo(postgres, conf.Audit.HashChain)
+	personalTokenRepo := repo.NewPersonalTokenRepo(postgres)
+	authSvc := service.NewAuthService(broker, txManager, userRepo, tokenRepo, linkRepo, roleRepo, auditRepo, []byte(conf.JwtSecret))
+	personalTokenSvc := service.NewPersonalTokenService(txManager, userRepo, roleRepo, personalTokenRepo, auditRepo)
+	adminSvc := service.NewAdminService(txManager, userRepo, roleRepo, tokenRepo, auditRepo, profileClient)
 
 	logger := newLogger()
+	signingKey, err := service.LoadSigningKey(conf.OIDC.SigningKey)
+	if err != nil {
+		log.Fatalf("failed to load oidc signing key: %v", err)
+	}
+	if conf.OIDC.SigningKey == "" {
+		logger.Warn("oidc signing key is not set, tokens of clients are invalid after restart")
+	}
+	oidcClientRepo := repo.NewOIDCClientRepo(postgres)
+	oidcGrantRepo := repo.NewOIDCGrantRepo(redis)
+	oidcSvc := service.NewOIDCService(txManager, oidcClientRepo, oidcGrantRepo, userRepo, profileClient, auditRepo, signingKey, conf.OIDC.Issuer)
+
+	assignAdmins(logger, authSvc, conf.Admins)
 	grpcController := controller.NewGRPCController(logger, authSvc)
-	httpController := controller.NewHTTPController(logger, conf.OAuth, authSvc)
+	adminController := controller.NewAdminController(logger, adminSvc)
+	personalTokensController := controller.NewPersonalTokensController(logger, personalTokenSvc)
+	httpController := controller.NewHTTPController(logger, conf.OAuth, authSvc, oidcSvc, conf.OIDC, []byte(conf.JwtSecret))
 
-	app := app.New(logger, conf, httpController, grpcController)
+	app := app.New(logger, conf, httpController, controller.Policy, personalTokenSvc, grpcController, adminController, personalTokensController)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -56,6 +89,15 @@ func main() {
 	broker.Close()
 }
 
+// Admins from config can assign roles to others, so there is always a way to grant first role
+func assignAdmins(logger *slog.Logger, svc controller.AuthService, admins []string) {
+	for _, userID := range admins {
+		if err := svc.AssignRole(context.Background(), "", userID, auth.RoleAdmin); err != nil {
+			logger.Error("failed to assign admin role", "user_id", userID, "error", err)
+		}
+	}
+}
+
 func init() {
 	godotenv.Load()
 }
@@ -10,7 +10,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
 	"github.com/SergeyBogomolovv/profile-manager/common/logger"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/config"
 	"github.com/go-chi/chi/v5"
 	"google.golang.org/grpc"
@@ -31,16 +33,26 @@ type GRPCController interface {
 	Init(srv *grpc.Server)
 }
 
-func New(log *slog.Logger, conf *config.Config, httpController HTTPController, gRPCController GRPCController) *app {
+// Methods of policy require permissions, other methods are public. Personal access tokens are verified by tokens
+func New(log *slog.Logger, conf *config.Config, httpController HTTPController, policy auth.Policy, tokens auth.TokenVerifier, gRPCControllers ...GRPCController) *app {
 	router := chi.NewRouter()
 
 	httpSrv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", conf.HttpPort),
 		Handler: router,
 	}
-	grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(logger.LoggerInterceptor(log)))
+	grpcSrv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logger.LoggerInterceptor(log),
+			request.Interceptor(),
+			auth.OptionalJwtInterceptor([]byte(conf.JwtSecret), auth.WithPersonalTokens(tokens)),
+			auth.AuthzInterceptor(policy),
+		),
+	)
 
-	gRPCController.Init(grpcSrv)
+	for _, controller := range gRPCControllers {
+		controller.Init(grpcSrv)
+	}
 	httpController.Init(router)
 
 	return &app{httpSrv: httpSrv, grpcSrv: grpcSrv, logger: log, conf: conf}
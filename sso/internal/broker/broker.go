@@ -51,3 +51,15 @@ func (b *broker) PublishUserLogin(user events.UserLogin) error {
 	}
 	return b.ch.Publish(events.UserExchange, events.LoginTopic, false, false, msg)
 }
+
+func (b *broker) PublishMagicLink(link events.MagicLink) error {
+	data, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to marshal magic link: %w", err)
+	}
+	msg := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        data,
+	}
+	return b.ch.Publish(events.UserExchange, events.MagicLinkTopic, false, false, msg)
+}
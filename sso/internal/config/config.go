@@ -15,6 +15,26 @@ type (
 		RabbitmqURL string `mapstructure:"rabbitmq_url"`
 		JwtSecret   string `mapstructure:"jwt_secret"`
 		OAuth       OAuth  `mapstructure:"oauth"`
+		// Profile service is used to show profiles in admin API
+		ProfileAddr string `mapstructure:"profile_addr"`
+		// IDs of users who are granted admin role on start
+		Admins []string `mapstructure:"admins"`
+		Audit  Audit    `mapstructure:"audit"`
+		OIDC   OIDC     `mapstructure:"oidc"`
+	}
+	Audit struct {
+		// Links every entry to previous one by hash, so changed or removed entries are detected
+		HashChain bool `mapstructure:"hash_chain"`
+	}
+	// sso is OpenID Connect provider for internal apps, it must be served on the same site as gateway,
+	// so access_token cookie reaches /oauth2/authorize
+	OIDC struct {
+		// Public URL of sso, it is issuer of ID tokens
+		Issuer string `mapstructure:"issuer"`
+		// PEM encoded RSA key, if empty random key is generated and tokens are invalid after restart
+		SigningKey string `mapstructure:"signing_key"`
+		// Page where user signs in, it is opened with return_to query parameter
+		LoginURL string `mapstructure:"login_url"`
 	}
 	OAuth struct {
 		ClientID     string `mapstructure:"client_id"`
@@ -32,6 +52,7 @@ func MustLoadConfig(path string) *Config {
 	viper.BindEnv("oauth.client_id", "OAUTH_CLIENT_ID")
 	viper.BindEnv("oauth.client_secret", "OAUTH_CLIENT_SECRET")
 	viper.BindEnv("rabbitmq_url", "RABBITMQ_URL")
+	viper.BindEnv("oidc.signing_key", "OIDC_SIGNING_KEY")
 
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatalf("could not read config file: %v", err)
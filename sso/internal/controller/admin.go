@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+type AdminService interface {
+	ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error)
+	GetUser(ctx context.Context, adminID, userID string) (domain.UserDetails, error)
+	BlockUser(ctx context.Context, adminID, userID, reason string) error
+	UnblockUser(ctx context.Context, adminID, userID string) error
+	ForceLogout(ctx context.Context, adminID, userID string) error
+	AuditLog(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+	VerifyAuditLog(ctx context.Context) (domain.AuditVerification, error)
+}
+
+type adminController struct {
+	pb.UnimplementedAdminServer
+	svc      AdminService
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+// NewAdminController serves admin API, permissions are checked by auth.AuthzInterceptor with Policy
+func NewAdminController(logger *slog.Logger, svc AdminService) *adminController {
+	validate := validator.New()
+	return &adminController{svc: svc, logger: logger, validate: validate}
+}
+
+func (c *adminController) Init(srv *grpc.Server) {
+	pb.RegisterAdminServer(srv, c)
+}
+
+func (c *adminController) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	const op = "grpc.ListUsers"
+	logger := c.logger.With(slog.String("op", op))
+
+	if err := c.validate.Var(req.Limit, "min=0,max=100"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
+	if err := c.validate.Var(req.Offset, "min=0"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid offset")
+	}
+	users, total, err := c.svc.ListUsers(ctx, domain.UserFilter{
+		Email:   req.Email,
+		Role:    req.Role,
+		Blocked: req.Blocked,
+		Limit:   int(req.Limit),
+		Offset:  int(req.Offset),
+	})
+	if err != nil {
+		logger.Error("failed to list users", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list users")
+	}
+	resp := &pb.ListUsersResponse{Users: make([]*pb.User, len(users)), Total: int32(total)}
+	for i, user := range users {
+		resp.Users[i] = userToGRPC(user)
+	}
+	return resp, nil
+}
+
+func (c *adminController) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserDetails, error) {
+	const op = "grpc.GetUser"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId))
+
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	details, err := c.svc.GetUser(ctx, auth.ExtractUserID(ctx), req.UserId)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		logger.Error("failed to get user", "error", err)
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	resp := &pb.UserDetails{
+		User:     userToGRPC(details.User),
+		Accounts: make([]string, len(details.Accounts)),
+		Roles:    details.Roles,
+		Sessions: make([]*pb.Session, len(details.Sessions)),
+	}
+	for i, account := range details.Accounts {
+		resp.Accounts[i] = string(account)
+	}
+	for i, s := range details.Sessions {
+		resp.Sessions[i] = &pb.Session{
+			Id:        s.ID,
+			Ip:        s.IP,
+			Type:      s.Type,
+			CreatedAt: s.CreatedAt.Unix(),
+			ExpiresAt: s.ExpiresAt.Unix(),
+		}
+	}
+	if p := details.Profile; p != nil {
+		resp.Profile = &pb.ProfileSummary{
+			Username:  p.Username,
+			FirstName: p.FirstName,
+			LastName:  p.LastName,
+			Avatar:    p.Avatar,
+		}
+	}
+	return resp, nil
+}
+
+func (c *adminController) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	const op = "grpc.BlockUser"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId))
+
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Reason, "max=500"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid reason")
+	}
+	adminID := auth.ExtractUserID(ctx)
+	if adminID == req.UserId {
+		return nil, status.Error(codes.FailedPrecondition, "you can't block yourself")
+	}
+	if err := c.svc.BlockUser(ctx, adminID, req.UserId, req.Reason); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		logger.Error("failed to block user", "error", err)
+		return nil, status.Error(codes.Internal, "failed to block user")
+	}
+	return &pb.BlockUserResponse{}, nil
+}
+
+func (c *adminController) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error) {
+	const op = "grpc.UnblockUser"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId))
+
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.svc.UnblockUser(ctx, auth.ExtractUserID(ctx), req.UserId); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		logger.Error("failed to unblock user", "error", err)
+		return nil, status.Error(codes.Internal, "failed to unblock user")
+	}
+	return &pb.UnblockUserResponse{}, nil
+}
+
+func (c *adminController) ForceLogout(ctx context.Context, req *pb.ForceLogoutRequest) (*pb.ForceLogoutResponse, error) {
+	const op = "grpc.ForceLogout"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId))
+
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.svc.ForceLogout(ctx, auth.ExtractUserID(ctx), req.UserId); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		logger.Error("failed to force logout", "error", err)
+		return nil, status.Error(codes.Internal, "failed to force logout")
+	}
+	return &pb.ForceLogoutResponse{}, nil
+}
+
+func (c *adminController) ListAuditLog(ctx context.Context, req *pb.ListAuditLogRequest) (*pb.ListAuditLogResponse, error) {
+	const op = "grpc.ListAuditLog"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId))
+
+	if err := c.validate.Var(req.UserId, "omitempty,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Limit, "min=0,max=200"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
+	if err := c.validate.Var(req.BeforeId, "min=0"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid before id")
+	}
+	filter := domain.AuditFilter{Action: req.Action, BeforeID: req.BeforeId, Limit: int(req.Limit)}
+	if req.UserId != "" {
+		filter.UserID = uuid.MustParse(req.UserId)
+	}
+	entries, err := c.svc.AuditLog(ctx, filter)
+	if err != nil {
+		logger.Error("failed to list audit log", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list audit log")
+	}
+	resp := &pb.ListAuditLogResponse{Entries: make([]*pb.AuditEntry, len(entries))}
+	for i, entry := range entries {
+		resp.Entries[i] = &pb.AuditEntry{
+			Id:        entry.ID,
+			ActorId:   uuidToGRPC(entry.ActorID),
+			Action:    entry.Action,
+			TargetId:  uuidToGRPC(entry.TargetID),
+			Result:    string(entry.Result),
+			Ip:        entry.IP,
+			UserAgent: entry.UserAgent,
+			RequestId: entry.RequestID,
+			Details:   entry.Details,
+			CreatedAt: entry.CreatedAt.Unix(),
+			Hash:      entry.Hash,
+		}
+	}
+	return resp, nil
+}
+
+func (c *adminController) VerifyAuditLog(ctx context.Context, req *pb.VerifyAuditLogRequest) (*pb.VerifyAuditLogResponse, error) {
+	const op = "grpc.VerifyAuditLog"
+	logger := c.logger.With(slog.String("op", op))
+
+	res, err := c.svc.VerifyAuditLog(ctx)
+	if err != nil {
+		logger.Error("failed to verify audit log", "error", err)
+		return nil, status.Error(codes.Internal, "failed to verify audit log")
+	}
+	if !res.Valid {
+		logger.Warn("audit log hash chain is broken", "entry_id", res.BrokenID)
+	}
+	return &pb.VerifyAuditLogResponse{Valid: res.Valid, Checked: int32(res.Checked), BrokenId: res.BrokenID}, nil
+}
+
+func uuidToGRPC(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+func userToGRPC(user domain.User) *pb.User {
+	resp := &pb.User{
+		Id:           user.ID.String(),
+		Email:        user.Email,
+		RegisteredAt: user.RegisteredAt.Unix(),
+		BlockReason:  user.BlockReason,
+	}
+	if user.IsBlocked() {
+		resp.BlockedAt = user.BlockedAt.Unix()
+	}
+	return resp
+}
@@ -0,0 +1,137 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/testutils"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminController_ListUsers(t *testing.T) {
+	svc := mocks.NewAdminService(t)
+	blocked := true
+	blockedAt := time.Now()
+	svc.EXPECT().ListUsers(mock.Anything, domain.UserFilter{Email: "gmail", Blocked: &blocked, Limit: 10}).
+		Return([]domain.User{{ID: uuid.New(), BlockedAt: blockedAt, BlockReason: "spam"}}, 11, nil)
+	c := controller.NewAdminController(testutils.NewTestLogger(), svc)
+
+	got, err := c.ListUsers(context.Background(), &pb.ListUsersRequest{Email: "gmail", Blocked: &blocked, Limit: 10})
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, got.Total)
+	require.Len(t, got.Users, 1)
+	assert.Equal(t, blockedAt.Unix(), got.Users[0].BlockedAt)
+	assert.Equal(t, "spam", got.Users[0].BlockReason)
+
+	_, err = c.ListUsers(context.Background(), &pb.ListUsersRequest{Limit: 1000})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdminController_GetUser(t *testing.T) {
+	userID := uuid.New()
+	svc := mocks.NewAdminService(t)
+	svc.EXPECT().GetUser(mock.Anything, mock.Anything, userID.String()).Return(domain.UserDetails{
+		User:     domain.User{ID: userID},
+		Accounts: []domain.AccountType{domain.AccountTypeGoogle},
+		Sessions: []domain.Session{{ID: "session"}},
+	}, nil).Once()
+	c := controller.NewAdminController(testutils.NewTestLogger(), svc)
+
+	got, err := c.GetUser(context.Background(), &pb.GetUserRequest{UserId: userID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{string(domain.AccountTypeGoogle)}, got.Accounts)
+	assert.Len(t, got.Sessions, 1)
+	assert.Nil(t, got.Profile)
+	assert.Zero(t, got.User.BlockedAt)
+}
+
+func TestAdminController_BlockUser(t *testing.T) {
+	type MockBehavior func(svc *mocks.AdminService, req *pb.BlockUserRequest)
+
+	adminID := uuid.NewString()
+	testCases := []struct {
+		name         string
+		req          *pb.BlockUserRequest
+		mockBehavior MockBehavior
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			req:  &pb.BlockUserRequest{UserId: uuid.NewString(), Reason: "spam"},
+			mockBehavior: func(svc *mocks.AdminService, req *pb.BlockUserRequest) {
+				svc.EXPECT().BlockUser(mock.Anything, adminID, req.UserId, req.Reason).Return(nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "user not found",
+			req:  &pb.BlockUserRequest{UserId: uuid.NewString()},
+			mockBehavior: func(svc *mocks.AdminService, req *pb.BlockUserRequest) {
+				svc.EXPECT().BlockUser(mock.Anything, adminID, req.UserId, "").Return(domain.ErrUserNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:         "invalid user id",
+			req:          &pb.BlockUserRequest{UserId: "invalid"},
+			mockBehavior: func(svc *mocks.AdminService, req *pb.BlockUserRequest) {},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name:         "block yourself",
+			req:          &pb.BlockUserRequest{UserId: adminID},
+			mockBehavior: func(svc *mocks.AdminService, req *pb.BlockUserRequest) {},
+			wantCode:     codes.FailedPrecondition,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewAdminService(t)
+			c := controller.NewAdminController(testutils.NewTestLogger(), svc)
+			tc.mockBehavior(svc, tc.req)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user_id", adminID))
+			_, err := c.BlockUser(ctx, tc.req)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestAdminController_ForceLogout(t *testing.T) {
+	svc := mocks.NewAdminService(t)
+	userID := uuid.NewString()
+	svc.EXPECT().ForceLogout(mock.Anything, mock.Anything, userID).Return(domain.ErrUserNotFound).Once()
+	c := controller.NewAdminController(testutils.NewTestLogger(), svc)
+
+	_, err := c.ForceLogout(context.Background(), &pb.ForceLogoutRequest{UserId: userID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAdminController_ListAuditLog(t *testing.T) {
+	userID := uuid.New()
+	svc := mocks.NewAdminService(t)
+	svc.EXPECT().AuditLog(mock.Anything, domain.AuditFilter{UserID: userID, BeforeID: 10}).Return([]domain.AuditEntry{
+		{ID: 9, TargetID: userID, Action: domain.AuditActionLogin, Result: domain.AuditResultFailure},
+	}, nil).Once()
+	c := controller.NewAdminController(testutils.NewTestLogger(), svc)
+
+	got, err := c.ListAuditLog(context.Background(), &pb.ListAuditLogRequest{UserId: userID.String(), BeforeId: 10})
+	require.NoError(t, err)
+	require.Len(t, got.Entries, 1)
+	assert.Empty(t, got.Entries[0].ActorId)
+	assert.Equal(t, userID.String(), got.Entries[0].TargetId)
+	assert.Equal(t, "failure", got.Entries[0].Result)
+
+	_, err = c.ListAuditLog(context.Background(), &pb.ListAuditLogRequest{UserId: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
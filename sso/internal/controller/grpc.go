@@ -6,6 +6,8 @@ import (
 	"log/slog"
 
 	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
 	"github.com/go-playground/validator/v10"
 	"google.golang.org/grpc"
@@ -15,10 +17,27 @@ import (
 )
 
 type AuthService interface {
-	Register(ctx context.Context, email, password string) (string, error)
+	Register(ctx context.Context, email, password, locale string) (string, error)
 	Login(ctx context.Context, email, password, ip string) (domain.Tokens, error)
 	Refresh(ctx context.Context, refreshToken string) (string, error)
 	Logout(ctx context.Context, refreshToken string) error
+	RequestMagicLink(ctx context.Context, email, locale string) error
+	ConsumeMagicLink(ctx context.Context, token, ip string) (domain.Tokens, error)
+	Sessions(ctx context.Context, userID string) ([]domain.Session, error)
+	TerminateSession(ctx context.Context, userID, sessionID string) error
+	AssignRole(ctx context.Context, actorID, userID, role string) error
+}
+
+// Policy lists permissions required by methods, it is enforced by auth.AuthzInterceptor
+var Policy = auth.Policy{
+	pb.SSO_AssignRole_FullMethodName:       auth.PermissionAssignRoles,
+	pb.Admin_ListUsers_FullMethodName:      auth.PermissionManageUsers,
+	pb.Admin_GetUser_FullMethodName:        auth.PermissionManageUsers,
+	pb.Admin_BlockUser_FullMethodName:      auth.PermissionManageUsers,
+	pb.Admin_UnblockUser_FullMethodName:    auth.PermissionManageUsers,
+	pb.Admin_ForceLogout_FullMethodName:    auth.PermissionManageUsers,
+	pb.Admin_ListAuditLog_FullMethodName:   auth.PermissionReadAudit,
+	pb.Admin_VerifyAuditLog_FullMethodName: auth.PermissionReadAudit,
 }
 
 type gRPCController struct {
@@ -44,7 +63,7 @@ func (c *gRPCController) Register(ctx context.Context, req *pb.RegisterRequest)
 	if err := c.validate.Var(req.Email, "required,email"); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid email")
 	}
-	userID, err := c.svc.Register(ctx, req.Email, req.Password)
+	userID, err := c.svc.Register(ctx, req.Email, req.Password, req.Locale)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserAlreadyExists) {
 			return nil, status.Errorf(codes.AlreadyExists, "user with email %s already exists", req.Email)
@@ -58,26 +77,71 @@ func (c *gRPCController) Register(ctx context.Context, req *pb.RegisterRequest)
 func (c *gRPCController) Login(ctx context.Context, req *pb.LoginRequest) (*pb.TokensResponse, error) {
 	const op = "grpc.Login"
 	logger := c.logger.With(slog.String("op", op), slog.String("email", req.Email))
-	p, ok := peer.FromContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.InvalidArgument, "failed to get peer from context")
+	ip, err := clientIP(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := c.validate.Var(req.Email, "required,email"); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid email")
 	}
 
-	tokens, err := c.svc.Login(ctx, req.Email, req.Password, p.Addr.String())
+	tokens, err := c.svc.Login(ctx, req.Email, req.Password, ip)
 	if err != nil {
 		if errors.Is(err, domain.ErrInvalidCredentials) {
 			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 		}
+		if errors.Is(err, domain.ErrUserBlocked) {
+			return nil, status.Error(codes.PermissionDenied, "user is blocked")
+		}
 		logger.Error("failed to login user", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to login user: %v", err)
 	}
 	return &pb.TokensResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
 }
 
+func (c *gRPCController) RequestMagicLink(ctx context.Context, req *pb.RequestMagicLinkRequest) (*pb.RequestMagicLinkResponse, error) {
+	const op = "grpc.RequestMagicLink"
+	logger := c.logger.With(slog.String("op", op), slog.String("email", req.Email))
+
+	if err := c.validate.Var(req.Email, "required,email"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid email")
+	}
+	if err := c.svc.RequestMagicLink(ctx, req.Email, req.Locale); err != nil {
+		if errors.Is(err, domain.ErrTooManyMagicLinks) {
+			return nil, status.Error(codes.ResourceExhausted, "too many sign in links, try again later")
+		}
+		logger.Error("failed to request magic link", "error", err)
+		return nil, status.Error(codes.Internal, "failed to request magic link")
+	}
+	return &pb.RequestMagicLinkResponse{}, nil
+}
+
+func (c *gRPCController) ConsumeMagicLink(ctx context.Context, req *pb.ConsumeMagicLinkRequest) (*pb.TokensResponse, error) {
+	const op = "grpc.ConsumeMagicLink"
+	logger := c.logger.With(slog.String("op", op))
+	ip, err := clientIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validate.Var(req.Token, "required,uuid"); err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	tokens, err := c.svc.ConsumeMagicLink(ctx, req.Token, ip)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if errors.Is(err, domain.ErrUserBlocked) {
+			return nil, status.Error(codes.PermissionDenied, "user is blocked")
+		}
+		logger.Error("failed to consume magic link", "error", err)
+		return nil, status.Error(codes.Internal, "failed to consume magic link")
+	}
+	return &pb.TokensResponse{AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
+}
+
 func (c *gRPCController) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.AccessTokenResponse, error) {
 	const op = "grpc.Refresh"
 	logger := c.logger.With(slog.String("op", op))
@@ -87,6 +151,9 @@ func (c *gRPCController) Refresh(ctx context.Context, req *pb.RefreshRequest) (*
 		if errors.Is(err, domain.ErrInvalidToken) {
 			return nil, status.Error(codes.Unauthenticated, "invalid token")
 		}
+		if errors.Is(err, domain.ErrUserBlocked) {
+			return nil, status.Error(codes.PermissionDenied, "user is blocked")
+		}
 		logger.Error("failed to refresh token", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to refresh token: %v", err)
 	}
@@ -103,3 +170,101 @@ func (c *gRPCController) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb
 	}
 	return &pb.LogoutResponse{Status: "success"}, nil
 }
+
+func (c *gRPCController) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	const op = "grpc.ListSessions"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId))
+
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := authorizeSessions(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	sessions, err := c.svc.Sessions(ctx, req.UserId)
+	if err != nil {
+		logger.Error("failed to list sessions", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list sessions")
+	}
+	resp := &pb.ListSessionsResponse{Sessions: make([]*pb.Session, len(sessions))}
+	for i, s := range sessions {
+		resp.Sessions[i] = &pb.Session{
+			Id:        s.ID,
+			Ip:        s.IP,
+			Type:      s.Type,
+			CreatedAt: s.CreatedAt.Unix(),
+			ExpiresAt: s.ExpiresAt.Unix(),
+		}
+	}
+	return resp, nil
+}
+
+func (c *gRPCController) TerminateSession(ctx context.Context, req *pb.TerminateSessionRequest) (*pb.TerminateSessionResponse, error) {
+	const op = "grpc.TerminateSession"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId))
+
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.SessionId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session id")
+	}
+	if err := authorizeSessions(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	if err := c.svc.TerminateSession(ctx, req.UserId, req.SessionId); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, status.Error(codes.NotFound, "session not found")
+		}
+		logger.Error("failed to terminate session", "error", err)
+		return nil, status.Error(codes.Internal, "failed to terminate session")
+	}
+	return &pb.TerminateSessionResponse{}, nil
+}
+
+// authorizeSessions lets users manage their own sessions, services manage sessions of any user with PermissionManageSessions
+func authorizeSessions(ctx context.Context, userID string) error {
+	claims := auth.ExtractClaims(ctx)
+	if claims == nil {
+		return status.Error(codes.Unauthenticated, "authorization token is missing")
+	}
+	if claims.UserID != userID && !claims.HasPermission(auth.PermissionManageSessions) {
+		return status.Error(codes.PermissionDenied, "permission denied")
+	}
+	return nil
+}
+
+func (c *gRPCController) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.AssignRoleResponse, error) {
+	const op = "grpc.AssignRole"
+	logger := c.logger.With(slog.String("op", op), slog.String("user_id", req.UserId), slog.String("role", req.Role))
+
+	if err := c.validate.Var(req.UserId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if err := c.validate.Var(req.Role, "required,max=32"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid role")
+	}
+	if err := c.svc.AssignRole(ctx, auth.ExtractUserID(ctx), req.UserId, req.Role); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		if errors.Is(err, domain.ErrRoleNotFound) {
+			return nil, status.Error(codes.NotFound, "role not found")
+		}
+		logger.Error("failed to assign role", "error", err)
+		return nil, status.Error(codes.Internal, "failed to assign role")
+	}
+	return &pb.AssignRoleResponse{}, nil
+}
+
+// clientIP returns IP which gateway forwards, direct callers are identified by their address
+func clientIP(ctx context.Context) (string, error) {
+	if ip := request.Extract(ctx).IP; ip != "" {
+		return ip, nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, "failed to get peer from context")
+	}
+	return p.Addr.String(), nil
+}
@@ -4,15 +4,23 @@ import (
 	"context"
 	"net"
 	"testing"
+	"time"
 
 	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
 	"github.com/SergeyBogomolovv/profile-manager/common/testutils"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller/mocks"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 func TestGRPCController_Register(t *testing.T) {
@@ -33,7 +41,7 @@ func TestGRPCController_Register(t *testing.T) {
 			name: "success",
 			args: args{req: &pb.RegisterRequest{Email: "xLb3u@example.com", Password: "password"}},
 			mockBehavior: func(svc *mocks.AuthService, req *pb.RegisterRequest) {
-				svc.EXPECT().Register(mock.Anything, req.Email, req.Password).Return("user_id", nil).Once()
+				svc.EXPECT().Register(mock.Anything, req.Email, req.Password, req.Locale).Return("user_id", nil).Once()
 			},
 			want:    &pb.RegisterResponse{UserId: "user_id"},
 			wantErr: false,
@@ -49,7 +57,7 @@ func TestGRPCController_Register(t *testing.T) {
 			name: "user already exists",
 			args: args{req: &pb.RegisterRequest{Email: "xLb3u@example.com", Password: "password"}},
 			mockBehavior: func(svc *mocks.AuthService, req *pb.RegisterRequest) {
-				svc.EXPECT().Register(mock.Anything, req.Email, req.Password).Return("", domain.ErrUserAlreadyExists).Once()
+				svc.EXPECT().Register(mock.Anything, req.Email, req.Password, req.Locale).Return("", domain.ErrUserAlreadyExists).Once()
 			},
 			want:    nil,
 			wantErr: true,
@@ -58,7 +66,7 @@ func TestGRPCController_Register(t *testing.T) {
 			name: "failed to register user",
 			args: args{req: &pb.RegisterRequest{Email: "xLb3u@example.com", Password: "password"}},
 			mockBehavior: func(svc *mocks.AuthService, req *pb.RegisterRequest) {
-				svc.EXPECT().Register(mock.Anything, req.Email, req.Password).Return("", assert.AnError).Once()
+				svc.EXPECT().Register(mock.Anything, req.Email, req.Password, req.Locale).Return("", assert.AnError).Once()
 			},
 			want:    nil,
 			wantErr: true,
@@ -135,6 +143,106 @@ func TestGRPCController_Login(t *testing.T) {
 	}
 }
 
+func TestGRPCController_RequestMagicLink(t *testing.T) {
+	type MockBehavior func(svc *mocks.AuthService, req *pb.RequestMagicLinkRequest)
+
+	testCases := []struct {
+		name         string
+		req          *pb.RequestMagicLinkRequest
+		mockBehavior MockBehavior
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			req:  &pb.RequestMagicLinkRequest{Email: "xLb3u@example.com", Locale: "en"},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.RequestMagicLinkRequest) {
+				svc.EXPECT().RequestMagicLink(mock.Anything, req.Email, req.Locale).Return(nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:         "invalid email",
+			req:          &pb.RequestMagicLinkRequest{Email: "invalid-email"},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.RequestMagicLinkRequest) {},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name: "too many links",
+			req:  &pb.RequestMagicLinkRequest{Email: "xLb3u@example.com"},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.RequestMagicLinkRequest) {
+				svc.EXPECT().RequestMagicLink(mock.Anything, req.Email, "").Return(domain.ErrTooManyMagicLinks).Once()
+			},
+			wantCode: codes.ResourceExhausted,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewAuthService(t)
+			controller := controller.NewGRPCController(testutils.NewTestLogger(), svc)
+			tc.mockBehavior(svc, tc.req)
+
+			_, err := controller.RequestMagicLink(context.Background(), tc.req)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCController_ConsumeMagicLink(t *testing.T) {
+	type MockBehavior func(svc *mocks.AuthService, req *pb.ConsumeMagicLinkRequest)
+
+	testCases := []struct {
+		name         string
+		req          *pb.ConsumeMagicLinkRequest
+		mockBehavior MockBehavior
+		want         *pb.TokensResponse
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			req:  &pb.ConsumeMagicLinkRequest{Token: uuid.NewString()},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.ConsumeMagicLinkRequest) {
+				svc.EXPECT().ConsumeMagicLink(mock.Anything, req.Token, "127.0.0.1:8080").Return(domain.Tokens{AccessToken: "access_token", RefreshToken: "refresh_token"}, nil).Once()
+			},
+			want:     &pb.TokensResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
+			wantCode: codes.OK,
+		},
+		{
+			name:         "malformed token",
+			req:          &pb.ConsumeMagicLinkRequest{Token: "token"},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.ConsumeMagicLinkRequest) {},
+			wantCode:     codes.Unauthenticated,
+		},
+		{
+			name: "used token",
+			req:  &pb.ConsumeMagicLinkRequest{Token: uuid.NewString()},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.ConsumeMagicLinkRequest) {
+				svc.EXPECT().ConsumeMagicLink(mock.Anything, req.Token, mock.Anything).Return(domain.Tokens{}, domain.ErrInvalidToken).Once()
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "blocked user",
+			req:  &pb.ConsumeMagicLinkRequest{Token: uuid.NewString()},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.ConsumeMagicLinkRequest) {
+				svc.EXPECT().ConsumeMagicLink(mock.Anything, req.Token, mock.Anything).Return(domain.Tokens{}, domain.ErrUserBlocked).Once()
+			},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewAuthService(t)
+			controller := controller.NewGRPCController(testutils.NewTestLogger(), svc)
+			tc.mockBehavior(svc, tc.req)
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Name: "127.0.0.1:8080", Net: "tcp"}})
+
+			got, err := controller.ConsumeMagicLink(ctx, tc.req)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestGRPCController_Refresh(t *testing.T) {
 	type args struct {
 		req *pb.RefreshRequest
@@ -182,3 +290,226 @@ func TestGRPCController_Refresh(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCController_TerminateSession(t *testing.T) {
+	type MockBehavior func(svc *mocks.AuthService, req *pb.TerminateSessionRequest)
+
+	userID, sessionID := uuid.NewString(), uuid.NewString()
+	owner, err := auth.SignJWT(userID, nil, nil, []byte("secret"), time.Minute, "sso")
+	require.NoError(t, err)
+	other, err := auth.SignJWT(uuid.NewString(), nil, nil, []byte("secret"), time.Minute, "sso")
+	require.NoError(t, err)
+	service, err := auth.SignJWT("", nil, []string{auth.PermissionManageSessions}, []byte("secret"), time.Minute, "notification")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		token        string
+		req          *pb.TerminateSessionRequest
+		mockBehavior MockBehavior
+		wantCode     codes.Code
+	}{
+		{
+			name:  "success",
+			token: owner,
+			req:   &pb.TerminateSessionRequest{UserId: userID, SessionId: sessionID},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.TerminateSessionRequest) {
+				svc.EXPECT().TerminateSession(mock.Anything, req.UserId, req.SessionId).Return(nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:  "service",
+			token: service,
+			req:   &pb.TerminateSessionRequest{UserId: userID, SessionId: sessionID},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.TerminateSessionRequest) {
+				svc.EXPECT().TerminateSession(mock.Anything, req.UserId, req.SessionId).Return(nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:  "session not found",
+			token: owner,
+			req:   &pb.TerminateSessionRequest{UserId: userID, SessionId: sessionID},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.TerminateSessionRequest) {
+				svc.EXPECT().TerminateSession(mock.Anything, req.UserId, req.SessionId).Return(domain.ErrSessionNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:         "invalid session id",
+			token:        owner,
+			req:          &pb.TerminateSessionRequest{UserId: userID, SessionId: "invalid"},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.TerminateSessionRequest) {},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name:         "session of another user",
+			token:        other,
+			req:          &pb.TerminateSessionRequest{UserId: userID, SessionId: sessionID},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.TerminateSessionRequest) {},
+			wantCode:     codes.PermissionDenied,
+		},
+		{
+			name:         "unauthenticated",
+			req:          &pb.TerminateSessionRequest{UserId: userID, SessionId: sessionID},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.TerminateSessionRequest) {},
+			wantCode:     codes.Unauthenticated,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewAuthService(t)
+			controller := controller.NewGRPCController(testutils.NewTestLogger(), svc)
+			tc.mockBehavior(svc, tc.req)
+			err := withToken(nil, tc.token, func(ctx context.Context) error {
+				_, err := controller.TerminateSession(ctx, tc.req)
+				return err
+			})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCController_ListSessions(t *testing.T) {
+	userID := uuid.NewString()
+	owner, err := auth.SignJWT(userID, nil, nil, []byte("secret"), time.Minute, "sso")
+	require.NoError(t, err)
+	other, err := auth.SignJWT(uuid.NewString(), nil, nil, []byte("secret"), time.Minute, "sso")
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		svc := mocks.NewAuthService(t)
+		controller := controller.NewGRPCController(testutils.NewTestLogger(), svc)
+		createdAt := time.Unix(1735689600, 0)
+		svc.EXPECT().Sessions(mock.Anything, userID).Return([]domain.Session{
+			{ID: "session", IP: "1.1.1.1", Type: "google", CreatedAt: createdAt, ExpiresAt: createdAt.Add(domain.RefreshTokenTTL)},
+		}, nil).Once()
+
+		var got *pb.ListSessionsResponse
+		err := withToken(nil, owner, func(ctx context.Context) (err error) {
+			got, err = controller.ListSessions(ctx, &pb.ListSessionsRequest{UserId: userID})
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []*pb.Session{{
+			Id:        "session",
+			Ip:        "1.1.1.1",
+			Type:      "google",
+			CreatedAt: createdAt.Unix(),
+			ExpiresAt: createdAt.Add(domain.RefreshTokenTTL).Unix(),
+		}}, got.Sessions)
+	})
+
+	testCases := []struct {
+		name     string
+		token    string
+		wantCode codes.Code
+	}{
+		{name: "sessions of another user", token: other, wantCode: codes.PermissionDenied},
+		{name: "unauthenticated", wantCode: codes.Unauthenticated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := controller.NewGRPCController(testutils.NewTestLogger(), mocks.NewAuthService(t))
+			err := withToken(nil, tc.token, func(ctx context.Context) error {
+				_, err := controller.ListSessions(ctx, &pb.ListSessionsRequest{UserId: userID})
+				return err
+			})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCController_AssignRole(t *testing.T) {
+	type MockBehavior func(svc *mocks.AuthService, req *pb.AssignRoleRequest)
+
+	userID := uuid.NewString()
+	testCases := []struct {
+		name         string
+		req          *pb.AssignRoleRequest
+		mockBehavior MockBehavior
+		wantCode     codes.Code
+	}{
+		{
+			name: "success",
+			req:  &pb.AssignRoleRequest{UserId: userID, Role: auth.RoleAdmin},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.AssignRoleRequest) {
+				svc.EXPECT().AssignRole(mock.Anything, mock.Anything, req.UserId, req.Role).Return(nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "role not found",
+			req:  &pb.AssignRoleRequest{UserId: userID, Role: "owner"},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.AssignRoleRequest) {
+				svc.EXPECT().AssignRole(mock.Anything, mock.Anything, req.UserId, req.Role).Return(domain.ErrRoleNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:         "invalid role",
+			req:          &pb.AssignRoleRequest{UserId: userID},
+			mockBehavior: func(svc *mocks.AuthService, req *pb.AssignRoleRequest) {},
+			wantCode:     codes.InvalidArgument,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewAuthService(t)
+			controller := controller.NewGRPCController(testutils.NewTestLogger(), svc)
+			tc.mockBehavior(svc, tc.req)
+			_, err := controller.AssignRole(context.Background(), tc.req)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	secret := []byte("secret")
+	admin, err := auth.SignJWT(uuid.NewString(), []string{auth.RoleAdmin}, []string{auth.PermissionAssignRoles}, secret, time.Minute, "sso")
+	require.NoError(t, err)
+	user, err := auth.SignJWT(uuid.NewString(), nil, nil, secret, time.Minute, "sso")
+	require.NoError(t, err)
+	tokens := mocks.NewPersonalTokenService(t)
+	tokens.EXPECT().VerifyPersonalToken(mock.Anything, "pat_sso").Return(&auth.TokenClaims{
+		UserID: uuid.NewString(), Permissions: []string{auth.PermissionAssignRoles}, Scopes: []string{auth.ScopeSSO},
+	}, nil).Maybe()
+	tokens.EXPECT().VerifyPersonalToken(mock.Anything, "pat_profile").Return(&auth.TokenClaims{
+		UserID: uuid.NewString(), Permissions: []string{auth.PermissionAssignRoles}, Scopes: []string{auth.ScopeProfile},
+	}, nil).Maybe()
+	tokens.EXPECT().VerifyPersonalToken(mock.Anything, "pat_revoked").Return(nil, auth.ErrInvalidToken).Maybe()
+
+	testCases := []struct {
+		name     string
+		method   string
+		token    string
+		wantCode codes.Code
+	}{
+		{name: "admin", method: pb.SSO_AssignRole_FullMethodName, token: admin, wantCode: codes.OK},
+		{name: "without permission", method: pb.SSO_AssignRole_FullMethodName, token: user, wantCode: codes.PermissionDenied},
+		{name: "without token", method: pb.SSO_AssignRole_FullMethodName, wantCode: codes.Unauthenticated},
+		{name: "invalid token", method: pb.SSO_AssignRole_FullMethodName, token: "invalid", wantCode: codes.Unauthenticated},
+		{name: "public method", method: pb.SSO_Login_FullMethodName, wantCode: codes.OK},
+		{name: "admin api without permission", method: pb.Admin_BlockUser_FullMethodName, token: admin, wantCode: codes.PermissionDenied},
+		{name: "personal token", method: pb.SSO_AssignRole_FullMethodName, token: "pat_sso", wantCode: codes.OK},
+		{name: "personal token without scope", method: pb.SSO_AssignRole_FullMethodName, token: "pat_profile", wantCode: codes.PermissionDenied},
+		{name: "revoked personal token", method: pb.SSO_AssignRole_FullMethodName, token: "pat_revoked", wantCode: codes.Unauthenticated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tc.token))
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+			authz := auth.AuthzInterceptor(controller.Policy)
+			_, err := auth.OptionalJwtInterceptor(secret, auth.WithPersonalTokens(tokens))(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				return authz(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+					return nil, nil
+				})
+			})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
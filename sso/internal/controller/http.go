@@ -3,12 +3,12 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
-	"net"
 	"net/http"
-	"strings"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/httpx"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/config"
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
 	"github.com/go-chi/chi/v5"
@@ -23,13 +23,16 @@ type OAuthService interface {
 }
 
 type httpController struct {
-	logger *slog.Logger
-	state  string
-	oauth  *oauth2.Config
-	svc    OAuthService
+	logger    *slog.Logger
+	state     string
+	oauth     *oauth2.Config
+	svc       OAuthService
+	oidc      OIDCService
+	oidcConf  config.OIDC
+	jwtSecret []byte
 }
 
-func NewHTTPController(logger *slog.Logger, conf config.OAuth, svc OAuthService) *httpController {
+func NewHTTPController(logger *slog.Logger, conf config.OAuth, svc OAuthService, oidc OIDCService, oidcConf config.OIDC, jwtSecret []byte) *httpController {
 	oauth := &oauth2.Config{
 		ClientID:     conf.ClientID,
 		ClientSecret: conf.ClientSecret,
@@ -40,7 +43,15 @@ func NewHTTPController(logger *slog.Logger, conf config.OAuth, svc OAuthService)
 		},
 		Endpoint: google.Endpoint,
 	}
-	return &httpController{svc: svc, oauth: oauth, state: "23490rfdslmfjn34i0skldfj", logger: logger}
+	return &httpController{
+		svc:       svc,
+		oauth:     oauth,
+		state:     "23490rfdslmfjn34i0skldfj",
+		logger:    logger,
+		oidc:      oidc,
+		oidcConf:  oidcConf,
+		jwtSecret: jwtSecret,
+	}
 }
 
 // Инициализация роутов
@@ -49,6 +60,7 @@ func (c *httpController) Init(router *chi.Mux) {
 		r.Get("/google", c.HandleLogin)
 		r.Get("/google/callback", c.HandleCallback)
 	})
+	c.initOIDC(router)
 }
 
 // Перенаправление пользователя на Google OAuth
@@ -92,15 +104,17 @@ func (c *httpController) HandleCallback(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ip := r.Header.Get("X-Forwarded-For")
-	if ip == "" {
-		ip, _, _ = net.SplitHostPort(r.RemoteAddr)
-	} else {
-		ips := strings.Split(ip, ",")
-		ip = strings.TrimSpace(ips[0])
+	if user.Locale == "" {
+		user.Locale = r.Header.Get("Accept-Language")
 	}
 
-	tokens, err := c.svc.OAuth(r.Context(), user, domain.AccountTypeGoogle, ip)
+	info := request.FromHTTP(r)
+	ctx := request.Inject(r.Context(), info)
+	tokens, err := c.svc.OAuth(ctx, user, domain.AccountTypeGoogle, info.IP)
+	if errors.Is(err, domain.ErrUserBlocked) {
+		httpx.WriteError(w, "User is blocked", http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		logger.Error("failed to sign in", "err", err)
 		httpx.WriteError(w, "Failed to sign in", http.StatusInternalServerError)
@@ -0,0 +1,422 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminService is an autogenerated mock type for the AdminService type
+type AdminService struct {
+	mock.Mock
+}
+
+type AdminService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminService) EXPECT() *AdminService_Expecter {
+	return &AdminService_Expecter{mock: &_m.Mock}
+}
+
+// AuditLog provides a mock function with given fields: ctx, filter
+func (_m *AdminService) AuditLog(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditLog")
+	}
+
+	var r0 []domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) ([]domain.AuditEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) []domain.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_AuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditLog'
+type AdminService_AuditLog_Call struct {
+	*mock.Call
+}
+
+// AuditLog is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.AuditFilter
+func (_e *AdminService_Expecter) AuditLog(ctx interface{}, filter interface{}) *AdminService_AuditLog_Call {
+	return &AdminService_AuditLog_Call{Call: _e.mock.On("AuditLog", ctx, filter)}
+}
+
+func (_c *AdminService_AuditLog_Call) Run(run func(ctx context.Context, filter domain.AuditFilter)) *AdminService_AuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *AdminService_AuditLog_Call) Return(_a0 []domain.AuditEntry, _a1 error) *AdminService_AuditLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_AuditLog_Call) RunAndReturn(run func(context.Context, domain.AuditFilter) ([]domain.AuditEntry, error)) *AdminService_AuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlockUser provides a mock function with given fields: ctx, adminID, userID, reason
+func (_m *AdminService) BlockUser(ctx context.Context, adminID string, userID string, reason string) error {
+	ret := _m.Called(ctx, adminID, userID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, adminID, userID, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminService_BlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUser'
+type AdminService_BlockUser_Call struct {
+	*mock.Call
+}
+
+// BlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adminID string
+//   - userID string
+//   - reason string
+func (_e *AdminService_Expecter) BlockUser(ctx interface{}, adminID interface{}, userID interface{}, reason interface{}) *AdminService_BlockUser_Call {
+	return &AdminService_BlockUser_Call{Call: _e.mock.On("BlockUser", ctx, adminID, userID, reason)}
+}
+
+func (_c *AdminService_BlockUser_Call) Run(run func(ctx context.Context, adminID string, userID string, reason string)) *AdminService_BlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_BlockUser_Call) Return(_a0 error) *AdminService_BlockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminService_BlockUser_Call) RunAndReturn(run func(context.Context, string, string, string) error) *AdminService_BlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForceLogout provides a mock function with given fields: ctx, adminID, userID
+func (_m *AdminService) ForceLogout(ctx context.Context, adminID string, userID string) error {
+	ret := _m.Called(ctx, adminID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceLogout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, adminID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminService_ForceLogout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceLogout'
+type AdminService_ForceLogout_Call struct {
+	*mock.Call
+}
+
+// ForceLogout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adminID string
+//   - userID string
+func (_e *AdminService_Expecter) ForceLogout(ctx interface{}, adminID interface{}, userID interface{}) *AdminService_ForceLogout_Call {
+	return &AdminService_ForceLogout_Call{Call: _e.mock.On("ForceLogout", ctx, adminID, userID)}
+}
+
+func (_c *AdminService_ForceLogout_Call) Run(run func(ctx context.Context, adminID string, userID string)) *AdminService_ForceLogout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_ForceLogout_Call) Return(_a0 error) *AdminService_ForceLogout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminService_ForceLogout_Call) RunAndReturn(run func(context.Context, string, string) error) *AdminService_ForceLogout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, adminID, userID
+func (_m *AdminService) GetUser(ctx context.Context, adminID string, userID string) (domain.UserDetails, error) {
+	ret := _m.Called(ctx, adminID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 domain.UserDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.UserDetails, error)); ok {
+		return rf(ctx, adminID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.UserDetails); ok {
+		r0 = rf(ctx, adminID, userID)
+	} else {
+		r0 = ret.Get(0).(domain.UserDetails)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, adminID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type AdminService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adminID string
+//   - userID string
+func (_e *AdminService_Expecter) GetUser(ctx interface{}, adminID interface{}, userID interface{}) *AdminService_GetUser_Call {
+	return &AdminService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, adminID, userID)}
+}
+
+func (_c *AdminService_GetUser_Call) Run(run func(ctx context.Context, adminID string, userID string)) *AdminService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_GetUser_Call) Return(_a0 domain.UserDetails, _a1 error) *AdminService_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_GetUser_Call) RunAndReturn(run func(context.Context, string, string) (domain.UserDetails, error)) *AdminService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *AdminService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []domain.User
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) ([]domain.User, int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) []domain.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFilter) int); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.UserFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AdminService_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type AdminService_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.UserFilter
+func (_e *AdminService_Expecter) ListUsers(ctx interface{}, filter interface{}) *AdminService_ListUsers_Call {
+	return &AdminService_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, filter)}
+}
+
+func (_c *AdminService_ListUsers_Call) Run(run func(ctx context.Context, filter domain.UserFilter)) *AdminService_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserFilter))
+	})
+	return _c
+}
+
+func (_c *AdminService_ListUsers_Call) Return(_a0 []domain.User, _a1 int, _a2 error) *AdminService_ListUsers_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AdminService_ListUsers_Call) RunAndReturn(run func(context.Context, domain.UserFilter) ([]domain.User, int, error)) *AdminService_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUser provides a mock function with given fields: ctx, adminID, userID
+func (_m *AdminService) UnblockUser(ctx context.Context, adminID string, userID string) error {
+	ret := _m.Called(ctx, adminID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, adminID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminService_UnblockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUser'
+type AdminService_UnblockUser_Call struct {
+	*mock.Call
+}
+
+// UnblockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adminID string
+//   - userID string
+func (_e *AdminService_Expecter) UnblockUser(ctx interface{}, adminID interface{}, userID interface{}) *AdminService_UnblockUser_Call {
+	return &AdminService_UnblockUser_Call{Call: _e.mock.On("UnblockUser", ctx, adminID, userID)}
+}
+
+func (_c *AdminService_UnblockUser_Call) Run(run func(ctx context.Context, adminID string, userID string)) *AdminService_UnblockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_UnblockUser_Call) Return(_a0 error) *AdminService_UnblockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminService_UnblockUser_Call) RunAndReturn(run func(context.Context, string, string) error) *AdminService_UnblockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyAuditLog provides a mock function with given fields: ctx
+func (_m *AdminService) VerifyAuditLog(ctx context.Context) (domain.AuditVerification, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAuditLog")
+	}
+
+	var r0 domain.AuditVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.AuditVerification, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.AuditVerification); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.AuditVerification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_VerifyAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyAuditLog'
+type AdminService_VerifyAuditLog_Call struct {
+	*mock.Call
+}
+
+// VerifyAuditLog is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AdminService_Expecter) VerifyAuditLog(ctx interface{}) *AdminService_VerifyAuditLog_Call {
+	return &AdminService_VerifyAuditLog_Call{Call: _e.mock.On("VerifyAuditLog", ctx)}
+}
+
+func (_c *AdminService_VerifyAuditLog_Call) Run(run func(ctx context.Context)) *AdminService_VerifyAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AdminService_VerifyAuditLog_Call) Return(_a0 domain.AuditVerification, _a1 error) *AdminService_VerifyAuditLog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_VerifyAuditLog_Call) RunAndReturn(run func(context.Context) (domain.AuditVerification, error)) *AdminService_VerifyAuditLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminService creates a new instance of AdminService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminService {
+	mock := &AdminService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
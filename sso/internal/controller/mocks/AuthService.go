@@ -23,6 +23,113 @@ func (_m *AuthService) EXPECT() *AuthService_Expecter {
 	return &AuthService_Expecter{mock: &_m.Mock}
 }
 
+// AssignRole provides a mock function with given fields: ctx, actorID, userID, role
+func (_m *AuthService) AssignRole(ctx context.Context, actorID string, userID string, role string) error {
+	ret := _m.Called(ctx, actorID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, actorID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthService_AssignRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignRole'
+type AuthService_AssignRole_Call struct {
+	*mock.Call
+}
+
+// AssignRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID string
+//   - userID string
+//   - role string
+func (_e *AuthService_Expecter) AssignRole(ctx interface{}, actorID interface{}, userID interface{}, role interface{}) *AuthService_AssignRole_Call {
+	return &AuthService_AssignRole_Call{Call: _e.mock.On("AssignRole", ctx, actorID, userID, role)}
+}
+
+func (_c *AuthService_AssignRole_Call) Run(run func(ctx context.Context, actorID string, userID string, role string)) *AuthService_AssignRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_AssignRole_Call) Return(_a0 error) *AuthService_AssignRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthService_AssignRole_Call) RunAndReturn(run func(context.Context, string, string, string) error) *AuthService_AssignRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeMagicLink provides a mock function with given fields: ctx, token, ip
+func (_m *AuthService) ConsumeMagicLink(ctx context.Context, token string, ip string) (domain.Tokens, error) {
+	ret := _m.Called(ctx, token, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeMagicLink")
+	}
+
+	var r0 domain.Tokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.Tokens, error)); ok {
+		return rf(ctx, token, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Tokens); ok {
+		r0 = rf(ctx, token, ip)
+	} else {
+		r0 = ret.Get(0).(domain.Tokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, token, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthService_ConsumeMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeMagicLink'
+type AuthService_ConsumeMagicLink_Call struct {
+	*mock.Call
+}
+
+// ConsumeMagicLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - ip string
+func (_e *AuthService_Expecter) ConsumeMagicLink(ctx interface{}, token interface{}, ip interface{}) *AuthService_ConsumeMagicLink_Call {
+	return &AuthService_ConsumeMagicLink_Call{Call: _e.mock.On("ConsumeMagicLink", ctx, token, ip)}
+}
+
+func (_c *AuthService_ConsumeMagicLink_Call) Run(run func(ctx context.Context, token string, ip string)) *AuthService_ConsumeMagicLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_ConsumeMagicLink_Call) Return(_a0 domain.Tokens, _a1 error) *AuthService_ConsumeMagicLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthService_ConsumeMagicLink_Call) RunAndReturn(run func(context.Context, string, string) (domain.Tokens, error)) *AuthService_ConsumeMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function with given fields: ctx, email, password, ip
 func (_m *AuthService) Login(ctx context.Context, email string, password string, ip string) (domain.Tokens, error) {
 	ret := _m.Called(ctx, email, password, ip)
@@ -186,9 +293,9 @@ func (_c *AuthService_Refresh_Call) RunAndReturn(run func(context.Context, strin
 	return _c
 }
 
-// Register provides a mock function with given fields: ctx, email, password
-func (_m *AuthService) Register(ctx context.Context, email string, password string) (string, error) {
-	ret := _m.Called(ctx, email, password)
+// Register provides a mock function with given fields: ctx, email, password, locale
+func (_m *AuthService) Register(ctx context.Context, email string, password string, locale string) (string, error) {
+	ret := _m.Called(ctx, email, password, locale)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
@@ -196,17 +303,17 @@ func (_m *AuthService) Register(ctx context.Context, email string, password stri
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, email, password, locale)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, email, password, locale)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, email, password, locale)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -223,13 +330,14 @@ type AuthService_Register_Call struct {
 //   - ctx context.Context
 //   - email string
 //   - password string
-func (_e *AuthService_Expecter) Register(ctx interface{}, email interface{}, password interface{}) *AuthService_Register_Call {
-	return &AuthService_Register_Call{Call: _e.mock.On("Register", ctx, email, password)}
+//   - locale string
+func (_e *AuthService_Expecter) Register(ctx interface{}, email interface{}, password interface{}, locale interface{}) *AuthService_Register_Call {
+	return &AuthService_Register_Call{Call: _e.mock.On("Register", ctx, email, password, locale)}
 }
 
-func (_c *AuthService_Register_Call) Run(run func(ctx context.Context, email string, password string)) *AuthService_Register_Call {
+func (_c *AuthService_Register_Call) Run(run func(ctx context.Context, email string, password string, locale string)) *AuthService_Register_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -239,7 +347,162 @@ func (_c *AuthService_Register_Call) Return(_a0 string, _a1 error) *AuthService_
 	return _c
 }
 
-func (_c *AuthService_Register_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *AuthService_Register_Call {
+func (_c *AuthService_Register_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *AuthService_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestMagicLink provides a mock function with given fields: ctx, email, locale
+func (_m *AuthService) RequestMagicLink(ctx context.Context, email string, locale string) error {
+	ret := _m.Called(ctx, email, locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestMagicLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, email, locale)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthService_RequestMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestMagicLink'
+type AuthService_RequestMagicLink_Call struct {
+	*mock.Call
+}
+
+// RequestMagicLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - locale string
+func (_e *AuthService_Expecter) RequestMagicLink(ctx interface{}, email interface{}, locale interface{}) *AuthService_RequestMagicLink_Call {
+	return &AuthService_RequestMagicLink_Call{Call: _e.mock.On("RequestMagicLink", ctx, email, locale)}
+}
+
+func (_c *AuthService_RequestMagicLink_Call) Run(run func(ctx context.Context, email string, locale string)) *AuthService_RequestMagicLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_RequestMagicLink_Call) Return(_a0 error) *AuthService_RequestMagicLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthService_RequestMagicLink_Call) RunAndReturn(run func(context.Context, string, string) error) *AuthService_RequestMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sessions provides a mock function with given fields: ctx, userID
+func (_m *AuthService) Sessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthService_Sessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sessions'
+type AuthService_Sessions_Call struct {
+	*mock.Call
+}
+
+// Sessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *AuthService_Expecter) Sessions(ctx interface{}, userID interface{}) *AuthService_Sessions_Call {
+	return &AuthService_Sessions_Call{Call: _e.mock.On("Sessions", ctx, userID)}
+}
+
+func (_c *AuthService_Sessions_Call) Run(run func(ctx context.Context, userID string)) *AuthService_Sessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_Sessions_Call) Return(_a0 []domain.Session, _a1 error) *AuthService_Sessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthService_Sessions_Call) RunAndReturn(run func(context.Context, string) ([]domain.Session, error)) *AuthService_Sessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TerminateSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *AuthService) TerminateSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TerminateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthService_TerminateSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TerminateSession'
+type AuthService_TerminateSession_Call struct {
+	*mock.Call
+}
+
+// TerminateSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+func (_e *AuthService_Expecter) TerminateSession(ctx interface{}, userID interface{}, sessionID interface{}) *AuthService_TerminateSession_Call {
+	return &AuthService_TerminateSession_Call{Call: _e.mock.On("TerminateSession", ctx, userID, sessionID)}
+}
+
+func (_c *AuthService_TerminateSession_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *AuthService_TerminateSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthService_TerminateSession_Call) Return(_a0 error) *AuthService_TerminateSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthService_TerminateSession_Call) RunAndReturn(run func(context.Context, string, string) error) *AuthService_TerminateSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,502 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCService is an autogenerated mock type for the OIDCService type
+type OIDCService struct {
+	mock.Mock
+}
+
+type OIDCService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCService) EXPECT() *OIDCService_Expecter {
+	return &OIDCService_Expecter{mock: &_m.Mock}
+}
+
+// Authorize provides a mock function with given fields: ctx, userID, req
+func (_m *OIDCService) Authorize(ctx context.Context, userID string, req domain.AuthorizationRequest) (string, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.AuthorizationRequest) (string, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.AuthorizationRequest) string); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.AuthorizationRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCService_Authorize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authorize'
+type OIDCService_Authorize_Call struct {
+	*mock.Call
+}
+
+// Authorize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - req domain.AuthorizationRequest
+func (_e *OIDCService_Expecter) Authorize(ctx interface{}, userID interface{}, req interface{}) *OIDCService_Authorize_Call {
+	return &OIDCService_Authorize_Call{Call: _e.mock.On("Authorize", ctx, userID, req)}
+}
+
+func (_c *OIDCService_Authorize_Call) Run(run func(ctx context.Context, userID string, req domain.AuthorizationRequest)) *OIDCService_Authorize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.AuthorizationRequest))
+	})
+	return _c
+}
+
+func (_c *OIDCService_Authorize_Call) Return(_a0 string, _a1 error) *OIDCService_Authorize_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCService_Authorize_Call) RunAndReturn(run func(context.Context, string, domain.AuthorizationRequest) (string, error)) *OIDCService_Authorize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Client provides a mock function with given fields: ctx, clientID
+func (_m *OIDCService) Client(ctx context.Context, clientID string) (domain.OIDCClient, error) {
+	ret := _m.Called(ctx, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Client")
+	}
+
+	var r0 domain.OIDCClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.OIDCClient, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.OIDCClient); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Get(0).(domain.OIDCClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCService_Client_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Client'
+type OIDCService_Client_Call struct {
+	*mock.Call
+}
+
+// Client is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *OIDCService_Expecter) Client(ctx interface{}, clientID interface{}) *OIDCService_Client_Call {
+	return &OIDCService_Client_Call{Call: _e.mock.On("Client", ctx, clientID)}
+}
+
+func (_c *OIDCService_Client_Call) Run(run func(ctx context.Context, clientID string)) *OIDCService_Client_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCService_Client_Call) Return(_a0 domain.OIDCClient, _a1 error) *OIDCService_Client_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCService_Client_Call) RunAndReturn(run func(context.Context, string) (domain.OIDCClient, error)) *OIDCService_Client_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Consent provides a mock function with given fields: ctx, userID, req
+func (_m *OIDCService) Consent(ctx context.Context, userID string, req domain.AuthorizationRequest) (string, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consent")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.AuthorizationRequest) (string, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.AuthorizationRequest) string); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.AuthorizationRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCService_Consent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consent'
+type OIDCService_Consent_Call struct {
+	*mock.Call
+}
+
+// Consent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - req domain.AuthorizationRequest
+func (_e *OIDCService_Expecter) Consent(ctx interface{}, userID interface{}, req interface{}) *OIDCService_Consent_Call {
+	return &OIDCService_Consent_Call{Call: _e.mock.On("Consent", ctx, userID, req)}
+}
+
+func (_c *OIDCService_Consent_Call) Run(run func(ctx context.Context, userID string, req domain.AuthorizationRequest)) *OIDCService_Consent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.AuthorizationRequest))
+	})
+	return _c
+}
+
+func (_c *OIDCService_Consent_Call) Return(_a0 string, _a1 error) *OIDCService_Consent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCService_Consent_Call) RunAndReturn(run func(context.Context, string, domain.AuthorizationRequest) (string, error)) *OIDCService_Consent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exchange provides a mock function with given fields: ctx, clientID, secret, code, redirectURI, verifier
+func (_m *OIDCService) Exchange(ctx context.Context, clientID string, secret string, code string, redirectURI string, verifier string) (domain.OIDCTokens, error) {
+	ret := _m.Called(ctx, clientID, secret, code, redirectURI, verifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 domain.OIDCTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) (domain.OIDCTokens, error)); ok {
+		return rf(ctx, clientID, secret, code, redirectURI, verifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) domain.OIDCTokens); ok {
+		r0 = rf(ctx, clientID, secret, code, redirectURI, verifier)
+	} else {
+		r0 = ret.Get(0).(domain.OIDCTokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string) error); ok {
+		r1 = rf(ctx, clientID, secret, code, redirectURI, verifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCService_Exchange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exchange'
+type OIDCService_Exchange_Call struct {
+	*mock.Call
+}
+
+// Exchange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - secret string
+//   - code string
+//   - redirectURI string
+//   - verifier string
+func (_e *OIDCService_Expecter) Exchange(ctx interface{}, clientID interface{}, secret interface{}, code interface{}, redirectURI interface{}, verifier interface{}) *OIDCService_Exchange_Call {
+	return &OIDCService_Exchange_Call{Call: _e.mock.On("Exchange", ctx, clientID, secret, code, redirectURI, verifier)}
+}
+
+func (_c *OIDCService_Exchange_Call) Run(run func(ctx context.Context, clientID string, secret string, code string, redirectURI string, verifier string)) *OIDCService_Exchange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCService_Exchange_Call) Return(_a0 domain.OIDCTokens, _a1 error) *OIDCService_Exchange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCService_Exchange_Call) RunAndReturn(run func(context.Context, string, string, string, string, string) (domain.OIDCTokens, error)) *OIDCService_Exchange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JWKS provides a mock function with no fields
+func (_m *OIDCService) JWKS() []domain.JWK {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 []domain.JWK
+	if rf, ok := ret.Get(0).(func() []domain.JWK); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.JWK)
+		}
+	}
+
+	return r0
+}
+
+// OIDCService_JWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JWKS'
+type OIDCService_JWKS_Call struct {
+	*mock.Call
+}
+
+// JWKS is a helper method to define mock.On call
+func (_e *OIDCService_Expecter) JWKS() *OIDCService_JWKS_Call {
+	return &OIDCService_JWKS_Call{Call: _e.mock.On("JWKS")}
+}
+
+func (_c *OIDCService_JWKS_Call) Run(run func()) *OIDCService_JWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *OIDCService_JWKS_Call) Return(_a0 []domain.JWK) *OIDCService_JWKS_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OIDCService_JWKS_Call) RunAndReturn(run func() []domain.JWK) *OIDCService_JWKS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, clientID, secret, refreshToken
+func (_m *OIDCService) Refresh(ctx context.Context, clientID string, secret string, refreshToken string) (domain.OIDCTokens, error) {
+	ret := _m.Called(ctx, clientID, secret, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 domain.OIDCTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.OIDCTokens, error)); ok {
+		return rf(ctx, clientID, secret, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) domain.OIDCTokens); ok {
+		r0 = rf(ctx, clientID, secret, refreshToken)
+	} else {
+		r0 = ret.Get(0).(domain.OIDCTokens)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, clientID, secret, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCService_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type OIDCService_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - secret string
+//   - refreshToken string
+func (_e *OIDCService_Expecter) Refresh(ctx interface{}, clientID interface{}, secret interface{}, refreshToken interface{}) *OIDCService_Refresh_Call {
+	return &OIDCService_Refresh_Call{Call: _e.mock.On("Refresh", ctx, clientID, secret, refreshToken)}
+}
+
+func (_c *OIDCService_Refresh_Call) Run(run func(ctx context.Context, clientID string, secret string, refreshToken string)) *OIDCService_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCService_Refresh_Call) Return(_a0 domain.OIDCTokens, _a1 error) *OIDCService_Refresh_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCService_Refresh_Call) RunAndReturn(run func(context.Context, string, string, string) (domain.OIDCTokens, error)) *OIDCService_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterClient provides a mock function with given fields: ctx, adminID, name, redirectURIs, public
+func (_m *OIDCService) RegisterClient(ctx context.Context, adminID string, name string, redirectURIs []string, public bool) (domain.OIDCClient, string, error) {
+	ret := _m.Called(ctx, adminID, name, redirectURIs, public)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterClient")
+	}
+
+	var r0 domain.OIDCClient
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, bool) (domain.OIDCClient, string, error)); ok {
+		return rf(ctx, adminID, name, redirectURIs, public)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, bool) domain.OIDCClient); ok {
+		r0 = rf(ctx, adminID, name, redirectURIs, public)
+	} else {
+		r0 = ret.Get(0).(domain.OIDCClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, bool) string); ok {
+		r1 = rf(ctx, adminID, name, redirectURIs, public)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, []string, bool) error); ok {
+		r2 = rf(ctx, adminID, name, redirectURIs, public)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// OIDCService_RegisterClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterClient'
+type OIDCService_RegisterClient_Call struct {
+	*mock.Call
+}
+
+// RegisterClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adminID string
+//   - name string
+//   - redirectURIs []string
+//   - public bool
+func (_e *OIDCService_Expecter) RegisterClient(ctx interface{}, adminID interface{}, name interface{}, redirectURIs interface{}, public interface{}) *OIDCService_RegisterClient_Call {
+	return &OIDCService_RegisterClient_Call{Call: _e.mock.On("RegisterClient", ctx, adminID, name, redirectURIs, public)}
+}
+
+func (_c *OIDCService_RegisterClient_Call) Run(run func(ctx context.Context, adminID string, name string, redirectURIs []string, public bool)) *OIDCService_RegisterClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]string), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *OIDCService_RegisterClient_Call) Return(_a0 domain.OIDCClient, _a1 string, _a2 error) *OIDCService_RegisterClient_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *OIDCService_RegisterClient_Call) RunAndReturn(run func(context.Context, string, string, []string, bool) (domain.OIDCClient, string, error)) *OIDCService_RegisterClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserInfo provides a mock function with given fields: ctx, accessToken
+func (_m *OIDCService) UserInfo(ctx context.Context, accessToken string) (domain.UserInfo, error) {
+	ret := _m.Called(ctx, accessToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserInfo")
+	}
+
+	var r0 domain.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.UserInfo, error)); ok {
+		return rf(ctx, accessToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.UserInfo); ok {
+		r0 = rf(ctx, accessToken)
+	} else {
+		r0 = ret.Get(0).(domain.UserInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accessToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCService_UserInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserInfo'
+type OIDCService_UserInfo_Call struct {
+	*mock.Call
+}
+
+// UserInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accessToken string
+func (_e *OIDCService_Expecter) UserInfo(ctx interface{}, accessToken interface{}) *OIDCService_UserInfo_Call {
+	return &OIDCService_UserInfo_Call{Call: _e.mock.On("UserInfo", ctx, accessToken)}
+}
+
+func (_c *OIDCService_UserInfo_Call) Run(run func(ctx context.Context, accessToken string)) *OIDCService_UserInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCService_UserInfo_Call) Return(_a0 domain.UserInfo, _a1 error) *OIDCService_UserInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCService_UserInfo_Call) RunAndReturn(run func(context.Context, string) (domain.UserInfo, error)) *OIDCService_UserInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCService creates a new instance of OIDCService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCService {
+	mock := &OIDCService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,275 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/SergeyBogomolovv/profile-manager/common/auth"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PersonalTokenService is an autogenerated mock type for the PersonalTokenService type
+type PersonalTokenService struct {
+	mock.Mock
+}
+
+type PersonalTokenService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersonalTokenService) EXPECT() *PersonalTokenService_Expecter {
+	return &PersonalTokenService_Expecter{mock: &_m.Mock}
+}
+
+// CreatePersonalToken provides a mock function with given fields: ctx, userID, name, scopes, ttl
+func (_m *PersonalTokenService) CreatePersonalToken(ctx context.Context, userID string, name string, scopes []string, ttl time.Duration) (domain.PersonalToken, string, error) {
+	ret := _m.Called(ctx, userID, name, scopes, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePersonalToken")
+	}
+
+	var r0 domain.PersonalToken
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, time.Duration) (domain.PersonalToken, string, error)); ok {
+		return rf(ctx, userID, name, scopes, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string, time.Duration) domain.PersonalToken); ok {
+		r0 = rf(ctx, userID, name, scopes, ttl)
+	} else {
+		r0 = ret.Get(0).(domain.PersonalToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string, time.Duration) string); ok {
+		r1 = rf(ctx, userID, name, scopes, ttl)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, []string, time.Duration) error); ok {
+		r2 = rf(ctx, userID, name, scopes, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PersonalTokenService_CreatePersonalToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePersonalToken'
+type PersonalTokenService_CreatePersonalToken_Call struct {
+	*mock.Call
+}
+
+// CreatePersonalToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - name string
+//   - scopes []string
+//   - ttl time.Duration
+func (_e *PersonalTokenService_Expecter) CreatePersonalToken(ctx interface{}, userID interface{}, name interface{}, scopes interface{}, ttl interface{}) *PersonalTokenService_CreatePersonalToken_Call {
+	return &PersonalTokenService_CreatePersonalToken_Call{Call: _e.mock.On("CreatePersonalToken", ctx, userID, name, scopes, ttl)}
+}
+
+func (_c *PersonalTokenService_CreatePersonalToken_Call) Run(run func(ctx context.Context, userID string, name string, scopes []string, ttl time.Duration)) *PersonalTokenService_CreatePersonalToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]string), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenService_CreatePersonalToken_Call) Return(_a0 domain.PersonalToken, _a1 string, _a2 error) *PersonalTokenService_CreatePersonalToken_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *PersonalTokenService_CreatePersonalToken_Call) RunAndReturn(run func(context.Context, string, string, []string, time.Duration) (domain.PersonalToken, string, error)) *PersonalTokenService_CreatePersonalToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PersonalTokens provides a mock function with given fields: ctx, userID
+func (_m *PersonalTokenService) PersonalTokens(ctx context.Context, userID string) ([]domain.PersonalToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersonalTokens")
+	}
+
+	var r0 []domain.PersonalToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.PersonalToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.PersonalToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PersonalToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalTokenService_PersonalTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PersonalTokens'
+type PersonalTokenService_PersonalTokens_Call struct {
+	*mock.Call
+}
+
+// PersonalTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *PersonalTokenService_Expecter) PersonalTokens(ctx interface{}, userID interface{}) *PersonalTokenService_PersonalTokens_Call {
+	return &PersonalTokenService_PersonalTokens_Call{Call: _e.mock.On("PersonalTokens", ctx, userID)}
+}
+
+func (_c *PersonalTokenService_PersonalTokens_Call) Run(run func(ctx context.Context, userID string)) *PersonalTokenService_PersonalTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenService_PersonalTokens_Call) Return(_a0 []domain.PersonalToken, _a1 error) *PersonalTokenService_PersonalTokens_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalTokenService_PersonalTokens_Call) RunAndReturn(run func(context.Context, string) ([]domain.PersonalToken, error)) *PersonalTokenService_PersonalTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokePersonalToken provides a mock function with given fields: ctx, userID, tokenID
+func (_m *PersonalTokenService) RevokePersonalToken(ctx context.Context, userID string, tokenID string) error {
+	ret := _m.Called(ctx, userID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokePersonalToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalTokenService_RevokePersonalToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokePersonalToken'
+type PersonalTokenService_RevokePersonalToken_Call struct {
+	*mock.Call
+}
+
+// RevokePersonalToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tokenID string
+func (_e *PersonalTokenService_Expecter) RevokePersonalToken(ctx interface{}, userID interface{}, tokenID interface{}) *PersonalTokenService_RevokePersonalToken_Call {
+	return &PersonalTokenService_RevokePersonalToken_Call{Call: _e.mock.On("RevokePersonalToken", ctx, userID, tokenID)}
+}
+
+func (_c *PersonalTokenService_RevokePersonalToken_Call) Run(run func(ctx context.Context, userID string, tokenID string)) *PersonalTokenService_RevokePersonalToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenService_RevokePersonalToken_Call) Return(_a0 error) *PersonalTokenService_RevokePersonalToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalTokenService_RevokePersonalToken_Call) RunAndReturn(run func(context.Context, string, string) error) *PersonalTokenService_RevokePersonalToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyPersonalToken provides a mock function with given fields: ctx, token
+func (_m *PersonalTokenService) VerifyPersonalToken(ctx context.Context, token string) (*auth.TokenClaims, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyPersonalToken")
+	}
+
+	var r0 *auth.TokenClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*auth.TokenClaims, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *auth.TokenClaims); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.TokenClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalTokenService_VerifyPersonalToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyPersonalToken'
+type PersonalTokenService_VerifyPersonalToken_Call struct {
+	*mock.Call
+}
+
+// VerifyPersonalToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *PersonalTokenService_Expecter) VerifyPersonalToken(ctx interface{}, token interface{}) *PersonalTokenService_VerifyPersonalToken_Call {
+	return &PersonalTokenService_VerifyPersonalToken_Call{Call: _e.mock.On("VerifyPersonalToken", ctx, token)}
+}
+
+func (_c *PersonalTokenService_VerifyPersonalToken_Call) Run(run func(ctx context.Context, token string)) *PersonalTokenService_VerifyPersonalToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenService_VerifyPersonalToken_Call) Return(_a0 *auth.TokenClaims, _a1 error) *PersonalTokenService_VerifyPersonalToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalTokenService_VerifyPersonalToken_Call) RunAndReturn(run func(context.Context, string) (*auth.TokenClaims, error)) *PersonalTokenService_VerifyPersonalToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPersonalTokenService creates a new instance of PersonalTokenService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalTokenService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalTokenService {
+	mock := &PersonalTokenService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
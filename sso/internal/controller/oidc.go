@@ -0,0 +1,457 @@
+package controller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/httpx"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const consentTTL = time.Minute * 10
+
+type OIDCService interface {
+	RegisterClient(ctx context.Context, adminID, name string, redirectURIs []string, public bool) (domain.OIDCClient, string, error)
+	Client(ctx context.Context, clientID string) (domain.OIDCClient, error)
+	Authorize(ctx context.Context, userID string, req domain.AuthorizationRequest) (string, error)
+	Consent(ctx context.Context, userID string, req domain.AuthorizationRequest) (string, error)
+	Exchange(ctx context.Context, clientID, secret, code, redirectURI, verifier string) (domain.OIDCTokens, error)
+	Refresh(ctx context.Context, clientID, secret, refreshToken string) (domain.OIDCTokens, error)
+	UserInfo(ctx context.Context, accessToken string) (domain.UserInfo, error)
+	JWKS() []domain.JWK
+}
+
+type oauthError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	Scope        string `json:"scope"`
+}
+
+// Client metadata of dynamic registration (RFC 7591)
+type clientMetadata struct {
+	ClientID                string   `json:"client_id,omitempty"`
+	ClientSecret            string   `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64    `json:"client_id_issued_at,omitempty"`
+	ClientName              string   `json:"client_name"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+}
+
+// Consent form carries authorization request signed by sso, so it can't be changed or forged by other sites
+type consentClaims struct {
+	Request domain.AuthorizationRequest `json:"req"`
+	jwt.RegisteredClaims
+}
+
+var consentPage = template.Must(template.New("consent").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Sign in to {{.Client}}</title></head>
+<body>
+<h1>{{.Client}} wants to access your account</h1>
+<ul>{{range .Scopes}}<li>{{.}}</li>{{end}}</ul>
+<form method="post">
+<input type="hidden" name="consent" value="{{.Consent}}">
+<button type="submit" name="decision" value="allow">Allow</button>
+<button type="submit" name="decision" value="deny">Deny</button>
+</form>
+</body>
+</html>`))
+
+// Claims shown on consent page
+var scopeDescriptions = map[string]string{
+	domain.ScopeOpenID:  "Your account ID",
+	domain.ScopeProfile: "Your name, username, avatar, birth date and gender",
+	domain.ScopeEmail:   "Your email",
+}
+
+func (c *httpController) initOIDC(router *chi.Mux) {
+	router.Get("/.well-known/openid-configuration", c.HandleDiscovery)
+	router.Route("/oauth2", func(r chi.Router) {
+		r.Get("/jwks", c.HandleJWKS)
+		r.Post("/register", c.HandleRegister)
+		r.Get("/authorize", c.HandleAuthorize)
+		r.Post("/authorize", c.HandleConsent)
+		r.Post("/token", c.HandleToken)
+		r.Get("/userinfo", c.HandleUserInfo)
+		r.Post("/userinfo", c.HandleUserInfo)
+	})
+}
+
+// Provider metadata of OpenID Connect Discovery
+func (c *httpController) HandleDiscovery(w http.ResponseWriter, r *http.Request) {
+	issuer := c.oidcConf.Issuer
+	httpx.WriteJSON(w, map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth2/authorize",
+		"token_endpoint":                        issuer + "/oauth2/token",
+		"userinfo_endpoint":                     issuer + "/oauth2/userinfo",
+		"jwks_uri":                              issuer + "/oauth2/jwks",
+		"registration_endpoint":                 issuer + "/oauth2/register",
+		"scopes_supported":                      domain.OIDCScopes,
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code", "refresh_token"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{jwt.SigningMethodRS256.Alg()},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported": []string{
+			"sub", "email", "name", "given_name", "family_name", "preferred_username",
+			"picture", "birthdate", "gender", "locale",
+		},
+	}, http.StatusOK)
+}
+
+func (c *httpController) HandleJWKS(w http.ResponseWriter, r *http.Request) {
+	httpx.WriteJSON(w, map[string]any{"keys": c.oidc.JWKS()}, http.StatusOK)
+}
+
+// Registration of clients, it requires access token with clients:manage permission
+func (c *httpController) HandleRegister(w http.ResponseWriter, r *http.Request) {
+	const op = "oidc.HandleRegister"
+	logger := c.logger.With(slog.String("op", op))
+
+	claims, err := auth.VerifyJWT(bearerToken(r), c.jwtSecret)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		httpx.WriteJSON(w, oauthError{Error: "invalid_token"}, http.StatusUnauthorized)
+		return
+	}
+	if !claims.HasPermission(auth.PermissionManageClients) {
+		httpx.WriteJSON(w, oauthError{Error: "insufficient_scope"}, http.StatusForbidden)
+		return
+	}
+
+	var req clientMetadata
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteJSON(w, oauthError{Error: "invalid_client_metadata"}, http.StatusBadRequest)
+		return
+	}
+	req.ClientName = strings.TrimSpace(req.ClientName)
+	if req.ClientName == "" || len(req.ClientName) > 100 {
+		httpx.WriteJSON(w, oauthError{Error: "invalid_client_metadata", Description: "invalid client_name"}, http.StatusBadRequest)
+		return
+	}
+	switch req.TokenEndpointAuthMethod {
+	case "":
+		req.TokenEndpointAuthMethod = "client_secret_basic"
+	case "client_secret_basic", "client_secret_post", "none":
+	default:
+		httpx.WriteJSON(w, oauthError{Error: "invalid_client_metadata", Description: "unsupported token_endpoint_auth_method"}, http.StatusBadRequest)
+		return
+	}
+
+	ctx := request.Inject(r.Context(), request.FromHTTP(r))
+	public := req.TokenEndpointAuthMethod == "none"
+	client, secret, err := c.oidc.RegisterClient(ctx, claims.UserID, req.ClientName, req.RedirectURIs, public)
+	if errors.Is(err, domain.ErrInvalidRedirectURI) {
+		httpx.WriteJSON(w, oauthError{Error: "invalid_redirect_uri"}, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		logger.Error("failed to register client", "err", err)
+		httpx.WriteJSON(w, oauthError{Error: "server_error"}, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	httpx.WriteJSON(w, clientMetadata{
+		ClientID:                client.ID,
+		ClientSecret:            secret,
+		ClientIDIssuedAt:        client.CreatedAt.Unix(),
+		ClientName:              client.Name,
+		RedirectURIs:            client.RedirectURIs,
+		TokenEndpointAuthMethod: req.TokenEndpointAuthMethod,
+	}, http.StatusCreated)
+}
+
+// Authorization endpoint, signed out users are sent to login page and come back with return_to
+func (c *httpController) HandleAuthorize(w http.ResponseWriter, r *http.Request) {
+	const op = "oidc.HandleAuthorize"
+	logger := c.logger.With(slog.String("op", op))
+
+	q := r.URL.Query()
+	req := domain.AuthorizationRequest{
+		ClientID:      q.Get("client_id"),
+		RedirectURI:   q.Get("redirect_uri"),
+		Scopes:        strings.Fields(q.Get("scope")),
+		State:         q.Get("state"),
+		Nonce:         q.Get("nonce"),
+		CodeChallenge: q.Get("code_challenge"),
+	}
+	client, ok := c.checkClient(w, r, logger, req)
+	if !ok {
+		return
+	}
+	if q.Get("response_type") != "code" {
+		redirectError(w, r, req, "unsupported_response_type")
+		return
+	}
+	if req.CodeChallenge == "" || q.Get("code_challenge_method") != "S256" {
+		redirectError(w, r, req, "invalid_request")
+		return
+	}
+
+	prompt := q.Get("prompt")
+	userID, ok := c.signedInUser(r)
+	if !ok {
+		if prompt == "none" {
+			redirectError(w, r, req, "login_required")
+			return
+		}
+		http.Redirect(w, r, c.oidcConf.LoginURL+"?"+url.Values{"return_to": {c.oidcConf.Issuer + r.URL.RequestURI()}}.Encode(), http.StatusFound)
+		return
+	}
+
+	ctx := request.Inject(r.Context(), request.FromHTTP(r))
+	err := domain.ErrConsentRequired
+	var code string
+	if prompt != "consent" {
+		code, err = c.oidc.Authorize(ctx, userID, req)
+	}
+	if errors.Is(err, domain.ErrConsentRequired) && prompt != "none" {
+		c.renderConsent(w, logger, client, userID, req)
+		return
+	}
+	c.finishAuthorize(w, r, logger, req, code, err)
+}
+
+// Decision of user on consent page
+func (c *httpController) HandleConsent(w http.ResponseWriter, r *http.Request) {
+	const op = "oidc.HandleConsent"
+	logger := c.logger.With(slog.String("op", op))
+
+	claims := &consentClaims{}
+	_, err := jwt.ParseWithClaims(r.PostFormValue("consent"), claims, func(t *jwt.Token) (any, error) {
+		return c.consentKey(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		httpx.WriteError(w, "Invalid consent", http.StatusBadRequest)
+		return
+	}
+	req := claims.Request
+	if _, ok := c.checkClient(w, r, logger, req); !ok {
+		return
+	}
+	// Form must be submitted by the same user who was asked
+	if userID, ok := c.signedInUser(r); !ok || userID != claims.Subject {
+		redirectError(w, r, req, "login_required")
+		return
+	}
+	if r.PostFormValue("decision") != "allow" {
+		redirectError(w, r, req, "access_denied")
+		return
+	}
+
+	ctx := request.Inject(r.Context(), request.FromHTTP(r))
+	code, err := c.oidc.Consent(ctx, claims.Subject, req)
+	c.finishAuthorize(w, r, logger, req, code, err)
+}
+
+// Token endpoint, clients authenticate with HTTP Basic or form parameters, public clients send only client_id
+func (c *httpController) HandleToken(w http.ResponseWriter, r *http.Request) {
+	const op = "oidc.HandleToken"
+	logger := c.logger.With(slog.String("op", op))
+
+	w.Header().Set("Cache-Control", "no-store")
+	if err := r.ParseForm(); err != nil {
+		httpx.WriteJSON(w, oauthError{Error: "invalid_request"}, http.StatusBadRequest)
+		return
+	}
+	clientID, secret, basic := clientCredentials(r)
+
+	var tokens domain.OIDCTokens
+	var err error
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		tokens, err = c.oidc.Exchange(r.Context(), clientID, secret, r.PostForm.Get("code"), r.PostForm.Get("redirect_uri"), r.PostForm.Get("code_verifier"))
+	case "refresh_token":
+		tokens, err = c.oidc.Refresh(r.Context(), clientID, secret, r.PostForm.Get("refresh_token"))
+	default:
+		httpx.WriteJSON(w, oauthError{Error: "unsupported_grant_type"}, http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidClient):
+		if basic {
+			w.Header().Set("WWW-Authenticate", `Basic realm="sso"`)
+		}
+		httpx.WriteJSON(w, oauthError{Error: "invalid_client"}, http.StatusUnauthorized)
+	case errors.Is(err, domain.ErrInvalidGrant):
+		httpx.WriteJSON(w, oauthError{Error: "invalid_grant"}, http.StatusBadRequest)
+	case err != nil:
+		logger.Error("failed to issue tokens", "err", err, "client_id", clientID)
+		httpx.WriteJSON(w, oauthError{Error: "server_error"}, http.StatusInternalServerError)
+	default:
+		httpx.WriteJSON(w, tokenResponse{
+			AccessToken:  tokens.AccessToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    int(tokens.ExpiresIn.Seconds()),
+			RefreshToken: tokens.RefreshToken,
+			IDToken:      tokens.IDToken,
+			Scope:        strings.Join(tokens.Scopes, " "),
+		}, http.StatusOK)
+	}
+}
+
+func (c *httpController) HandleUserInfo(w http.ResponseWriter, r *http.Request) {
+	const op = "oidc.HandleUserInfo"
+	logger := c.logger.With(slog.String("op", op))
+
+	info, err := c.oidc.UserInfo(r.Context(), bearerToken(r))
+	if errors.Is(err, domain.ErrInvalidToken) {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		httpx.WriteJSON(w, oauthError{Error: "invalid_token"}, http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		logger.Error("failed to get user info", "err", err)
+		httpx.WriteJSON(w, oauthError{Error: "server_error"}, http.StatusInternalServerError)
+		return
+	}
+	httpx.WriteJSON(w, info, http.StatusOK)
+}
+
+// checkClient writes error without redirect if client or redirect URI is unknown, so sso never redirects to unregistered URI
+func (c *httpController) checkClient(w http.ResponseWriter, r *http.Request, logger *slog.Logger, req domain.AuthorizationRequest) (domain.OIDCClient, bool) {
+	client, err := c.oidc.Client(r.Context(), req.ClientID)
+	if errors.Is(err, domain.ErrClientNotFound) {
+		httpx.WriteError(w, "Unknown client", http.StatusBadRequest)
+		return domain.OIDCClient{}, false
+	}
+	if err != nil {
+		logger.Error("failed to get client", "err", err, "client_id", req.ClientID)
+		httpx.WriteError(w, "Failed to get client", http.StatusInternalServerError)
+		return domain.OIDCClient{}, false
+	}
+	if !client.HasRedirectURI(req.RedirectURI) {
+		httpx.WriteError(w, "Invalid redirect URI", http.StatusBadRequest)
+		return domain.OIDCClient{}, false
+	}
+	return client, true
+}
+
+func (c *httpController) finishAuthorize(w http.ResponseWriter, r *http.Request, logger *slog.Logger, req domain.AuthorizationRequest, code string, err error) {
+	switch {
+	case err == nil:
+		redirectWith(w, r, req, url.Values{"code": {code}})
+	case errors.Is(err, domain.ErrInvalidScope):
+		redirectError(w, r, req, "invalid_scope")
+	case errors.Is(err, domain.ErrCodeChallengeRequired):
+		redirectError(w, r, req, "invalid_request")
+	case errors.Is(err, domain.ErrConsentRequired):
+		redirectError(w, r, req, "consent_required")
+	case errors.Is(err, domain.ErrUserBlocked), errors.Is(err, domain.ErrUserNotFound):
+		redirectError(w, r, req, "access_denied")
+	default:
+		logger.Error("failed to authorize", "err", err, "client_id", req.ClientID)
+		redirectError(w, r, req, "server_error")
+	}
+}
+
+func (c *httpController) renderConsent(w http.ResponseWriter, logger *slog.Logger, client domain.OIDCClient, userID string, req domain.AuthorizationRequest) {
+	now := time.Now()
+	consent, err := jwt.NewWithClaims(jwt.SigningMethodHS256, consentClaims{
+		Request: req,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(consentTTL)),
+		},
+	}).SignedString(c.consentKey())
+	if err != nil {
+		logger.Error("failed to sign consent", "err", err)
+		httpx.WriteError(w, "Failed to authorize", http.StatusInternalServerError)
+		return
+	}
+	scopes := make([]string, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = scopeDescriptions[scope]
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "frame-ancestors 'none'")
+	consentPage.Execute(w, map[string]any{"Client": client.Name, "Scopes": scopes, "Consent": consent})
+}
+
+// signedInUser reads access token which gateway sets as cookie, or sent in Authorization header
+func (c *httpController) signedInUser(r *http.Request) (string, bool) {
+	token := bearerToken(r)
+	if cookie, err := r.Cookie("access_token"); err == nil && token == "" {
+		token = cookie.Value
+	}
+	claims, err := auth.VerifyJWT(token, c.jwtSecret)
+	if err != nil || claims.UserID == "" {
+		return "", false
+	}
+	return claims.UserID, true
+}
+
+// consentKey is derived from JWT secret, so consent can't be used as access token
+func (c *httpController) consentKey() []byte {
+	mac := hmac.New(sha256.New, c.jwtSecret)
+	mac.Write([]byte("oidc consent"))
+	return mac.Sum(nil)
+}
+
+func redirectError(w http.ResponseWriter, r *http.Request, req domain.AuthorizationRequest, code string) {
+	redirectWith(w, r, req, url.Values{"error": {code}})
+}
+
+// redirectWith redirects to registered redirect URI of client, state is returned as is
+func redirectWith(w http.ResponseWriter, r *http.Request, req domain.AuthorizationRequest, params url.Values) {
+	u, _ := url.Parse(req.RedirectURI)
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	if req.State != "" {
+		q.Set("state", req.State)
+	}
+	u.RawQuery = q.Encode()
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func bearerToken(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return token
+}
+
+// clientCredentials returns credentials of client, basic reports whether HTTP Basic was used
+func clientCredentials(r *http.Request) (clientID, secret string, basic bool) {
+	clientID, secret, basic = r.BasicAuth()
+	if !basic {
+		return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"), false
+	}
+	// Credentials in Basic header are form encoded (RFC 6749, section 2.3.1)
+	if id, err := url.QueryUnescape(clientID); err == nil {
+		clientID = id
+	}
+	if s, err := url.QueryUnescape(secret); err == nil {
+		secret = s
+	}
+	return clientID, secret, true
+}
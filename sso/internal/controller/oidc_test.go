@@ -0,0 +1,246 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/testutils"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/config"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURI = "https://app.example.com/callback"
+
+func newOIDCRouter(t *testing.T) (*mocks.OIDCService, *chi.Mux) {
+	svc := mocks.NewOIDCService(t)
+	conf := config.OIDC{Issuer: "https://sso.example.com", LoginURL: "https://example.com/login"}
+	router := chi.NewRouter()
+	controller.NewHTTPController(testutils.NewTestLogger(), config.OAuth{}, nil, svc, conf, []byte("secret")).Init(router)
+	return svc, router
+}
+
+func TestHTTPController_HandleDiscovery(t *testing.T) {
+	_, router := newOIDCRouter(t)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var metadata map[string]any
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&metadata))
+	assert.Equal(t, "https://sso.example.com", metadata["issuer"])
+	assert.Equal(t, "https://sso.example.com/oauth2/token", metadata["token_endpoint"])
+	assert.Equal(t, []any{"S256"}, metadata["code_challenge_methods_supported"])
+}
+
+func TestHTTPController_HandleAuthorize(t *testing.T) {
+	type MockBehavior func(svc *mocks.OIDCService)
+
+	userID := uuid.NewString()
+	accessToken, err := auth.SignJWT(userID, nil, nil, []byte("secret"), time.Minute, "sso")
+	require.NoError(t, err)
+	client := domain.OIDCClient{ID: "client", Name: "Wiki", RedirectURIs: []string{redirectURI}}
+	query := url.Values{
+		"client_id":             {client.ID},
+		"redirect_uri":          {redirectURI},
+		"response_type":         {"code"},
+		"scope":                 {"openid email"},
+		"state":                 {"state"},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+	}
+
+	testCases := []struct {
+		name         string
+		query        func(q url.Values)
+		token        string
+		mockBehavior MockBehavior
+		wantCode     int
+		wantLocation string
+	}{
+		{
+			name:  "consented",
+			token: accessToken,
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Client(mock.Anything, client.ID).Return(client, nil).Once()
+				svc.EXPECT().Authorize(mock.Anything, userID, domain.AuthorizationRequest{
+					ClientID:      client.ID,
+					RedirectURI:   redirectURI,
+					Scopes:        []string{domain.ScopeOpenID, domain.ScopeEmail},
+					State:         "state",
+					CodeChallenge: "challenge",
+				}).Return("code", nil).Once()
+			},
+			wantCode:     http.StatusFound,
+			wantLocation: redirectURI + "?code=code&state=state",
+		},
+		{
+			name:  "consent page",
+			token: accessToken,
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Client(mock.Anything, client.ID).Return(client, nil).Once()
+				svc.EXPECT().Authorize(mock.Anything, userID, mock.Anything).Return("", domain.ErrConsentRequired).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "signed out",
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Client(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			wantCode:     http.StatusFound,
+			wantLocation: "https://example.com/login?return_to=",
+		},
+		{
+			name:  "signed out without prompt",
+			query: func(q url.Values) { q.Set("prompt", "none") },
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Client(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			wantCode:     http.StatusFound,
+			wantLocation: redirectURI + "?error=login_required&state=state",
+		},
+		{
+			name:  "without pkce",
+			token: accessToken,
+			query: func(q url.Values) { q.Del("code_challenge_method") },
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Client(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			wantCode:     http.StatusFound,
+			wantLocation: redirectURI + "?error=invalid_request&state=state",
+		},
+		{
+			name:  "unregistered redirect uri",
+			token: accessToken,
+			query: func(q url.Values) { q.Set("redirect_uri", "https://evil.example.com") },
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Client(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:  "unknown client",
+			token: accessToken,
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Client(mock.Anything, client.ID).Return(domain.OIDCClient{}, domain.ErrClientNotFound).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, router := newOIDCRouter(t)
+			tc.mockBehavior(svc)
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = v
+			}
+			if tc.query != nil {
+				tc.query(q)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/oauth2/authorize?"+q.Encode(), nil)
+			if tc.token != "" {
+				req.AddCookie(&http.Cookie{Name: "access_token", Value: tc.token})
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantLocation != "" {
+				assert.True(t, strings.HasPrefix(rec.Header().Get("Location"), tc.wantLocation), rec.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestHTTPController_HandleToken(t *testing.T) {
+	type MockBehavior func(svc *mocks.OIDCService)
+
+	testCases := []struct {
+		name         string
+		form         url.Values
+		basic        bool
+		mockBehavior MockBehavior
+		wantCode     int
+		wantError    string
+	}{
+		{
+			name:  "authorization code",
+			form:  url.Values{"grant_type": {"authorization_code"}, "code": {"code"}, "redirect_uri": {redirectURI}, "code_verifier": {"verifier"}},
+			basic: true,
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Exchange(mock.Anything, "client", "secret", "code", redirectURI, "verifier").Return(domain.OIDCTokens{
+					AccessToken:  "access",
+					IDToken:      "id",
+					RefreshToken: "refresh",
+					ExpiresIn:    time.Hour,
+					Scopes:       []string{domain.ScopeOpenID},
+				}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "refresh token of public client",
+			form: url.Values{"grant_type": {"refresh_token"}, "refresh_token": {"refresh"}, "client_id": {"client"}},
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Refresh(mock.Anything, "client", "", "refresh").Return(domain.OIDCTokens{}, domain.ErrInvalidGrant).Once()
+			},
+			wantCode:  http.StatusBadRequest,
+			wantError: "invalid_grant",
+		},
+		{
+			name:  "invalid client",
+			form:  url.Values{"grant_type": {"authorization_code"}, "code": {"code"}},
+			basic: true,
+			mockBehavior: func(svc *mocks.OIDCService) {
+				svc.EXPECT().Exchange(mock.Anything, "client", "secret", "code", "", "").Return(domain.OIDCTokens{}, domain.ErrInvalidClient).Once()
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantError: "invalid_client",
+		},
+		{
+			name:         "unsupported grant",
+			form:         url.Values{"grant_type": {"password"}},
+			mockBehavior: func(svc *mocks.OIDCService) {},
+			wantCode:     http.StatusBadRequest,
+			wantError:    "unsupported_grant_type",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, router := newOIDCRouter(t)
+			tc.mockBehavior(svc)
+			req := httptest.NewRequest(http.MethodPost, "/oauth2/token", strings.NewReader(tc.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tc.basic {
+				req.SetBasicAuth("client", "secret")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+			if tc.wantError != "" {
+				assert.Equal(t, tc.wantError, body["error"])
+			} else {
+				assert.Equal(t, "Bearer", body["token_type"])
+				assert.Equal(t, float64(3600), body["expires_in"])
+			}
+		})
+	}
+}
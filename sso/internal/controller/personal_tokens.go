@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+type PersonalTokenService interface {
+	CreatePersonalToken(ctx context.Context, userID, name string, scopes []string, ttl time.Duration) (domain.PersonalToken, string, error)
+	PersonalTokens(ctx context.Context, userID string) ([]domain.PersonalToken, error)
+	RevokePersonalToken(ctx context.Context, userID, tokenID string) error
+	VerifyPersonalToken(ctx context.Context, token string) (*auth.TokenClaims, error)
+}
+
+type personalTokensController struct {
+	pb.UnimplementedPersonalTokensServer
+	svc      PersonalTokenService
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+func NewPersonalTokensController(logger *slog.Logger, svc PersonalTokenService) *personalTokensController {
+	validate := validator.New()
+	return &personalTokensController{svc: svc, logger: logger, validate: validate}
+}
+
+func (c *personalTokensController) Init(srv *grpc.Server) {
+	pb.RegisterPersonalTokensServer(srv, c)
+}
+
+func (c *personalTokensController) CreatePersonalToken(ctx context.Context, req *pb.CreatePersonalTokenRequest) (*pb.CreatePersonalTokenResponse, error) {
+	const op = "grpc.CreatePersonalToken"
+	logger := c.logger.With(slog.String("op", op))
+
+	userID, err := tokenOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validate.Var(req.Name, "required,max=64"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid name")
+	}
+	if err := c.validate.Var(req.Scopes, "required,min=1"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "scopes are required")
+	}
+	if err := c.validate.Var(req.ExpiresInDays, "min=1,max=365"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid expiration")
+	}
+	ttl := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	token, secret, err := c.svc.CreatePersonalToken(ctx, userID, req.Name, req.Scopes, ttl)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidScope) {
+			return nil, status.Error(codes.InvalidArgument, "invalid scope")
+		}
+		if errors.Is(err, domain.ErrTooManyPersonalTokens) {
+			return nil, status.Errorf(codes.FailedPrecondition, "at most %d tokens are allowed", domain.MaxPersonalTokens)
+		}
+		logger.Error("failed to create personal token", "error", err)
+		return nil, status.Error(codes.Internal, "failed to create personal token")
+	}
+	return &pb.CreatePersonalTokenResponse{Token: personalTokenToGRPC(token), Secret: secret}, nil
+}
+
+func (c *personalTokensController) ListPersonalTokens(ctx context.Context, req *pb.ListPersonalTokensRequest) (*pb.ListPersonalTokensResponse, error) {
+	const op = "grpc.ListPersonalTokens"
+	logger := c.logger.With(slog.String("op", op))
+
+	userID, err := tokenOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := c.svc.PersonalTokens(ctx, userID)
+	if err != nil {
+		logger.Error("failed to list personal tokens", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list personal tokens")
+	}
+	resp := &pb.ListPersonalTokensResponse{Tokens: make([]*pb.PersonalToken, len(tokens))}
+	for i, token := range tokens {
+		resp.Tokens[i] = personalTokenToGRPC(token)
+	}
+	return resp, nil
+}
+
+func (c *personalTokensController) RevokePersonalToken(ctx context.Context, req *pb.RevokePersonalTokenRequest) (*pb.RevokePersonalTokenResponse, error) {
+	const op = "grpc.RevokePersonalToken"
+	logger := c.logger.With(slog.String("op", op), slog.String("token_id", req.TokenId))
+
+	userID, err := tokenOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validate.Var(req.TokenId, "required,uuid"); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid token id")
+	}
+	if err := c.svc.RevokePersonalToken(ctx, userID, req.TokenId); err != nil {
+		if errors.Is(err, domain.ErrPersonalTokenNotFound) {
+			return nil, status.Error(codes.NotFound, "token not found")
+		}
+		logger.Error("failed to revoke personal token", "error", err)
+		return nil, status.Error(codes.Internal, "failed to revoke personal token")
+	}
+	return &pb.RevokePersonalTokenResponse{}, nil
+}
+
+func (c *personalTokensController) IntrospectToken(ctx context.Context, req *pb.IntrospectTokenRequest) (*pb.IntrospectTokenResponse, error) {
+	const op = "grpc.IntrospectToken"
+	logger := c.logger.With(slog.String("op", op))
+
+	claims, err := c.svc.VerifyPersonalToken(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		logger.Error("failed to verify personal token", "error", err)
+		return nil, status.Error(codes.Internal, "failed to verify token")
+	}
+	return &pb.IntrospectTokenResponse{
+		UserId:      claims.UserID,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		Scopes:      claims.Scopes,
+	}, nil
+}
+
+// tokenOwner returns user of access token, personal access tokens can't manage tokens,
+// so leaked token can't be used to issue new ones
+func tokenOwner(ctx context.Context) (string, error) {
+	claims := auth.ExtractClaims(ctx)
+	if claims == nil {
+		return "", status.Error(codes.Unauthenticated, "authorization token is missing")
+	}
+	if claims.IsPersonal() {
+		return "", status.Error(codes.PermissionDenied, "personal access tokens can't manage tokens")
+	}
+	return claims.UserID, nil
+}
+
+func personalTokenToGRPC(token domain.PersonalToken) *pb.PersonalToken {
+	resp := &pb.PersonalToken{
+		Id:         token.ID.String(),
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     token.Scopes,
+		CreatedAt:  token.CreatedAt.Unix(),
+		ExpiresAt:  token.ExpiresAt.Unix(),
+		LastUsedIp: token.LastUsedIP,
+	}
+	if !token.LastUsedAt.IsZero() {
+		resp.LastUsedAt = token.LastUsedAt.Unix()
+	}
+	return resp
+}
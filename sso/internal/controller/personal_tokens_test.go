@@ -0,0 +1,162 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/sso"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/testutils"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/controller/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// withToken authenticates call like the server does
+func withToken(svc controller.PersonalTokenService, token string, call func(ctx context.Context) error) error {
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+	interceptor := auth.OptionalJwtInterceptor([]byte("secret"), auth.WithPersonalTokens(svc))
+	info := &grpc.UnaryServerInfo{FullMethod: pb.PersonalTokens_CreatePersonalToken_FullMethodName}
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, call(ctx)
+	})
+	return err
+}
+
+func TestPersonalTokensController_CreatePersonalToken(t *testing.T) {
+	type MockBehavior func(svc *mocks.PersonalTokenService, userID string, req *pb.CreatePersonalTokenRequest)
+
+	userID := uuid.NewString()
+	accessToken, err := auth.SignJWT(userID, nil, nil, []byte("secret"), time.Minute, "sso")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		token        string
+		req          *pb.CreatePersonalTokenRequest
+		mockBehavior MockBehavior
+		wantCode     codes.Code
+	}{
+		{
+			name:  "success",
+			token: accessToken,
+			req:   &pb.CreatePersonalTokenRequest{Name: "CI", Scopes: []string{auth.ScopeProfile}, ExpiresInDays: 30},
+			mockBehavior: func(svc *mocks.PersonalTokenService, userID string, req *pb.CreatePersonalTokenRequest) {
+				svc.EXPECT().CreatePersonalToken(mock.Anything, userID, req.Name, req.Scopes, 30*24*time.Hour).
+					Return(domain.PersonalToken{ID: uuid.New(), Name: req.Name}, "pat_secret", nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:  "invalid scope",
+			token: accessToken,
+			req:   &pb.CreatePersonalTokenRequest{Name: "CI", Scopes: []string{"admin"}, ExpiresInDays: 30},
+			mockBehavior: func(svc *mocks.PersonalTokenService, userID string, req *pb.CreatePersonalTokenRequest) {
+				svc.EXPECT().CreatePersonalToken(mock.Anything, userID, req.Name, req.Scopes, mock.Anything).
+					Return(domain.PersonalToken{}, "", domain.ErrInvalidScope).Once()
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:  "too many tokens",
+			token: accessToken,
+			req:   &pb.CreatePersonalTokenRequest{Name: "CI", Scopes: []string{auth.ScopeProfile}, ExpiresInDays: 30},
+			mockBehavior: func(svc *mocks.PersonalTokenService, userID string, req *pb.CreatePersonalTokenRequest) {
+				svc.EXPECT().CreatePersonalToken(mock.Anything, userID, req.Name, req.Scopes, mock.Anything).
+					Return(domain.PersonalToken{}, "", domain.ErrTooManyPersonalTokens).Once()
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "invalid expiration",
+			token:        accessToken,
+			req:          &pb.CreatePersonalTokenRequest{Name: "CI", Scopes: []string{auth.ScopeProfile}, ExpiresInDays: 400},
+			mockBehavior: func(svc *mocks.PersonalTokenService, userID string, req *pb.CreatePersonalTokenRequest) {},
+			wantCode:     codes.InvalidArgument,
+		},
+		{
+			name:         "without token",
+			req:          &pb.CreatePersonalTokenRequest{Name: "CI", Scopes: []string{auth.ScopeProfile}, ExpiresInDays: 30},
+			mockBehavior: func(svc *mocks.PersonalTokenService, userID string, req *pb.CreatePersonalTokenRequest) {},
+			wantCode:     codes.Unauthenticated,
+		},
+		{
+			name:  "personal token",
+			token: "pat_secret",
+			req:   &pb.CreatePersonalTokenRequest{Name: "CI", Scopes: []string{auth.ScopeProfile}, ExpiresInDays: 30},
+			mockBehavior: func(svc *mocks.PersonalTokenService, userID string, req *pb.CreatePersonalTokenRequest) {
+				svc.EXPECT().VerifyPersonalToken(mock.Anything, "pat_secret").
+					Return(&auth.TokenClaims{UserID: userID, Scopes: []string{auth.ScopeSSO}}, nil).Once()
+			},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := mocks.NewPersonalTokenService(t)
+			c := controller.NewPersonalTokensController(testutils.NewTestLogger(), svc)
+			tc.mockBehavior(svc, userID, tc.req)
+			err := withToken(svc, tc.token, func(ctx context.Context) error {
+				resp, err := c.CreatePersonalToken(ctx, tc.req)
+				if err == nil {
+					assert.Equal(t, "pat_secret", resp.Secret)
+				}
+				return err
+			})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestPersonalTokensController_RevokePersonalToken(t *testing.T) {
+	userID := uuid.NewString()
+	accessToken, err := auth.SignJWT(userID, nil, nil, []byte("secret"), time.Minute, "sso")
+	require.NoError(t, err)
+	tokenID := uuid.NewString()
+
+	svc := mocks.NewPersonalTokenService(t)
+	svc.EXPECT().RevokePersonalToken(mock.Anything, userID, tokenID).Return(domain.ErrPersonalTokenNotFound).Once()
+	c := controller.NewPersonalTokensController(testutils.NewTestLogger(), svc)
+
+	err = withToken(svc, accessToken, func(ctx context.Context) error {
+		_, err := c.RevokePersonalToken(ctx, &pb.RevokePersonalTokenRequest{TokenId: tokenID})
+		return err
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = withToken(svc, accessToken, func(ctx context.Context) error {
+		_, err := c.RevokePersonalToken(ctx, &pb.RevokePersonalTokenRequest{TokenId: "invalid"})
+		return err
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPersonalTokensController_IntrospectToken(t *testing.T) {
+	userID := uuid.NewString()
+	svc := mocks.NewPersonalTokenService(t)
+	svc.EXPECT().VerifyPersonalToken(mock.Anything, "pat_valid").
+		Return(&auth.TokenClaims{UserID: userID, Roles: []string{auth.RoleAdmin}, Scopes: []string{auth.ScopeProfile}}, nil).Once()
+	svc.EXPECT().VerifyPersonalToken(mock.Anything, "pat_revoked").Return(nil, auth.ErrInvalidToken).Once()
+	c := controller.NewPersonalTokensController(testutils.NewTestLogger(), svc)
+
+	got, err := c.IntrospectToken(context.Background(), &pb.IntrospectTokenRequest{Token: "pat_valid"})
+	require.NoError(t, err)
+	assert.Equal(t, userID, got.UserId)
+	assert.Equal(t, []string{auth.RoleAdmin}, got.Roles)
+	assert.Equal(t, []string{auth.ScopeProfile}, got.Scopes)
+
+	_, err = c.IntrospectToken(context.Background(), &pb.IntrospectTokenRequest{Token: "pat_revoked"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
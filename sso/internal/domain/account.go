@@ -11,6 +11,7 @@ type AccountType string
 const (
 	AccountTypeGoogle      AccountType = "google"
 	AccountTypeCredentials AccountType = "credentials"
+	AccountTypeMagicLink   AccountType = "magic_link"
 )
 
 type Account struct {
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionRegister  = "auth.register"
+	AuditActionLogin     = "auth.login"
+	AuditActionRefresh   = "auth.refresh"
+	AuditActionLogout    = "auth.logout"
+	AuditActionCreatePAT = "auth.pat_create"
+	AuditActionRevokePAT = "auth.pat_revoke"
+	AuditActionMagicLink = "auth.magic_link"
+	// Client is target of OpenID Connect actions, it is written to details since it is not a user
+	AuditActionRegisterClient = "oidc.register_client"
+	AuditActionConsent        = "oidc.consent"
+	AuditActionAssignRole     = "admin.assign_role"
+	AuditActionViewUser       = "admin.view_user"
+	AuditActionBlockUser      = "admin.block_user"
+	AuditActionUnblockUser    = "admin.unblock_user"
+	AuditActionForceLogout    = "admin.force_logout"
+)
+
+type AuditResult string
+
+const (
+	AuditResultSuccess AuditResult = "success"
+	AuditResultFailure AuditResult = "failure"
+)
+
+// AuditEntry records action of actor on target user, actor is nil for actions of the system
+// and of anonymous clients, target is nil if it is unknown, e.g. login with unknown email
+type AuditEntry struct {
+	ID        int64
+	ActorID   uuid.UUID
+	Action    string
+	TargetID  uuid.UUID
+	Result    AuditResult
+	IP        string
+	UserAgent string
+	RequestID string
+	Details   map[string]string
+	CreatedAt time.Time
+	// Set only when hash chaining is enabled
+	PrevHash string
+	Hash     string
+}
+
+// ComputeHash hashes entry together with hash of previous entry, so changed or removed entry
+// breaks the chain. ID is assigned by database and is not hashed
+func (e AuditEntry) ComputeHash() string {
+	details := e.Details
+	if len(details) == 0 {
+		details = nil
+	}
+	// Map keys are sorted by encoding/json, so encoding is stable
+	data, _ := json.Marshal(struct {
+		ActorID   uuid.UUID         `json:"actor_id"`
+		Action    string            `json:"action"`
+		TargetID  uuid.UUID         `json:"target_id"`
+		Result    AuditResult       `json:"result"`
+		IP        string            `json:"ip"`
+		UserAgent string            `json:"user_agent"`
+		RequestID string            `json:"request_id"`
+		Details   map[string]string `json:"details"`
+		CreatedAt int64             `json:"created_at"`
+	}{e.ActorID, e.Action, e.TargetID, e.Result, e.IP, e.UserAgent, e.RequestID, details, e.CreatedAt.UnixMicro()})
+	sum := sha256.Sum256(append([]byte(e.PrevHash), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditFilter selects entries newest first, empty fields match every entry
+type AuditFilter struct {
+	// Matches entries where user is actor or target
+	UserID uuid.UUID
+	Action string
+	// Entries with smaller ID are returned, used for pagination
+	BeforeID int64
+	Limit    int
+}
+
+// AuditVerification is result of hash chain check, BrokenID is first entry which doesn't match the chain
+type AuditVerification struct {
+	Checked  int
+	Valid    bool
+	BrokenID int64
+}
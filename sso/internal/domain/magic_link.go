@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	MagicLinkTTL = time.Minute * 15
+	// Links which can be requested for one email within MagicLinkWindow
+	MagicLinkLimit  = 5
+	MagicLinkWindow = time.Hour
+)
+
+// MagicLink is request of sign in link, user with the email is registered when link is used
+type MagicLink struct {
+	Email string `json:"email"`
+	// Preferred languages in Accept-Language format, they are used if user is registered
+	Locale string `json:"locale,omitempty"`
+}
+
+var ErrTooManyMagicLinks = errors.New("too many magic links")
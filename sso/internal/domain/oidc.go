@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scopes which OpenID Connect clients can request, openid is required
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+var OIDCScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
+
+const (
+	AuthorizationCodeTTL = time.Minute
+	OIDCRefreshTokenTTL  = time.Hour * 24 * 30
+)
+
+// OIDCClient is application which signs users in with sso
+type OIDCClient struct {
+	ID           string
+	Name         string
+	RedirectURIs []string
+	// Empty for public clients, they are authenticated by PKCE only
+	SecretHash string
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+}
+
+func (c OIDCClient) IsPublic() bool {
+	return c.SecretHash == ""
+}
+
+// HasRedirectURI checks that URI is registered, URIs are compared as is
+func (c OIDCClient) HasRedirectURI(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// AuthorizationRequest is request of client to /authorize, PKCE is required with S256 method
+type AuthorizationRequest struct {
+	ClientID      string   `json:"client_id"`
+	RedirectURI   string   `json:"redirect_uri"`
+	Scopes        []string `json:"scopes"`
+	State         string   `json:"state,omitempty"`
+	Nonce         string   `json:"nonce,omitempty"`
+	CodeChallenge string   `json:"code_challenge"`
+}
+
+// AuthorizationCode is issued when user consents, it is exchanged for tokens once
+type AuthorizationCode struct {
+	ClientID      string    `json:"client_id"`
+	RedirectURI   string    `json:"redirect_uri"`
+	UserID        uuid.UUID `json:"user_id"`
+	Scopes        []string  `json:"scopes"`
+	Nonce         string    `json:"nonce,omitempty"`
+	CodeChallenge string    `json:"code_challenge"`
+}
+
+// OIDCGrant is access of client to user, refresh token of client holds it
+type OIDCGrant struct {
+	ClientID string    `json:"client_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Scopes   []string  `json:"scopes"`
+}
+
+type OIDCTokens struct {
+	AccessToken  string
+	IDToken      string
+	RefreshToken string
+	ExpiresIn    time.Duration
+	Scopes       []string
+}
+
+// UserInfo holds claims about user which client is allowed to get by scopes
+type UserInfo struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email,omitempty"`
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	Birthdate         string `json:"birthdate,omitempty"`
+	Gender            string `json:"gender,omitempty"`
+	Locale            string `json:"locale,omitempty"`
+}
+
+// JWK is public key which verifies tokens issued by sso
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+var (
+	ErrClientNotFound        = errors.New("client not found")
+	ErrInvalidClient         = errors.New("invalid client")
+	ErrInvalidRedirectURI    = errors.New("invalid redirect uri")
+	ErrInvalidGrant          = errors.New("invalid grant")
+	ErrConsentRequired       = errors.New("consent required")
+	ErrCodeChallengeRequired = errors.New("code challenge is required")
+)
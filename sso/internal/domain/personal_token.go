@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxPersonalTokens       = 20
+	MaxPersonalTokenTTLDays = 365
+)
+
+// PersonalToken is issued by user for programmatic access, only hash of the token is stored
+type PersonalToken struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Name   string
+	// First characters of token, shown to tell tokens apart
+	Prefix    string
+	Scopes    []string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	// Zero if token was never used
+	LastUsedAt time.Time
+	LastUsedIP string
+}
+
+func (t PersonalToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+var (
+	ErrPersonalTokenNotFound = errors.New("personal token not found")
+	ErrTooManyPersonalTokens = errors.New("too many personal tokens")
+	ErrInvalidScope          = errors.New("invalid scope")
+)
@@ -0,0 +1,13 @@
+package domain
+
+import "errors"
+
+// Access is granted to user by roles, it is embedded in access token
+type Access struct {
+	Roles       []string
+	Permissions []string
+}
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+)
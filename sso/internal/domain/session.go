@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a login of user, it lives as long as its refresh token
+type Session struct {
+	ID     string
+	UserID uuid.UUID
+	IP     string
+	// How user logged in, credentials or OAuth provider
+	Type      string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+var ErrSessionNotFound = errors.New("session not found")
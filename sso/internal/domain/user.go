@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,15 +11,60 @@ type OAuthUserInfo struct {
 	Name    string `json:"name"`
 	Email   string `json:"email"`
 	Picture string `json:"picture"`
+	Locale  string `json:"locale"`
 }
 
 type User struct {
-	ID    uuid.UUID `json:"id"`
-	Email string    `json:"email"`
+	ID           uuid.UUID `json:"id"`
+	Email        string    `json:"email"`
+	RegisteredAt time.Time `json:"registered_at"`
+	// Blocked user can't log in or refresh tokens, zero if user is not blocked
+	BlockedAt   time.Time `json:"blocked_at,omitzero"`
+	BlockReason string    `json:"block_reason,omitempty"`
+}
+
+func (u User) IsBlocked() bool {
+	return !u.BlockedAt.IsZero()
+}
+
+// UserFilter selects users in admin API, empty fields match every user
+type UserFilter struct {
+	// Part of email, case insensitive
+	Email   string
+	Role    string
+	Blocked *bool
+	Limit   int
+	Offset  int
+}
+
+// UserDetails is shown to admin, profile is nil if user has no profile
+type UserDetails struct {
+	User     User
+	Accounts []AccountType
+	Roles    []string
+	Sessions []Session
+	Profile  *ProfileSummary
+}
+
+type ProfileSummary struct {
+	Username  string
+	FirstName string
+	LastName  string
+	Avatar    string
+}
+
+// Profile is seen by its owner, it is shared with OpenID Connect clients which user consented to
+type Profile struct {
+	ProfileSummary
+	BirthDate string
+	Gender    string
+	Locale    string
 }
 
 var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrUserAlreadyExists  = errors.New("user already exists")
+	ErrUserBlocked        = errors.New("user is blocked")
+	ErrProfileNotFound    = errors.New("profile not found")
 )
@@ -0,0 +1,78 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/SergeyBogomolovv/profile-manager/common/api/profile"
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type client struct {
+	api       pb.ProfileClient
+	jwtSecret []byte
+}
+
+// NewClient reads profiles of users from profile service, jwtSecret signs requests made on behalf of profile owner
+func NewClient(api pb.ProfileClient, jwtSecret []byte) *client {
+	return &client{api: api, jwtSecret: jwtSecret}
+}
+
+// ProfileSummary requests profile on behalf of caller, so profile service applies privacy settings for caller
+func (c *client) ProfileSummary(ctx context.Context, userID string) (domain.ProfileSummary, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", firstOf(md.Get("authorization")))
+	}
+	resp, err := c.api.GetUserProfile(ctx, &pb.GetUserProfileRequest{UserId: userID})
+	if status.Code(err) == codes.NotFound {
+		return domain.ProfileSummary{}, domain.ErrProfileNotFound
+	}
+	if err != nil {
+		return domain.ProfileSummary{}, fmt.Errorf("failed to get profile: %w", err)
+	}
+	return domain.ProfileSummary{
+		Username:  resp.Username,
+		FirstName: resp.FirstName,
+		LastName:  resp.LastName,
+		Avatar:    resp.Avatar,
+	}, nil
+}
+
+// Profile requests profile as its owner, so every field is returned regardless of privacy settings
+func (c *client) Profile(ctx context.Context, userID string) (domain.Profile, error) {
+	token, err := auth.SignJWT(userID, nil, nil, c.jwtSecret, time.Minute, "sso")
+	if err != nil {
+		return domain.Profile{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	resp, err := c.api.GetProfile(ctx, &pb.GetProfileRequest{})
+	if status.Code(err) == codes.NotFound {
+		return domain.Profile{}, domain.ErrProfileNotFound
+	}
+	if err != nil {
+		return domain.Profile{}, fmt.Errorf("failed to get profile: %w", err)
+	}
+	return domain.Profile{
+		ProfileSummary: domain.ProfileSummary{
+			Username:  resp.Username,
+			FirstName: resp.FirstName,
+			LastName:  resp.LastName,
+			Avatar:    resp.Avatar,
+		},
+		BirthDate: resp.BirthDate,
+		Gender:    resp.Gender,
+		Locale:    resp.Locale,
+	}, nil
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Key of advisory lock which serializes appends to hash chain
+const auditChainLock = 7216001
+
+type auditRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+	// Entries are hash chained if set
+	chain bool
+}
+
+func NewAuditRepo(db *sqlx.DB, chain bool) *auditRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &auditRepo{
+		db:    db,
+		qb:    qb,
+		chain: chain,
+	}
+}
+
+// Add appends entry to audit log, entries can't be updated or deleted.
+// With hash chaining appends are serialized until the end of transaction of ctx
+func (r *auditRepo) Add(ctx context.Context, entry domain.AuditEntry) error {
+	if !r.chain {
+		return r.insert(ctx, entry)
+	}
+	if transaction.ExtractTx(ctx) != nil {
+		return r.addChained(ctx, entry)
+	}
+	return transaction.NewTxManager(r.db).Run(ctx, func(ctx context.Context) error {
+		return r.addChained(ctx, entry)
+	})
+}
+
+func (r *auditRepo) addChained(ctx context.Context, entry domain.AuditEntry) error {
+	if _, err := r.execContext(ctx, "SELECT pg_advisory_xact_lock($1)", auditChainLock); err != nil {
+		return fmt.Errorf("failed to lock audit log: %w", err)
+	}
+	// Entries added while chaining was disabled are not part of the chain
+	query, args := r.qb.Select("hash").From("audit_log").Where(sq.NotEq{"hash": ""}).OrderBy("id DESC").Limit(1).MustSql()
+	err := r.getContext(ctx, &entry.PrevHash, query, args...)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get last audit hash: %w", err)
+	}
+	// Stored precision, so hash can be verified after reading entry back
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	entry.Hash = entry.ComputeHash()
+	return r.insert(ctx, entry)
+}
+
+func (r *auditRepo) insert(ctx context.Context, entry domain.AuditEntry) error {
+	details := []byte("{}")
+	if len(entry.Details) > 0 {
+		var err error
+		if details, err = json.Marshal(entry.Details); err != nil {
+			return fmt.Errorf("failed to marshal audit details: %w", err)
+		}
+	}
+	createdAt := any(sq.Expr("NOW()"))
+	if !entry.CreatedAt.IsZero() {
+		createdAt = entry.CreatedAt
+	}
+	query, args := r.qb.
+		Insert("audit_log").
+		Columns("actor_id", "action", "target_id", "result", "ip", "user_agent", "request_id", "details", "created_at", "prev_hash", "hash").
+		Values(nullUUID(entry.ActorID), entry.Action, nullUUID(entry.TargetID), entry.Result, entry.IP, entry.UserAgent,
+			entry.RequestID, details, createdAt, entry.PrevHash, entry.Hash).
+		MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return err
+}
+
+// List returns entries matching filter, newest first
+func (r *auditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	builder := r.qb.Select("*").From("audit_log").OrderBy("id DESC").Limit(uint64(filter.Limit))
+	if filter.UserID != uuid.Nil {
+		builder = builder.Where(sq.Or{sq.Eq{"actor_id": filter.UserID}, sq.Eq{"target_id": filter.UserID}})
+	}
+	if filter.Action != "" {
+		builder = builder.Where(sq.Eq{"action": filter.Action})
+	}
+	if filter.BeforeID > 0 {
+		builder = builder.Where(sq.Lt{"id": filter.BeforeID})
+	}
+	query, args := builder.MustSql()
+	return r.selectEntries(ctx, query, args...)
+}
+
+// Chain returns entries after ID, oldest first, it is used to verify hash chain
+func (r *auditRepo) Chain(ctx context.Context, afterID int64, limit int) ([]domain.AuditEntry, error) {
+	query, args := r.qb.
+		Select("*").
+		From("audit_log").
+		Where(sq.Gt{"id": afterID}).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		MustSql()
+	return r.selectEntries(ctx, query, args...)
+}
+
+func (r *auditRepo) selectEntries(ctx context.Context, query string, args ...any) ([]domain.AuditEntry, error) {
+	var entries []AuditEntry
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, err
+	}
+	res := make([]domain.AuditEntry, len(entries))
+	for i, entry := range entries {
+		var err error
+		if res[i], err = entry.ToDomain(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (r *auditRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *auditRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
+
+func nullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
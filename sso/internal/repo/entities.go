@@ -1,22 +1,31 @@
 package repo
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type User struct {
-	ID           uuid.UUID `db:"user_id"`
-	Email        string    `db:"email"`
-	RegisteredAt time.Time `db:"registered_at"`
+	ID           uuid.UUID      `db:"user_id"`
+	Email        string         `db:"email"`
+	RegisteredAt time.Time      `db:"registered_at"`
+	BlockedAt    sql.NullTime   `db:"blocked_at"`
+	BlockReason  sql.NullString `db:"block_reason"`
 }
 
 func (u User) ToDomain() domain.User {
 	return domain.User{
-		ID:    u.ID,
-		Email: u.Email,
+		ID:           u.ID,
+		Email:        u.Email,
+		RegisteredAt: u.RegisteredAt,
+		BlockedAt:    u.BlockedAt.Time,
+		BlockReason:  u.BlockReason.String,
 	}
 }
 
@@ -34,7 +43,106 @@ func (a Account) ToDomain() domain.Account {
 	}
 }
 
+// Tokens issued before sessions were tracked have empty SessionID
 type RefreshToken struct {
 	UserID    uuid.UUID `json:"user_id"`
+	SessionID string    `json:"session_id,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	Type      string    `json:"type,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
+
+func (t RefreshToken) ToSession() domain.Session {
+	return domain.Session{
+		ID:        t.SessionID,
+		UserID:    t.UserID,
+		IP:        t.IP,
+		Type:      t.Type,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
+}
+
+type AuditEntry struct {
+	ID        int64         `db:"id"`
+	ActorID   uuid.NullUUID `db:"actor_id"`
+	Action    string        `db:"action"`
+	TargetID  uuid.NullUUID `db:"target_id"`
+	Details   []byte        `db:"details"`
+	CreatedAt time.Time     `db:"created_at"`
+	IP        string        `db:"ip"`
+	UserAgent string        `db:"user_agent"`
+	Result    string        `db:"result"`
+	RequestID string        `db:"request_id"`
+	PrevHash  string        `db:"prev_hash"`
+	Hash      string        `db:"hash"`
+}
+
+func (e AuditEntry) ToDomain() (domain.AuditEntry, error) {
+	var details map[string]string
+	if err := json.Unmarshal(e.Details, &details); err != nil {
+		return domain.AuditEntry{}, fmt.Errorf("failed to unmarshal audit details: %w", err)
+	}
+	return domain.AuditEntry{
+		ID:        e.ID,
+		ActorID:   e.ActorID.UUID,
+		Action:    e.Action,
+		TargetID:  e.TargetID.UUID,
+		Result:    domain.AuditResult(e.Result),
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		RequestID: e.RequestID,
+		Details:   details,
+		CreatedAt: e.CreatedAt,
+		PrevHash:  e.PrevHash,
+		Hash:      e.Hash,
+	}, nil
+}
+
+type PersonalToken struct {
+	ID         uuid.UUID      `db:"token_id"`
+	UserID     uuid.UUID      `db:"user_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	Hash       string         `db:"token_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	ExpiresAt  time.Time      `db:"expires_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+	LastUsedAt sql.NullTime   `db:"last_used_at"`
+	LastUsedIP sql.NullString `db:"last_used_ip"`
+}
+
+func (t PersonalToken) ToDomain() domain.PersonalToken {
+	return domain.PersonalToken{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     t.Scopes,
+		ExpiresAt:  t.ExpiresAt,
+		CreatedAt:  t.CreatedAt,
+		LastUsedAt: t.LastUsedAt.Time,
+		LastUsedIP: t.LastUsedIP.String,
+	}
+}
+
+type OIDCClient struct {
+	ID           string         `db:"client_id"`
+	Name         string         `db:"name"`
+	SecretHash   sql.NullString `db:"secret_hash"`
+	RedirectURIs pq.StringArray `db:"redirect_uris"`
+	CreatedBy    uuid.NullUUID  `db:"created_by"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+func (c OIDCClient) ToDomain() domain.OIDCClient {
+	return domain.OIDCClient{
+		ID:           c.ID,
+		Name:         c.Name,
+		RedirectURIs: c.RedirectURIs,
+		SecretHash:   c.SecretHash.String,
+		CreatedBy:    c.CreatedBy.UUID,
+		CreatedAt:    c.CreatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// magicLinkRepo stores tokens of sign in links, token is deleted when link is used
+type magicLinkRepo struct {
+	db *redis.Client
+}
+
+func NewMagicLinkRepo(db *redis.Client) *magicLinkRepo {
+	return &magicLinkRepo{db: db}
+}
+
+// Create stores link for domain.MagicLinkTTL and returns it, returns domain.ErrTooManyMagicLinks
+// if more than domain.MagicLinkLimit links were requested for the email within domain.MagicLinkWindow
+func (r *magicLinkRepo) Create(ctx context.Context, link domain.MagicLink) (string, error) {
+	limitKey := magicLinkLimitKey(link.Email)
+	var count *redis.IntCmd
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, limitKey)
+		pipe.ExpireNX(ctx, limitKey, domain.MagicLinkWindow)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to count magic links: %w", err)
+	}
+	if count.Val() > domain.MagicLinkLimit {
+		return "", domain.ErrTooManyMagicLinks
+	}
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal magic link: %w", err)
+	}
+	token := uuid.NewString()
+	if err := r.db.Set(ctx, magicLinkKey(token), data, domain.MagicLinkTTL).Err(); err != nil {
+		return "", fmt.Errorf("failed to create magic link: %w", err)
+	}
+	return token, nil
+}
+
+// Take returns domain.ErrInvalidToken if link is not exists, expired or already used
+func (r *magicLinkRepo) Take(ctx context.Context, token string) (domain.MagicLink, error) {
+	data, err := r.db.GetDel(ctx, magicLinkKey(token)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.MagicLink{}, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return domain.MagicLink{}, fmt.Errorf("failed to take magic link: %w", err)
+	}
+	var link domain.MagicLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		return domain.MagicLink{}, fmt.Errorf("failed to unmarshal magic link: %w", err)
+	}
+	return link, nil
+}
+
+func magicLinkKey(token string) string {
+	return fmt.Sprintf("magicLink:%s", token)
+}
+
+// Emails which differ only by case share the limit
+func magicLinkLimitKey(email string) string {
+	return fmt.Sprintf("magicLinkLimit:%s", strings.ToLower(email))
+}
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type oidcClientRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewOIDCClientRepo(db *sqlx.DB) *oidcClientRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &oidcClientRepo{
+		db: db,
+		qb: qb,
+	}
+}
+
+func (r *oidcClientRepo) Create(ctx context.Context, client domain.OIDCClient) (domain.OIDCClient, error) {
+	secretHash := sql.NullString{String: client.SecretHash, Valid: client.SecretHash != ""}
+	query, args := r.qb.
+		Insert("oidc_clients").
+		Columns("client_id", "name", "secret_hash", "redirect_uris", "created_by").
+		Values(client.ID, client.Name, secretHash, pq.StringArray(client.RedirectURIs), nullUUID(client.CreatedBy)).
+		Suffix("RETURNING *").
+		MustSql()
+	var res OIDCClient
+	if err := r.getContext(ctx, &res, query, args...); err != nil {
+		return domain.OIDCClient{}, err
+	}
+	return res.ToDomain(), nil
+}
+
+func (r *oidcClientRepo) ByID(ctx context.Context, clientID string) (domain.OIDCClient, error) {
+	query, args := r.qb.Select("*").From("oidc_clients").Where(sq.Eq{"client_id": clientID}).MustSql()
+	var client OIDCClient
+	if err := r.getContext(ctx, &client, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.OIDCClient{}, domain.ErrClientNotFound
+		}
+		return domain.OIDCClient{}, err
+	}
+	return client.ToDomain(), nil
+}
+
+// Consent returns scopes which user granted to client, nil if user never consented
+func (r *oidcClientRepo) Consent(ctx context.Context, userID uuid.UUID, clientID string) ([]string, error) {
+	query, args := r.qb.
+		Select("scopes").
+		From("oidc_consents").
+		Where(sq.Eq{"user_id": userID, "client_id": clientID}).
+		MustSql()
+	var scopes pq.StringArray
+	err := r.getContext(ctx, &scopes, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return scopes, err
+}
+
+// SaveConsent replaces scopes which user granted to client
+func (r *oidcClientRepo) SaveConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) error {
+	query, args := r.qb.
+		Insert("oidc_consents").
+		Columns("user_id", "client_id", "scopes").
+		Values(userID, clientID, pq.StringArray(scopes)).
+		Suffix("ON CONFLICT (user_id, client_id) DO UPDATE SET scopes = EXCLUDED.scopes, granted_at = NOW()").
+		MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return err
+}
+
+func (r *oidcClientRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *oidcClientRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// takeGrant deletes grant only if it was issued to client, so other client can't burn it
+var takeGrant = redis.NewScript(`
+local data = redis.call("GET", KEYS[1])
+if not data or cjson.decode(data).client_id ~= ARGV[1] then
+	return false
+end
+redis.call("DEL", KEYS[1])
+return data
+`)
+
+// oidcGrantRepo stores authorization codes and refresh tokens of OpenID Connect clients,
+// both are single use, so they are deleted when taken
+type oidcGrantRepo struct {
+	db *redis.Client
+}
+
+func NewOIDCGrantRepo(db *redis.Client) *oidcGrantRepo {
+	return &oidcGrantRepo{db: db}
+}
+
+// CreateCode stores code for domain.AuthorizationCodeTTL and returns it
+func (r *oidcGrantRepo) CreateCode(ctx context.Context, code domain.AuthorizationCode) (string, error) {
+	key := uuid.NewString()
+	if err := r.set(ctx, codeKey(key), code, domain.AuthorizationCodeTTL); err != nil {
+		return "", fmt.Errorf("failed to create authorization code: %w", err)
+	}
+	return key, nil
+}
+
+// TakeCode returns domain.ErrInvalidGrant if code is not exists, expired, already taken or issued to other client
+func (r *oidcGrantRepo) TakeCode(ctx context.Context, clientID, key string) (domain.AuthorizationCode, error) {
+	var code domain.AuthorizationCode
+	if err := r.take(ctx, codeKey(key), clientID, &code); err != nil {
+		return domain.AuthorizationCode{}, err
+	}
+	return code, nil
+}
+
+// CreateRefreshToken stores grant for domain.OIDCRefreshTokenTTL and returns its refresh token
+func (r *oidcGrantRepo) CreateRefreshToken(ctx context.Context, grant domain.OIDCGrant) (string, error) {
+	token := uuid.NewString()
+	if err := r.set(ctx, oidcRefreshTokenKey(token), grant, domain.OIDCRefreshTokenTTL); err != nil {
+		return "", fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// TakeRefreshToken returns domain.ErrInvalidGrant if token is not exists, expired, already taken or issued to other client
+func (r *oidcGrantRepo) TakeRefreshToken(ctx context.Context, clientID, token string) (domain.OIDCGrant, error) {
+	var grant domain.OIDCGrant
+	if err := r.take(ctx, oidcRefreshTokenKey(token), clientID, &grant); err != nil {
+		return domain.OIDCGrant{}, err
+	}
+	return grant, nil
+}
+
+func (r *oidcGrantRepo) set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.db.SetEx(ctx, key, data, ttl).Err()
+}
+
+func (r *oidcGrantRepo) take(ctx context.Context, key, clientID string, dest any) error {
+	data, err := takeGrant.Run(ctx, r.db, []string{key}, clientID).Text()
+	if errors.Is(err, redis.Nil) {
+		return domain.ErrInvalidGrant
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get grant: %w", err)
+	}
+	if err := json.Unmarshal([]byte(data), dest); err != nil {
+		return fmt.Errorf("failed to unmarshal grant: %w", err)
+	}
+	return nil
+}
+
+func codeKey(code string) string {
+	return fmt.Sprintf("oidcCode:%s", code)
+}
+
+func oidcRefreshTokenKey(token string) string {
+	return fmt.Sprintf("oidcRefreshToken:%s", token)
+}
@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Last use is written at most once per this interval, so frequent calls don't update row on every request
+const touchInterval = "1 minute"
+
+type personalTokenRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewPersonalTokenRepo(db *sqlx.DB) *personalTokenRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &personalTokenRepo{
+		db: db,
+		qb: qb,
+	}
+}
+
+// Create stores token with hash of its secret, ID and creation time are assigned by database
+func (r *personalTokenRepo) Create(ctx context.Context, token domain.PersonalToken, hash string) (domain.PersonalToken, error) {
+	query, args := r.qb.
+		Insert("personal_tokens").
+		Columns("user_id", "name", "prefix", "token_hash", "scopes", "expires_at").
+		Values(token.UserID, token.Name, token.Prefix, hash, pq.StringArray(token.Scopes), token.ExpiresAt).
+		Suffix("RETURNING *").
+		MustSql()
+	var res PersonalToken
+	if err := r.getContext(ctx, &res, query, args...); err != nil {
+		return domain.PersonalToken{}, err
+	}
+	return res.ToDomain(), nil
+}
+
+// CountForUpdate returns number of tokens of user which are not expired. Row of user is locked until
+// transaction ends, so concurrent transactions count and create tokens of one user in turn.
+// Returns domain.ErrUserNotFound if user doesn't exist
+func (r *personalTokenRepo) CountForUpdate(ctx context.Context, userID uuid.UUID) (int, error) {
+	lock, lockArgs := r.qb.Select("id").From("users").Where(sq.Eq{"id": userID}).Suffix("FOR UPDATE").MustSql()
+	var id uuid.UUID
+	if err := r.getContext(ctx, &id, lock, lockArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+		return 0, err
+	}
+
+	query, args := r.qb.
+		Select("COUNT(*)").
+		From("personal_tokens").
+		Where(sq.Eq{"user_id": userID}).
+		Where("expires_at > NOW()").
+		MustSql()
+	var count int
+	err := r.getContext(ctx, &count, query, args...)
+	return count, err
+}
+
+func (r *personalTokenRepo) ByHash(ctx context.Context, hash string) (domain.PersonalToken, error) {
+	query, args := r.qb.Select("*").From("personal_tokens").Where(sq.Eq{"token_hash": hash}).MustSql()
+	var token PersonalToken
+	if err := r.getContext(ctx, &token, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PersonalToken{}, domain.ErrPersonalTokenNotFound
+		}
+		return domain.PersonalToken{}, err
+	}
+	return token.ToDomain(), nil
+}
+
+// List returns tokens of user, newest first, expired tokens are included until they are revoked
+func (r *personalTokenRepo) List(ctx context.Context, userID uuid.UUID) ([]domain.PersonalToken, error) {
+	query, args := r.qb.
+		Select("*").
+		From("personal_tokens").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("created_at DESC").
+		MustSql()
+	var tokens []PersonalToken
+	if err := r.selectContext(ctx, &tokens, query, args...); err != nil {
+		return nil, err
+	}
+	res := make([]domain.PersonalToken, len(tokens))
+	for i, token := range tokens {
+		res[i] = token.ToDomain()
+	}
+	return res, nil
+}
+
+// Delete removes token of user, tokens of other users are not found
+func (r *personalTokenRepo) Delete(ctx context.Context, userID, tokenID uuid.UUID) error {
+	query, args := r.qb.Delete("personal_tokens").Where(sq.Eq{"token_id": tokenID, "user_id": userID}).MustSql()
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrPersonalTokenNotFound
+	}
+	return nil
+}
+
+// Touch records last use of token
+func (r *personalTokenRepo) Touch(ctx context.Context, tokenID uuid.UUID, ip string) error {
+	query, args := r.qb.
+		Update("personal_tokens").
+		Set("last_used_at", sq.Expr("NOW()")).
+		Set("last_used_ip", ip).
+		Where(sq.Eq{"token_id": tokenID}).
+		Where(sq.Or{
+			sq.Eq{"last_used_at": nil},
+			sq.Expr("last_used_at < NOW() - INTERVAL '" + touchInterval + "'"),
+		}).
+		MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return err
+}
+
+func (r *personalTokenRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *personalTokenRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
+
+func (r *personalTokenRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
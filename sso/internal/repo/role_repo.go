@@ -0,0 +1,98 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type roleRepo struct {
+	db *sqlx.DB
+	qb sq.StatementBuilderType
+}
+
+func NewRoleRepo(db *sqlx.DB) *roleRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &roleRepo{
+		db: db,
+		qb: qb,
+	}
+}
+
+// Access returns roles of user and permissions granted by them, sorted by name
+func (r *roleRepo) Access(ctx context.Context, userID uuid.UUID) (domain.Access, error) {
+	var access domain.Access
+	query, args := r.qb.
+		Select("role").
+		From("user_roles").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("role").
+		MustSql()
+	if err := r.selectContext(ctx, &access.Roles, query, args...); err != nil {
+		return domain.Access{}, err
+	}
+
+	query, args = r.qb.
+		Select("DISTINCT p.permission").
+		From("user_roles u").
+		Join("role_permissions p ON p.role = u.role").
+		Where(sq.Eq{"u.user_id": userID}).
+		OrderBy("p.permission").
+		MustSql()
+	if err := r.selectContext(ctx, &access.Permissions, query, args...); err != nil {
+		return domain.Access{}, err
+	}
+	return access, nil
+}
+
+func (r *roleRepo) IsRoleExists(ctx context.Context, role string) (bool, error) {
+	query, args := r.qb.Select("TRUE").From("roles").Where(sq.Eq{"name": role}).MustSql()
+	var exists bool
+	err := r.getContext(ctx, &exists, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return exists, err
+}
+
+// AssignRole does nothing if user already has the role
+func (r *roleRepo) AssignRole(ctx context.Context, userID uuid.UUID, role string) error {
+	query, args := r.qb.
+		Insert("user_roles").
+		Columns("user_id", "role").
+		Values(userID, role).
+		Suffix("ON CONFLICT DO NOTHING").
+		MustSql()
+	_, err := r.execContext(ctx, query, args...)
+	return err
+}
+
+func (r *roleRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *roleRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.db.GetContext(ctx, dest, query, args...)
+}
+
+func (r *roleRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
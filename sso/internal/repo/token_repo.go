@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
@@ -20,10 +21,16 @@ func NewTokensRepo(db *redis.Client) *tokensRepo {
 	return &tokensRepo{db: db}
 }
 
-func (r *tokensRepo) Create(ctx context.Context, userID uuid.UUID) (string, error) {
+// Create starts session of user and returns its refresh token
+func (r *tokensRepo) Create(ctx context.Context, session domain.Session) (string, error) {
+	now := time.Now()
 	payload := RefreshToken{
-		UserID:    userID,
-		ExpiresAt: time.Now().Add(domain.RefreshTokenTTL),
+		UserID:    session.UserID,
+		SessionID: uuid.NewString(),
+		IP:        session.IP,
+		Type:      session.Type,
+		CreatedAt: now,
+		ExpiresAt: now.Add(domain.RefreshTokenTTL),
 	}
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -31,7 +38,13 @@ func (r *tokensRepo) Create(ctx context.Context, userID uuid.UUID) (string, erro
 	}
 
 	token := uuid.New().String()
-	if err := r.db.Set(ctx, tokenKey(token), data, domain.RefreshTokenTTL).Err(); err != nil {
+	_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, tokenKey(token), data, domain.RefreshTokenTTL)
+		pipe.HSet(ctx, sessionsKey(session.UserID), payload.SessionID, token)
+		pipe.Expire(ctx, sessionsKey(session.UserID), domain.RefreshTokenTTL)
+		return nil
+	})
+	if err != nil {
 		return "", fmt.Errorf("failed to create refresh token: %w", err)
 	}
 	return token, nil
@@ -39,16 +52,9 @@ func (r *tokensRepo) Create(ctx context.Context, userID uuid.UUID) (string, erro
 
 // Gets user id from refresh token, if token is not exists, returns domain.ErrInvalidToken
 func (r *tokensRepo) UserID(ctx context.Context, token string) (uuid.UUID, error) {
-	data, err := r.db.Get(ctx, tokenKey(token)).Bytes()
+	payload, err := r.payload(ctx, token)
 	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return uuid.Nil, domain.ErrInvalidToken
-		}
-		return uuid.Nil, fmt.Errorf("failed to get refresh token: %w", err)
-	}
-	var payload RefreshToken
-	if err := json.Unmarshal(data, &payload); err != nil {
-		return uuid.Nil, fmt.Errorf("failed to unmarshal refresh token: %w", err)
+		return uuid.Nil, err
 	}
 	if payload.ExpiresAt.Before(time.Now()) {
 		return uuid.Nil, domain.ErrInvalidToken
@@ -56,15 +62,118 @@ func (r *tokensRepo) UserID(ctx context.Context, token string) (uuid.UUID, error
 	return payload.UserID, nil
 }
 
-// If token is not exists, returns nil
-func (r *tokensRepo) Revoke(ctx context.Context, token string) error {
-	err := r.db.Del(ctx, tokenKey(token)).Err()
+// Revoke returns ID of token owner, if token is not exists, returns nil ID and error
+func (r *tokensRepo) Revoke(ctx context.Context, token string) (uuid.UUID, error) {
+	payload, err := r.payload(ctx, token)
+	if errors.Is(err, domain.ErrInvalidToken) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tokenKey(token))
+		if payload.SessionID != "" {
+			pipe.HDel(ctx, sessionsKey(payload.UserID), payload.SessionID)
+		}
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return payload.UserID, nil
+}
+
+// Sessions returns active sessions of user, newest first
+func (r *tokensRepo) Sessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	tokens, err := r.db.HGetAll(ctx, sessionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	sessions := make([]domain.Session, 0, len(tokens))
+	var stale []string
+	for sessionID, token := range tokens {
+		payload, err := r.payload(ctx, token)
+		if errors.Is(err, domain.ErrInvalidToken) {
+			stale = append(stale, sessionID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, payload.ToSession())
+	}
+	// Tokens expire by themselves, their sessions are removed lazily
+	if len(stale) > 0 {
+		if err := r.db.HDel(ctx, sessionsKey(userID), stale...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to remove expired sessions: %w", err)
+		}
+	}
+	slices.SortFunc(sessions, func(a, b domain.Session) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return sessions, nil
+}
+
+// RevokeSession revokes refresh token of the session, returns domain.ErrSessionNotFound if session is not of the user
+func (r *tokensRepo) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	token, err := r.db.HGet(ctx, sessionsKey(userID), sessionID).Result()
 	if errors.Is(err, redis.Nil) {
+		return domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tokenKey(token))
+		pipe.HDel(ctx, sessionsKey(userID), sessionID)
 		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeAll revokes refresh tokens of all sessions of user
+func (r *tokensRepo) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	tokens, err := r.db.HGetAll(ctx, sessionsKey(userID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %w", err)
+	}
+	_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, token := range tokens {
+			pipe.Del(ctx, tokenKey(token))
+		}
+		pipe.Del(ctx, sessionsKey(userID))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
 	}
-	return err
+	return nil
+}
+
+func (r *tokensRepo) payload(ctx context.Context, token string) (RefreshToken, error) {
+	data, err := r.db.Get(ctx, tokenKey(token)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return RefreshToken{}, domain.ErrInvalidToken
+		}
+		return RefreshToken{}, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	var payload RefreshToken
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return RefreshToken{}, fmt.Errorf("failed to unmarshal refresh token: %w", err)
+	}
+	return payload, nil
 }
 
 func tokenKey(token string) string {
 	return fmt.Sprintf("refreshToken:%s", token)
 }
+
+// Hash of session ID to refresh token
+func sessionsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("userSessions:%s", userID)
+}
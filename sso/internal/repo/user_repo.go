@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
@@ -83,6 +84,96 @@ func (r *userRepo) AccountByID(ctx context.Context, userID uuid.UUID, provider d
 	return account.ToDomain(), nil
 }
 
+// List returns page of users matching filter, newest first, and number of all matching users
+func (r *userRepo) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	where := sq.And{}
+	if filter.Email != "" {
+		where = append(where, sq.ILike{"email": "%" + filter.Email + "%"})
+	}
+	if filter.Role != "" {
+		where = append(where, sq.Expr("EXISTS (SELECT 1 FROM user_roles r WHERE r.user_id = users.user_id AND r.role = ?)", filter.Role))
+	}
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			where = append(where, sq.NotEq{"blocked_at": nil})
+		} else {
+			where = append(where, sq.Eq{"blocked_at": nil})
+		}
+	}
+
+	query, args := r.qb.Select("COUNT(*)").From("users").Where(where).MustSql()
+	var total int
+	if err := r.getContext(ctx, &total, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query, args = r.qb.
+		Select("*").
+		From("users").
+		Where(where).
+		OrderBy("registered_at DESC", "user_id").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		MustSql()
+	var users []User
+	if err := r.selectContext(ctx, &users, query, args...); err != nil {
+		return nil, 0, err
+	}
+	res := make([]domain.User, len(users))
+	for i, user := range users {
+		res[i] = user.ToDomain()
+	}
+	return res, total, nil
+}
+
+// Accounts returns providers which user can log in with
+func (r *userRepo) Accounts(ctx context.Context, userID uuid.UUID) ([]domain.AccountType, error) {
+	query, args := r.qb.Select("provider").From("accounts").Where(sq.Eq{"user_id": userID}).OrderBy("provider").MustSql()
+	var accounts []domain.AccountType
+	if err := r.selectContext(ctx, &accounts, query, args...); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// SetBlocked blocks user with reason, zero time unblocks user
+func (r *userRepo) SetBlocked(ctx context.Context, userID uuid.UUID, blockedAt time.Time, reason string) error {
+	var blocked, blockReason any
+	if !blockedAt.IsZero() {
+		blocked, blockReason = blockedAt, reason
+	}
+	query, args := r.qb.
+		Update("users").
+		Set("blocked_at", blocked).
+		Set("block_reason", blockReason).
+		Where(sq.Eq{"user_id": userID}).
+		MustSql()
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+func (r *userRepo) selectContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return r.db.SelectContext(ctx, dest, query, args...)
+}
+
 func (r *userRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
 	tx := transaction.ExtractTx(ctx)
 	if tx != nil {
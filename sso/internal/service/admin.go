@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/logger"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AdminUserRepo interface {
+	GetByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
+	List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error)
+	Accounts(ctx context.Context, userID uuid.UUID) ([]domain.AccountType, error)
+	SetBlocked(ctx context.Context, userID uuid.UUID, blockedAt time.Time, reason string) error
+}
+
+type ProfileClient interface {
+	ProfileSummary(ctx context.Context, userID string) (domain.ProfileSummary, error)
+}
+
+const (
+	defaultUsersLimit = 20
+	maxUsersLimit     = 100
+)
+
+type adminService struct {
+	txManager transaction.TxManager
+	users     AdminUserRepo
+	roles     RoleRepo
+	tokens    TokenRepo
+	audit     AuditRepo
+	profiles  ProfileClient
+}
+
+func NewAdminService(txManager transaction.TxManager, users AdminUserRepo, roles RoleRepo, tokens TokenRepo, audit AuditRepo, profiles ProfileClient) *adminService {
+	return &adminService{txManager: txManager, users: users, roles: roles, tokens: tokens, audit: audit, profiles: profiles}
+}
+
+// ListUsers returns page of users matching filter and number of all matching users
+func (s *adminService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUsersLimit
+	}
+	filter.Limit = min(filter.Limit, maxUsersLimit)
+	filter.Offset = max(filter.Offset, 0)
+	users, total, err := s.users.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, total, nil
+}
+
+// GetUser returns everything admin can see about user, profile is omitted if profile service fails
+func (s *adminService) GetUser(ctx context.Context, adminID, userID string) (domain.UserDetails, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.UserDetails{}, domain.ErrUserNotFound
+	}
+	user, err := s.users.GetByID(ctx, id)
+	if err != nil {
+		return domain.UserDetails{}, err
+	}
+	details := domain.UserDetails{User: user}
+	if details.Accounts, err = s.users.Accounts(ctx, id); err != nil {
+		return domain.UserDetails{}, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	access, err := s.roles.Access(ctx, id)
+	if err != nil {
+		return domain.UserDetails{}, fmt.Errorf("failed to get access: %w", err)
+	}
+	details.Roles = access.Roles
+	if details.Sessions, err = s.tokens.Sessions(ctx, id); err != nil {
+		return domain.UserDetails{}, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	profile, err := s.profiles.ProfileSummary(ctx, userID)
+	switch {
+	case err == nil:
+		details.Profile = &profile
+	case !errors.Is(err, domain.ErrProfileNotFound):
+		logger.Extract(ctx).Warn("failed to get profile summary", "user_id", userID, "error", err)
+	}
+
+	if err := s.record(ctx, adminID, domain.AuditActionViewUser, id, nil); err != nil {
+		return domain.UserDetails{}, err
+	}
+	return details, nil
+}
+
+// BlockUser prevents user from logging in and refreshing tokens, and ends all sessions of user.
+// Access tokens which are already issued live until expiration
+func (s *adminService) BlockUser(ctx context.Context, adminID, userID, reason string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	err = s.txManager.Run(ctx, func(ctx context.Context) error {
+		if err := s.users.SetBlocked(ctx, id, time.Now(), reason); err != nil {
+			return err
+		}
+		return s.record(ctx, adminID, domain.AuditActionBlockUser, id, map[string]string{"reason": reason})
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.tokens.RevokeAll(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+func (s *adminService) UnblockUser(ctx context.Context, adminID, userID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		if err := s.users.SetBlocked(ctx, id, time.Time{}, ""); err != nil {
+			return err
+		}
+		return s.record(ctx, adminID, domain.AuditActionUnblockUser, id, nil)
+	})
+}
+
+// ForceLogout ends all sessions of user, user can log in again
+func (s *adminService) ForceLogout(ctx context.Context, adminID, userID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	if _, err := s.users.GetByID(ctx, id); err != nil {
+		return err
+	}
+	if err := s.tokens.RevokeAll(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return s.record(ctx, adminID, domain.AuditActionForceLogout, id, nil)
+}
+
+func (s *adminService) record(ctx context.Context, adminID, action string, targetID uuid.UUID, details map[string]string) error {
+	actor, _ := uuid.Parse(adminID)
+	entry := auditEntry(ctx, action, actor, targetID, nil)
+	entry.Details = details
+	if err := s.audit.Add(ctx, entry); err != nil {
+		return fmt.Errorf("failed to add audit entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	txMocks "github.com/SergeyBogomolovv/profile-manager/common/transaction/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTxManager(t *testing.T) *txMocks.TxManager {
+	tx := txMocks.NewTxManager(t)
+	tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+		func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		},
+	).Maybe()
+	return tx
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter domain.UserFilter
+		want   domain.UserFilter
+	}{
+		{
+			name:   "default limit",
+			filter: domain.UserFilter{Email: "gmail"},
+			want:   domain.UserFilter{Email: "gmail", Limit: 20},
+		},
+		{
+			name:   "limit is capped",
+			filter: domain.UserFilter{Limit: 1000, Offset: 40},
+			want:   domain.UserFilter{Limit: 100, Offset: 40},
+		},
+		{
+			name:   "negative offset",
+			filter: domain.UserFilter{Limit: 10, Offset: -5},
+			want:   domain.UserFilter{Limit: 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewAdminUserRepo(t)
+			users.EXPECT().List(mock.Anything, tc.want).Return([]domain.User{{ID: uuid.New()}}, 1, nil)
+			svc := service.NewAdminService(nil, users, nil, nil, nil, nil)
+			got, total, err := svc.ListUsers(context.Background(), tc.filter)
+			require.NoError(t, err)
+			assert.Len(t, got, 1)
+			assert.Equal(t, 1, total)
+		})
+	}
+}
+
+func TestAdminService_GetUser(t *testing.T) {
+	type MockBehavior func(users *mocks.AdminUserRepo, roles *mocks.RoleRepo, tokens *mocks.TokenRepo, profiles *mocks.ProfileClient, audit *mocks.AuditRepo, userID uuid.UUID)
+
+	adminID := uuid.New()
+	summary := domain.ProfileSummary{Username: "john", FirstName: "John"}
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantProfile  *domain.ProfileSummary
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(users *mocks.AdminUserRepo, roles *mocks.RoleRepo, tokens *mocks.TokenRepo, profiles *mocks.ProfileClient, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+				users.EXPECT().Accounts(mock.Anything, userID).Return([]domain.AccountType{domain.AccountTypeCredentials}, nil)
+				roles.EXPECT().Access(mock.Anything, userID).Return(domain.Access{Roles: []string{"admin"}}, nil)
+				tokens.EXPECT().Sessions(mock.Anything, userID).Return([]domain.Session{{ID: "session"}}, nil)
+				profiles.EXPECT().ProfileSummary(mock.Anything, userID.String()).Return(summary, nil)
+				audit.EXPECT().Add(mock.Anything, domain.AuditEntry{ActorID: adminID, Action: domain.AuditActionViewUser, Result: domain.AuditResultSuccess, TargetID: userID}).Return(nil)
+			},
+			wantProfile: &summary,
+		},
+		{
+			name: "profile service fails",
+			mockBehavior: func(users *mocks.AdminUserRepo, roles *mocks.RoleRepo, tokens *mocks.TokenRepo, profiles *mocks.ProfileClient, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+				users.EXPECT().Accounts(mock.Anything, userID).Return(nil, nil)
+				roles.EXPECT().Access(mock.Anything, userID).Return(domain.Access{}, nil)
+				tokens.EXPECT().Sessions(mock.Anything, userID).Return(nil, nil)
+				profiles.EXPECT().ProfileSummary(mock.Anything, userID.String()).Return(domain.ProfileSummary{}, assert.AnError)
+				audit.EXPECT().Add(mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "user not found",
+			mockBehavior: func(users *mocks.AdminUserRepo, roles *mocks.RoleRepo, tokens *mocks.TokenRepo, profiles *mocks.ProfileClient, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{}, domain.ErrUserNotFound)
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewAdminUserRepo(t)
+			roles := mocks.NewRoleRepo(t)
+			tokens := mocks.NewTokenRepo(t)
+			profiles := mocks.NewProfileClient(t)
+			audit := mocks.NewAuditRepo(t)
+			svc := service.NewAdminService(nil, users, roles, tokens, audit, profiles)
+			userID := uuid.New()
+			tc.mockBehavior(users, roles, tokens, profiles, audit, userID)
+			details, err := svc.GetUser(context.Background(), adminID.String(), userID.String())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, userID, details.User.ID)
+			assert.Equal(t, tc.wantProfile, details.Profile)
+		})
+	}
+}
+
+func TestAdminService_BlockUser(t *testing.T) {
+	type MockBehavior func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID)
+
+	adminID := uuid.New()
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().SetBlocked(mock.Anything, userID, mock.MatchedBy(func(at time.Time) bool {
+					return !at.IsZero()
+				}), "spam").Return(nil)
+				audit.EXPECT().Add(mock.Anything, domain.AuditEntry{
+					ActorID: adminID,
+					Action:  domain.AuditActionBlockUser, Result: domain.AuditResultSuccess,
+					TargetID: userID,
+					Details:  map[string]string{"reason": "spam"},
+				}).Return(nil)
+				tokens.EXPECT().RevokeAll(mock.Anything, userID).Return(nil)
+			},
+		},
+		{
+			name: "user not found",
+			mockBehavior: func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().SetBlocked(mock.Anything, userID, mock.Anything, "spam").Return(domain.ErrUserNotFound)
+			},
+			want: domain.ErrUserNotFound,
+		},
+		{
+			name: "failed to audit",
+			mockBehavior: func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().SetBlocked(mock.Anything, userID, mock.Anything, "spam").Return(nil)
+				audit.EXPECT().Add(mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			want: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewAdminUserRepo(t)
+			tokens := mocks.NewTokenRepo(t)
+			audit := mocks.NewAuditRepo(t)
+			svc := service.NewAdminService(newTxManager(t), users, nil, tokens, audit, nil)
+			userID := uuid.New()
+			tc.mockBehavior(users, tokens, audit, userID)
+			err := svc.BlockUser(context.Background(), adminID.String(), userID.String(), "spam")
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestAdminService_UnblockUser(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+	users := mocks.NewAdminUserRepo(t)
+	audit := mocks.NewAuditRepo(t)
+	users.EXPECT().SetBlocked(mock.Anything, userID, time.Time{}, "").Return(nil)
+	audit.EXPECT().Add(mock.Anything, domain.AuditEntry{ActorID: adminID, Action: domain.AuditActionUnblockUser, Result: domain.AuditResultSuccess, TargetID: userID}).Return(nil)
+	svc := service.NewAdminService(newTxManager(t), users, nil, nil, audit, nil)
+	assert.NoError(t, svc.UnblockUser(context.Background(), adminID.String(), userID.String()))
+}
+
+func TestAdminService_ForceLogout(t *testing.T) {
+	type MockBehavior func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID)
+
+	adminID := uuid.New()
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+				tokens.EXPECT().RevokeAll(mock.Anything, userID).Return(nil)
+				audit.EXPECT().Add(mock.Anything, domain.AuditEntry{ActorID: adminID, Action: domain.AuditActionForceLogout, Result: domain.AuditResultSuccess, TargetID: userID}).Return(nil)
+			},
+		},
+		{
+			name: "user not found",
+			mockBehavior: func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{}, domain.ErrUserNotFound)
+			},
+			want: domain.ErrUserNotFound,
+		},
+		{
+			name: "failed to revoke",
+			mockBehavior: func(users *mocks.AdminUserRepo, tokens *mocks.TokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+				tokens.EXPECT().RevokeAll(mock.Anything, userID).Return(assert.AnError)
+			},
+			want: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewAdminUserRepo(t)
+			tokens := mocks.NewTokenRepo(t)
+			audit := mocks.NewAuditRepo(t)
+			svc := service.NewAdminService(nil, users, nil, tokens, audit, nil)
+			userID := uuid.New()
+			tc.mockBehavior(users, tokens, audit, userID)
+			err := svc.ForceLogout(context.Background(), adminID.String(), userID.String())
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/logger"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+	auditVerifyPage   = 500
+)
+
+// auditEntry describes action made in request of ctx, it failed if err is not nil
+func auditEntry(ctx context.Context, action string, actorID, targetID uuid.UUID, err error) domain.AuditEntry {
+	info := request.Extract(ctx)
+	entry := domain.AuditEntry{
+		ActorID:   actorID,
+		Action:    action,
+		TargetID:  targetID,
+		Result:    domain.AuditResultSuccess,
+		IP:        info.IP,
+		UserAgent: info.UserAgent,
+		RequestID: info.ID,
+	}
+	if err != nil {
+		entry.Result = domain.AuditResultFailure
+	}
+	return entry
+}
+
+// record adds entry about action which is already done, so failure to record it is logged instead of returned.
+// Actions of admins are recorded in their transactions instead
+func (s *authService) record(ctx context.Context, action string, actorID, targetID uuid.UUID, err error, details map[string]string) {
+	entry := auditEntry(ctx, action, actorID, targetID, err)
+	entry.Details = details
+	if err := s.auditLog.Add(ctx, entry); err != nil {
+		logger.Extract(ctx).Error("failed to add audit entry", "action", action, "error", err)
+	}
+}
+
+// AuditLog returns entries matching filter, newest first
+func (s *adminService) AuditLog(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	filter.Limit = min(filter.Limit, maxAuditLimit)
+	entries, err := s.audit.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// VerifyAuditLog checks hash chain from the first entry. Entries added while chaining was disabled
+// are skipped, removal of the last entries can't be detected
+func (s *adminService) VerifyAuditLog(ctx context.Context) (domain.AuditVerification, error) {
+	var (
+		res      = domain.AuditVerification{Valid: true}
+		lastID   int64
+		prevHash string
+	)
+	for {
+		entries, err := s.audit.Chain(ctx, lastID, auditVerifyPage)
+		if err != nil {
+			return domain.AuditVerification{}, fmt.Errorf("failed to get audit log: %w", err)
+		}
+		for _, entry := range entries {
+			lastID = entry.ID
+			if entry.Hash == "" {
+				continue
+			}
+			res.Checked++
+			if entry.PrevHash != prevHash || entry.ComputeHash() != entry.Hash {
+				return domain.AuditVerification{Checked: res.Checked, BrokenID: entry.ID}, nil
+			}
+			prevHash = entry.Hash
+		}
+		if len(entries) < auditVerifyPage {
+			return res, nil
+		}
+	}
+}
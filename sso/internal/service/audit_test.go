@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// chain links entries like audit repo does with hash chaining enabled
+func chain(entries ...domain.AuditEntry) []domain.AuditEntry {
+	var prevHash string
+	for i := range entries {
+		entries[i].ID = int64(i + 1)
+		if entries[i].Action == "" {
+			continue
+		}
+		entries[i].PrevHash = prevHash
+		entries[i].Hash = entries[i].ComputeHash()
+		prevHash = entries[i].Hash
+	}
+	return entries
+}
+
+func TestAdminService_VerifyAuditLog(t *testing.T) {
+	userID := uuid.New()
+	entry := func(action string) domain.AuditEntry {
+		return domain.AuditEntry{
+			ActorID:   userID,
+			Action:    action,
+			TargetID:  userID,
+			Result:    domain.AuditResultSuccess,
+			Details:   map[string]string{"type": "credentials"},
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		entries func() []domain.AuditEntry
+		want    domain.AuditVerification
+	}{
+		{
+			name: "valid",
+			entries: func() []domain.AuditEntry {
+				return chain(entry(domain.AuditActionRegister), entry(domain.AuditActionLogin), entry(domain.AuditActionLogout))
+			},
+			want: domain.AuditVerification{Checked: 3, Valid: true},
+		},
+		{
+			name: "entry without hash is skipped",
+			entries: func() []domain.AuditEntry {
+				// Empty action marks entry added while chaining was disabled
+				return chain(entry(domain.AuditActionRegister), domain.AuditEntry{}, entry(domain.AuditActionLogin))
+			},
+			want: domain.AuditVerification{Checked: 2, Valid: true},
+		},
+		{
+			name: "changed entry",
+			entries: func() []domain.AuditEntry {
+				entries := chain(entry(domain.AuditActionRegister), entry(domain.AuditActionLogin), entry(domain.AuditActionLogout))
+				entries[1].Result = domain.AuditResultFailure
+				return entries
+			},
+			want: domain.AuditVerification{Checked: 2, BrokenID: 2},
+		},
+		{
+			name: "removed entry",
+			entries: func() []domain.AuditEntry {
+				entries := chain(entry(domain.AuditActionRegister), entry(domain.AuditActionLogin), entry(domain.AuditActionLogout))
+				return append(entries[:1], entries[2])
+			},
+			want: domain.AuditVerification{Checked: 2, BrokenID: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			audit := mocks.NewAuditRepo(t)
+			audit.EXPECT().Chain(mock.Anything, int64(0), mock.Anything).Return(tc.entries(), nil)
+			svc := service.NewAdminService(nil, nil, nil, nil, audit, nil)
+			got, err := svc.VerifyAuditLog(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAdminService_AuditLog(t *testing.T) {
+	userID := uuid.New()
+	audit := mocks.NewAuditRepo(t)
+	audit.EXPECT().List(mock.Anything, domain.AuditFilter{UserID: userID, Limit: 50}).Return([]domain.AuditEntry{{ID: 1}}, nil)
+	svc := service.NewAdminService(nil, nil, nil, nil, audit, nil)
+	got, err := svc.AuditLog(context.Background(), domain.AuditFilter{UserID: userID})
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+func TestAuthService_RecordsRequest(t *testing.T) {
+	userID := uuid.New()
+	info := request.Info{IP: "1.1.1.1", UserAgent: "Mozilla/5.0", ID: "request"}
+	tokens := mocks.NewTokenRepo(t)
+	audit := mocks.NewAuditRepo(t)
+	tokens.EXPECT().Revoke(mock.Anything, "token").Return(userID, nil)
+	audit.EXPECT().Add(mock.Anything, domain.AuditEntry{
+		ActorID:   userID,
+		Action:    domain.AuditActionLogout,
+		TargetID:  userID,
+		Result:    domain.AuditResultSuccess,
+		IP:        info.IP,
+		UserAgent: info.UserAgent,
+		RequestID: info.ID,
+	}).Return(nil)
+	svc := service.NewAuthService(mocks.NewBroker(t), nil, nil, tokens, nil, nil, audit, []byte("secret"))
+	require.NoError(t, svc.Logout(request.Inject(context.Background(), info), "token"))
+}
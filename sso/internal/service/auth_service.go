@@ -16,6 +16,7 @@ import (
 type Broker interface {
 	PublishUserRegister(user events.UserRegister) error
 	PublishUserLogin(user events.UserLogin) error
+	PublishMagicLink(link events.MagicLink) error
 }
 
 type UserRepo interface {
@@ -23,27 +24,51 @@ type UserRepo interface {
 	Create(ctx context.Context, email string) (domain.User, error)
 	AddAccount(ctx context.Context, userID uuid.UUID, provider domain.AccountType, password []byte) (domain.Account, error)
 	AccountByID(ctx context.Context, userID uuid.UUID, provider domain.AccountType) (domain.Account, error)
+	GetByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
+}
+
+type RoleRepo interface {
+	Access(ctx context.Context, userID uuid.UUID) (domain.Access, error)
+	IsRoleExists(ctx context.Context, role string) (bool, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, role string) error
+}
+
+type AuditRepo interface {
+	Add(ctx context.Context, entry domain.AuditEntry) error
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+	Chain(ctx context.Context, afterID int64, limit int) ([]domain.AuditEntry, error)
 }
 
 type TokenRepo interface {
-	Create(ctx context.Context, userID uuid.UUID) (string, error)
+	Create(ctx context.Context, session domain.Session) (string, error)
 	UserID(ctx context.Context, token string) (uuid.UUID, error)
-	Revoke(ctx context.Context, token string) error
+	Revoke(ctx context.Context, token string) (uuid.UUID, error)
+	Sessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error
+	RevokeAll(ctx context.Context, userID uuid.UUID) error
+}
+
+type MagicLinkRepo interface {
+	Create(ctx context.Context, link domain.MagicLink) (string, error)
+	Take(ctx context.Context, token string) (domain.MagicLink, error)
 }
 
 type authService struct {
 	txManager transaction.TxManager
 	users     UserRepo
 	tokens    TokenRepo
+	links     MagicLinkRepo
+	roles     RoleRepo
+	auditLog  AuditRepo
 	broker    Broker
 	jwtSecret []byte
 }
 
-func NewAuthService(broker Broker, txManager transaction.TxManager, users UserRepo, tokens TokenRepo, jwtSecret []byte) *authService {
-	return &authService{users: users, tokens: tokens, txManager: txManager, jwtSecret: jwtSecret, broker: broker}
+func NewAuthService(broker Broker, txManager transaction.TxManager, users UserRepo, tokens TokenRepo, links MagicLinkRepo, roles RoleRepo, auditLog AuditRepo, jwtSecret []byte) *authService {
+	return &authService{users: users, tokens: tokens, links: links, roles: roles, auditLog: auditLog, txManager: txManager, jwtSecret: jwtSecret, broker: broker}
 }
 
-func (s *authService) Register(ctx context.Context, email, password string) (string, error) {
+func (s *authService) Register(ctx context.Context, email, password, locale string) (string, error) {
 	var userID uuid.UUID
 	err := s.txManager.Run(ctx, func(ctx context.Context) error {
 		user, added, err := s.ensureUser(ctx, email)
@@ -75,22 +100,31 @@ func (s *authService) Register(ctx context.Context, email, password string) (str
 		}
 		// Publish user register
 		return s.broker.PublishUserRegister(events.UserRegister{
-			ID:    userID.String(),
-			Email: email,
+			ID:     userID.String(),
+			Email:  email,
+			Locale: locale,
 		})
 	})
 	if err != nil {
+		s.record(ctx, domain.AuditActionRegister, uuid.Nil, userID, err, map[string]string{"email": email})
 		return "", err
 	}
+	s.record(ctx, domain.AuditActionRegister, userID, userID, nil, nil)
 	return userID.String(), nil
 }
 
 func (s *authService) Login(ctx context.Context, email, password, ip string) (domain.Tokens, error) {
+	// Failed attempts are recorded with the user they target, if the user exists
+	fail := func(userID uuid.UUID, err error) (domain.Tokens, error) {
+		s.record(ctx, domain.AuditActionLogin, uuid.Nil, userID, err, map[string]string{"email": email, "reason": err.Error()})
+		return domain.Tokens{}, err
+	}
+
 	// Get user
 	user, err := s.users.GetByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
-			return domain.Tokens{}, domain.ErrInvalidCredentials
+			return fail(uuid.Nil, domain.ErrInvalidCredentials)
 		}
 		return domain.Tokens{}, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -99,16 +133,20 @@ func (s *authService) Login(ctx context.Context, email, password, ip string) (do
 	account, err := s.users.AccountByID(ctx, user.ID, domain.AccountTypeCredentials)
 	if err != nil {
 		if errors.Is(err, domain.ErrAccountNotFound) {
-			return domain.Tokens{}, domain.ErrInvalidCredentials
+			return fail(user.ID, domain.ErrInvalidCredentials)
 		}
 		return domain.Tokens{}, fmt.Errorf("failed to get account: %w", err)
 	}
 
 	if err := comparePassword(password, account.Password); err != nil {
-		return domain.Tokens{}, domain.ErrInvalidCredentials
+		return fail(user.ID, domain.ErrInvalidCredentials)
+	}
+	// Checked after password, so blocked state is not revealed to others
+	if user.IsBlocked() {
+		return fail(user.ID, domain.ErrUserBlocked)
 	}
 
-	tokens, err := s.createTokens(ctx, user.ID)
+	tokens, err := s.createTokens(ctx, user.ID, ip, events.LoginTypeCredentials)
 	if err != nil {
 		return domain.Tokens{}, err
 	}
@@ -122,6 +160,7 @@ func (s *authService) Login(ctx context.Context, email, password, ip string) (do
 		return domain.Tokens{}, fmt.Errorf("failed to publish user login: %w", err)
 	}
 
+	s.record(ctx, domain.AuditActionLogin, user.ID, user.ID, nil, map[string]string{"email": email, "type": events.LoginTypeCredentials})
 	return tokens, nil
 }
 
@@ -130,17 +169,56 @@ func (s *authService) Refresh(ctx context.Context, refreshToken string) (string,
 	if err != nil {
 		return "", fmt.Errorf("failed to get user id: %w", err)
 	}
-	accessToken, err := s.signJwt(userID)
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsBlocked() {
+		s.record(ctx, domain.AuditActionRefresh, userID, userID, domain.ErrUserBlocked, nil)
+		return "", domain.ErrUserBlocked
+	}
+	accessToken, err := s.signJwt(ctx, userID)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign access token: %w", err)
 	}
+	s.record(ctx, domain.AuditActionRefresh, userID, userID, nil, nil)
 	return accessToken, nil
 }
 
 func (s *authService) Logout(ctx context.Context, refreshToken string) error {
-	if err := s.tokens.Revoke(ctx, refreshToken); err != nil {
+	userID, err := s.tokens.Revoke(ctx, refreshToken)
+	if err != nil {
 		return fmt.Errorf("failed to revoke token: %w", err)
 	}
+	// Logout with unknown token changes nothing
+	if userID != uuid.Nil {
+		s.record(ctx, domain.AuditActionLogout, userID, userID, nil, nil)
+	}
+	return nil
+}
+
+// Sessions returns active logins of user, newest first
+func (s *authService) Sessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	sessions, err := s.tokens.Sessions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// TerminateSession revokes refresh token of the session, access tokens issued for it live until expiration
+func (s *authService) TerminateSession(ctx context.Context, userID, sessionID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.ErrSessionNotFound
+	}
+	if err := s.tokens.RevokeSession(ctx, id, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
 	"github.com/SergeyBogomolovv/profile-manager/common/auth"
@@ -22,7 +23,7 @@ func TestAuthService_Login(t *testing.T) {
 		password string
 	}
 
-	type MockBehavior func(users *mocks.UserRepo, tokens *mocks.TokenRepo, broker *mocks.Broker, args args)
+	type MockBehavior func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker, args args)
 
 	testCases := []struct {
 		name         string
@@ -30,11 +31,12 @@ func TestAuthService_Login(t *testing.T) {
 		mockBehavior MockBehavior
 		want         domain.Tokens
 		wantErr      error
+		wantAudit    domain.AuditResult
 	}{
 		{
 			name: "success",
 			args: args{email: "email@email.com", password: "password"},
-			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, broker *mocks.Broker, args args) {
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker, args args) {
 				hashedPassword, err := bcrypt.GenerateFromPassword([]byte(args.password), bcrypt.DefaultCost)
 				require.NoError(t, err)
 				userID := uuid.New()
@@ -42,7 +44,8 @@ func TestAuthService_Login(t *testing.T) {
 				users.EXPECT().
 					AccountByID(mock.Anything, userID, domain.AccountTypeCredentials).
 					Return(domain.Account{Password: hashedPassword}, nil)
-				tokens.EXPECT().Create(mock.Anything, userID).Return("token", nil)
+				tokens.EXPECT().Create(mock.Anything, domain.Session{UserID: userID, IP: "1.1.1.1", Type: events.LoginTypeCredentials}).Return("token", nil)
+				roles.EXPECT().Access(mock.Anything, userID).Return(domain.Access{}, nil)
 				broker.EXPECT().PublishUserLogin(mock.MatchedBy(func(event events.UserLogin) bool {
 					return event.ID == userID.String() &&
 						event.IP == "1.1.1.1" &&
@@ -50,38 +53,57 @@ func TestAuthService_Login(t *testing.T) {
 						!event.Time.IsZero()
 				})).Return(nil)
 			},
-			want:    domain.Tokens{RefreshToken: "token"},
-			wantErr: nil,
+			want:      domain.Tokens{RefreshToken: "token"},
+			wantErr:   nil,
+			wantAudit: domain.AuditResultSuccess,
 		},
 		{
 			name: "account not exists",
 			args: args{email: "email@email.com", password: "password"},
-			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, broker *mocks.Broker, args args) {
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker, args args) {
 				userID := uuid.New()
 				users.EXPECT().GetByEmail(mock.Anything, args.email).Return(domain.User{ID: userID}, nil)
 				users.EXPECT().AccountByID(mock.Anything, userID, domain.AccountTypeCredentials).Return(domain.Account{}, domain.ErrAccountNotFound)
 			},
-			wantErr: domain.ErrInvalidCredentials,
+			wantErr:   domain.ErrInvalidCredentials,
+			wantAudit: domain.AuditResultFailure,
 		},
 		{
 			name: "user not exists",
 			args: args{email: "email@email.com", password: "password"},
-			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, broker *mocks.Broker, args args) {
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker, args args) {
 				users.EXPECT().GetByEmail(mock.Anything, args.email).Return(domain.User{}, domain.ErrUserNotFound)
 			},
-			wantErr: domain.ErrInvalidCredentials,
+			wantErr:   domain.ErrInvalidCredentials,
+			wantAudit: domain.AuditResultFailure,
 		},
 		{
 			name: "wrong password",
 			args: args{email: "email@email.com", password: "password"},
-			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, broker *mocks.Broker, args args) {
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker, args args) {
 				userID := uuid.New()
 				users.EXPECT().GetByEmail(mock.Anything, args.email).Return(domain.User{ID: userID}, nil)
 				users.EXPECT().
 					AccountByID(mock.Anything, userID, domain.AccountTypeCredentials).
 					Return(domain.Account{Password: []byte("wrongpass")}, nil)
 			},
-			wantErr: domain.ErrInvalidCredentials,
+			wantErr:   domain.ErrInvalidCredentials,
+			wantAudit: domain.AuditResultFailure,
+		},
+		{
+			name: "user blocked",
+			args: args{email: "email@email.com", password: "password"},
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker, args args) {
+				hashedPassword, err := bcrypt.GenerateFromPassword([]byte(args.password), bcrypt.DefaultCost)
+				require.NoError(t, err)
+				userID := uuid.New()
+				users.EXPECT().GetByEmail(mock.Anything, args.email).Return(domain.User{ID: userID, BlockedAt: time.Now()}, nil)
+				users.EXPECT().
+					AccountByID(mock.Anything, userID, domain.AccountTypeCredentials).
+					Return(domain.Account{Password: hashedPassword}, nil)
+			},
+			wantErr:   domain.ErrUserBlocked,
+			wantAudit: domain.AuditResultFailure,
 		},
 	}
 
@@ -89,9 +111,14 @@ func TestAuthService_Login(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			userRepo := mocks.NewUserRepo(t)
 			tokenRepo := mocks.NewTokenRepo(t)
+			roleRepo := mocks.NewRoleRepo(t)
 			broker := mocks.NewBroker(t)
-			svc := service.NewAuthService(broker, nil, userRepo, tokenRepo, []byte("secret"))
-			tc.mockBehavior(userRepo, tokenRepo, broker, tc.args)
+			auditRepo := mocks.NewAuditRepo(t)
+			auditRepo.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionLogin && entry.Result == tc.wantAudit && entry.Details["email"] == tc.args.email
+			})).Return(nil).Once()
+			svc := service.NewAuthService(broker, nil, userRepo, tokenRepo, nil, roleRepo, auditRepo, []byte("secret"))
+			tc.mockBehavior(userRepo, tokenRepo, roleRepo, broker, tc.args)
 			tokens, err := svc.Login(context.Background(), tc.args.email, tc.args.password, "1.1.1.1")
 			if tc.wantErr != nil {
 				assert.ErrorIs(t, err, tc.wantErr)
@@ -109,30 +136,57 @@ func TestAuthService_Refresh(t *testing.T) {
 		userID       uuid.UUID
 	}
 
-	type MockBehavior func(tokens *mocks.TokenRepo, args args)
+	type MockBehavior func(tokens *mocks.TokenRepo, users *mocks.UserRepo, roles *mocks.RoleRepo, args args)
+
+	access := domain.Access{Roles: []string{auth.RoleAdmin}, Permissions: []string{auth.PermissionAssignRoles}}
 
 	testCases := []struct {
 		name         string
 		args         args
 		mockBehavior MockBehavior
 		wantErr      error
+		// Empty if nothing is recorded
+		wantAudit domain.AuditResult
 	}{
 		{
 			name: "success",
 			args: args{refreshToken: "token", userID: uuid.New()},
-			mockBehavior: func(tokens *mocks.TokenRepo, args args) {
+			mockBehavior: func(tokens *mocks.TokenRepo, users *mocks.UserRepo, roles *mocks.RoleRepo, args args) {
 				tokens.EXPECT().UserID(mock.Anything, args.refreshToken).Return(args.userID, nil)
+				users.EXPECT().GetByID(mock.Anything, args.userID).Return(domain.User{ID: args.userID}, nil)
+				roles.EXPECT().Access(mock.Anything, args.userID).Return(access, nil)
 			},
-			wantErr: nil,
+			wantErr:   nil,
+			wantAudit: domain.AuditResultSuccess,
 		},
 		{
 			name: "ivalid token",
 			args: args{refreshToken: "token"},
-			mockBehavior: func(tokens *mocks.TokenRepo, args args) {
+			mockBehavior: func(tokens *mocks.TokenRepo, users *mocks.UserRepo, roles *mocks.RoleRepo, args args) {
 				tokens.EXPECT().UserID(mock.Anything, args.refreshToken).Return(uuid.Nil, domain.ErrInvalidToken)
 			},
 			wantErr: domain.ErrInvalidToken,
 		},
+		{
+			name: "failed to get access",
+			args: args{refreshToken: "token", userID: uuid.New()},
+			mockBehavior: func(tokens *mocks.TokenRepo, users *mocks.UserRepo, roles *mocks.RoleRepo, args args) {
+				tokens.EXPECT().UserID(mock.Anything, args.refreshToken).Return(args.userID, nil)
+				users.EXPECT().GetByID(mock.Anything, args.userID).Return(domain.User{ID: args.userID}, nil)
+				roles.EXPECT().Access(mock.Anything, args.userID).Return(domain.Access{}, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "user blocked",
+			args: args{refreshToken: "token", userID: uuid.New()},
+			mockBehavior: func(tokens *mocks.TokenRepo, users *mocks.UserRepo, roles *mocks.RoleRepo, args args) {
+				tokens.EXPECT().UserID(mock.Anything, args.refreshToken).Return(args.userID, nil)
+				users.EXPECT().GetByID(mock.Anything, args.userID).Return(domain.User{ID: args.userID, BlockedAt: time.Now()}, nil)
+			},
+			wantErr:   domain.ErrUserBlocked,
+			wantAudit: domain.AuditResultFailure,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -140,8 +194,16 @@ func TestAuthService_Refresh(t *testing.T) {
 			tokenRepo := mocks.NewTokenRepo(t)
 			secret := []byte("secret")
 			broker := mocks.NewBroker(t)
-			svc := service.NewAuthService(broker, nil, nil, tokenRepo, secret)
-			tc.mockBehavior(tokenRepo, tc.args)
+			userRepo := mocks.NewUserRepo(t)
+			roleRepo := mocks.NewRoleRepo(t)
+			auditRepo := mocks.NewAuditRepo(t)
+			if tc.wantAudit != "" {
+				auditRepo.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+					return entry.Action == domain.AuditActionRefresh && entry.Result == tc.wantAudit && entry.TargetID == tc.args.userID
+				})).Return(nil).Once()
+			}
+			svc := service.NewAuthService(broker, nil, userRepo, tokenRepo, nil, roleRepo, auditRepo, secret)
+			tc.mockBehavior(tokenRepo, userRepo, roleRepo, tc.args)
 			accessToken, err := svc.Refresh(context.Background(), tc.args.refreshToken)
 			if tc.wantErr != nil {
 				assert.ErrorIs(t, err, tc.wantErr)
@@ -150,12 +212,16 @@ func TestAuthService_Refresh(t *testing.T) {
 			claims, err := auth.VerifyJWT(accessToken, secret)
 			require.NoError(t, err)
 			assert.Equal(t, claims.UserID, tc.args.userID.String())
+			assert.Equal(t, access.Roles, claims.Roles)
+			assert.True(t, claims.HasPermission(auth.PermissionAssignRoles))
 		})
 	}
 }
 
 func TestAuthService_Logout(t *testing.T) {
-	type MockBehavior func(tokens *mocks.TokenRepo, token string)
+	type MockBehavior func(tokens *mocks.TokenRepo, audit *mocks.AuditRepo, token string)
+
+	userID := uuid.New()
 	testCases := []struct {
 		name         string
 		token        string
@@ -165,8 +231,28 @@ func TestAuthService_Logout(t *testing.T) {
 		{
 			name:  "success",
 			token: "token",
-			mockBehavior: func(tokens *mocks.TokenRepo, token string) {
-				tokens.EXPECT().Revoke(mock.Anything, token).Return(nil)
+			mockBehavior: func(tokens *mocks.TokenRepo, audit *mocks.AuditRepo, token string) {
+				tokens.EXPECT().Revoke(mock.Anything, token).Return(userID, nil)
+				audit.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+					return entry.Action == domain.AuditActionLogout && entry.ActorID == userID && entry.Result == domain.AuditResultSuccess
+				})).Return(nil)
+			},
+			want: nil,
+		},
+		{
+			name:  "unknown token",
+			token: "token",
+			mockBehavior: func(tokens *mocks.TokenRepo, audit *mocks.AuditRepo, token string) {
+				tokens.EXPECT().Revoke(mock.Anything, token).Return(uuid.Nil, nil)
+			},
+			want: nil,
+		},
+		{
+			name:  "audit fails",
+			token: "token",
+			mockBehavior: func(tokens *mocks.TokenRepo, audit *mocks.AuditRepo, token string) {
+				tokens.EXPECT().Revoke(mock.Anything, token).Return(userID, nil)
+				audit.EXPECT().Add(mock.Anything, mock.Anything).Return(assert.AnError)
 			},
 			want: nil,
 		},
@@ -175,15 +261,74 @@ func TestAuthService_Logout(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tokenRepo := mocks.NewTokenRepo(t)
+			auditRepo := mocks.NewAuditRepo(t)
 			broker := mocks.NewBroker(t)
-			svc := service.NewAuthService(broker, nil, nil, tokenRepo, []byte("secret"))
-			tc.mockBehavior(tokenRepo, tc.token)
+			svc := service.NewAuthService(broker, nil, nil, tokenRepo, nil, nil, auditRepo, []byte("secret"))
+			tc.mockBehavior(tokenRepo, auditRepo, tc.token)
 			err := svc.Logout(context.Background(), tc.token)
 			assert.ErrorIs(t, err, tc.want)
 		})
 	}
 }
 
+func TestAuthService_Sessions(t *testing.T) {
+	userID := uuid.New()
+	sessions := []domain.Session{{ID: uuid.NewString(), UserID: userID, IP: "1.1.1.1", Type: events.LoginTypeCredentials}}
+
+	t.Run("success", func(t *testing.T) {
+		tokenRepo := mocks.NewTokenRepo(t)
+		svc := service.NewAuthService(mocks.NewBroker(t), nil, nil, tokenRepo, nil, nil, nil, []byte("secret"))
+		tokenRepo.EXPECT().Sessions(mock.Anything, userID).Return(sessions, nil)
+		got, err := svc.Sessions(context.Background(), userID.String())
+		require.NoError(t, err)
+		assert.Equal(t, sessions, got)
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		svc := service.NewAuthService(mocks.NewBroker(t), nil, nil, mocks.NewTokenRepo(t), nil, nil, nil, []byte("secret"))
+		_, err := svc.Sessions(context.Background(), "invalid")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestAuthService_TerminateSession(t *testing.T) {
+	type MockBehavior func(tokens *mocks.TokenRepo, userID uuid.UUID, sessionID string)
+
+	userID := uuid.New()
+	testCases := []struct {
+		name         string
+		sessionID    string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name:      "success",
+			sessionID: uuid.NewString(),
+			mockBehavior: func(tokens *mocks.TokenRepo, userID uuid.UUID, sessionID string) {
+				tokens.EXPECT().RevokeSession(mock.Anything, userID, sessionID).Return(nil)
+			},
+		},
+		{
+			name:      "session not found",
+			sessionID: uuid.NewString(),
+			mockBehavior: func(tokens *mocks.TokenRepo, userID uuid.UUID, sessionID string) {
+				tokens.EXPECT().RevokeSession(mock.Anything, userID, sessionID).Return(domain.ErrSessionNotFound)
+			},
+			want: domain.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenRepo := mocks.NewTokenRepo(t)
+			svc := service.NewAuthService(mocks.NewBroker(t), nil, nil, tokenRepo, nil, nil, nil, []byte("secret"))
+			tc.mockBehavior(tokenRepo, userID, tc.sessionID)
+			err := svc.TerminateSession(context.Background(), userID.String(), tc.sessionID)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
 func TestAuthService_Register(t *testing.T) {
 	t.Skip()
 }
@@ -9,12 +9,13 @@ import (
 	"github.com/google/uuid"
 )
 
-func (s *authService) createTokens(ctx context.Context, userID uuid.UUID) (domain.Tokens, error) {
-	refreshToken, err := s.tokens.Create(ctx, userID)
+// createTokens starts new session of user
+func (s *authService) createTokens(ctx context.Context, userID uuid.UUID, ip, loginType string) (domain.Tokens, error) {
+	refreshToken, err := s.tokens.Create(ctx, domain.Session{UserID: userID, IP: ip, Type: loginType})
 	if err != nil {
 		return domain.Tokens{}, fmt.Errorf("failed to create refresh token: %w", err)
 	}
-	accessToken, err := s.signJwt(userID)
+	accessToken, err := s.signJwt(ctx, userID)
 	if err != nil {
 		return domain.Tokens{}, fmt.Errorf("failed to sign access token: %w", err)
 	}
@@ -23,6 +24,11 @@ func (s *authService) createTokens(ctx context.Context, userID uuid.UUID) (domai
 
 const issuer = "sso"
 
-func (s *authService) signJwt(userID uuid.UUID) (string, error) {
-	return auth.SignJWT(userID.String(), s.jwtSecret, domain.AccessTokenTTL, issuer)
+// signJwt embeds current roles of user, so changed roles are applied on next refresh
+func (s *authService) signJwt(ctx context.Context, userID uuid.UUID) (string, error) {
+	access, err := s.roles.Access(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access: %w", err)
+	}
+	return auth.SignJWT(userID.String(), access.Roles, access.Permissions, s.jwtSecret, domain.AccessTokenTTL, issuer)
 }
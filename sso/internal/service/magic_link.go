@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+)
+
+// RequestMagicLink publishes single use sign in link for email. Unknown emails get link too,
+// so response doesn't reveal whether email is registered
+func (s *authService) RequestMagicLink(ctx context.Context, email, locale string) error {
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	// Blocked user gets no link, the same way as unknown user gets no error
+	if user.IsBlocked() {
+		s.record(ctx, domain.AuditActionMagicLink, uuid.Nil, user.ID, domain.ErrUserBlocked, map[string]string{"email": email})
+		return nil
+	}
+
+	token, err := s.links.Create(ctx, domain.MagicLink{Email: email, Locale: locale})
+	if errors.Is(err, domain.ErrTooManyMagicLinks) {
+		s.record(ctx, domain.AuditActionMagicLink, uuid.Nil, user.ID, err, map[string]string{"email": email, "reason": err.Error()})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create magic link: %w", err)
+	}
+
+	event := events.MagicLink{
+		Email:     email,
+		Token:     token,
+		Locale:    locale,
+		ExpiresAt: time.Now().Add(domain.MagicLinkTTL),
+	}
+	if user.ID != uuid.Nil {
+		event.ID = user.ID.String()
+	}
+	if err := s.broker.PublishMagicLink(event); err != nil {
+		return fmt.Errorf("failed to publish magic link: %w", err)
+	}
+	s.record(ctx, domain.AuditActionMagicLink, uuid.Nil, user.ID, nil, map[string]string{"email": email})
+	return nil
+}
+
+// ConsumeMagicLink signs user in by token from the link, user is registered if there is no user with the email
+func (s *authService) ConsumeMagicLink(ctx context.Context, token, ip string) (domain.Tokens, error) {
+	link, err := s.links.Take(ctx, token)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	var user domain.User
+	err = s.txManager.Run(ctx, func(ctx context.Context) error {
+		usr, added, err := s.ensureUser(ctx, link.Email)
+		if err != nil {
+			return fmt.Errorf("failed to ensure user: %w", err)
+		}
+		user = usr
+		// Existing user is only read by ensureUser, blocked one gets no account
+		if user.IsBlocked() {
+			return domain.ErrUserBlocked
+		}
+		if _, err := s.ensureAccount(ctx, user.ID, domain.AccountTypeMagicLink); err != nil {
+			return fmt.Errorf("failed to ensure account: %w", err)
+		}
+		if !added {
+			return nil
+		}
+		return s.broker.PublishUserRegister(events.UserRegister{
+			ID:     user.ID.String(),
+			Email:  user.Email,
+			Locale: link.Locale,
+		})
+	})
+	details := map[string]string{"email": link.Email, "type": events.LoginTypeMagicLink}
+	if errors.Is(err, domain.ErrUserBlocked) {
+		s.record(ctx, domain.AuditActionLogin, uuid.Nil, user.ID, err, details)
+		return domain.Tokens{}, err
+	}
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	tokens, err := s.createTokens(ctx, user.ID, ip, events.LoginTypeMagicLink)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+	err = s.broker.PublishUserLogin(events.UserLogin{
+		ID:   user.ID.String(),
+		IP:   ip,
+		Time: time.Now(),
+		Type: events.LoginTypeMagicLink,
+	})
+	if err != nil {
+		return domain.Tokens{}, fmt.Errorf("failed to publish user login: %w", err)
+	}
+	s.record(ctx, domain.AuditActionLogin, user.ID, user.ID, nil, details)
+	return tokens, nil
+}
@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/api/events"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthService_RequestMagicLink(t *testing.T) {
+	type MockBehavior func(users *mocks.UserRepo, links *mocks.MagicLinkRepo, broker *mocks.Broker)
+
+	const email = "user@example.com"
+	userID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantAudit    domain.AuditResult
+		want         error
+	}{
+		{
+			name: "new email",
+			mockBehavior: func(users *mocks.UserRepo, links *mocks.MagicLinkRepo, broker *mocks.Broker) {
+				users.EXPECT().GetByEmail(mock.Anything, email).Return(domain.User{}, domain.ErrUserNotFound).Once()
+				links.EXPECT().Create(mock.Anything, domain.MagicLink{Email: email, Locale: "en"}).Return("token", nil).Once()
+				broker.EXPECT().PublishMagicLink(mock.MatchedBy(func(link events.MagicLink) bool {
+					return link.ID == "" && link.Email == email && link.Token == "token" && link.Locale == "en" &&
+						time.Until(link.ExpiresAt) > domain.MagicLinkTTL-time.Minute
+				})).Return(nil).Once()
+			},
+			wantAudit: domain.AuditResultSuccess,
+		},
+		{
+			name: "registered email",
+			mockBehavior: func(users *mocks.UserRepo, links *mocks.MagicLinkRepo, broker *mocks.Broker) {
+				users.EXPECT().GetByEmail(mock.Anything, email).Return(domain.User{ID: userID, Email: email}, nil).Once()
+				links.EXPECT().Create(mock.Anything, mock.Anything).Return("token", nil).Once()
+				broker.EXPECT().PublishMagicLink(mock.MatchedBy(func(link events.MagicLink) bool {
+					return link.ID == userID.String()
+				})).Return(nil).Once()
+			},
+			wantAudit: domain.AuditResultSuccess,
+		},
+		{
+			name: "blocked user",
+			mockBehavior: func(users *mocks.UserRepo, links *mocks.MagicLinkRepo, broker *mocks.Broker) {
+				users.EXPECT().GetByEmail(mock.Anything, email).Return(domain.User{ID: userID, BlockedAt: time.Now()}, nil).Once()
+			},
+			wantAudit: domain.AuditResultFailure,
+		},
+		{
+			name: "too many links",
+			mockBehavior: func(users *mocks.UserRepo, links *mocks.MagicLinkRepo, broker *mocks.Broker) {
+				users.EXPECT().GetByEmail(mock.Anything, email).Return(domain.User{}, domain.ErrUserNotFound).Once()
+				links.EXPECT().Create(mock.Anything, mock.Anything).Return("", domain.ErrTooManyMagicLinks).Once()
+			},
+			wantAudit: domain.AuditResultFailure,
+			want:      domain.ErrTooManyMagicLinks,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewUserRepo(t)
+			links := mocks.NewMagicLinkRepo(t)
+			broker := mocks.NewBroker(t)
+			audit := mocks.NewAuditRepo(t)
+			audit.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionMagicLink && entry.Result == tc.wantAudit && entry.Details["email"] == email
+			})).Return(nil).Once()
+			tc.mockBehavior(users, links, broker)
+			svc := service.NewAuthService(broker, nil, users, nil, links, nil, audit, []byte("secret"))
+
+			err := svc.RequestMagicLink(context.Background(), email, "en")
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestAuthService_ConsumeMagicLink(t *testing.T) {
+	type MockBehavior func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker)
+
+	link := domain.MagicLink{Email: "user@example.com", Locale: "en"}
+	userID := uuid.New()
+	// signIn expects session and login event of user
+	signIn := func(tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker) {
+		tokens.EXPECT().Create(mock.Anything, mock.MatchedBy(func(session domain.Session) bool {
+			return session.UserID == userID && session.Type == events.LoginTypeMagicLink
+		})).Return("refresh_token", nil).Once()
+		roles.EXPECT().Access(mock.Anything, userID).Return(domain.Access{}, nil).Once()
+		broker.EXPECT().PublishUserLogin(mock.MatchedBy(func(login events.UserLogin) bool {
+			return login.ID == userID.String() && login.Type == events.LoginTypeMagicLink
+		})).Return(nil).Once()
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantAudit    domain.AuditResult
+		want         error
+	}{
+		{
+			name: "new user",
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker) {
+				users.EXPECT().GetByEmail(mock.Anything, link.Email).Return(domain.User{}, domain.ErrUserNotFound).Once()
+				users.EXPECT().Create(mock.Anything, link.Email).Return(domain.User{ID: userID, Email: link.Email}, nil).Once()
+				users.EXPECT().AccountByID(mock.Anything, userID, domain.AccountTypeMagicLink).Return(domain.Account{}, domain.ErrAccountNotFound).Once()
+				users.EXPECT().AddAccount(mock.Anything, userID, domain.AccountTypeMagicLink, []byte(nil)).Return(domain.Account{}, nil).Once()
+				broker.EXPECT().PublishUserRegister(events.UserRegister{ID: userID.String(), Email: link.Email, Locale: link.Locale}).Return(nil).Once()
+				signIn(tokens, roles, broker)
+			},
+			wantAudit: domain.AuditResultSuccess,
+		},
+		{
+			name: "registered user",
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker) {
+				users.EXPECT().GetByEmail(mock.Anything, link.Email).Return(domain.User{ID: userID, Email: link.Email}, nil).Once()
+				users.EXPECT().AccountByID(mock.Anything, userID, domain.AccountTypeMagicLink).Return(domain.Account{UserID: userID}, nil).Once()
+				signIn(tokens, roles, broker)
+			},
+			wantAudit: domain.AuditResultSuccess,
+		},
+		{
+			name: "blocked user",
+			mockBehavior: func(users *mocks.UserRepo, tokens *mocks.TokenRepo, roles *mocks.RoleRepo, broker *mocks.Broker) {
+				users.EXPECT().GetByEmail(mock.Anything, link.Email).Return(domain.User{ID: userID, BlockedAt: time.Now()}, nil).Once()
+			},
+			wantAudit: domain.AuditResultFailure,
+			want:      domain.ErrUserBlocked,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewUserRepo(t)
+			tokens := mocks.NewTokenRepo(t)
+			roles := mocks.NewRoleRepo(t)
+			broker := mocks.NewBroker(t)
+			links := mocks.NewMagicLinkRepo(t)
+			links.EXPECT().Take(mock.Anything, "token").Return(link, nil).Once()
+			audit := mocks.NewAuditRepo(t)
+			audit.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionLogin && entry.Result == tc.wantAudit && entry.Details["type"] == events.LoginTypeMagicLink
+			})).Return(nil).Once()
+			tc.mockBehavior(users, tokens, roles, broker)
+			svc := service.NewAuthService(broker, newTxManager(t), users, tokens, links, roles, audit, []byte("secret"))
+
+			got, err := svc.ConsumeMagicLink(context.Background(), "token", "1.1.1.1")
+			assert.ErrorIs(t, err, tc.want)
+			if tc.want == nil {
+				assert.Equal(t, "refresh_token", got.RefreshToken)
+				assert.NotEmpty(t, got.AccessToken)
+			}
+		})
+	}
+
+	t.Run("used token", func(t *testing.T) {
+		links := mocks.NewMagicLinkRepo(t)
+		links.EXPECT().Take(mock.Anything, "token").Return(domain.MagicLink{}, domain.ErrInvalidToken).Once()
+		svc := service.NewAuthService(mocks.NewBroker(t), nil, nil, nil, links, nil, nil, []byte("secret"))
+
+		_, err := svc.ConsumeMagicLink(context.Background(), "token", "1.1.1.1")
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,272 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// AdminUserRepo is an autogenerated mock type for the AdminUserRepo type
+type AdminUserRepo struct {
+	mock.Mock
+}
+
+type AdminUserRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminUserRepo) EXPECT() *AdminUserRepo_Expecter {
+	return &AdminUserRepo_Expecter{mock: &_m.Mock}
+}
+
+// Accounts provides a mock function with given fields: ctx, userID
+func (_m *AdminUserRepo) Accounts(ctx context.Context, userID uuid.UUID) ([]domain.AccountType, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accounts")
+	}
+
+	var r0 []domain.AccountType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.AccountType, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.AccountType); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AccountType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminUserRepo_Accounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Accounts'
+type AdminUserRepo_Accounts_Call struct {
+	*mock.Call
+}
+
+// Accounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AdminUserRepo_Expecter) Accounts(ctx interface{}, userID interface{}) *AdminUserRepo_Accounts_Call {
+	return &AdminUserRepo_Accounts_Call{Call: _e.mock.On("Accounts", ctx, userID)}
+}
+
+func (_c *AdminUserRepo_Accounts_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AdminUserRepo_Accounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminUserRepo_Accounts_Call) Return(_a0 []domain.AccountType, _a1 error) *AdminUserRepo_Accounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminUserRepo_Accounts_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]domain.AccountType, error)) *AdminUserRepo_Accounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, userID
+func (_m *AdminUserRepo) GetByID(ctx context.Context, userID uuid.UUID) (domain.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminUserRepo_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type AdminUserRepo_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AdminUserRepo_Expecter) GetByID(ctx interface{}, userID interface{}) *AdminUserRepo_GetByID_Call {
+	return &AdminUserRepo_GetByID_Call{Call: _e.mock.On("GetByID", ctx, userID)}
+}
+
+func (_c *AdminUserRepo_GetByID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AdminUserRepo_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminUserRepo_GetByID_Call) Return(_a0 domain.User, _a1 error) *AdminUserRepo_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminUserRepo_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (domain.User, error)) *AdminUserRepo_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *AdminUserRepo) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.User
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) ([]domain.User, int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter) []domain.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFilter) int); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.UserFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AdminUserRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AdminUserRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.UserFilter
+func (_e *AdminUserRepo_Expecter) List(ctx interface{}, filter interface{}) *AdminUserRepo_List_Call {
+	return &AdminUserRepo_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *AdminUserRepo_List_Call) Run(run func(ctx context.Context, filter domain.UserFilter)) *AdminUserRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserFilter))
+	})
+	return _c
+}
+
+func (_c *AdminUserRepo_List_Call) Return(_a0 []domain.User, _a1 int, _a2 error) *AdminUserRepo_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AdminUserRepo_List_Call) RunAndReturn(run func(context.Context, domain.UserFilter) ([]domain.User, int, error)) *AdminUserRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetBlocked provides a mock function with given fields: ctx, userID, blockedAt, reason
+func (_m *AdminUserRepo) SetBlocked(ctx context.Context, userID uuid.UUID, blockedAt time.Time, reason string) error {
+	ret := _m.Called(ctx, userID, blockedAt, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBlocked")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, string) error); ok {
+		r0 = rf(ctx, userID, blockedAt, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminUserRepo_SetBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetBlocked'
+type AdminUserRepo_SetBlocked_Call struct {
+	*mock.Call
+}
+
+// SetBlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - blockedAt time.Time
+//   - reason string
+func (_e *AdminUserRepo_Expecter) SetBlocked(ctx interface{}, userID interface{}, blockedAt interface{}, reason interface{}) *AdminUserRepo_SetBlocked_Call {
+	return &AdminUserRepo_SetBlocked_Call{Call: _e.mock.On("SetBlocked", ctx, userID, blockedAt, reason)}
+}
+
+func (_c *AdminUserRepo_SetBlocked_Call) Run(run func(ctx context.Context, userID uuid.UUID, blockedAt time.Time, reason string)) *AdminUserRepo_SetBlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *AdminUserRepo_SetBlocked_Call) Return(_a0 error) *AdminUserRepo_SetBlocked_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminUserRepo_SetBlocked_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, string) error) *AdminUserRepo_SetBlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminUserRepo creates a new instance of AdminUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminUserRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminUserRepo {
+	mock := &AdminUserRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
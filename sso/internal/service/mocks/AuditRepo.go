@@ -0,0 +1,203 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditRepo is an autogenerated mock type for the AuditRepo type
+type AuditRepo struct {
+	mock.Mock
+}
+
+type AuditRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRepo) EXPECT() *AuditRepo_Expecter {
+	return &AuditRepo_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, entry
+func (_m *AuditRepo) Add(ctx context.Context, entry domain.AuditEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditRepo_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type AuditRepo_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry domain.AuditEntry
+func (_e *AuditRepo_Expecter) Add(ctx interface{}, entry interface{}) *AuditRepo_Add_Call {
+	return &AuditRepo_Add_Call{Call: _e.mock.On("Add", ctx, entry)}
+}
+
+func (_c *AuditRepo_Add_Call) Run(run func(ctx context.Context, entry domain.AuditEntry)) *AuditRepo_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *AuditRepo_Add_Call) Return(_a0 error) *AuditRepo_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditRepo_Add_Call) RunAndReturn(run func(context.Context, domain.AuditEntry) error) *AuditRepo_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Chain provides a mock function with given fields: ctx, afterID, limit
+func (_m *AuditRepo) Chain(ctx context.Context, afterID int64, limit int) ([]domain.AuditEntry, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Chain")
+	}
+
+	var r0 []domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]domain.AuditEntry, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []domain.AuditEntry); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRepo_Chain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Chain'
+type AuditRepo_Chain_Call struct {
+	*mock.Call
+}
+
+// Chain is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int64
+//   - limit int
+func (_e *AuditRepo_Expecter) Chain(ctx interface{}, afterID interface{}, limit interface{}) *AuditRepo_Chain_Call {
+	return &AuditRepo_Chain_Call{Call: _e.mock.On("Chain", ctx, afterID, limit)}
+}
+
+func (_c *AuditRepo_Chain_Call) Run(run func(ctx context.Context, afterID int64, limit int)) *AuditRepo_Chain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *AuditRepo_Chain_Call) Return(_a0 []domain.AuditEntry, _a1 error) *AuditRepo_Chain_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRepo_Chain_Call) RunAndReturn(run func(context.Context, int64, int) ([]domain.AuditEntry, error)) *AuditRepo_Chain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *AuditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) ([]domain.AuditEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) []domain.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AuditRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.AuditFilter
+func (_e *AuditRepo_Expecter) List(ctx interface{}, filter interface{}) *AuditRepo_List_Call {
+	return &AuditRepo_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *AuditRepo_List_Call) Run(run func(ctx context.Context, filter domain.AuditFilter)) *AuditRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *AuditRepo_List_Call) Return(_a0 []domain.AuditEntry, _a1 error) *AuditRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditRepo_List_Call) RunAndReturn(run func(context.Context, domain.AuditFilter) ([]domain.AuditEntry, error)) *AuditRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRepo creates a new instance of AuditRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepo {
+	mock := &AuditRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
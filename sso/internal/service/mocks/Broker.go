@@ -20,6 +20,52 @@ func (_m *Broker) EXPECT() *Broker_Expecter {
 	return &Broker_Expecter{mock: &_m.Mock}
 }
 
+// PublishMagicLink provides a mock function with given fields: link
+func (_m *Broker) PublishMagicLink(link events.MagicLink) error {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishMagicLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(events.MagicLink) error); ok {
+		r0 = rf(link)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Broker_PublishMagicLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishMagicLink'
+type Broker_PublishMagicLink_Call struct {
+	*mock.Call
+}
+
+// PublishMagicLink is a helper method to define mock.On call
+//   - link events.MagicLink
+func (_e *Broker_Expecter) PublishMagicLink(link interface{}) *Broker_PublishMagicLink_Call {
+	return &Broker_PublishMagicLink_Call{Call: _e.mock.On("PublishMagicLink", link)}
+}
+
+func (_c *Broker_PublishMagicLink_Call) Run(run func(link events.MagicLink)) *Broker_PublishMagicLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(events.MagicLink))
+	})
+	return _c
+}
+
+func (_c *Broker_PublishMagicLink_Call) Return(_a0 error) *Broker_PublishMagicLink_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Broker_PublishMagicLink_Call) RunAndReturn(run func(events.MagicLink) error) *Broker_PublishMagicLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PublishUserLogin provides a mock function with given fields: user
 func (_m *Broker) PublishUserLogin(user events.UserLogin) error {
 	ret := _m.Called(user)
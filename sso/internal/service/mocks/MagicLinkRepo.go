@@ -0,0 +1,151 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MagicLinkRepo is an autogenerated mock type for the MagicLinkRepo type
+type MagicLinkRepo struct {
+	mock.Mock
+}
+
+type MagicLinkRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MagicLinkRepo) EXPECT() *MagicLinkRepo_Expecter {
+	return &MagicLinkRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, link
+func (_m *MagicLinkRepo) Create(ctx context.Context, link domain.MagicLink) (string, error) {
+	ret := _m.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MagicLink) (string, error)); ok {
+		return rf(ctx, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.MagicLink) string); ok {
+		r0 = rf(ctx, link)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.MagicLink) error); ok {
+		r1 = rf(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MagicLinkRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MagicLinkRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link domain.MagicLink
+func (_e *MagicLinkRepo_Expecter) Create(ctx interface{}, link interface{}) *MagicLinkRepo_Create_Call {
+	return &MagicLinkRepo_Create_Call{Call: _e.mock.On("Create", ctx, link)}
+}
+
+func (_c *MagicLinkRepo_Create_Call) Run(run func(ctx context.Context, link domain.MagicLink)) *MagicLinkRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.MagicLink))
+	})
+	return _c
+}
+
+func (_c *MagicLinkRepo_Create_Call) Return(_a0 string, _a1 error) *MagicLinkRepo_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MagicLinkRepo_Create_Call) RunAndReturn(run func(context.Context, domain.MagicLink) (string, error)) *MagicLinkRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Take provides a mock function with given fields: ctx, token
+func (_m *MagicLinkRepo) Take(ctx context.Context, token string) (domain.MagicLink, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 domain.MagicLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.MagicLink, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.MagicLink); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(domain.MagicLink)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MagicLinkRepo_Take_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Take'
+type MagicLinkRepo_Take_Call struct {
+	*mock.Call
+}
+
+// Take is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MagicLinkRepo_Expecter) Take(ctx interface{}, token interface{}) *MagicLinkRepo_Take_Call {
+	return &MagicLinkRepo_Take_Call{Call: _e.mock.On("Take", ctx, token)}
+}
+
+func (_c *MagicLinkRepo_Take_Call) Run(run func(ctx context.Context, token string)) *MagicLinkRepo_Take_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MagicLinkRepo_Take_Call) Return(_a0 domain.MagicLink, _a1 error) *MagicLinkRepo_Take_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MagicLinkRepo_Take_Call) RunAndReturn(run func(context.Context, string) (domain.MagicLink, error)) *MagicLinkRepo_Take_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMagicLinkRepo creates a new instance of MagicLinkRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMagicLinkRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MagicLinkRepo {
+	mock := &MagicLinkRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,262 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// OIDCClientRepo is an autogenerated mock type for the OIDCClientRepo type
+type OIDCClientRepo struct {
+	mock.Mock
+}
+
+type OIDCClientRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCClientRepo) EXPECT() *OIDCClientRepo_Expecter {
+	return &OIDCClientRepo_Expecter{mock: &_m.Mock}
+}
+
+// ByID provides a mock function with given fields: ctx, clientID
+func (_m *OIDCClientRepo) ByID(ctx context.Context, clientID string) (domain.OIDCClient, error) {
+	ret := _m.Called(ctx, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByID")
+	}
+
+	var r0 domain.OIDCClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.OIDCClient, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.OIDCClient); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Get(0).(domain.OIDCClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCClientRepo_ByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByID'
+type OIDCClientRepo_ByID_Call struct {
+	*mock.Call
+}
+
+// ByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+func (_e *OIDCClientRepo_Expecter) ByID(ctx interface{}, clientID interface{}) *OIDCClientRepo_ByID_Call {
+	return &OIDCClientRepo_ByID_Call{Call: _e.mock.On("ByID", ctx, clientID)}
+}
+
+func (_c *OIDCClientRepo_ByID_Call) Run(run func(ctx context.Context, clientID string)) *OIDCClientRepo_ByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCClientRepo_ByID_Call) Return(_a0 domain.OIDCClient, _a1 error) *OIDCClientRepo_ByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCClientRepo_ByID_Call) RunAndReturn(run func(context.Context, string) (domain.OIDCClient, error)) *OIDCClientRepo_ByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Consent provides a mock function with given fields: ctx, userID, clientID
+func (_m *OIDCClientRepo) Consent(ctx context.Context, userID uuid.UUID, clientID string) ([]string, error) {
+	ret := _m.Called(ctx, userID, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consent")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]string, error)); ok {
+		return rf(ctx, userID, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []string); ok {
+		r0 = rf(ctx, userID, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCClientRepo_Consent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consent'
+type OIDCClientRepo_Consent_Call struct {
+	*mock.Call
+}
+
+// Consent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - clientID string
+func (_e *OIDCClientRepo_Expecter) Consent(ctx interface{}, userID interface{}, clientID interface{}) *OIDCClientRepo_Consent_Call {
+	return &OIDCClientRepo_Consent_Call{Call: _e.mock.On("Consent", ctx, userID, clientID)}
+}
+
+func (_c *OIDCClientRepo_Consent_Call) Run(run func(ctx context.Context, userID uuid.UUID, clientID string)) *OIDCClientRepo_Consent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCClientRepo_Consent_Call) Return(_a0 []string, _a1 error) *OIDCClientRepo_Consent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCClientRepo_Consent_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) ([]string, error)) *OIDCClientRepo_Consent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, client
+func (_m *OIDCClientRepo) Create(ctx context.Context, client domain.OIDCClient) (domain.OIDCClient, error) {
+	ret := _m.Called(ctx, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.OIDCClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OIDCClient) (domain.OIDCClient, error)); ok {
+		return rf(ctx, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OIDCClient) domain.OIDCClient); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Get(0).(domain.OIDCClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OIDCClient) error); ok {
+		r1 = rf(ctx, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCClientRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type OIDCClientRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - client domain.OIDCClient
+func (_e *OIDCClientRepo_Expecter) Create(ctx interface{}, client interface{}) *OIDCClientRepo_Create_Call {
+	return &OIDCClientRepo_Create_Call{Call: _e.mock.On("Create", ctx, client)}
+}
+
+func (_c *OIDCClientRepo_Create_Call) Run(run func(ctx context.Context, client domain.OIDCClient)) *OIDCClientRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.OIDCClient))
+	})
+	return _c
+}
+
+func (_c *OIDCClientRepo_Create_Call) Return(_a0 domain.OIDCClient, _a1 error) *OIDCClientRepo_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCClientRepo_Create_Call) RunAndReturn(run func(context.Context, domain.OIDCClient) (domain.OIDCClient, error)) *OIDCClientRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveConsent provides a mock function with given fields: ctx, userID, clientID, scopes
+func (_m *OIDCClientRepo) SaveConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) error {
+	ret := _m.Called(ctx, userID, clientID, scopes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveConsent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, []string) error); ok {
+		r0 = rf(ctx, userID, clientID, scopes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OIDCClientRepo_SaveConsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveConsent'
+type OIDCClientRepo_SaveConsent_Call struct {
+	*mock.Call
+}
+
+// SaveConsent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - clientID string
+//   - scopes []string
+func (_e *OIDCClientRepo_Expecter) SaveConsent(ctx interface{}, userID interface{}, clientID interface{}, scopes interface{}) *OIDCClientRepo_SaveConsent_Call {
+	return &OIDCClientRepo_SaveConsent_Call{Call: _e.mock.On("SaveConsent", ctx, userID, clientID, scopes)}
+}
+
+func (_c *OIDCClientRepo_SaveConsent_Call) Run(run func(ctx context.Context, userID uuid.UUID, clientID string, scopes []string)) *OIDCClientRepo_SaveConsent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *OIDCClientRepo_SaveConsent_Call) Return(_a0 error) *OIDCClientRepo_SaveConsent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OIDCClientRepo_SaveConsent_Call) RunAndReturn(run func(context.Context, uuid.UUID, string, []string) error) *OIDCClientRepo_SaveConsent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCClientRepo creates a new instance of OIDCClientRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCClientRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCClientRepo {
+	mock := &OIDCClientRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,267 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCGrantRepo is an autogenerated mock type for the OIDCGrantRepo type
+type OIDCGrantRepo struct {
+	mock.Mock
+}
+
+type OIDCGrantRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCGrantRepo) EXPECT() *OIDCGrantRepo_Expecter {
+	return &OIDCGrantRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateCode provides a mock function with given fields: ctx, code
+func (_m *OIDCGrantRepo) CreateCode(ctx context.Context, code domain.AuthorizationCode) (string, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCode")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuthorizationCode) (string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuthorizationCode) string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuthorizationCode) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCGrantRepo_CreateCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCode'
+type OIDCGrantRepo_CreateCode_Call struct {
+	*mock.Call
+}
+
+// CreateCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code domain.AuthorizationCode
+func (_e *OIDCGrantRepo_Expecter) CreateCode(ctx interface{}, code interface{}) *OIDCGrantRepo_CreateCode_Call {
+	return &OIDCGrantRepo_CreateCode_Call{Call: _e.mock.On("CreateCode", ctx, code)}
+}
+
+func (_c *OIDCGrantRepo_CreateCode_Call) Run(run func(ctx context.Context, code domain.AuthorizationCode)) *OIDCGrantRepo_CreateCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AuthorizationCode))
+	})
+	return _c
+}
+
+func (_c *OIDCGrantRepo_CreateCode_Call) Return(_a0 string, _a1 error) *OIDCGrantRepo_CreateCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCGrantRepo_CreateCode_Call) RunAndReturn(run func(context.Context, domain.AuthorizationCode) (string, error)) *OIDCGrantRepo_CreateCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, grant
+func (_m *OIDCGrantRepo) CreateRefreshToken(ctx context.Context, grant domain.OIDCGrant) (string, error) {
+	ret := _m.Called(ctx, grant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OIDCGrant) (string, error)); ok {
+		return rf(ctx, grant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OIDCGrant) string); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OIDCGrant) error); ok {
+		r1 = rf(ctx, grant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCGrantRepo_CreateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRefreshToken'
+type OIDCGrantRepo_CreateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// CreateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - grant domain.OIDCGrant
+func (_e *OIDCGrantRepo_Expecter) CreateRefreshToken(ctx interface{}, grant interface{}) *OIDCGrantRepo_CreateRefreshToken_Call {
+	return &OIDCGrantRepo_CreateRefreshToken_Call{Call: _e.mock.On("CreateRefreshToken", ctx, grant)}
+}
+
+func (_c *OIDCGrantRepo_CreateRefreshToken_Call) Run(run func(ctx context.Context, grant domain.OIDCGrant)) *OIDCGrantRepo_CreateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.OIDCGrant))
+	})
+	return _c
+}
+
+func (_c *OIDCGrantRepo_CreateRefreshToken_Call) Return(_a0 string, _a1 error) *OIDCGrantRepo_CreateRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCGrantRepo_CreateRefreshToken_Call) RunAndReturn(run func(context.Context, domain.OIDCGrant) (string, error)) *OIDCGrantRepo_CreateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakeCode provides a mock function with given fields: ctx, clientID, code
+func (_m *OIDCGrantRepo) TakeCode(ctx context.Context, clientID string, code string) (domain.AuthorizationCode, error) {
+	ret := _m.Called(ctx, clientID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeCode")
+	}
+
+	var r0 domain.AuthorizationCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.AuthorizationCode, error)); ok {
+		return rf(ctx, clientID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.AuthorizationCode); ok {
+		r0 = rf(ctx, clientID, code)
+	} else {
+		r0 = ret.Get(0).(domain.AuthorizationCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clientID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCGrantRepo_TakeCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakeCode'
+type OIDCGrantRepo_TakeCode_Call struct {
+	*mock.Call
+}
+
+// TakeCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - code string
+func (_e *OIDCGrantRepo_Expecter) TakeCode(ctx interface{}, clientID interface{}, code interface{}) *OIDCGrantRepo_TakeCode_Call {
+	return &OIDCGrantRepo_TakeCode_Call{Call: _e.mock.On("TakeCode", ctx, clientID, code)}
+}
+
+func (_c *OIDCGrantRepo_TakeCode_Call) Run(run func(ctx context.Context, clientID string, code string)) *OIDCGrantRepo_TakeCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCGrantRepo_TakeCode_Call) Return(_a0 domain.AuthorizationCode, _a1 error) *OIDCGrantRepo_TakeCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCGrantRepo_TakeCode_Call) RunAndReturn(run func(context.Context, string, string) (domain.AuthorizationCode, error)) *OIDCGrantRepo_TakeCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakeRefreshToken provides a mock function with given fields: ctx, clientID, token
+func (_m *OIDCGrantRepo) TakeRefreshToken(ctx context.Context, clientID string, token string) (domain.OIDCGrant, error) {
+	ret := _m.Called(ctx, clientID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeRefreshToken")
+	}
+
+	var r0 domain.OIDCGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.OIDCGrant, error)); ok {
+		return rf(ctx, clientID, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.OIDCGrant); ok {
+		r0 = rf(ctx, clientID, token)
+	} else {
+		r0 = ret.Get(0).(domain.OIDCGrant)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, clientID, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCGrantRepo_TakeRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakeRefreshToken'
+type OIDCGrantRepo_TakeRefreshToken_Call struct {
+	*mock.Call
+}
+
+// TakeRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientID string
+//   - token string
+func (_e *OIDCGrantRepo_Expecter) TakeRefreshToken(ctx interface{}, clientID interface{}, token interface{}) *OIDCGrantRepo_TakeRefreshToken_Call {
+	return &OIDCGrantRepo_TakeRefreshToken_Call{Call: _e.mock.On("TakeRefreshToken", ctx, clientID, token)}
+}
+
+func (_c *OIDCGrantRepo_TakeRefreshToken_Call) Run(run func(ctx context.Context, clientID string, token string)) *OIDCGrantRepo_TakeRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCGrantRepo_TakeRefreshToken_Call) Return(_a0 domain.OIDCGrant, _a1 error) *OIDCGrantRepo_TakeRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCGrantRepo_TakeRefreshToken_Call) RunAndReturn(run func(context.Context, string, string) (domain.OIDCGrant, error)) *OIDCGrantRepo_TakeRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCGrantRepo creates a new instance of OIDCGrantRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCGrantRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCGrantRepo {
+	mock := &OIDCGrantRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,366 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// PersonalTokenRepo is an autogenerated mock type for the PersonalTokenRepo type
+type PersonalTokenRepo struct {
+	mock.Mock
+}
+
+type PersonalTokenRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PersonalTokenRepo) EXPECT() *PersonalTokenRepo_Expecter {
+	return &PersonalTokenRepo_Expecter{mock: &_m.Mock}
+}
+
+// ByHash provides a mock function with given fields: ctx, hash
+func (_m *PersonalTokenRepo) ByHash(ctx context.Context, hash string) (domain.PersonalToken, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByHash")
+	}
+
+	var r0 domain.PersonalToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.PersonalToken, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.PersonalToken); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(domain.PersonalToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalTokenRepo_ByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByHash'
+type PersonalTokenRepo_ByHash_Call struct {
+	*mock.Call
+}
+
+// ByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *PersonalTokenRepo_Expecter) ByHash(ctx interface{}, hash interface{}) *PersonalTokenRepo_ByHash_Call {
+	return &PersonalTokenRepo_ByHash_Call{Call: _e.mock.On("ByHash", ctx, hash)}
+}
+
+func (_c *PersonalTokenRepo_ByHash_Call) Run(run func(ctx context.Context, hash string)) *PersonalTokenRepo_ByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenRepo_ByHash_Call) Return(_a0 domain.PersonalToken, _a1 error) *PersonalTokenRepo_ByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalTokenRepo_ByHash_Call) RunAndReturn(run func(context.Context, string) (domain.PersonalToken, error)) *PersonalTokenRepo_ByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountForUpdate provides a mock function with given fields: ctx, userID
+func (_m *PersonalTokenRepo) CountForUpdate(ctx context.Context, userID uuid.UUID) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountForUpdate")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalTokenRepo_CountForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountForUpdate'
+type PersonalTokenRepo_CountForUpdate_Call struct {
+	*mock.Call
+}
+
+// CountForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *PersonalTokenRepo_Expecter) CountForUpdate(ctx interface{}, userID interface{}) *PersonalTokenRepo_CountForUpdate_Call {
+	return &PersonalTokenRepo_CountForUpdate_Call{Call: _e.mock.On("CountForUpdate", ctx, userID)}
+}
+
+func (_c *PersonalTokenRepo_CountForUpdate_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *PersonalTokenRepo_CountForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenRepo_CountForUpdate_Call) Return(_a0 int, _a1 error) *PersonalTokenRepo_CountForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalTokenRepo_CountForUpdate_Call) RunAndReturn(run func(context.Context, uuid.UUID) (int, error)) *PersonalTokenRepo_CountForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, token, hash
+func (_m *PersonalTokenRepo) Create(ctx context.Context, token domain.PersonalToken, hash string) (domain.PersonalToken, error) {
+	ret := _m.Called(ctx, token, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 domain.PersonalToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PersonalToken, string) (domain.PersonalToken, error)); ok {
+		return rf(ctx, token, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PersonalToken, string) domain.PersonalToken); ok {
+		r0 = rf(ctx, token, hash)
+	} else {
+		r0 = ret.Get(0).(domain.PersonalToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PersonalToken, string) error); ok {
+		r1 = rf(ctx, token, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalTokenRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type PersonalTokenRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token domain.PersonalToken
+//   - hash string
+func (_e *PersonalTokenRepo_Expecter) Create(ctx interface{}, token interface{}, hash interface{}) *PersonalTokenRepo_Create_Call {
+	return &PersonalTokenRepo_Create_Call{Call: _e.mock.On("Create", ctx, token, hash)}
+}
+
+func (_c *PersonalTokenRepo_Create_Call) Run(run func(ctx context.Context, token domain.PersonalToken, hash string)) *PersonalTokenRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PersonalToken), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenRepo_Create_Call) Return(_a0 domain.PersonalToken, _a1 error) *PersonalTokenRepo_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalTokenRepo_Create_Call) RunAndReturn(run func(context.Context, domain.PersonalToken, string) (domain.PersonalToken, error)) *PersonalTokenRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, userID, tokenID
+func (_m *PersonalTokenRepo) Delete(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalTokenRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type PersonalTokenRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - tokenID uuid.UUID
+func (_e *PersonalTokenRepo_Expecter) Delete(ctx interface{}, userID interface{}, tokenID interface{}) *PersonalTokenRepo_Delete_Call {
+	return &PersonalTokenRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, userID, tokenID)}
+}
+
+func (_c *PersonalTokenRepo_Delete_Call) Run(run func(ctx context.Context, userID uuid.UUID, tokenID uuid.UUID)) *PersonalTokenRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenRepo_Delete_Call) Return(_a0 error) *PersonalTokenRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalTokenRepo_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *PersonalTokenRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *PersonalTokenRepo) List(ctx context.Context, userID uuid.UUID) ([]domain.PersonalToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.PersonalToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.PersonalToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.PersonalToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PersonalToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PersonalTokenRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type PersonalTokenRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *PersonalTokenRepo_Expecter) List(ctx interface{}, userID interface{}) *PersonalTokenRepo_List_Call {
+	return &PersonalTokenRepo_List_Call{Call: _e.mock.On("List", ctx, userID)}
+}
+
+func (_c *PersonalTokenRepo_List_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *PersonalTokenRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenRepo_List_Call) Return(_a0 []domain.PersonalToken, _a1 error) *PersonalTokenRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PersonalTokenRepo_List_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]domain.PersonalToken, error)) *PersonalTokenRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Touch provides a mock function with given fields: ctx, tokenID, ip
+func (_m *PersonalTokenRepo) Touch(ctx context.Context, tokenID uuid.UUID, ip string) error {
+	ret := _m.Called(ctx, tokenID, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, tokenID, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PersonalTokenRepo_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type PersonalTokenRepo_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID uuid.UUID
+//   - ip string
+func (_e *PersonalTokenRepo_Expecter) Touch(ctx interface{}, tokenID interface{}, ip interface{}) *PersonalTokenRepo_Touch_Call {
+	return &PersonalTokenRepo_Touch_Call{Call: _e.mock.On("Touch", ctx, tokenID, ip)}
+}
+
+func (_c *PersonalTokenRepo_Touch_Call) Run(run func(ctx context.Context, tokenID uuid.UUID, ip string)) *PersonalTokenRepo_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PersonalTokenRepo_Touch_Call) Return(_a0 error) *PersonalTokenRepo_Touch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PersonalTokenRepo_Touch_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *PersonalTokenRepo_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPersonalTokenRepo creates a new instance of PersonalTokenRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPersonalTokenRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PersonalTokenRepo {
+	mock := &PersonalTokenRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
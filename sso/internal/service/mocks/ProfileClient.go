@@ -0,0 +1,94 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProfileClient is an autogenerated mock type for the ProfileClient type
+type ProfileClient struct {
+	mock.Mock
+}
+
+type ProfileClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ProfileClient) EXPECT() *ProfileClient_Expecter {
+	return &ProfileClient_Expecter{mock: &_m.Mock}
+}
+
+// ProfileSummary provides a mock function with given fields: ctx, userID
+func (_m *ProfileClient) ProfileSummary(ctx context.Context, userID string) (domain.ProfileSummary, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProfileSummary")
+	}
+
+	var r0 domain.ProfileSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.ProfileSummary, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.ProfileSummary); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.ProfileSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileClient_ProfileSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProfileSummary'
+type ProfileClient_ProfileSummary_Call struct {
+	*mock.Call
+}
+
+// ProfileSummary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ProfileClient_Expecter) ProfileSummary(ctx interface{}, userID interface{}) *ProfileClient_ProfileSummary_Call {
+	return &ProfileClient_ProfileSummary_Call{Call: _e.mock.On("ProfileSummary", ctx, userID)}
+}
+
+func (_c *ProfileClient_ProfileSummary_Call) Run(run func(ctx context.Context, userID string)) *ProfileClient_ProfileSummary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileClient_ProfileSummary_Call) Return(_a0 domain.ProfileSummary, _a1 error) *ProfileClient_ProfileSummary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileClient_ProfileSummary_Call) RunAndReturn(run func(context.Context, string) (domain.ProfileSummary, error)) *ProfileClient_ProfileSummary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewProfileClient creates a new instance of ProfileClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProfileClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProfileClient {
+	mock := &ProfileClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
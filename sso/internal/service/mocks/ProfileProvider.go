@@ -0,0 +1,94 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProfileProvider is an autogenerated mock type for the ProfileProvider type
+type ProfileProvider struct {
+	mock.Mock
+}
+
+type ProfileProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ProfileProvider) EXPECT() *ProfileProvider_Expecter {
+	return &ProfileProvider_Expecter{mock: &_m.Mock}
+}
+
+// Profile provides a mock function with given fields: ctx, userID
+func (_m *ProfileProvider) Profile(ctx context.Context, userID string) (domain.Profile, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Profile")
+	}
+
+	var r0 domain.Profile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Profile, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Profile); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Profile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProfileProvider_Profile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Profile'
+type ProfileProvider_Profile_Call struct {
+	*mock.Call
+}
+
+// Profile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *ProfileProvider_Expecter) Profile(ctx interface{}, userID interface{}) *ProfileProvider_Profile_Call {
+	return &ProfileProvider_Profile_Call{Call: _e.mock.On("Profile", ctx, userID)}
+}
+
+func (_c *ProfileProvider_Profile_Call) Run(run func(ctx context.Context, userID string)) *ProfileProvider_Profile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ProfileProvider_Profile_Call) Return(_a0 domain.Profile, _a1 error) *ProfileProvider_Profile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProfileProvider_Profile_Call) RunAndReturn(run func(context.Context, string) (domain.Profile, error)) *ProfileProvider_Profile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewProfileProvider creates a new instance of ProfileProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProfileProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProfileProvider {
+	mock := &ProfileProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
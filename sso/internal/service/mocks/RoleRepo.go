@@ -0,0 +1,201 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// RoleRepo is an autogenerated mock type for the RoleRepo type
+type RoleRepo struct {
+	mock.Mock
+}
+
+type RoleRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RoleRepo) EXPECT() *RoleRepo_Expecter {
+	return &RoleRepo_Expecter{mock: &_m.Mock}
+}
+
+// Access provides a mock function with given fields: ctx, userID
+func (_m *RoleRepo) Access(ctx context.Context, userID uuid.UUID) (domain.Access, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Access")
+	}
+
+	var r0 domain.Access
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.Access, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.Access); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.Access)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_Access_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Access'
+type RoleRepo_Access_Call struct {
+	*mock.Call
+}
+
+// Access is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *RoleRepo_Expecter) Access(ctx interface{}, userID interface{}) *RoleRepo_Access_Call {
+	return &RoleRepo_Access_Call{Call: _e.mock.On("Access", ctx, userID)}
+}
+
+func (_c *RoleRepo_Access_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *RoleRepo_Access_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_Access_Call) Return(_a0 domain.Access, _a1 error) *RoleRepo_Access_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_Access_Call) RunAndReturn(run func(context.Context, uuid.UUID) (domain.Access, error)) *RoleRepo_Access_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignRole provides a mock function with given fields: ctx, userID, role
+func (_m *RoleRepo) AssignRole(ctx context.Context, userID uuid.UUID, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RoleRepo_AssignRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignRole'
+type RoleRepo_AssignRole_Call struct {
+	*mock.Call
+}
+
+// AssignRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - role string
+func (_e *RoleRepo_Expecter) AssignRole(ctx interface{}, userID interface{}, role interface{}) *RoleRepo_AssignRole_Call {
+	return &RoleRepo_AssignRole_Call{Call: _e.mock.On("AssignRole", ctx, userID, role)}
+}
+
+func (_c *RoleRepo_AssignRole_Call) Run(run func(ctx context.Context, userID uuid.UUID, role string)) *RoleRepo_AssignRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_AssignRole_Call) Return(_a0 error) *RoleRepo_AssignRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RoleRepo_AssignRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *RoleRepo_AssignRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsRoleExists provides a mock function with given fields: ctx, role
+func (_m *RoleRepo) IsRoleExists(ctx context.Context, role string) (bool, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRoleExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_IsRoleExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRoleExists'
+type RoleRepo_IsRoleExists_Call struct {
+	*mock.Call
+}
+
+// IsRoleExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role string
+func (_e *RoleRepo_Expecter) IsRoleExists(ctx interface{}, role interface{}) *RoleRepo_IsRoleExists_Call {
+	return &RoleRepo_IsRoleExists_Call{Call: _e.mock.On("IsRoleExists", ctx, role)}
+}
+
+func (_c *RoleRepo_IsRoleExists_Call) Run(run func(ctx context.Context, role string)) *RoleRepo_IsRoleExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_IsRoleExists_Call) Return(_a0 bool, _a1 error) *RoleRepo_IsRoleExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_IsRoleExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *RoleRepo_IsRoleExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRoleRepo creates a new instance of RoleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleRepo {
+	mock := &RoleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	domain "github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -23,9 +24,9 @@ func (_m *TokenRepo) EXPECT() *TokenRepo_Expecter {
 	return &TokenRepo_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: ctx, userID
-func (_m *TokenRepo) Create(ctx context.Context, userID uuid.UUID) (string, error) {
-	ret := _m.Called(ctx, userID)
+// Create provides a mock function with given fields: ctx, session
+func (_m *TokenRepo) Create(ctx context.Context, session domain.Session) (string, error) {
+	ret := _m.Called(ctx, session)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -33,17 +34,17 @@ func (_m *TokenRepo) Create(ctx context.Context, userID uuid.UUID) (string, erro
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
-		return rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Session) (string, error)); ok {
+		return rf(ctx, session)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
-		r0 = rf(ctx, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Session) string); ok {
+		r0 = rf(ctx, session)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Session) error); ok {
+		r1 = rf(ctx, session)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,14 +59,14 @@ type TokenRepo_Create_Call struct {
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-func (_e *TokenRepo_Expecter) Create(ctx interface{}, userID interface{}) *TokenRepo_Create_Call {
-	return &TokenRepo_Create_Call{Call: _e.mock.On("Create", ctx, userID)}
+//   - session domain.Session
+func (_e *TokenRepo_Expecter) Create(ctx interface{}, session interface{}) *TokenRepo_Create_Call {
+	return &TokenRepo_Create_Call{Call: _e.mock.On("Create", ctx, session)}
 }
 
-func (_c *TokenRepo_Create_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TokenRepo_Create_Call {
+func (_c *TokenRepo_Create_Call) Run(run func(ctx context.Context, session domain.Session)) *TokenRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(domain.Session))
 	})
 	return _c
 }
@@ -75,27 +76,39 @@ func (_c *TokenRepo_Create_Call) Return(_a0 string, _a1 error) *TokenRepo_Create
 	return _c
 }
 
-func (_c *TokenRepo_Create_Call) RunAndReturn(run func(context.Context, uuid.UUID) (string, error)) *TokenRepo_Create_Call {
+func (_c *TokenRepo_Create_Call) RunAndReturn(run func(context.Context, domain.Session) (string, error)) *TokenRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Revoke provides a mock function with given fields: ctx, token
-func (_m *TokenRepo) Revoke(ctx context.Context, token string) error {
+func (_m *TokenRepo) Revoke(ctx context.Context, token string) (uuid.UUID, error) {
 	ret := _m.Called(ctx, token)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Revoke")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
 		r0 = rf(ctx, token)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // TokenRepo_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
@@ -117,12 +130,166 @@ func (_c *TokenRepo_Revoke_Call) Run(run func(ctx context.Context, token string)
 	return _c
 }
 
-func (_c *TokenRepo_Revoke_Call) Return(_a0 error) *TokenRepo_Revoke_Call {
+func (_c *TokenRepo_Revoke_Call) Return(_a0 uuid.UUID, _a1 error) *TokenRepo_Revoke_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenRepo_Revoke_Call) RunAndReturn(run func(context.Context, string) (uuid.UUID, error)) *TokenRepo_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAll provides a mock function with given fields: ctx, userID
+func (_m *TokenRepo) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TokenRepo_RevokeAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAll'
+type TokenRepo_RevokeAll_Call struct {
+	*mock.Call
+}
+
+// RevokeAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *TokenRepo_Expecter) RevokeAll(ctx interface{}, userID interface{}) *TokenRepo_RevokeAll_Call {
+	return &TokenRepo_RevokeAll_Call{Call: _e.mock.On("RevokeAll", ctx, userID)}
+}
+
+func (_c *TokenRepo_RevokeAll_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TokenRepo_RevokeAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TokenRepo_RevokeAll_Call) Return(_a0 error) *TokenRepo_RevokeAll_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *TokenRepo_Revoke_Call) RunAndReturn(run func(context.Context, string) error) *TokenRepo_Revoke_Call {
+func (_c *TokenRepo_RevokeAll_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *TokenRepo_RevokeAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *TokenRepo) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TokenRepo_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type TokenRepo_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - sessionID string
+func (_e *TokenRepo_Expecter) RevokeSession(ctx interface{}, userID interface{}, sessionID interface{}) *TokenRepo_RevokeSession_Call {
+	return &TokenRepo_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, userID, sessionID)}
+}
+
+func (_c *TokenRepo_RevokeSession_Call) Run(run func(ctx context.Context, userID uuid.UUID, sessionID string)) *TokenRepo_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TokenRepo_RevokeSession_Call) Return(_a0 error) *TokenRepo_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TokenRepo_RevokeSession_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *TokenRepo_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sessions provides a mock function with given fields: ctx, userID
+func (_m *TokenRepo) Sessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenRepo_Sessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sessions'
+type TokenRepo_Sessions_Call struct {
+	*mock.Call
+}
+
+// Sessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *TokenRepo_Expecter) Sessions(ctx interface{}, userID interface{}) *TokenRepo_Sessions_Call {
+	return &TokenRepo_Sessions_Call{Call: _e.mock.On("Sessions", ctx, userID)}
+}
+
+func (_c *TokenRepo_Sessions_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TokenRepo_Sessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TokenRepo_Sessions_Call) Return(_a0 []domain.Session, _a1 error) *TokenRepo_Sessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenRepo_Sessions_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]domain.Session, error)) *TokenRepo_Sessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -255,6 +255,63 @@ func (_c *UserRepo_GetByEmail_Call) RunAndReturn(run func(context.Context, strin
 	return _c
 }
 
+// GetByID provides a mock function with given fields: ctx, userID
+func (_m *UserRepo) GetByID(ctx context.Context, userID uuid.UUID) (domain.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type UserRepo_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepo_Expecter) GetByID(ctx interface{}, userID interface{}) *UserRepo_GetByID_Call {
+	return &UserRepo_GetByID_Call{Call: _e.mock.On("GetByID", ctx, userID)}
+}
+
+func (_c *UserRepo_GetByID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepo_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserRepo_GetByID_Call) Return(_a0 domain.User, _a1 error) *UserRepo_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_GetByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (domain.User, error)) *UserRepo_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserRepo creates a new instance of UserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepo(t interface {
@@ -33,6 +33,7 @@ func (s *authService) OAuth(ctx context.Context, info domain.OAuthUserInfo, prov
 			Email:  user.Email,
 			Name:   info.Name,
 			Avatar: info.Picture,
+			Locale: info.Locale,
 		})
 	})
 	if err != nil {
@@ -42,8 +43,12 @@ func (s *authService) OAuth(ctx context.Context, info domain.OAuthUserInfo, prov
 	if account.UserID != user.ID {
 		return domain.Tokens{}, domain.ErrInvalidCredentials
 	}
+	if user.IsBlocked() {
+		s.record(ctx, domain.AuditActionLogin, uuid.Nil, user.ID, domain.ErrUserBlocked, map[string]string{"type": string(provider)})
+		return domain.Tokens{}, domain.ErrUserBlocked
+	}
 
-	tokens, err := s.createTokens(ctx, user.ID)
+	tokens, err := s.createTokens(ctx, user.ID, ip, string(provider))
 	if err != nil {
 		return domain.Tokens{}, err
 	}
@@ -56,6 +61,7 @@ func (s *authService) OAuth(ctx context.Context, info domain.OAuthUserInfo, prov
 	if err != nil {
 		return domain.Tokens{}, err
 	}
+	s.record(ctx, domain.AuditActionLogin, user.ID, user.ID, nil, map[string]string{"type": string(provider)})
 	return tokens, nil
 }
 
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+)
+
+type OIDCClientRepo interface {
+	Create(ctx context.Context, client domain.OIDCClient) (domain.OIDCClient, error)
+	ByID(ctx context.Context, clientID string) (domain.OIDCClient, error)
+	Consent(ctx context.Context, userID uuid.UUID, clientID string) ([]string, error)
+	SaveConsent(ctx context.Context, userID uuid.UUID, clientID string, scopes []string) error
+}
+
+type OIDCGrantRepo interface {
+	CreateCode(ctx context.Context, code domain.AuthorizationCode) (string, error)
+	TakeCode(ctx context.Context, clientID, code string) (domain.AuthorizationCode, error)
+	CreateRefreshToken(ctx context.Context, grant domain.OIDCGrant) (string, error)
+	TakeRefreshToken(ctx context.Context, clientID, token string) (domain.OIDCGrant, error)
+}
+
+type ProfileProvider interface {
+	Profile(ctx context.Context, userID string) (domain.Profile, error)
+}
+
+// oidcService is OpenID Connect provider, it signs tokens with RSA key which is published as JWK
+type oidcService struct {
+	txManager transaction.TxManager
+	clients   OIDCClientRepo
+	grants    OIDCGrantRepo
+	users     UserRepo
+	profiles  ProfileProvider
+	auditLog  AuditRepo
+	key       *rsa.PrivateKey
+	jwk       domain.JWK
+	issuer    string
+}
+
+func NewOIDCService(txManager transaction.TxManager, clients OIDCClientRepo, grants OIDCGrantRepo, users UserRepo, profiles ProfileProvider, auditLog AuditRepo, key *rsa.PrivateKey, issuer string) *oidcService {
+	return &oidcService{
+		txManager: txManager,
+		clients:   clients,
+		grants:    grants,
+		users:     users,
+		profiles:  profiles,
+		auditLog:  auditLog,
+		key:       key,
+		jwk:       jwk(&key.PublicKey),
+		issuer:    issuer,
+	}
+}
+
+// RegisterClient adds client with redirect URIs, secret is returned only once and is empty for public clients
+func (s *oidcService) RegisterClient(ctx context.Context, adminID, name string, redirectURIs []string, public bool) (domain.OIDCClient, string, error) {
+	if len(redirectURIs) == 0 {
+		return domain.OIDCClient{}, "", domain.ErrInvalidRedirectURI
+	}
+	for _, uri := range redirectURIs {
+		if !isValidRedirectURI(uri) {
+			return domain.OIDCClient{}, "", domain.ErrInvalidRedirectURI
+		}
+	}
+	clientID, err := randomHex(16)
+	if err != nil {
+		return domain.OIDCClient{}, "", fmt.Errorf("failed to generate client id: %w", err)
+	}
+	admin, _ := uuid.Parse(adminID)
+	client := domain.OIDCClient{ID: clientID, Name: name, RedirectURIs: redirectURIs, CreatedBy: admin}
+	var secret string
+	if !public {
+		if secret, err = randomSecret(); err != nil {
+			return domain.OIDCClient{}, "", fmt.Errorf("failed to generate client secret: %w", err)
+		}
+		client.SecretHash = hashSecret(secret)
+	}
+
+	err = s.txManager.Run(ctx, func(ctx context.Context) error {
+		if client, err = s.clients.Create(ctx, client); err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		entry := auditEntry(ctx, domain.AuditActionRegisterClient, admin, uuid.Nil, nil)
+		entry.Details = map[string]string{"client_id": client.ID, "name": name}
+		return s.auditLog.Add(ctx, entry)
+	})
+	if err != nil {
+		return domain.OIDCClient{}, "", err
+	}
+	return client, secret, nil
+}
+
+func (s *oidcService) Client(ctx context.Context, clientID string) (domain.OIDCClient, error) {
+	return s.clients.ByID(ctx, clientID)
+}
+
+// Authorize issues authorization code if user already consented to requested scopes,
+// otherwise it returns domain.ErrConsentRequired
+func (s *oidcService) Authorize(ctx context.Context, userID string, req domain.AuthorizationRequest) (string, error) {
+	id, err := s.checkRequest(ctx, userID, req)
+	if err != nil {
+		return "", err
+	}
+	granted, err := s.clients.Consent(ctx, id, req.ClientID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get consent: %w", err)
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(granted, scope) {
+			return "", domain.ErrConsentRequired
+		}
+	}
+	return s.issueCode(ctx, id, req)
+}
+
+// Consent remembers that user granted requested scopes to client and issues authorization code
+func (s *oidcService) Consent(ctx context.Context, userID string, req domain.AuthorizationRequest) (string, error) {
+	id, err := s.checkRequest(ctx, userID, req)
+	if err != nil {
+		return "", err
+	}
+	err = s.txManager.Run(ctx, func(ctx context.Context) error {
+		granted, err := s.clients.Consent(ctx, id, req.ClientID)
+		if err != nil {
+			return fmt.Errorf("failed to get consent: %w", err)
+		}
+		scopes := slices.Compact(slices.Sorted(slices.Values(append(granted, req.Scopes...))))
+		if err := s.clients.SaveConsent(ctx, id, req.ClientID, scopes); err != nil {
+			return fmt.Errorf("failed to save consent: %w", err)
+		}
+		entry := auditEntry(ctx, domain.AuditActionConsent, id, id, nil)
+		entry.Details = map[string]string{"client_id": req.ClientID, "scopes": strings.Join(scopes, " ")}
+		return s.auditLog.Add(ctx, entry)
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.issueCode(ctx, id, req)
+}
+
+// Exchange redeems authorization code, it can be redeemed once by client which requested it with PKCE verifier
+func (s *oidcService) Exchange(ctx context.Context, clientID, secret, code, redirectURI, verifier string) (domain.OIDCTokens, error) {
+	client, err := s.authenticateClient(ctx, clientID, secret)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	// Code is kept if it was issued to other client, so it can't be burnt by guessing client
+	grant, err := s.grants.TakeCode(ctx, client.ID, code)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	if grant.ClientID != client.ID || grant.RedirectURI != redirectURI || !verifyCodeChallenge(verifier, grant.CodeChallenge) {
+		return domain.OIDCTokens{}, domain.ErrInvalidGrant
+	}
+	return s.issueTokens(ctx, domain.OIDCGrant{ClientID: client.ID, UserID: grant.UserID, Scopes: grant.Scopes}, grant.Nonce)
+}
+
+// Refresh rotates refresh token, so each token can be used once
+func (s *oidcService) Refresh(ctx context.Context, clientID, secret, refreshToken string) (domain.OIDCTokens, error) {
+	client, err := s.authenticateClient(ctx, clientID, secret)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	grant, err := s.grants.TakeRefreshToken(ctx, client.ID, refreshToken)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	if grant.ClientID != client.ID {
+		return domain.OIDCTokens{}, domain.ErrInvalidGrant
+	}
+	return s.issueTokens(ctx, grant, "")
+}
+
+// UserInfo returns claims allowed by scopes of access token, profile claims are read from profile service
+func (s *oidcService) UserInfo(ctx context.Context, accessToken string) (domain.UserInfo, error) {
+	claims, err := s.verifyAccessToken(accessToken)
+	if err != nil {
+		return domain.UserInfo{}, err
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return domain.UserInfo{}, domain.ErrInvalidToken
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.UserInfo{}, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return domain.UserInfo{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsBlocked() {
+		return domain.UserInfo{}, domain.ErrInvalidToken
+	}
+
+	info := domain.UserInfo{Subject: user.ID.String()}
+	scopes := strings.Fields(claims.Scope)
+	if slices.Contains(scopes, domain.ScopeEmail) {
+		info.Email = user.Email
+	}
+	if !slices.Contains(scopes, domain.ScopeProfile) {
+		return info, nil
+	}
+	profile, err := s.profiles.Profile(ctx, user.ID.String())
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return info, nil
+	}
+	if err != nil {
+		return domain.UserInfo{}, fmt.Errorf("failed to get profile: %w", err)
+	}
+	info.Name = strings.TrimSpace(profile.FirstName + " " + profile.LastName)
+	info.GivenName = profile.FirstName
+	info.FamilyName = profile.LastName
+	info.PreferredUsername = profile.Username
+	info.Picture = profile.Avatar
+	info.Birthdate = profile.BirthDate
+	info.Gender = profile.Gender
+	info.Locale = profile.Locale
+	return info, nil
+}
+
+// JWKS returns public keys which verify ID tokens
+func (s *oidcService) JWKS() []domain.JWK {
+	return []domain.JWK{s.jwk}
+}
+
+// checkRequest validates request of client and returns ID of user who authorizes it
+func (s *oidcService) checkRequest(ctx context.Context, userID string, req domain.AuthorizationRequest) (uuid.UUID, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, domain.ErrUserNotFound
+	}
+	client, err := s.clients.ByID(ctx, req.ClientID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !client.HasRedirectURI(req.RedirectURI) {
+		return uuid.Nil, domain.ErrInvalidRedirectURI
+	}
+	if !slices.Contains(req.Scopes, domain.ScopeOpenID) {
+		return uuid.Nil, domain.ErrInvalidScope
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(domain.OIDCScopes, scope) {
+			return uuid.Nil, domain.ErrInvalidScope
+		}
+	}
+	if req.CodeChallenge == "" {
+		return uuid.Nil, domain.ErrCodeChallengeRequired
+	}
+	user, err := s.users.GetByID(ctx, id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if user.IsBlocked() {
+		return uuid.Nil, domain.ErrUserBlocked
+	}
+	return id, nil
+}
+
+func (s *oidcService) issueCode(ctx context.Context, userID uuid.UUID, req domain.AuthorizationRequest) (string, error) {
+	code, err := s.grants.CreateCode(ctx, domain.AuthorizationCode{
+		ClientID:      req.ClientID,
+		RedirectURI:   req.RedirectURI,
+		UserID:        userID,
+		Scopes:        req.Scopes,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create code: %w", err)
+	}
+	return code, nil
+}
+
+// issueTokens returns tokens of grant and new refresh token, grants of blocked users are invalid
+func (s *oidcService) issueTokens(ctx context.Context, grant domain.OIDCGrant, nonce string) (domain.OIDCTokens, error) {
+	user, err := s.users.GetByID(ctx, grant.UserID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.OIDCTokens{}, domain.ErrInvalidGrant
+	}
+	if err != nil {
+		return domain.OIDCTokens{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsBlocked() {
+		return domain.OIDCTokens{}, domain.ErrInvalidGrant
+	}
+	tokens := domain.OIDCTokens{ExpiresIn: domain.AccessTokenTTL, Scopes: grant.Scopes}
+	if tokens.AccessToken, err = s.signAccessToken(grant); err != nil {
+		return domain.OIDCTokens{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	if tokens.IDToken, err = s.signIDToken(user, grant, nonce); err != nil {
+		return domain.OIDCTokens{}, fmt.Errorf("failed to sign id token: %w", err)
+	}
+	if tokens.RefreshToken, err = s.grants.CreateRefreshToken(ctx, grant); err != nil {
+		return domain.OIDCTokens{}, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return tokens, nil
+}
+
+// authenticateClient checks secret of confidential client, public clients must not send secret
+func (s *oidcService) authenticateClient(ctx context.Context, clientID, secret string) (domain.OIDCClient, error) {
+	client, err := s.clients.ByID(ctx, clientID)
+	if errors.Is(err, domain.ErrClientNotFound) {
+		return domain.OIDCClient{}, domain.ErrInvalidClient
+	}
+	if err != nil {
+		return domain.OIDCClient{}, fmt.Errorf("failed to get client: %w", err)
+	}
+	if client.IsPublic() {
+		if secret != "" {
+			return domain.OIDCClient{}, domain.ErrInvalidClient
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(client.SecretHash)) != 1 {
+		return domain.OIDCClient{}, domain.ErrInvalidClient
+	}
+	return client, nil
+}
+
+// isValidRedirectURI allows https URIs and http URIs of loopback, which are used in development
+func isValidRedirectURI(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" || u.Fragment != "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	}
+	return false
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func randomSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
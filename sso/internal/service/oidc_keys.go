@@ -0,0 +1,61 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+)
+
+// LoadSigningKey parses PEM encoded RSA private key in PKCS #1 or PKCS #8 form,
+// new key is generated if data is empty, tokens signed by it are invalid after restart
+func LoadSigningKey(data string) (*rsa.PrivateKey, error) {
+	if data == "" {
+		return rsa.GenerateKey(rand.Reader, 2048)
+	}
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("failed to decode pem")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not RSA")
+	}
+	return rsaKey, nil
+}
+
+// jwk describes public part of key, its ID is JWK thumbprint (RFC 7638)
+func jwk(key *rsa.PublicKey) domain.JWK {
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	// Members are in lexicographic order as thumbprint requires
+	thumbprint, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{e, "RSA", n})
+	sum := sha256.Sum256(thumbprint)
+	return domain.JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: base64.RawURLEncoding.EncodeToString(sum[:]),
+		N:   n,
+		E:   e,
+	}
+}
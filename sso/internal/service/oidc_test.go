@@ -0,0 +1,517 @@
+package service_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer      = "https://sso.example.com"
+	testRedirectURI = "https://app.example.com/callback"
+	testVerifier    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+var oidcKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+
+type oidcMocks struct {
+	clients  *mocks.OIDCClientRepo
+	grants   *mocks.OIDCGrantRepo
+	users    *mocks.UserRepo
+	profiles *mocks.ProfileProvider
+	audit    *mocks.AuditRepo
+}
+
+func newOIDCMocks(t *testing.T) *oidcMocks {
+	return &oidcMocks{
+		clients:  mocks.NewOIDCClientRepo(t),
+		grants:   mocks.NewOIDCGrantRepo(t),
+		users:    mocks.NewUserRepo(t),
+		profiles: mocks.NewProfileProvider(t),
+		audit:    mocks.NewAuditRepo(t),
+	}
+}
+
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestOIDCService_RegisterClient(t *testing.T) {
+	testCases := []struct {
+		name         string
+		redirectURIs []string
+		public       bool
+		want         error
+	}{
+		{name: "confidential", redirectURIs: []string{testRedirectURI}},
+		{name: "public", redirectURIs: []string{"http://localhost:3000/callback"}, public: true},
+		{name: "http", redirectURIs: []string{"http://app.example.com/callback"}, want: domain.ErrInvalidRedirectURI},
+		{name: "fragment", redirectURIs: []string{testRedirectURI + "#token"}, want: domain.ErrInvalidRedirectURI},
+		{name: "relative", redirectURIs: []string{"/callback"}, want: domain.ErrInvalidRedirectURI},
+		{name: "without redirect uris", want: domain.ErrInvalidRedirectURI},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newOIDCMocks(t)
+			svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+			adminID := uuid.New()
+			if tc.want == nil {
+				m.clients.EXPECT().Create(mock.Anything, mock.MatchedBy(func(client domain.OIDCClient) bool {
+					return client.Name == "Wiki" && client.CreatedBy == adminID && client.IsPublic() == tc.public
+				})).RunAndReturn(func(ctx context.Context, client domain.OIDCClient) (domain.OIDCClient, error) {
+					return client, nil
+				}).Once()
+				m.audit.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+					return entry.Action == domain.AuditActionRegisterClient && entry.ActorID == adminID && entry.Details["client_id"] != ""
+				})).Return(nil).Once()
+			}
+
+			client, secret, err := svc.RegisterClient(context.Background(), adminID.String(), "Wiki", tc.redirectURIs, tc.public)
+			assert.ErrorIs(t, err, tc.want)
+			if tc.want == nil {
+				assert.NotEmpty(t, client.ID)
+				assert.Equal(t, tc.public, secret == "")
+			}
+		})
+	}
+}
+
+func TestOIDCService_Authorize(t *testing.T) {
+	userID := uuid.New()
+	client := domain.OIDCClient{ID: "client", RedirectURIs: []string{testRedirectURI}}
+	validRequest := domain.AuthorizationRequest{
+		ClientID:      client.ID,
+		RedirectURI:   testRedirectURI,
+		Scopes:        []string{domain.ScopeOpenID, domain.ScopeEmail},
+		CodeChallenge: codeChallenge(testVerifier),
+	}
+	type MockBehavior func(m *oidcMocks)
+
+	testCases := []struct {
+		name         string
+		req          func(req domain.AuthorizationRequest) domain.AuthorizationRequest
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "consented",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil).Once()
+				m.clients.EXPECT().Consent(mock.Anything, userID, client.ID).Return([]string{domain.ScopeEmail, domain.ScopeOpenID, domain.ScopeProfile}, nil).Once()
+				m.grants.EXPECT().CreateCode(mock.Anything, mock.MatchedBy(func(code domain.AuthorizationCode) bool {
+					return code.UserID == userID && code.ClientID == client.ID && code.CodeChallenge == validRequest.CodeChallenge
+				})).Return("code", nil).Once()
+			},
+		},
+		{
+			name: "consent required",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil).Once()
+				m.clients.EXPECT().Consent(mock.Anything, userID, client.ID).Return([]string{domain.ScopeOpenID}, nil).Once()
+			},
+			want: domain.ErrConsentRequired,
+		},
+		{
+			name: "without openid scope",
+			req: func(req domain.AuthorizationRequest) domain.AuthorizationRequest {
+				req.Scopes = []string{domain.ScopeEmail}
+				return req
+			},
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			want: domain.ErrInvalidScope,
+		},
+		{
+			name: "unknown scope",
+			req: func(req domain.AuthorizationRequest) domain.AuthorizationRequest {
+				req.Scopes = []string{domain.ScopeOpenID, "admin"}
+				return req
+			},
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			want: domain.ErrInvalidScope,
+		},
+		{
+			name: "unregistered redirect uri",
+			req: func(req domain.AuthorizationRequest) domain.AuthorizationRequest {
+				req.RedirectURI = "https://evil.example.com/callback"
+				return req
+			},
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			want: domain.ErrInvalidRedirectURI,
+		},
+		{
+			name: "without code challenge",
+			req: func(req domain.AuthorizationRequest) domain.AuthorizationRequest {
+				req.CodeChallenge = ""
+				return req
+			},
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+			},
+			want: domain.ErrCodeChallengeRequired,
+		},
+		{
+			name: "blocked user",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID, BlockedAt: time.Now()}, nil).Once()
+			},
+			want: domain.ErrUserBlocked,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newOIDCMocks(t)
+			svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+			tc.mockBehavior(m)
+			req := validRequest
+			if tc.req != nil {
+				req = tc.req(req)
+			}
+
+			code, err := svc.Authorize(context.Background(), userID.String(), req)
+			assert.ErrorIs(t, err, tc.want)
+			if tc.want == nil {
+				assert.Equal(t, "code", code)
+			}
+		})
+	}
+}
+
+func TestOIDCService_Consent(t *testing.T) {
+	m := newOIDCMocks(t)
+	svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+	userID := uuid.New()
+	client := domain.OIDCClient{ID: "client", RedirectURIs: []string{testRedirectURI}}
+	req := domain.AuthorizationRequest{
+		ClientID:      client.ID,
+		RedirectURI:   testRedirectURI,
+		Scopes:        []string{domain.ScopeOpenID, domain.ScopeProfile},
+		CodeChallenge: codeChallenge(testVerifier),
+	}
+	m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+	m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil).Once()
+	m.clients.EXPECT().Consent(mock.Anything, userID, client.ID).Return([]string{domain.ScopeEmail, domain.ScopeOpenID}, nil).Once()
+	m.clients.EXPECT().SaveConsent(mock.Anything, userID, client.ID, []string{domain.ScopeEmail, domain.ScopeOpenID, domain.ScopeProfile}).Return(nil).Once()
+	m.audit.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+		return entry.Action == domain.AuditActionConsent && entry.ActorID == userID && entry.Details["client_id"] == client.ID
+	})).Return(nil).Once()
+	m.grants.EXPECT().CreateCode(mock.Anything, mock.AnythingOfType("domain.AuthorizationCode")).Return("code", nil).Once()
+
+	code, err := svc.Consent(context.Background(), userID.String(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "code", code)
+}
+
+func TestOIDCService_Exchange(t *testing.T) {
+	userID := uuid.New()
+	secretHash := sha256.Sum256([]byte("secret"))
+	confidential := domain.OIDCClient{ID: "confidential", SecretHash: hex.EncodeToString(secretHash[:])}
+	public := domain.OIDCClient{ID: "public"}
+	grant := domain.AuthorizationCode{
+		ClientID:      confidential.ID,
+		RedirectURI:   testRedirectURI,
+		UserID:        userID,
+		Scopes:        []string{domain.ScopeOpenID, domain.ScopeEmail},
+		Nonce:         "nonce",
+		CodeChallenge: codeChallenge(testVerifier),
+	}
+	type MockBehavior func(m *oidcMocks)
+
+	testCases := []struct {
+		name         string
+		clientID     string
+		secret       string
+		redirectURI  string
+		verifier     string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name:        "success",
+			clientID:    confidential.ID,
+			secret:      "secret",
+			redirectURI: testRedirectURI,
+			verifier:    testVerifier,
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, confidential.ID).Return(confidential, nil).Once()
+				m.grants.EXPECT().TakeCode(mock.Anything, confidential.ID, "code").Return(grant, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID, Email: "user@example.com"}, nil).Once()
+				m.grants.EXPECT().CreateRefreshToken(mock.Anything, domain.OIDCGrant{ClientID: confidential.ID, UserID: userID, Scopes: grant.Scopes}).Return("refresh", nil).Once()
+			},
+		},
+		{
+			name:        "wrong verifier",
+			clientID:    confidential.ID,
+			secret:      "secret",
+			redirectURI: testRedirectURI,
+			verifier:    strings.Repeat("a", 43),
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, confidential.ID).Return(confidential, nil).Once()
+				m.grants.EXPECT().TakeCode(mock.Anything, confidential.ID, "code").Return(grant, nil).Once()
+			},
+			want: domain.ErrInvalidGrant,
+		},
+		{
+			name:        "other redirect uri",
+			clientID:    confidential.ID,
+			secret:      "secret",
+			redirectURI: "https://app.example.com/other",
+			verifier:    testVerifier,
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, confidential.ID).Return(confidential, nil).Once()
+				m.grants.EXPECT().TakeCode(mock.Anything, confidential.ID, "code").Return(grant, nil).Once()
+			},
+			want: domain.ErrInvalidGrant,
+		},
+		{
+			name:        "code of other client",
+			clientID:    public.ID,
+			redirectURI: testRedirectURI,
+			verifier:    testVerifier,
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, public.ID).Return(public, nil).Once()
+				m.grants.EXPECT().TakeCode(mock.Anything, public.ID, "code").Return(domain.AuthorizationCode{}, domain.ErrInvalidGrant).Once()
+			},
+			want: domain.ErrInvalidGrant,
+		},
+		{
+			name:     "used code",
+			clientID: confidential.ID,
+			secret:   "secret",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, confidential.ID).Return(confidential, nil).Once()
+				m.grants.EXPECT().TakeCode(mock.Anything, confidential.ID, "code").Return(domain.AuthorizationCode{}, domain.ErrInvalidGrant).Once()
+			},
+			want: domain.ErrInvalidGrant,
+		},
+		{
+			name:     "wrong secret",
+			clientID: confidential.ID,
+			secret:   "wrong",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, confidential.ID).Return(confidential, nil).Once()
+			},
+			want: domain.ErrInvalidClient,
+		},
+		{
+			name:     "public client with secret",
+			clientID: public.ID,
+			secret:   "secret",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, public.ID).Return(public, nil).Once()
+			},
+			want: domain.ErrInvalidClient,
+		},
+		{
+			name:     "unknown client",
+			clientID: "unknown",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, "unknown").Return(domain.OIDCClient{}, domain.ErrClientNotFound).Once()
+			},
+			want: domain.ErrInvalidClient,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newOIDCMocks(t)
+			svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+			tc.mockBehavior(m)
+
+			tokens, err := svc.Exchange(context.Background(), tc.clientID, tc.secret, "code", tc.redirectURI, tc.verifier)
+			assert.ErrorIs(t, err, tc.want)
+			if tc.want != nil {
+				return
+			}
+			assert.Equal(t, "refresh", tokens.RefreshToken)
+			claims := jwt.MapClaims{}
+			_, err = jwt.ParseWithClaims(tokens.IDToken, claims, func(t *jwt.Token) (any, error) {
+				return &oidcKey.PublicKey, nil
+			}, jwt.WithIssuer(testIssuer), jwt.WithAudience(confidential.ID))
+			require.NoError(t, err)
+			assert.Equal(t, userID.String(), claims["sub"])
+			assert.Equal(t, "nonce", claims["nonce"])
+			assert.Equal(t, "user@example.com", claims["email"])
+		})
+	}
+}
+
+func TestOIDCService_Exchange_OtherClient(t *testing.T) {
+	userID := uuid.New()
+	owner := domain.OIDCClient{ID: "owner"}
+	other := domain.OIDCClient{ID: "other"}
+	grant := domain.AuthorizationCode{
+		ClientID:      owner.ID,
+		RedirectURI:   testRedirectURI,
+		UserID:        userID,
+		Scopes:        []string{domain.ScopeOpenID},
+		CodeChallenge: codeChallenge(testVerifier),
+	}
+	m := newOIDCMocks(t)
+	svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+
+	// Code is not consumed by other client, so owner can still redeem it
+	m.clients.EXPECT().ByID(mock.Anything, other.ID).Return(other, nil).Once()
+	m.grants.EXPECT().TakeCode(mock.Anything, other.ID, "code").Return(domain.AuthorizationCode{}, domain.ErrInvalidGrant).Once()
+	_, err := svc.Exchange(context.Background(), other.ID, "", "code", testRedirectURI, testVerifier)
+	assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+
+	m.clients.EXPECT().ByID(mock.Anything, owner.ID).Return(owner, nil).Once()
+	m.grants.EXPECT().TakeCode(mock.Anything, owner.ID, "code").Return(grant, nil).Once()
+	m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil).Once()
+	m.grants.EXPECT().CreateRefreshToken(mock.Anything, domain.OIDCGrant{ClientID: owner.ID, UserID: userID, Scopes: grant.Scopes}).Return("refresh", nil).Once()
+	tokens, err := svc.Exchange(context.Background(), owner.ID, "", "code", testRedirectURI, testVerifier)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", tokens.RefreshToken)
+}
+
+func TestOIDCService_Refresh(t *testing.T) {
+	userID := uuid.New()
+	client := domain.OIDCClient{ID: "client"}
+	grant := domain.OIDCGrant{ClientID: client.ID, UserID: userID, Scopes: []string{domain.ScopeOpenID}}
+	type MockBehavior func(m *oidcMocks)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+				m.grants.EXPECT().TakeRefreshToken(mock.Anything, client.ID, "refresh").Return(grant, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil).Once()
+				m.grants.EXPECT().CreateRefreshToken(mock.Anything, grant).Return("rotated", nil).Once()
+			},
+		},
+		{
+			name: "token of other client",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+				m.grants.EXPECT().TakeRefreshToken(mock.Anything, client.ID, "refresh").Return(domain.OIDCGrant{}, domain.ErrInvalidGrant).Once()
+			},
+			want: domain.ErrInvalidGrant,
+		},
+		{
+			name: "blocked user",
+			mockBehavior: func(m *oidcMocks) {
+				m.clients.EXPECT().ByID(mock.Anything, client.ID).Return(client, nil).Once()
+				m.grants.EXPECT().TakeRefreshToken(mock.Anything, client.ID, "refresh").Return(grant, nil).Once()
+				m.users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID, BlockedAt: time.Now()}, nil).Once()
+			},
+			want: domain.ErrInvalidGrant,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newOIDCMocks(t)
+			svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+			tc.mockBehavior(m)
+
+			tokens, err := svc.Refresh(context.Background(), client.ID, "", "refresh")
+			assert.ErrorIs(t, err, tc.want)
+			if tc.want == nil {
+				assert.Equal(t, "rotated", tokens.RefreshToken)
+				assert.NotEmpty(t, tokens.AccessToken)
+			}
+		})
+	}
+}
+
+func TestOIDCService_UserInfo(t *testing.T) {
+	userID := uuid.New()
+	user := domain.User{ID: userID, Email: "user@example.com"}
+	profile := domain.Profile{
+		ProfileSummary: domain.ProfileSummary{Username: "user", FirstName: "John", LastName: "Doe"},
+		Locale:         "en",
+	}
+
+	// issue returns tokens of grant with scopes
+	issue := func(t *testing.T, m *oidcMocks, scopes ...string) domain.OIDCTokens {
+		svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+		grant := domain.OIDCGrant{ClientID: "client", UserID: userID, Scopes: scopes}
+		m.clients.EXPECT().ByID(mock.Anything, "client").Return(domain.OIDCClient{ID: "client"}, nil).Once()
+		m.grants.EXPECT().TakeRefreshToken(mock.Anything, "client", "refresh").Return(grant, nil).Once()
+		m.users.EXPECT().GetByID(mock.Anything, userID).Return(user, nil).Once()
+		m.grants.EXPECT().CreateRefreshToken(mock.Anything, grant).Return("rotated", nil).Once()
+		tokens, err := svc.Refresh(context.Background(), "client", "", "refresh")
+		require.NoError(t, err)
+		return tokens
+	}
+
+	t.Run("email and profile", func(t *testing.T) {
+		m := newOIDCMocks(t)
+		svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+		tokens := issue(t, m, domain.ScopeOpenID, domain.ScopeEmail, domain.ScopeProfile)
+		m.users.EXPECT().GetByID(mock.Anything, userID).Return(user, nil).Once()
+		m.profiles.EXPECT().Profile(mock.Anything, userID.String()).Return(profile, nil).Once()
+
+		info, err := svc.UserInfo(context.Background(), tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserInfo{
+			Subject:           userID.String(),
+			Email:             user.Email,
+			Name:              "John Doe",
+			GivenName:         "John",
+			FamilyName:        "Doe",
+			PreferredUsername: "user",
+			Locale:            "en",
+		}, info)
+	})
+
+	t.Run("without profile", func(t *testing.T) {
+		m := newOIDCMocks(t)
+		svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+		tokens := issue(t, m, domain.ScopeOpenID, domain.ScopeProfile)
+		m.users.EXPECT().GetByID(mock.Anything, userID).Return(user, nil).Once()
+		m.profiles.EXPECT().Profile(mock.Anything, userID.String()).Return(domain.Profile{}, domain.ErrProfileNotFound).Once()
+
+		info, err := svc.UserInfo(context.Background(), tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserInfo{Subject: userID.String()}, info)
+	})
+
+	t.Run("id token", func(t *testing.T) {
+		m := newOIDCMocks(t)
+		svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+		tokens := issue(t, m, domain.ScopeOpenID)
+
+		_, err := svc.UserInfo(context.Background(), tokens.IDToken)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("access token of gateway", func(t *testing.T) {
+		m := newOIDCMocks(t)
+		svc := service.NewOIDCService(newTxManager(t), m.clients, m.grants, m.users, m.profiles, m.audit, oidcKey, testIssuer)
+		token, err := auth.SignJWT(userID.String(), nil, nil, []byte("secret"), time.Hour, testIssuer)
+		require.NoError(t, err)
+
+		_, err = svc.UserInfo(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Type of access tokens (RFC 9068), ID tokens have default type, so one can't be used as another
+const accessTokenType = "at+jwt"
+
+// Access tokens of clients are audienced to sso, they are accepted only by /userinfo
+type oidcAccessClaims struct {
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+type idTokenClaims struct {
+	Nonce string `json:"nonce,omitempty"`
+	Email string `json:"email,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (s *oidcService) signAccessToken(grant domain.OIDCGrant) (string, error) {
+	now := time.Now()
+	claims := oidcAccessClaims{
+		ClientID: grant.ClientID,
+		Scope:    strings.Join(grant.Scopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   grant.UserID.String(),
+			Audience:  jwt.ClaimStrings{s.issuer},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(domain.AccessTokenTTL)),
+		},
+	}
+	return s.sign(claims, accessTokenType)
+}
+
+func (s *oidcService) signIDToken(user domain.User, grant domain.OIDCGrant, nonce string) (string, error) {
+	now := time.Now()
+	claims := idTokenClaims{
+		Nonce: nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   user.ID.String(),
+			Audience:  jwt.ClaimStrings{grant.ClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(domain.AccessTokenTTL)),
+		},
+	}
+	if slices.Contains(grant.Scopes, domain.ScopeEmail) {
+		claims.Email = user.Email
+	}
+	return s.sign(claims, "")
+}
+
+func (s *oidcService) sign(claims jwt.Claims, typ string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.jwk.Kid
+	if typ != "" {
+		token.Header["typ"] = typ
+	}
+	return token.SignedString(s.key)
+}
+
+// verifyAccessToken returns domain.ErrInvalidToken for tokens not issued by sso to clients
+func (s *oidcService) verifyAccessToken(tokenString string) (*oidcAccessClaims, error) {
+	claims := &oidcAccessClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
+		if t.Header["typ"] != accessTokenType {
+			return nil, fmt.Errorf("unexpected token type %v", t.Header["typ"])
+		}
+		return &s.key.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithAudience(s.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// verifyCodeChallenge checks PKCE verifier with S256 method (RFC 7636)
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/logger"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
+	"github.com/SergeyBogomolovv/profile-manager/common/transaction"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+)
+
+type PersonalTokenRepo interface {
+	Create(ctx context.Context, token domain.PersonalToken, hash string) (domain.PersonalToken, error)
+	CountForUpdate(ctx context.Context, userID uuid.UUID) (int, error)
+	ByHash(ctx context.Context, hash string) (domain.PersonalToken, error)
+	List(ctx context.Context, userID uuid.UUID) ([]domain.PersonalToken, error)
+	Delete(ctx context.Context, userID, tokenID uuid.UUID) error
+	Touch(ctx context.Context, tokenID uuid.UUID, ip string) error
+}
+
+// Length of token prefix which is stored in plain text, it includes auth.PersonalTokenPrefix
+const personalTokenPrefixLen = 12
+
+type personalTokenService struct {
+	txManager transaction.TxManager
+	users     UserRepo
+	roles     RoleRepo
+	tokens    PersonalTokenRepo
+	auditLog  AuditRepo
+}
+
+func NewPersonalTokenService(txManager transaction.TxManager, users UserRepo, roles RoleRepo, tokens PersonalTokenRepo, auditLog AuditRepo) *personalTokenService {
+	return &personalTokenService{txManager: txManager, users: users, roles: roles, tokens: tokens, auditLog: auditLog}
+}
+
+// CreatePersonalToken issues token which expires in ttl, the secret is returned only once
+func (s *personalTokenService) CreatePersonalToken(ctx context.Context, userID, name string, scopes []string, ttl time.Duration) (domain.PersonalToken, string, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.PersonalToken{}, "", domain.ErrUserNotFound
+	}
+	scopes = slices.Compact(slices.Sorted(slices.Values(scopes)))
+	if len(scopes) == 0 {
+		return domain.PersonalToken{}, "", domain.ErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(auth.Scopes, scope) {
+			return domain.PersonalToken{}, "", domain.ErrInvalidScope
+		}
+	}
+
+	secret, err := newPersonalToken()
+	if err != nil {
+		return domain.PersonalToken{}, "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	var token domain.PersonalToken
+	err = s.txManager.Run(ctx, func(ctx context.Context) error {
+		// Locks user, so concurrent requests can't exceed the limit
+		count, err := s.tokens.CountForUpdate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to count tokens: %w", err)
+		}
+		if count >= domain.MaxPersonalTokens {
+			return domain.ErrTooManyPersonalTokens
+		}
+		token, err = s.tokens.Create(ctx, domain.PersonalToken{
+			UserID:    id,
+			Name:      name,
+			Prefix:    secret[:personalTokenPrefixLen],
+			Scopes:    scopes,
+			ExpiresAt: time.Now().Add(ttl),
+		}, hashSecret(secret))
+		if err != nil {
+			return fmt.Errorf("failed to create token: %w", err)
+		}
+		entry := auditEntry(ctx, domain.AuditActionCreatePAT, id, id, nil)
+		entry.Details = map[string]string{"token_id": token.ID.String(), "name": name, "scopes": strings.Join(scopes, ",")}
+		return s.auditLog.Add(ctx, entry)
+	})
+	if err != nil {
+		return domain.PersonalToken{}, "", err
+	}
+	return token, secret, nil
+}
+
+// PersonalTokens returns tokens of user, newest first
+func (s *personalTokenService) PersonalTokens(ctx context.Context, userID string) ([]domain.PersonalToken, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	tokens, err := s.tokens.List(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *personalTokenService) RevokePersonalToken(ctx context.Context, userID, tokenID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.ErrPersonalTokenNotFound
+	}
+	token, err := uuid.Parse(tokenID)
+	if err != nil {
+		return domain.ErrPersonalTokenNotFound
+	}
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		if err := s.tokens.Delete(ctx, id, token); err != nil {
+			return err
+		}
+		entry := auditEntry(ctx, domain.AuditActionRevokePAT, id, id, nil)
+		entry.Details = map[string]string{"token_id": tokenID}
+		return s.auditLog.Add(ctx, entry)
+	})
+}
+
+// VerifyPersonalToken returns current access of token owner limited by token scopes.
+// Unknown and expired tokens and tokens of blocked users are invalid
+func (s *personalTokenService) VerifyPersonalToken(ctx context.Context, secret string) (*auth.TokenClaims, error) {
+	if !auth.IsPersonalToken(secret) {
+		return nil, auth.ErrInvalidToken
+	}
+	token, err := s.tokens.ByHash(ctx, hashSecret(secret))
+	if errors.Is(err, domain.ErrPersonalTokenNotFound) {
+		return nil, auth.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if token.IsExpired() {
+		return nil, auth.ErrInvalidToken
+	}
+	user, err := s.users.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsBlocked() {
+		return nil, auth.ErrInvalidToken
+	}
+	access, err := s.roles.Access(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access: %w", err)
+	}
+	if err := s.tokens.Touch(ctx, token.ID, request.Extract(ctx).IP); err != nil {
+		logger.Extract(ctx).Warn("failed to record token use", "token_id", token.ID, "error", err)
+	}
+	return &auth.TokenClaims{
+		UserID:      token.UserID.String(),
+		Roles:       access.Roles,
+		Permissions: access.Permissions,
+		Scopes:      token.Scopes,
+	}, nil
+}
+
+func newPersonalToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return auth.PersonalTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Tokens and secrets have enough entropy, so fast hash is sufficient and allows lookup by hash
+func hashSecret(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	"github.com/SergeyBogomolovv/profile-manager/common/request"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalTokenService_CreatePersonalToken(t *testing.T) {
+	type MockBehavior func(tokens *mocks.PersonalTokenRepo, audit *mocks.AuditRepo, userID uuid.UUID)
+
+	testCases := []struct {
+		name         string
+		scopes       []string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name:   "success",
+			scopes: []string{auth.ScopeProfile, auth.ScopeSSO, auth.ScopeProfile},
+			mockBehavior: func(tokens *mocks.PersonalTokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				tokens.EXPECT().CountForUpdate(mock.Anything, userID).Return(1, nil).Once()
+				tokens.EXPECT().Create(mock.Anything, mock.MatchedBy(func(token domain.PersonalToken) bool {
+					return token.UserID == userID && token.Name == "CI" &&
+						assert.ObjectsAreEqual([]string{auth.ScopeProfile, auth.ScopeSSO}, token.Scopes) &&
+						strings.HasPrefix(token.Prefix, auth.PersonalTokenPrefix) &&
+						time.Until(token.ExpiresAt) > 29*24*time.Hour
+				}), mock.AnythingOfType("string")).RunAndReturn(
+					func(ctx context.Context, token domain.PersonalToken, hash string) (domain.PersonalToken, error) {
+						token.ID = uuid.New()
+						return token, nil
+					},
+				).Once()
+				audit.EXPECT().Add(mock.Anything, mock.MatchedBy(func(entry domain.AuditEntry) bool {
+					return entry.Action == domain.AuditActionCreatePAT && entry.ActorID == userID && entry.Details["scopes"] == "profile,sso"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:         "unknown scope",
+			scopes:       []string{auth.ScopeProfile, "admin"},
+			mockBehavior: func(tokens *mocks.PersonalTokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {},
+			want:         domain.ErrInvalidScope,
+		},
+		{
+			name:         "without scopes",
+			mockBehavior: func(tokens *mocks.PersonalTokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {},
+			want:         domain.ErrInvalidScope,
+		},
+		{
+			name:   "too many tokens",
+			scopes: []string{auth.ScopeProfile},
+			mockBehavior: func(tokens *mocks.PersonalTokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				tokens.EXPECT().CountForUpdate(mock.Anything, userID).Return(domain.MaxPersonalTokens, nil).Once()
+			},
+			want: domain.ErrTooManyPersonalTokens,
+		},
+		{
+			name:   "user not found",
+			scopes: []string{auth.ScopeProfile},
+			mockBehavior: func(tokens *mocks.PersonalTokenRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				tokens.EXPECT().CountForUpdate(mock.Anything, userID).Return(0, domain.ErrUserNotFound).Once()
+			},
+			want: domain.ErrUserNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens := mocks.NewPersonalTokenRepo(t)
+			audit := mocks.NewAuditRepo(t)
+			svc := service.NewPersonalTokenService(newTxManager(t), nil, nil, tokens, audit)
+			userID := uuid.New()
+			tc.mockBehavior(tokens, audit, userID)
+
+			token, secret, err := svc.CreatePersonalToken(context.Background(), userID.String(), "CI", tc.scopes, 30*24*time.Hour)
+			assert.ErrorIs(t, err, tc.want)
+			if tc.want == nil {
+				assert.True(t, strings.HasPrefix(secret, token.Prefix))
+				assert.Greater(t, len(secret), len(token.Prefix))
+			}
+		})
+	}
+}
+
+func TestPersonalTokenService_RevokePersonalToken(t *testing.T) {
+	userID, tokenID := uuid.New(), uuid.New()
+	tokens := mocks.NewPersonalTokenRepo(t)
+	audit := mocks.NewAuditRepo(t)
+	tokens.EXPECT().Delete(mock.Anything, userID, tokenID).Return(nil).Once()
+	audit.EXPECT().Add(mock.Anything, domain.AuditEntry{
+		ActorID:  userID,
+		Action:   domain.AuditActionRevokePAT,
+		TargetID: userID,
+		Result:   domain.AuditResultSuccess,
+		Details:  map[string]string{"token_id": tokenID.String()},
+	}).Return(nil).Once()
+	svc := service.NewPersonalTokenService(newTxManager(t), nil, nil, tokens, audit)
+
+	err := svc.RevokePersonalToken(context.Background(), userID.String(), tokenID.String())
+	assert.NoError(t, err)
+
+	err = svc.RevokePersonalToken(context.Background(), userID.String(), "invalid")
+	assert.ErrorIs(t, err, domain.ErrPersonalTokenNotFound)
+}
+
+func TestPersonalTokenService_VerifyPersonalToken(t *testing.T) {
+	type MockBehavior func(users *mocks.UserRepo, roles *mocks.RoleRepo, tokens *mocks.PersonalTokenRepo, token domain.PersonalToken)
+
+	valid := domain.PersonalToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Scopes:    []string{auth.ScopeProfile},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	testCases := []struct {
+		name         string
+		secret       string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name:   "success",
+			secret: "pat_valid",
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, tokens *mocks.PersonalTokenRepo, token domain.PersonalToken) {
+				tokens.EXPECT().ByHash(mock.Anything, mock.AnythingOfType("string")).Return(token, nil).Once()
+				users.EXPECT().GetByID(mock.Anything, token.UserID).Return(domain.User{ID: token.UserID}, nil).Once()
+				roles.EXPECT().Access(mock.Anything, token.UserID).Return(domain.Access{Roles: []string{auth.RoleAdmin}}, nil).Once()
+				tokens.EXPECT().Touch(mock.Anything, token.ID, "192.168.0.1").Return(nil).Once()
+			},
+		},
+		{
+			name:   "not personal token",
+			secret: "access_token",
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, tokens *mocks.PersonalTokenRepo, token domain.PersonalToken) {
+			},
+			want: auth.ErrInvalidToken,
+		},
+		{
+			name:   "unknown token",
+			secret: "pat_unknown",
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, tokens *mocks.PersonalTokenRepo, token domain.PersonalToken) {
+				tokens.EXPECT().ByHash(mock.Anything, mock.AnythingOfType("string")).Return(domain.PersonalToken{}, domain.ErrPersonalTokenNotFound).Once()
+			},
+			want: auth.ErrInvalidToken,
+		},
+		{
+			name:   "expired token",
+			secret: "pat_expired",
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, tokens *mocks.PersonalTokenRepo, token domain.PersonalToken) {
+				token.ExpiresAt = time.Now().Add(-time.Minute)
+				tokens.EXPECT().ByHash(mock.Anything, mock.AnythingOfType("string")).Return(token, nil).Once()
+			},
+			want: auth.ErrInvalidToken,
+		},
+		{
+			name:   "blocked user",
+			secret: "pat_blocked",
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, tokens *mocks.PersonalTokenRepo, token domain.PersonalToken) {
+				tokens.EXPECT().ByHash(mock.Anything, mock.AnythingOfType("string")).Return(token, nil).Once()
+				users.EXPECT().GetByID(mock.Anything, token.UserID).Return(domain.User{ID: token.UserID, BlockedAt: time.Now()}, nil).Once()
+			},
+			want: auth.ErrInvalidToken,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := mocks.NewUserRepo(t)
+			roles := mocks.NewRoleRepo(t)
+			tokens := mocks.NewPersonalTokenRepo(t)
+			svc := service.NewPersonalTokenService(nil, users, roles, tokens, nil)
+			tc.mockBehavior(users, roles, tokens, valid)
+
+			ctx := request.Inject(context.Background(), request.Info{IP: "192.168.0.1"})
+			claims, err := svc.VerifyPersonalToken(ctx, tc.secret)
+			assert.ErrorIs(t, err, tc.want)
+			if tc.want == nil {
+				require.NotNil(t, claims)
+				assert.Equal(t, valid.UserID.String(), claims.UserID)
+				assert.Equal(t, []string{auth.RoleAdmin}, claims.Roles)
+				assert.True(t, claims.IsPersonal())
+				assert.True(t, claims.AllowsMethod("/profile.Profile/GetProfile"))
+				assert.False(t, claims.AllowsMethod("/sso.SSO/AssignRole"))
+			}
+		})
+	}
+}
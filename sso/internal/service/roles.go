@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AssignRole grants role to user, it takes effect when user refreshes access token.
+// Empty actor ID means role is assigned by the system, e.g. admins from config
+func (s *authService) AssignRole(ctx context.Context, actorID, userID, role string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	actor, _ := uuid.Parse(actorID)
+	return s.txManager.Run(ctx, func(ctx context.Context) error {
+		if _, err := s.users.GetByID(ctx, id); err != nil {
+			return err
+		}
+		exists, err := s.roles.IsRoleExists(ctx, role)
+		if err != nil {
+			return fmt.Errorf("failed to check role: %w", err)
+		}
+		if !exists {
+			return domain.ErrRoleNotFound
+		}
+		if err := s.roles.AssignRole(ctx, id, role); err != nil {
+			return fmt.Errorf("failed to assign role: %w", err)
+		}
+		entry := auditEntry(ctx, domain.AuditActionAssignRole, actor, id, nil)
+		entry.Details = map[string]string{"role": role}
+		return s.auditLog.Add(ctx, entry)
+	})
+}
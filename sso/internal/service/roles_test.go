@@ -0,0 +1,95 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SergeyBogomolovv/profile-manager/common/auth"
+	txMocks "github.com/SergeyBogomolovv/profile-manager/common/transaction/mocks"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/domain"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service"
+	"github.com/SergeyBogomolovv/profile-manager/sso/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthService_AssignRole(t *testing.T) {
+	type MockBehavior func(users *mocks.UserRepo, roles *mocks.RoleRepo, audit *mocks.AuditRepo, userID uuid.UUID)
+
+	actorID := uuid.New()
+	testCases := []struct {
+		name         string
+		role         string
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "success",
+			role: auth.RoleAdmin,
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+				roles.EXPECT().IsRoleExists(mock.Anything, auth.RoleAdmin).Return(true, nil)
+				roles.EXPECT().AssignRole(mock.Anything, userID, auth.RoleAdmin).Return(nil)
+				audit.EXPECT().Add(mock.Anything, domain.AuditEntry{
+					ActorID: actorID,
+					Action:  domain.AuditActionAssignRole, Result: domain.AuditResultSuccess,
+					TargetID: userID,
+					Details:  map[string]string{"role": auth.RoleAdmin},
+				}).Return(nil)
+			},
+		},
+		{
+			name: "user not found",
+			role: auth.RoleAdmin,
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{}, domain.ErrUserNotFound)
+			},
+			want: domain.ErrUserNotFound,
+		},
+		{
+			name: "role not found",
+			role: "owner",
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+				roles.EXPECT().IsRoleExists(mock.Anything, "owner").Return(false, nil)
+			},
+			want: domain.ErrRoleNotFound,
+		},
+		{
+			name: "failed to assign",
+			role: auth.RoleAdmin,
+			mockBehavior: func(users *mocks.UserRepo, roles *mocks.RoleRepo, audit *mocks.AuditRepo, userID uuid.UUID) {
+				users.EXPECT().GetByID(mock.Anything, userID).Return(domain.User{ID: userID}, nil)
+				roles.EXPECT().IsRoleExists(mock.Anything, auth.RoleAdmin).Return(true, nil)
+				roles.EXPECT().AssignRole(mock.Anything, userID, auth.RoleAdmin).Return(assert.AnError)
+			},
+			want: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := txMocks.NewTxManager(t)
+			tx.EXPECT().Run(mock.Anything, mock.AnythingOfType("func(context.Context) error")).RunAndReturn(
+				func(ctx context.Context, f func(context.Context) error) error {
+					return f(ctx)
+				},
+			)
+			users := mocks.NewUserRepo(t)
+			roles := mocks.NewRoleRepo(t)
+			audit := mocks.NewAuditRepo(t)
+			svc := service.NewAuthService(mocks.NewBroker(t), tx, users, mocks.NewTokenRepo(t), nil, roles, audit, []byte("secret"))
+			userID := uuid.New()
+			tc.mockBehavior(users, roles, audit, userID)
+			err := svc.AssignRole(context.Background(), actorID.String(), userID.String(), tc.role)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+
+	t.Run("invalid user id", func(t *testing.T) {
+		svc := service.NewAuthService(mocks.NewBroker(t), nil, nil, nil, nil, nil, nil, []byte("secret"))
+		err := svc.AssignRole(context.Background(), actorID.String(), "invalid", auth.RoleAdmin)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
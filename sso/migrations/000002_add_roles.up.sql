@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS roles
+(
+	name VARCHAR(32) PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions
+(
+	role VARCHAR(32) REFERENCES roles(name) ON DELETE CASCADE NOT NULL,
+	permission VARCHAR(64) NOT NULL,
+	PRIMARY KEY(role, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles
+(
+	user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+	role VARCHAR(32) REFERENCES roles(name) ON DELETE CASCADE NOT NULL,
+	assigned_at TIMESTAMP DEFAULT NOW(),
+	PRIMARY KEY(user_id, role)
+);
+
+INSERT INTO roles (name) VALUES ('admin');
+INSERT INTO role_permissions (role, permission) VALUES
+	('admin', 'roles:assign'),
+	('admin', 'broadcasts:send');
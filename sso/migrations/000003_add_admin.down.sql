@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission = 'users:manage';
+DROP TABLE IF EXISTS audit_log;
+ALTER TABLE users DROP COLUMN IF EXISTS block_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_at;
@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS blocked_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS block_reason TEXT;
+
+CREATE TABLE IF NOT EXISTS audit_log
+(
+	id BIGSERIAL PRIMARY KEY,
+	actor_id UUID,
+	action VARCHAR(64) NOT NULL,
+	target_id UUID,
+	details JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_target_idx ON audit_log (target_id, id DESC);
+
+INSERT INTO role_permissions (role, permission) VALUES ('admin', 'users:manage');
@@ -0,0 +1,14 @@
+DELETE FROM role_permissions WHERE permission = 'audit:read';
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+
+DROP INDEX IF EXISTS audit_log_actor_idx;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS hash;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS request_id;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS result;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS ip;
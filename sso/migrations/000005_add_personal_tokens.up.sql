@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS personal_tokens
+(
+	token_id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+	user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+	name VARCHAR(64) NOT NULL,
+	prefix VARCHAR(16) NOT NULL,
+	token_hash CHAR(64) UNIQUE NOT NULL,
+	scopes TEXT[] NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP DEFAULT NOW(),
+	last_used_at TIMESTAMP,
+	last_used_ip TEXT
+);
+
+CREATE INDEX IF NOT EXISTS personal_tokens_user_idx ON personal_tokens (user_id);
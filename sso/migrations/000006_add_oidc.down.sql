@@ -0,0 +1,3 @@
+DELETE FROM role_permissions WHERE permission = 'clients:manage';
+DROP TABLE IF EXISTS oidc_consents;
+DROP TABLE IF EXISTS oidc_clients;
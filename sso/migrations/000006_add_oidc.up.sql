@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS oidc_clients
+(
+	client_id VARCHAR(64) PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	-- Public clients have no secret
+	secret_hash CHAR(64),
+	redirect_uris TEXT[] NOT NULL,
+	created_by UUID REFERENCES users(user_id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS oidc_consents
+(
+	user_id UUID REFERENCES users(user_id) ON DELETE CASCADE NOT NULL,
+	client_id VARCHAR(64) REFERENCES oidc_clients(client_id) ON DELETE CASCADE NOT NULL,
+	scopes TEXT[] NOT NULL,
+	granted_at TIMESTAMP DEFAULT NOW(),
+	PRIMARY KEY(user_id, client_id)
+);
+
+INSERT INTO role_permissions (role, permission) VALUES ('admin', 'clients:manage');
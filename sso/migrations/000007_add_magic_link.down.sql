@@ -0,0 +1,9 @@
+DELETE FROM accounts WHERE provider = 'magic_link';
+
+ALTER TYPE account_type RENAME TO account_type_old;
+CREATE TYPE account_type AS ENUM ('google', 'credentials');
+ALTER TABLE accounts
+	ALTER COLUMN provider DROP DEFAULT,
+	ALTER COLUMN provider TYPE account_type USING provider::text::account_type,
+	ALTER COLUMN provider SET DEFAULT 'credentials';
+DROP TYPE account_type_old;
@@ -0,0 +1 @@
+ALTER TYPE account_type ADD VALUE IF NOT EXISTS 'magic_link';